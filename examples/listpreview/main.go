@@ -0,0 +1,237 @@
+package main
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// commit is an object in the list pane
+type commit struct {
+	hash    string
+	author  string
+	date    time.Time
+	subject string
+	files   []string
+}
+
+func (c commit) GetItem() item.Item {
+	return item.NewItem(fmt.Sprintf("%s %s %s", hashStyle.Render(c.hash[:7]), c.date.Format("2006-01-02"), c.subject))
+}
+
+// line is an object in the preview pane
+type line struct {
+	item item.Item
+}
+
+func (l line) GetItem() item.Item {
+	return l.item
+}
+
+var (
+	hashStyle    = lipgloss.NewStyle().Foreground(lipgloss.Yellow)
+	addedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Green)
+	removedStyle = lipgloss.NewStyle().Foreground(lipgloss.Red)
+	hunkStyle    = lipgloss.NewStyle().Foreground(lipgloss.Cyan)
+	focusedStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(lipgloss.BrightBlue)
+	blurredStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+)
+
+var (
+	authors  = []string{"Ada", "Grace", "Linus", "Barbara", "Ken"}
+	verbs    = []string{"Fix", "Add", "Refactor", "Document", "Speed up"}
+	subjects = []string{"wrapping of wide characters", "footer percentage", "search highlighting", "mouse scrolling",
+		"filter history", "item source refresh"}
+	files = []string{"viewport/viewport.go", "viewport/footer.go", "viewport/search.go", "viewport/mouse.go",
+		"filterableviewport/filterableviewport.go", "README.md"}
+)
+
+// commits returns n synthetic commits, newest first
+func commits(n int) []commit {
+	res := make([]commit, n)
+	start := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := range res {
+		res[i] = commit{
+			hash:    fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprint(i)))),
+			author:  authors[i%len(authors)],
+			date:    start.Add(-time.Duration(i) * 7 * time.Hour),
+			subject: fmt.Sprintf("%s %s", verbs[i%len(verbs)], subjects[(i/2)%len(subjects)]),
+			files:   []string{files[i%len(files)], files[(i+3)%len(files)]},
+		}
+	}
+	return res
+}
+
+// previewLines returns the lines of c's message and a synthetic diff of its files
+func previewLines(c commit) []line {
+	text := []string{
+		hashStyle.Render("commit " + c.hash),
+		"Author: " + c.author,
+		"Date:   " + c.date.Format(time.RFC1123Z),
+		"",
+		"    " + c.subject,
+		"",
+	}
+	for i, file := range c.files {
+		text = append(text,
+			"diff --git a/"+file+" b/"+file,
+			hunkStyle.Render(fmt.Sprintf("@@ -%d,6 +%d,7 @@", 10*(i+1), 10*(i+1))),
+			"     func example() {",
+			removedStyle.Render("-    return old()"),
+			addedStyle.Render("+    // "+strings.ToLower(c.subject)),
+			addedStyle.Render("+    return updated()"),
+			"     }",
+		)
+	}
+	lines := make([]line, len(text))
+	for i, t := range text {
+		lines[i] = line{item: item.NewItem(t)}
+	}
+	return lines
+}
+
+type appKeys struct {
+	quit        key.Binding
+	switchFocus key.Binding
+}
+
+var appKeyMap = appKeys{
+	quit:        key.NewBinding(key.WithKeys("ctrl+c", "q"), key.WithHelp("q", "quit")),
+	switchFocus: key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "switch pane")),
+}
+
+type model struct {
+	// list shows the commits, and preview the selected one
+	list    *viewport.Model[commit]
+	preview *viewport.Model[line]
+
+	commits []commit
+
+	// previewFocused is true when keys scroll the preview rather than move through the list
+	previewFocused bool
+
+	ready bool
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, appKeyMap.quit):
+			return m, tea.Quit
+		case !m.ready:
+			return m, nil
+		case key.Matches(msg, appKeyMap.switchFocus):
+			m.previewFocused = !m.previewFocused
+			if m.previewFocused {
+				m.list.Blur()
+				m.preview.Focus()
+			} else {
+				m.preview.Blur()
+				m.list.Focus()
+			}
+			return m, nil
+		}
+		// keys go to the focused pane only
+		if m.previewFocused {
+			m.preview, cmd = m.preview.Update(msg)
+		} else {
+			m.list, cmd = m.list.Update(msg)
+		}
+		return m, cmd
+
+	case tea.WindowSizeMsg:
+		// 2 for each pane's border, 1 for the help line
+		listWidth := msg.Width * 2 / 5
+		listWidth, previewWidth := listWidth-2, msg.Width-listWidth-2
+		height := msg.Height - 1 - 2
+		if !m.ready {
+			m.list = viewport.New[commit](
+				listWidth,
+				height,
+				viewport.WithStyles[commit](viewport.DefaultStyles()),
+				viewport.WithSelectionEnabled[commit](true),
+			)
+			m.list.SetObjects(m.commits)
+			m.preview = viewport.New[line](
+				previewWidth,
+				height,
+				viewport.WithStyles[line](viewport.DefaultStyles()),
+			)
+			m.preview.Blur()
+			m.showPreview(m.list.GetSelectedItemIdx())
+			m.ready = true
+		} else {
+			m.list.SetWidth(listWidth)
+			m.list.SetHeight(height)
+			m.preview.SetWidth(previewWidth)
+			m.preview.SetHeight(height)
+		}
+		return m, nil
+
+	case viewport.SelectionChangedMsg:
+		// the list's selection moved, so the preview follows it
+		m.showPreview(msg.ItemIdx)
+		return m, nil
+	}
+
+	if m.ready {
+		m.list, cmd = m.list.Update(msg)
+	}
+	return m, cmd
+}
+
+// showPreview shows the commit at idx in the preview pane, scrolled to its top
+func (m *model) showPreview(idx int) {
+	if idx < 0 || idx >= len(m.commits) {
+		m.preview.SetObjects(nil)
+		return
+	}
+	m.preview.SetObjects(previewLines(m.commits[idx]))
+	m.preview.GoToTop()
+	m.preview.ScrollLeft(m.preview.GetWidth())
+}
+
+func (m model) View() tea.View {
+	content := "Initializing..."
+	if m.ready {
+		listStyle, previewStyle := focusedStyle, blurredStyle
+		if m.previewFocused {
+			listStyle, previewStyle = blurredStyle, focusedStyle
+		}
+		panes := lipgloss.JoinHorizontal(
+			lipgloss.Top,
+			listStyle.Render(m.list.View()),
+			previewStyle.Render(m.preview.View()),
+		)
+		help := fmt.Sprintf("%s %s  %s %s",
+			appKeyMap.switchFocus.Help().Key, appKeyMap.switchFocus.Help().Desc,
+			appKeyMap.quit.Help().Key, appKeyMap.quit.Help().Desc)
+		content = lipgloss.JoinVertical(lipgloss.Left, help, panes)
+	}
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
+
+func main() {
+	p := tea.NewProgram(model{commits: commits(200)})
+	if _, err := p.Run(); err != nil {
+		fmt.Println("could not run program:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+// An example program rendering markdown with glamour in the viewport, using a preprocessor so that the document is
+// rendered again at the new width when the terminal is resized or wrapping is toggled
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/glamour/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+type object struct {
+	item item.Item
+
+	// markdown is the source of a document, which renderMarkdown turns into one object per rendered line
+	markdown string
+}
+
+func (o object) GetItem() item.Item {
+	return o.item
+}
+
+// renderMarkdown is a viewport.Preprocessor rendering each markdown document into lines
+func renderMarkdown(objects []object, width int, wrapText bool) []object {
+	if !wrapText {
+		// glamour doesn't wrap at width 0, leaving long lines to pan horizontally
+		width = 0
+	}
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle("dark"),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return []object{{item: item.NewItem(err.Error())}}
+	}
+
+	var lines []object
+	for _, o := range objects {
+		rendered, err := renderer.Render(o.markdown)
+		if err != nil {
+			rendered = err.Error()
+		}
+		for _, line := range strings.Split(strings.Trim(rendered, "\n"), "\n") {
+			lines = append(lines, object{item: item.NewItem(line)})
+		}
+	}
+	return lines
+}
+
+var keyMap = viewport.DefaultKeyMap()
+
+var (
+	searchKey = key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search"))
+	wrapKey   = key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "toggle wrapping"))
+	quitKey   = key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit"))
+)
+
+type model struct {
+	// viewport displays the rendered markdown
+	viewport *viewport.Model[object]
+
+	// searchInput is focused while entering a search query
+	searchInput textinput.Model
+
+	// ready indicates whether the model has been initialized
+	ready bool
+}
+
+func (m model) Init() tea.Cmd {
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if m.searchInput.Focused() {
+			switch msg.String() {
+			case "enter":
+				m.searchInput.Blur()
+				m.viewport.SetSearch(m.searchInput.Value())
+			case "esc":
+				m.searchInput.Blur()
+				m.searchInput.SetValue(m.viewport.GetSearch())
+			default:
+				m.searchInput, cmd = m.searchInput.Update(msg)
+			}
+			return m, cmd
+		}
+		switch {
+		case key.Matches(msg, quitKey):
+			return m, tea.Quit
+		case key.Matches(msg, wrapKey):
+			m.viewport.SetWrapText(!m.viewport.GetWrapText())
+		case key.Matches(msg, searchKey):
+			m.searchInput.SetValue("")
+			return m, m.searchInput.Focus()
+		}
+
+	case tea.WindowSizeMsg:
+		// 2 for horizontal border, 2 for content above viewport and 2 for vertical border
+		viewportWidth, viewportHeight := msg.Width-2, msg.Height-2-2
+		if !m.ready {
+			m.viewport = viewport.New[object](
+				viewportWidth,
+				viewportHeight,
+				viewport.WithKeyMap[object](keyMap),
+				viewport.WithWrapText[object](true),
+				viewport.WithPreprocessor[object](renderMarkdown),
+			)
+			m.viewport.SetObjects([]object{{item: item.NewItem(""), markdown: document}})
+			m.searchInput = textinput.New()
+			m.searchInput.Prompt = "/"
+			m.ready = true
+		} else {
+			m.viewport.SetWidth(viewportWidth)
+			m.viewport.SetHeight(viewportHeight)
+		}
+	}
+
+	if m.ready {
+		m.viewport, cmd = m.viewport.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) View() tea.View {
+	var content string
+	if !m.ready {
+		content = "Initializing viewport..."
+	} else {
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Render("Markdown in a viewport"),
+			m.getStatusLine(),
+			lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Render(m.viewport.View()),
+		)
+	}
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
+
+func (m model) getStatusLine() string {
+	if m.searchInput.Focused() {
+		return m.searchInput.View()
+	}
+	status := fmt.Sprintf("%s %s  %s %s  %s %s",
+		searchKey.Help().Key, searchKey.Help().Desc,
+		wrapKey.Help().Key, wrapKey.Help().Desc,
+		quitKey.Help().Key, quitKey.Help().Desc,
+	)
+	if query := m.viewport.GetSearch(); query != "" {
+		status += fmt.Sprintf("  |  %q: match %d of %d (n/N to navigate)",
+			query, m.viewport.GetFocusedSearchMatchIdx()+1, m.viewport.GetSearchMatchCount())
+	}
+	return status
+}
+
+func main() {
+	p := tea.NewProgram(model{})
+	if _, err := p.Run(); err != nil {
+		fmt.Println("could not run program:", err)
+		os.Exit(1)
+	}
+}
+
+const document = "# Markdown in a viewport\n" +
+	"\n" +
+	"This document is rendered with [glamour](https://github.com/charmbracelet/glamour) by a " +
+	"`viewport.Preprocessor`. The preprocessor runs again whenever the viewport is resized or wrapping is " +
+	"toggled, so paragraphs always reflow to the available width.\n" +
+	"\n" +
+	"## Searching\n" +
+	"\n" +
+	"Press `/` and type a query, then `enter`. Every match is highlighted, and `n` and `N` move between them, " +
+	"scrolling each into view. Try searching for *viewport*.\n" +
+	"\n" +
+	"## Wrapping\n" +
+	"\n" +
+	"Press `w` to turn wrapping off. The document is then rendered wide and long lines can be panned with the " +
+	"left and right arrow keys:\n" +
+	"\n" +
+	"```go\n" +
+	"vp := viewport.New[object](width, height, viewport.WithPreprocessor[object](renderMarkdown))\n" +
+	"vp.SetObjects([]object{{markdown: document}})\n" +
+	"```\n" +
+	"\n" +
+	"## Lists and tables\n" +
+	"\n" +
+	"- Headings, emphasis and links keep their styling\n" +
+	"- Code blocks are syntax highlighted\n" +
+	"- Search highlights are layered on top of the rendered styles\n" +
+	"\n" +
+	"| Key | Action |\n" +
+	"|-----|--------|\n" +
+	"| `/` | Search |\n" +
+	"| `n` / `N` | Next/previous match |\n" +
+	"| `w` | Toggle wrapping |\n" +
+	"| `q` | Quit |\n"
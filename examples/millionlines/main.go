@@ -0,0 +1,355 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/filterableviewport"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// appendInterval is how often lines are appended while streaming
+const appendInterval = 100 * time.Millisecond
+
+type object struct {
+	item item.Item
+}
+
+func (o object) GetItem() item.Item {
+	return o.item
+}
+
+var (
+	levels   = []string{"DEBUG", "INFO", "INFO", "INFO", "WARN", "ERROR"}
+	services = []string{"api", "auth", "billing", "search", "worker"}
+	paths    = []string{"/v1/users", "/v1/orders", "/v1/search?q=viewport", "/healthz", "/v1/invoices/export"}
+	baseTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// hash scrambles i so that synthetic lines vary without keeping any state
+func hash(i int) int {
+	x := uint64(i)*0x9e3779b97f4a7c15 + 0x632be59bd9b4e019
+	x ^= x >> 31
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 29
+	return int(x >> 1)
+}
+
+// syntheticLine returns the log line at index i, the same every time
+func syntheticLine(i int) string {
+	h := hash(i)
+	ts := baseTime.Add(time.Duration(i) * 37 * time.Millisecond).Format("2006-01-02T15:04:05.000")
+	return fmt.Sprintf("%s %-5s [%-7s] req=%08x %s %s took %dms",
+		ts,
+		levels[h%len(levels)],
+		services[(h/7)%len(services)],
+		h%0xffffffff,
+		[]string{"GET", "POST", "PUT"}[(h/11)%3],
+		paths[(h/13)%len(paths)],
+		(h/17)%2000,
+	)
+}
+
+// syntheticSource is an ItemSource that generates each line when it's read, so it holds no lines in memory
+type syntheticSource struct {
+	n int
+}
+
+func (s *syntheticSource) Len() int {
+	return s.n
+}
+
+func (s *syntheticSource) At(i int) object {
+	return object{item: item.NewItem(syntheticLine(i))}
+}
+
+// syntheticObjects returns the lines in [from, to) as objects
+func syntheticObjects(from, to int) []object {
+	objects := make([]object, 0, to-from)
+	for i := from; i < to; i++ {
+		objects = append(objects, object{item: item.NewItem(syntheticLine(i))})
+	}
+	return objects
+}
+
+// tickMsg appends lines while streaming
+type tickMsg struct{}
+
+// generatedMsg carries the lines generated for the filterable pane
+type generatedMsg struct {
+	objects []object
+	elapsed time.Duration
+}
+
+// generate returns a command generating the first n lines in the background
+func generate(n int) tea.Cmd {
+	return func() tea.Msg {
+		start := time.Now()
+		objects := syntheticObjects(0, n)
+		return generatedMsg{objects: objects, elapsed: time.Since(start)}
+	}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(appendInterval, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+type appKeys struct {
+	quit          key.Binding
+	switchPane    key.Binding
+	toggleStream  key.Binding
+	toggleFollow  key.Binding
+	toggleWrapKey key.Binding
+}
+
+var appKeyMap = appKeys{
+	quit:          key.NewBinding(key.WithKeys("ctrl+c"), key.WithHelp("ctrl+c", "quit")),
+	switchPane:    key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "switch pane")),
+	toggleStream:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "toggle streaming")),
+	toggleFollow:  key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "toggle follow")),
+	toggleWrapKey: key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "toggle wrapping")),
+}
+
+// pane is which viewport is shown
+type pane int
+
+const (
+	// paneLazy shows a viewport reading lines on demand from an ItemSource
+	paneLazy pane = iota
+
+	// paneFilter shows a filterable viewport holding every line, filtered in the background
+	paneFilter
+)
+
+// renderStats records how long the last render took, shown in the header
+type renderStats struct {
+	last time.Duration
+}
+
+type model struct {
+	// lazy reads its lines from source
+	lazy   *viewport.Model[object]
+	source *syntheticSource
+
+	// fv holds numLines lines, filtered asynchronously
+	fv *filterableviewport.Model[object]
+	vp *viewport.Model[object]
+
+	pane pane
+
+	// numLines is how many lines each pane has
+	numLines int
+
+	// linesPerTick is how many lines are appended every appendInterval while streaming
+	linesPerTick int
+	streaming    bool
+
+	// generated is how long generating the filterable pane's first lines took, valid once isGenerated
+	generated   time.Duration
+	isGenerated bool
+
+	stats *renderStats
+	ready bool
+}
+
+func (m model) Init() tea.Cmd {
+	return tick()
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if key.Matches(msg, appKeyMap.quit) {
+			return m, tea.Quit
+		}
+		if !m.ready {
+			return m, nil
+		}
+		if m.pane == paneFilter && m.fv.IsCapturingInput() {
+			m.fv, cmd = m.fv.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, appKeyMap.switchPane):
+			m.pane = 1 - m.pane
+			return m, nil
+		case key.Matches(msg, appKeyMap.toggleStream):
+			m.streaming = !m.streaming
+			return m, nil
+		case key.Matches(msg, appKeyMap.toggleFollow):
+			vp := m.activeViewport()
+			vp.SetFollowMode(!vp.GetFollowMode())
+			return m, nil
+		case key.Matches(msg, appKeyMap.toggleWrapKey):
+			vp := m.activeViewport()
+			vp.SetWrapText(!vp.GetWrapText())
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		// 2 for border, 3 for content above viewport
+		width, height := msg.Width-2, msg.Height-3-2
+		if !m.ready {
+			m.source = &syntheticSource{n: m.numLines}
+			m.lazy = viewport.New[object](
+				width,
+				height,
+				viewport.WithStyles[object](viewport.DefaultStyles()),
+				viewport.WithSelectionEnabled[object](true),
+				viewport.WithScrollbar[object](true),
+			)
+			m.lazy.SetItemSource(m.source)
+			m.lazy.SetFollowMode(true)
+
+			m.vp = viewport.New[object](
+				width,
+				height,
+				viewport.WithStyles[object](viewport.DefaultStyles()),
+				viewport.WithSelectionEnabled[object](true),
+				viewport.WithScrollbar[object](true),
+			)
+			m.fv = filterableviewport.New[object](
+				m.vp,
+				filterableviewport.WithStyles[object](filterableviewport.DefaultStyles()),
+				filterableviewport.WithPrefixText[object]("Filter:"),
+				filterableviewport.WithEmptyText[object]("No Current Filter"),
+				filterableviewport.WithCanToggleMatchingItemsOnly[object](true),
+				filterableviewport.WithAsyncFiltering[object](100_000),
+			)
+			m.vp.SetFollowMode(true)
+			m.ready = true
+			return m, generate(m.numLines)
+		} else {
+			m.lazy.SetWidth(width)
+			m.lazy.SetHeight(height)
+			m.fv.SetWidth(width)
+			m.fv.SetHeight(height)
+		}
+		return m, nil
+
+	case generatedMsg:
+		m.generated, m.isGenerated = msg.elapsed, true
+		// lines streamed in while generating
+		objects := append(msg.objects, syntheticObjects(len(msg.objects), m.numLines)...)
+		m.fv.SetObjects(objects)
+		return m, m.fv.FilterCmd()
+
+	case tickMsg:
+		if m.ready && m.streaming {
+			from := m.numLines
+			m.numLines += m.linesPerTick
+			m.source.n = m.numLines
+			m.lazy.RefreshItemSource()
+			if m.isGenerated {
+				m.fv.AppendObjects(syntheticObjects(from, m.numLines))
+				cmds = append(cmds, m.fv.FilterCmd())
+			}
+		}
+		cmds = append(cmds, tick())
+		return m, tea.Batch(cmds...)
+	}
+
+	if m.ready {
+		if m.pane == paneLazy {
+			m.lazy, cmd = m.lazy.Update(msg)
+		} else {
+			m.fv, cmd = m.fv.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// activeViewport returns the viewport of the shown pane
+func (m model) activeViewport() *viewport.Model[object] {
+	if m.pane == paneLazy {
+		return m.lazy
+	}
+	return m.vp
+}
+
+func (m model) View() tea.View {
+	content := "Initializing..."
+	if m.ready {
+		start := time.Now()
+		var paneView string
+		if m.pane == paneLazy {
+			paneView = m.lazy.View()
+		} else {
+			paneView = m.fv.View()
+		}
+		elapsed := time.Since(start)
+
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			strings.Join(m.header(), "\n"),
+			lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Render(paneView),
+		)
+		m.stats.last = elapsed
+	}
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
+
+func (m model) header() []string {
+	title := "Lazy ItemSource: lines are generated as they're viewed"
+	if m.pane == paneFilter {
+		title = "Filterable, async filtering: generating lines..."
+		if m.isGenerated {
+			title = fmt.Sprintf("Filterable, async filtering: first lines generated in %s",
+				m.generated.Round(time.Millisecond))
+		}
+	}
+	vp := m.activeViewport()
+	return []string{
+		lipgloss.NewStyle().Bold(true).Render(title),
+		fmt.Sprintf("%d lines, streaming %t, following %t, wrapped %t, last render %s",
+			m.numLines, m.streaming, vp.IsFollowing(), vp.GetWrapText(), m.stats.last.Round(time.Microsecond)),
+		getShortHelp([]key.Binding{
+			appKeyMap.switchPane,
+			appKeyMap.toggleStream,
+			appKeyMap.toggleFollow,
+			appKeyMap.toggleWrapKey,
+			appKeyMap.quit,
+		}),
+	}
+}
+
+func getShortHelp(bindings []key.Binding) string {
+	var output string
+	for _, km := range bindings {
+		output += km.Help().Key + " " + km.Help().Desc + "  "
+	}
+	return strings.TrimSpace(output)
+}
+
+func main() {
+	numLines := flag.Int("lines", 1_000_000, "number of lines to start with")
+	linesPerTick := flag.Int("rate", 1_000, "lines appended every 100ms while streaming")
+	flag.Parse()
+
+	p := tea.NewProgram(model{
+		numLines:     max(0, *numLines),
+		linesPerTick: max(0, *linesPerTick),
+		streaming:    true,
+		stats:        &renderStats{},
+	})
+	if _, err := p.Run(); err != nil {
+		fmt.Println("could not run program:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+var (
+	// gzipMagic and zstdMagic are the bytes gzip and zstd streams start with
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// decompressed returns r decompressed if it's gzip or zstd, detected by its first bytes rather than a file extension
+// so that compressed standard input works too, or r as it is otherwise. Go has no zstd decoder in its standard
+// library, so zstd is decompressed by the zstd command. Close the reader when done, stopping the command if it's still
+// running, e.g. when quitting before the input is read.
+func decompressed(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		// concatenated gzip streams, as from appending to a .gz file, are read as one
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(head, zstdMagic):
+		return zstdReader(br)
+	}
+	return io.NopCloser(br), nil
+}
+
+// isCompressed returns whether the file at path is gzip or zstd
+func isCompressed(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+	head := make([]byte, len(zstdMagic))
+	n, _ := io.ReadFull(f, head)
+	return bytes.HasPrefix(head[:n], gzipMagic) || bytes.HasPrefix(head[:n], zstdMagic)
+}
+
+// zstdReader returns the output of the zstd command decompressing r
+func zstdReader(r io.Reader) (io.ReadCloser, error) {
+	path, err := exec.LookPath("zstd")
+	if err != nil {
+		return nil, fmt.Errorf("input is zstd compressed, which needs the zstd command installed and on PATH, or "+
+			"decompress it first: %w", err)
+	}
+	cmd := exec.Command(path, "--decompress", "--stdout")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// r is copied to the command here rather than by cmd, whose Wait would wait for the copy, which may be blocked
+	// reading r after the command is killed
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	go func() {
+		_, _ = io.Copy(in, r)
+		_ = in.Close()
+	}()
+	return &commandReader{out: out, cmd: cmd, stderr: &stderr}, nil
+}
+
+// commandReader reads the output of a command, reporting the command's failure once its output ends. Closing it
+// kills the command if it's still running.
+type commandReader struct {
+	out    io.Reader
+	cmd    *exec.Cmd
+	stderr *bytes.Buffer
+
+	// waitOnce waits for the command once, whether its output ended or the reader was closed first
+	waitOnce sync.Once
+	waitErr  error
+}
+
+func (c *commandReader) Read(p []byte) (int, error) {
+	n, err := c.out.Read(p)
+	if errors.Is(err, io.EOF) {
+		if waitErr := c.wait(); waitErr != nil {
+			return n, fmt.Errorf("%s: %w: %s", filepath.Base(c.cmd.Path), waitErr, bytes.TrimSpace(c.stderr.Bytes()))
+		}
+	}
+	return n, err
+}
+
+// Close kills the command if it's still running and waits for it to exit
+func (c *commandReader) Close() error {
+	_ = c.cmd.Process.Kill()
+	_ = c.wait()
+	return nil
+}
+
+// wait waits for the command to exit, returning why it failed if it did
+func (c *commandReader) wait() error {
+	c.waitOnce.Do(func() { c.waitErr = c.cmd.Wait() })
+	return c.waitErr
+}
@@ -0,0 +1,303 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/filterableviewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// jsonIndent is the indent per level of pretty-printed JSON
+const jsonIndent = "  "
+
+var (
+	jsonKeyStyle     = lipgloss.NewStyle().Foreground(lipgloss.Blue)
+	jsonStringStyle  = lipgloss.NewStyle().Foreground(lipgloss.Green)
+	jsonNumberStyle  = lipgloss.NewStyle().Foreground(lipgloss.Yellow)
+	jsonKeywordStyle = lipgloss.NewStyle().Foreground(lipgloss.Magenta)
+)
+
+// jsonView is how lines holding JSON are shown, shared by every object
+type jsonView struct {
+	// formatted is true to colorize JSON lines, false to show them raw
+	formatted bool
+
+	// pretty is true to indent formatted JSON over several lines
+	pretty bool
+
+	// byText maps the unstyled text of each line formatted differently from its raw content to its formatting, so
+	// that filters match the raw content
+	byText map[string]*formattedJSON
+}
+
+func newJSONView(formatted, pretty bool) *jsonView {
+	return &jsonView{formatted: formatted, pretty: pretty, byText: make(map[string]*formattedJSON)}
+}
+
+// formattedJSON is a line of JSON formatted for display
+type formattedJSON struct {
+	item item.Item
+
+	// raw is the line as read
+	raw string
+
+	// starts and ends are the byte offsets in the unstyled formatted text where each byte of raw starts and ends,
+	// equal for whitespace dropped when pretty-printing
+	starts, ends []int
+}
+
+// toFormatted maps a byte range of the raw line onto the formatted text
+func (f *formattedJSON) toFormatted(r item.ByteRange) item.ByteRange {
+	if r.End <= r.Start {
+		return item.ByteRange{Start: f.starts[r.Start], End: f.starts[r.Start]}
+	}
+	return item.ByteRange{Start: f.starts[r.Start], End: f.ends[r.End-1]}
+}
+
+// inputLine is a line of input, with its JSON formatting computed when first shown
+type inputLine struct {
+	raw item.Item
+
+	// origin is the input the line was read from when several are merged, nil otherwise
+	origin *origin
+
+	// compact and pretty are the line formatted on one line and indented, nil until needed or if it isn't JSON
+	compact, pretty *formattedJSON
+
+	// notJSON is true once the line is known not to hold a JSON object or array
+	notJSON bool
+}
+
+func newInputLine(raw string, origin *origin) *inputLine {
+	return &inputLine{raw: item.NewItem(raw), origin: origin}
+}
+
+// itemFor returns the item showing the line in view
+func (l *inputLine) itemFor(view *jsonView) item.Item {
+	if !view.formatted || l.notJSON {
+		return l.raw
+	}
+	cached := &l.compact
+	if view.pretty {
+		cached = &l.pretty
+	}
+	if *cached == nil {
+		f, ok := formatJSON(l.raw.ContentNoAnsi(), view.pretty)
+		if !ok {
+			l.notJSON = true
+			return l.raw
+		}
+		if text := f.item.ContentNoAnsi(); text != f.raw {
+			view.byText[text] = f
+		}
+		*cached = f
+	}
+	return (*cached).item
+}
+
+// rawFilterModes returns the default filter modes, matching the raw content of JSON lines shown formatted and
+// highlighting the matches where they show in the formatted text. The labels of origins aren't matched; filter by
+// origin with "origin:<name>" instead.
+func rawFilterModes(view *jsonView, origins []*origin) []filterableviewport.FilterMode {
+	modes := filterableviewport.DefaultFilterModes()
+	for i := range modes {
+		mode := modes[i]
+		modes[i].GetMatcher = func(filterText string) (filterableviewport.Matcher, error) {
+			matcher, err := mode.NewMatcher(filterText)
+			if err != nil || matcher == nil {
+				return nil, err
+			}
+			return rawMatcher{matcher: matcher, view: view, origins: origins}, nil
+		}
+	}
+	return modes
+}
+
+// rawMatcher matches the raw content behind formatted JSON lines, after the label of their origin
+type rawMatcher struct {
+	matcher filterableviewport.Matcher
+	view    *jsonView
+	origins []*origin
+}
+
+func (r rawMatcher) Match(content string) []item.ByteRange {
+	content, label := cutLabel(content, r.origins)
+	var ranges []item.ByteRange
+	if f, ok := r.view.byText[content]; ok {
+		ranges = r.matcher.Match(f.raw)
+		for i := range ranges {
+			ranges[i] = f.toFormatted(ranges[i])
+		}
+	} else {
+		ranges = r.matcher.Match(content)
+	}
+	for i := range ranges {
+		ranges[i].Start += len(label)
+		ranges[i].End += len(label)
+	}
+	return ranges
+}
+
+// formatJSON colorizes raw, which must hold a JSON object or array, indenting it over several lines if pretty.
+// Returns false if raw isn't JSON.
+func formatJSON(raw string, pretty bool) (*formattedJSON, bool) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid([]byte(raw)) {
+		return nil, false
+	}
+	w := jsonWriter{
+		raw:    raw,
+		pretty: pretty,
+		starts: make([]int, len(raw)+1),
+		ends:   make([]int, len(raw)+1),
+	}
+	w.write()
+	text := w.styled.String()
+	f := &formattedJSON{raw: raw, starts: w.starts, ends: w.ends}
+	if strings.Contains(text, "\n") {
+		f.item = item.NewMultiLineItemFromString(text)
+	} else {
+		f.item = item.NewItem(text)
+	}
+	return f, true
+}
+
+// jsonWriter formats valid JSON, tracking where each raw byte ends up in the unstyled output
+type jsonWriter struct {
+	raw    string
+	pretty bool
+
+	styled strings.Builder
+
+	// plainLen is the length of the output without styling
+	plainLen int
+
+	starts, ends []int
+	depth        int
+}
+
+func (w *jsonWriter) write() {
+	for i := 0; i < len(w.raw); {
+		c := w.raw[i]
+		switch {
+		case c == '"':
+			end := stringEnd(w.raw, i)
+			style := &jsonStringStyle
+			if w.nextNonSpace(end) == ':' {
+				style = &jsonKeyStyle
+			}
+			w.emit(i, end, style)
+			i = end
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			if w.pretty {
+				w.drop(i)
+			} else {
+				w.emit(i, i+1, nil)
+			}
+			i++
+		case c == '{' || c == '[':
+			w.emit(i, i+1, nil)
+			w.depth++
+			if next := w.nextNonSpace(i + 1); next != '}' && next != ']' {
+				w.newline()
+			}
+			i++
+		case c == '}' || c == ']':
+			w.depth--
+			if prev := w.prevNonSpace(i); prev != '{' && prev != '[' {
+				w.newline()
+			}
+			w.emit(i, i+1, nil)
+			i++
+		case c == ',':
+			w.emit(i, i+1, nil)
+			w.newline()
+			i++
+		case c == ':':
+			w.emit(i, i+1, nil)
+			if w.pretty {
+				w.insert(" ")
+			}
+			i++
+		default:
+			end := i
+			for end < len(w.raw) && !strings.ContainsRune(",:]} \t\r\n", rune(w.raw[end])) {
+				end++
+			}
+			style := &jsonNumberStyle
+			if c == 't' || c == 'f' || c == 'n' {
+				style = &jsonKeywordStyle
+			}
+			w.emit(i, end, style)
+			i = end
+		}
+	}
+	w.starts[len(w.raw)], w.ends[len(w.raw)] = w.plainLen, w.plainLen
+}
+
+// emit writes raw[start:end] in style, unstyled if it's nil
+func (w *jsonWriter) emit(start, end int, style *lipgloss.Style) {
+	for k := start; k < end; k++ {
+		w.starts[k] = w.plainLen + k - start
+		w.ends[k] = w.starts[k] + 1
+	}
+	if style == nil {
+		w.styled.WriteString(w.raw[start:end])
+	} else {
+		w.styled.WriteString(style.Render(w.raw[start:end]))
+	}
+	w.plainLen += end - start
+}
+
+// drop leaves out the raw byte at i
+func (w *jsonWriter) drop(i int) {
+	w.starts[i], w.ends[i] = w.plainLen, w.plainLen
+}
+
+// insert writes s, which isn't in the raw line
+func (w *jsonWriter) insert(s string) {
+	w.styled.WriteString(s)
+	w.plainLen += len(s)
+}
+
+// newline starts a new, indented line when pretty-printing
+func (w *jsonWriter) newline() {
+	if w.pretty {
+		w.insert("\n" + strings.Repeat(jsonIndent, w.depth))
+	}
+}
+
+// nextNonSpace returns the first byte from i that isn't whitespace, 0 if there's none
+func (w *jsonWriter) nextNonSpace(i int) byte {
+	for ; i < len(w.raw); i++ {
+		if c := w.raw[i]; c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return c
+		}
+	}
+	return 0
+}
+
+// prevNonSpace returns the last byte before i that isn't whitespace, 0 if there's none
+func (w *jsonWriter) prevNonSpace(i int) byte {
+	for i--; i >= 0; i-- {
+		if c := w.raw[i]; c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return c
+		}
+	}
+	return 0
+}
+
+// stringEnd returns the index just past the JSON string starting with the quote at start
+func stringEnd(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(s)
+}
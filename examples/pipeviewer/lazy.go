@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+const (
+	// lazyThreshold is the file size from which lines are read from the file on demand rather than into memory
+	lazyThreshold = 256 << 20
+
+	// indexingRefreshInterval is how often the view shows the lines indexed so far while a file is indexed
+	indexingRefreshInterval = 100 * time.Millisecond
+
+	// followInterval is how often a followed file is checked for new lines
+	followInterval = time.Second
+)
+
+// indexedMsg reports that the file was indexed up to its end
+type indexedMsg struct {
+	err error
+}
+
+// indexingTickMsg refreshes the view while the file is indexed
+type indexingTickMsg struct{}
+
+// followTickMsg checks a followed file for new lines
+type followTickMsg struct{}
+
+// lazyModel views a file too large to read into memory, reading the lines in view from a FileSource. Filtering reads
+// every line, so it isn't offered.
+type lazyModel struct {
+	vp     *viewport.Model[object]
+	source *viewport.FileSource[object]
+	opts   options
+
+	// indexing is true while the file is indexed
+	indexing bool
+
+	// wentToLine is true once the --goto-line flag has been applied
+	wentToLine bool
+
+	ready bool
+	err   error
+}
+
+// newLazyModel returns a model reading the lines of the file at path on demand
+func newLazyModel(path string, opts options) (lazyModel, error) {
+	view := newJSONView(opts.json || opts.pretty, opts.pretty)
+	source, err := viewport.OpenFileSource(path, func(line string) object {
+		return object{line: newInputLine(line, nil), view: view}
+	})
+	if err != nil {
+		return lazyModel{}, err
+	}
+	return lazyModel{source: source, opts: opts, indexing: true}, nil
+}
+
+func (m lazyModel) Init() tea.Cmd {
+	return tea.Batch(m.index(), indexingTick())
+}
+
+func (m lazyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if msg.String() == "ctrl+c" || (key.Matches(msg, quitKey) && (!m.ready || !m.vp.IsCapturingInput())) {
+			return m, tea.Quit
+		}
+		if m.ready && !m.vp.IsCapturingInput() && key.Matches(msg, invisibleKey) {
+			m.vp.SetShowInvisibles(!m.vp.GetShowInvisibles())
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			m.vp = viewport.New[object](
+				msg.Width,
+				msg.Height,
+				viewport.WithStyles[object](viewport.DefaultStyles()),
+				viewport.WithSelectionEnabled[object](true),
+				viewport.WithFollowMode[object](m.opts.follow),
+				viewport.WithLineNumbers[object](m.opts.lineNumberMode(), 1),
+				viewport.WithTabWidth[object](8),
+				viewport.WithHexView[object](viewport.HexViewBinary),
+				viewport.WithKeyMap[object](keyMap()),
+			)
+			m.vp.SetItemSource(m.source)
+			m.ready = true
+			m.maybeGoToLine()
+		} else {
+			m.vp.SetWidth(msg.Width)
+			m.vp.SetHeight(msg.Height)
+		}
+
+	case indexingTickMsg:
+		if m.ready {
+			m.vp.RefreshItemSource()
+		}
+		if m.indexing {
+			return m, indexingTick()
+		}
+		return m, nil
+
+	case indexedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		m.indexing = false
+		if m.ready {
+			m.vp.RefreshItemSource()
+			m.maybeGoToLine()
+		}
+		if m.opts.follow {
+			return m, tea.Tick(followInterval, func(time.Time) tea.Msg { return followTickMsg{} })
+		}
+		return m, nil
+
+	case followTickMsg:
+		return m, m.index()
+	}
+
+	if m.ready {
+		var cmd tea.Cmd
+		m.vp, cmd = m.vp.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// index returns a command indexing the lines of the file not yet indexed
+func (m lazyModel) index() tea.Cmd {
+	source := m.source
+	return func() tea.Msg {
+		return indexedMsg{err: source.Refresh()}
+	}
+}
+
+func indexingTick() tea.Cmd {
+	return tea.Tick(indexingRefreshInterval, func(time.Time) tea.Msg { return indexingTickMsg{} })
+}
+
+// maybeGoToLine selects the line of the --goto-line flag once the file is indexed
+func (m *lazyModel) maybeGoToLine() {
+	if m.opts.gotoLine <= 0 || m.wentToLine || m.indexing || !m.ready {
+		return
+	}
+	m.wentToLine = true
+	lineIdx := min(m.opts.gotoLine, m.source.Len()) - 1
+	m.vp.SetSelectedItemIdx(lineIdx)
+	m.vp.EnsureItemInView(lineIdx, 0, 0, m.vp.GetHeight()/2, 0)
+}
+
+func (m lazyModel) View() tea.View {
+	content := fmt.Sprintf("Indexing... %d lines", m.source.Len())
+	if m.ready {
+		content = m.vp.View()
+	}
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
@@ -0,0 +1,536 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/filterableviewport"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// maxLinesPerMsg caps how many lines are appended to the viewport at once while reading input
+const maxLinesPerMsg = 10_000
+
+type object struct {
+	line *inputLine
+	view *jsonView
+}
+
+func (o object) GetItem() item.Item {
+	itm := o.line.itemFor(o.view)
+	if o.line.origin != nil {
+		return o.line.origin.labeled(itm)
+	}
+	return itm
+}
+
+// options are the startup flags
+type options struct {
+	// filter and regex set an initial exact or regex filter; at most one is non-empty
+	filter, regex string
+
+	// gotoLine selects the 1-based line, 0 for none
+	gotoLine int
+
+	// follow keeps the view at the bottom as input arrives
+	follow bool
+
+	// followFile keeps reading the file as it grows and is rotated, like tail -F, implying follow
+	followFile bool
+
+	// json starts with JSON lines formatted, and pretty indents them over several lines
+	json, pretty bool
+
+	// lazy reads lines from the file on demand instead of into memory, as for files of lazyThreshold bytes or more
+	lazy bool
+
+	// lineNumbers numbers the lines
+	lineNumbers bool
+}
+
+// lineNumberMode returns how lines are numbered
+func (o options) lineNumberMode() viewport.LineNumberMode {
+	if o.lineNumbers {
+		return viewport.LineNumbersAbsolute
+	}
+	return viewport.LineNumbersOff
+}
+
+// lineRead is a line read from an input
+type lineRead struct {
+	text string
+
+	// origin is the input it was read from when several are merged, nil otherwise
+	origin *origin
+}
+
+// linesMsg carries lines read from the inputs
+type linesMsg struct {
+	lines []lineRead
+
+	// eof is true once the inputs have been read entirely
+	eof bool
+}
+
+// readErrMsg reports an error reading the input
+type readErrMsg struct {
+	err error
+}
+
+var (
+	quitKey       = key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit"))
+	toggleJSONKey = key.NewBinding(key.WithKeys("J"), key.WithHelp("J", "toggle raw/formatted JSON"))
+	prettyJSONKey = key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "toggle pretty-printed JSON"))
+	invisibleKey  = key.NewBinding(key.WithKeys("I"), key.WithHelp("I", "toggle showing invisible characters"))
+)
+
+// keyMap returns the viewport's key bindings, with the hex view toggle enabled for binary input and the go-to input
+// enabled for jumping around large files
+func keyMap() viewport.KeyMap {
+	km := viewport.DefaultKeyMap()
+	km.ToggleHexView.SetEnabled(true)
+	km.GoTo.SetEnabled(true)
+	return km
+}
+
+type model struct {
+	fv   *filterableviewport.Model[object]
+	vp   *viewport.Model[object]
+	opts options
+
+	// lines receives lines read from the inputs in the order they arrive, closed at the end of them, nil when
+	// following files
+	lines <-chan lineRead
+
+	// followed are the files read as they grow with -F by path, nil otherwise
+	followed map[string]followedFile
+
+	// origins are the inputs merged, nil for a single input
+	origins []*origin
+
+	// objects holds every line read, kept to refresh the viewport when the JSON view changes
+	objects []object
+
+	// view is how JSON lines are shown
+	view *jsonView
+
+	// numLines is how many lines have been read
+	numLines int
+
+	// eof is true once the input has been read entirely
+	eof bool
+
+	// wentToLine is true once the --goto-line flag has been applied
+	wentToLine bool
+
+	ready bool
+	err   error
+}
+
+// followedFile is a file read as it grows with -F
+type followedFile struct {
+	follower *viewport.FileFollower
+	origin   *origin
+}
+
+func (m model) Init() tea.Cmd {
+	if m.followed != nil {
+		var cmds []tea.Cmd
+		for _, f := range m.followed {
+			cmds = append(cmds, f.follower.Next())
+		}
+		return tea.Batch(cmds...)
+	}
+	return waitForLines(m.lines)
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if msg.String() == "ctrl+c" || (key.Matches(msg, quitKey) && (!m.ready || !m.fv.IsCapturingInput())) {
+			return m, tea.Quit
+		}
+		if m.ready && !m.fv.IsCapturingInput() {
+			switch {
+			case key.Matches(msg, toggleJSONKey):
+				m.view.formatted = !m.view.formatted
+				m.fv.SetObjects(m.objects)
+				return m, nil
+			case key.Matches(msg, prettyJSONKey):
+				m.view.pretty = !m.view.pretty
+				m.view.formatted = true
+				m.fv.SetObjects(m.objects)
+				return m, nil
+			case key.Matches(msg, invisibleKey):
+				m.vp.SetShowInvisibles(!m.vp.GetShowInvisibles())
+				return m, nil
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		if !m.ready {
+			m.vp = viewport.New[object](
+				msg.Width,
+				msg.Height,
+				viewport.WithStyles[object](viewport.DefaultStyles()),
+				viewport.WithSelectionEnabled[object](true),
+				viewport.WithFollowMode[object](m.opts.follow),
+				viewport.WithLineNumbers[object](m.opts.lineNumberMode(), 1),
+				viewport.WithTabWidth[object](8),
+				viewport.WithHexView[object](viewport.HexViewBinary),
+				viewport.WithKeyMap[object](keyMap()),
+			)
+			m.fv = filterableviewport.New[object](
+				m.vp,
+				filterableviewport.WithStyles[object](filterableviewport.DefaultStyles()),
+				filterableviewport.WithPrefixText[object]("Filter:"),
+				filterableviewport.WithEmptyText[object]("No Current Filter"),
+				filterableviewport.WithCanToggleMatchingItemsOnly[object](true),
+				filterableviewport.WithFilterModes[object](rawFilterModes(m.view, m.origins)),
+				filterableviewport.WithFieldAccessors(map[string]func(object) string{
+					"origin": func(o object) string {
+						if o.line.origin == nil {
+							return ""
+						}
+						return o.line.origin.name
+					},
+				}),
+			)
+			m.fv.SetObjects(m.objects)
+			switch {
+			case m.opts.filter != "":
+				m.fv.SetFilter(m.opts.filter, filterableviewport.FilterExact)
+			case m.opts.regex != "":
+				m.fv.SetFilter(m.opts.regex, filterableviewport.FilterRegex)
+			}
+			if m.lines != nil && !m.eof {
+				m.vp.SetLoadingMessage("Reading...")
+				cmds = append(cmds, m.vp.SetLoading(true))
+			}
+			m.ready = true
+			m.maybeGoToLine()
+		} else {
+			m.fv.SetWidth(msg.Width)
+			m.fv.SetHeight(msg.Height)
+		}
+
+	case linesMsg:
+		m.eof = msg.eof
+		m.appendLines(msg.lines)
+		if !msg.eof {
+			cmds = append(cmds, waitForLines(m.lines))
+		} else if m.ready {
+			m.vp.SetLoading(false)
+		}
+		return m, tea.Batch(cmds...)
+
+	case viewport.FileLinesMsg:
+		f := m.followed[msg.Path]
+		if msg.Truncated && len(m.followed) == 1 {
+			// like the file, start over from the top
+			m.objects, m.numLines = nil, 0
+			if m.ready {
+				m.fv.SetObjects(nil)
+			}
+		}
+		// Next retries after errors, e.g. while the file is briefly unreadable during rotation. The file is read up to
+		// its current end, so a --goto-line past it selects the last line.
+		m.eof = true
+		lines := make([]lineRead, len(msg.Lines))
+		for i, text := range msg.Lines {
+			lines[i] = lineRead{text: text, origin: f.origin}
+		}
+		m.appendLines(lines)
+		return m, f.follower.Next()
+
+	case readErrMsg:
+		m.err = msg.err
+		return m, tea.Quit
+	}
+
+	if m.ready {
+		var cmd tea.Cmd
+		m.fv, cmd = m.fv.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// appendLines adds lines read from the inputs
+func (m *model) appendLines(lines []lineRead) {
+	objects := make([]object, len(lines))
+	for i, line := range lines {
+		objects[i] = object{line: newInputLine(line.text, line.origin), view: m.view}
+	}
+	m.objects = append(m.objects, objects...)
+	m.numLines += len(objects)
+	if m.ready {
+		m.fv.AppendObjects(objects)
+		m.maybeGoToLine()
+	}
+}
+
+// maybeGoToLine selects the line of the --goto-line flag once it's been read, or the last line if the input ends
+// before it
+func (m *model) maybeGoToLine() {
+	if m.opts.gotoLine <= 0 || m.wentToLine || !m.ready {
+		return
+	}
+	if m.numLines < m.opts.gotoLine && !m.eof {
+		return
+	}
+	m.wentToLine = true
+	lineIdx := min(m.opts.gotoLine, m.numLines) - 1
+	if m.fv.GetMatchingItemsOnly() && m.fv.GetFilterText() != "" {
+		// line numbers refer to the input, not the filtered lines
+		m.fv.SetMatchingItemsOnly(false)
+	}
+	m.fv.SetSelectedItemIdx(lineIdx)
+	m.vp.EnsureItemInView(lineIdx, 0, 0, m.vp.GetHeight()/2, 0)
+}
+
+func (m model) View() tea.View {
+	content := "Reading input..."
+	if m.ready {
+		content = m.fv.View()
+	}
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
+
+// waitForLines returns a command awaiting the next lines read from the inputs, returning as many as are already
+// available up to maxLinesPerMsg
+func waitForLines(lines <-chan lineRead) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return linesMsg{eof: true}
+		}
+		batch := []lineRead{line}
+		for len(batch) < maxLinesPerMsg {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					return linesMsg{lines: batch, eof: true}
+				}
+				batch = append(batch, line)
+			default:
+				return linesMsg{lines: batch}
+			}
+		}
+		return linesMsg{lines: batch}
+	}
+}
+
+// readLines sends each line of r, read from origin, to lines
+func readLines(r io.Reader, origin *origin, lines chan<- lineRead, errs chan<- error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lines <- lineRead{text: scanner.Text(), origin: origin}
+	}
+	if err := scanner.Err(); err != nil {
+		if origin != nil {
+			err = fmt.Errorf("%s: %w", origin.name, err)
+		}
+		select {
+		case errs <- err:
+		default:
+			// another input's error is already being reported
+		}
+	}
+}
+
+func parseFlags(args []string) (options, []string, error) {
+	var opts options
+	fs := flag.NewFlagSet("pipeviewer", flag.ContinueOnError)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "usage: pipeviewer [flags] [file...]\n\nViews files or, without any, standard input, decompressing gzip and zstd. Lines of several files, or - for standard input, are merged as they arrive, labeled by file; filter by file with origin:<name>.\n\nflags:")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.filter, "filter", "", "start filtered to lines containing `text`")
+	fs.StringVar(&opts.regex, "regex", "", "start filtered to lines matching `pattern`")
+	fs.IntVar(&opts.gotoLine, "goto-line", 0, "start with 1-based line `n` selected")
+	fs.BoolVar(&opts.follow, "follow", false, "follow new input at the bottom, like tail -f")
+	fs.BoolVar(&opts.followFile, "F", false, "keep reading the files as they grow, truncated or rotated, like tail -F (implies --follow)")
+	fs.BoolVar(&opts.json, "json", false, "start with JSON lines colorized (toggle with J)")
+	fs.BoolVar(&opts.pretty, "pretty", false, "pretty-print colorized JSON lines over several lines (toggle with P)")
+	fs.BoolVar(&opts.lineNumbers, "n", false, "number the lines")
+	fs.BoolVar(&opts.lazy, "lazy", false, "read lines from the file as they're viewed instead of into memory, without filtering; the default for files of 256MB or more")
+	if err := fs.Parse(args); err != nil {
+		return opts, nil, err
+	}
+	if opts.filter != "" && opts.regex != "" {
+		return opts, nil, errors.New("--filter and --regex are mutually exclusive")
+	}
+	if opts.gotoLine < 0 {
+		return opts, nil, errors.New("--goto-line must be positive")
+	}
+	numStdin := 0
+	for _, arg := range fs.Args() {
+		if arg == stdinArg {
+			numStdin++
+		}
+	}
+	if numStdin > 1 || (numStdin > 0 && opts.followFile) {
+		return opts, nil, errors.New("standard input may be given once, and not with -F")
+	}
+	if opts.followFile {
+		if fs.NArg() == 0 {
+			return opts, nil, errors.New("-F needs a file")
+		}
+		opts.follow = true
+	}
+	if opts.lazy && (fs.NArg() != 1 || opts.filter != "" || opts.regex != "") {
+		return opts, nil, errors.New("--lazy needs a single file and can't be used with --filter or --regex")
+	}
+	return opts, fs.Args(), nil
+}
+
+func main() {
+	opts, args, err := parseFlags(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pipeviewer:", err)
+		os.Exit(2)
+	}
+
+	if len(args) == 1 && args[0] != stdinArg && useLazy(args[0], opts) {
+		runLazy(args[0], opts)
+		return
+	}
+
+	view := newJSONView(opts.json || opts.pretty, opts.pretty)
+	origins := newOrigins(args)
+	originAt := func(i int) *origin {
+		if origins == nil {
+			return nil
+		}
+		return origins[i]
+	}
+
+	if opts.followFile {
+		followed := make(map[string]followedFile, len(args))
+		var followers []io.Closer
+		for i, path := range args {
+			if isCompressed(path) {
+				fmt.Fprintln(os.Stderr, "pipeviewer: -F can't follow a compressed file:", path)
+				os.Exit(2)
+			}
+			follower := viewport.NewFileFollower(path, 0)
+			followers = append(followers, follower)
+			followed[path] = followedFile{follower: follower, origin: originAt(i)}
+		}
+		run(tea.NewProgram(model{opts: opts, followed: followed, origins: origins, view: view}), followers...)
+		return
+	}
+
+	if len(args) == 0 {
+		args = []string{stdinArg}
+	}
+	inputs := make([]io.ReadCloser, len(args))
+	closers := make([]io.Closer, len(args))
+	for i, arg := range args {
+		input := io.Reader(os.Stdin)
+		if arg != stdinArg {
+			f, err := os.Open(arg)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "pipeviewer:", err)
+				os.Exit(1)
+			}
+			defer func() { _ = f.Close() }()
+			input = f
+		}
+		if inputs[i], err = decompressed(input); err != nil {
+			fmt.Fprintln(os.Stderr, "pipeviewer:", err)
+			os.Exit(1)
+		}
+		closers[i] = inputs[i]
+	}
+
+	lines := make(chan lineRead, maxLinesPerMsg)
+	errs := make(chan error, 1)
+	var wg sync.WaitGroup
+	for i, input := range inputs {
+		wg.Go(func() { readLines(input, originAt(i), lines, errs) })
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	p := tea.NewProgram(model{opts: opts, lines: lines, origins: origins, view: view})
+	go func() {
+		if err := <-errs; err != nil {
+			p.Send(readErrMsg{err: err})
+		}
+	}()
+	run(p, closers...)
+}
+
+// run runs p, closing closers once it exits, e.g. to stop decompressing input that's no longer read, then exits on an
+// error running it or reading the input
+func run(p *tea.Program, closers ...io.Closer) {
+	final, err := p.Run()
+	for _, c := range closers {
+		_ = c.Close()
+	}
+	if err != nil {
+		fmt.Println("could not run program:", err)
+		os.Exit(1)
+	}
+	if m, ok := final.(model); ok && m.err != nil {
+		fmt.Fprintln(os.Stderr, "pipeviewer: reading input:", m.err)
+		os.Exit(1)
+	}
+}
+
+// useLazy returns whether the file at path is read on demand: with --lazy, or when it's large, not filtered from the
+// start, as filtering reads every line, and not compressed, as compressed lines can only be read in order
+func useLazy(path string, opts options) bool {
+	if opts.lazy {
+		return true
+	}
+	if opts.filter != "" || opts.regex != "" || isCompressed(path) {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Size() >= lazyThreshold
+}
+
+// runLazy views the file at path, reading its lines on demand
+func runLazy(path string, opts options) {
+	if isCompressed(path) {
+		fmt.Fprintln(os.Stderr, "pipeviewer: --lazy can't read a compressed file on demand")
+		os.Exit(2)
+	}
+	m, err := newLazyModel(path, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "pipeviewer:", err)
+		os.Exit(1)
+	}
+	defer func() { _ = m.source.Close() }()
+
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		fmt.Println("could not run program:", err)
+		os.Exit(1)
+	}
+	if m, ok := final.(lazyModel); ok && m.err != nil {
+		fmt.Fprintln(os.Stderr, "pipeviewer: reading input:", m.err)
+		os.Exit(1)
+	}
+}
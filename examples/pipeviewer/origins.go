@@ -0,0 +1,93 @@
+package main
+
+import (
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// stdinArg names standard input among the inputs
+const stdinArg = "-"
+
+// originColors are the colors of the labels of merged inputs, in order
+var originColors = []color.Color{lipgloss.Cyan, lipgloss.Magenta, lipgloss.Yellow, lipgloss.Green, lipgloss.Blue,
+	lipgloss.Red}
+
+// origin is an input lines are read from, labeled in a column pinned left of its lines when several inputs are merged
+type origin struct {
+	// name identifies the input in filters like "origin:api.log"
+	name string
+
+	// label is the name padded to the width of the longest, styled in the input's color, and text the same unstyled
+	label item.SingleItem
+	text  string
+}
+
+// newOrigins returns the origins of the inputs named by args, nil for a single input, which isn't labeled
+func newOrigins(args []string) []*origin {
+	if len(args) < 2 {
+		return nil
+	}
+	names := make([]string, len(args))
+	numNamed := make(map[string]int)
+	for i, arg := range args {
+		names[i] = inputName(arg)
+		numNamed[names[i]]++
+	}
+	width := 0
+	for i, arg := range args {
+		if numNamed[names[i]] > 1 {
+			// e.g. app.log in two directories
+			names[i] = arg
+		}
+		width = max(width, lipgloss.Width(names[i]))
+	}
+
+	origins := make([]*origin, len(args))
+	for i, name := range names {
+		pad := strings.Repeat(" ", width-lipgloss.Width(name)+1)
+		style := lipgloss.NewStyle().Foreground(originColors[i%len(originColors)])
+		origins[i] = &origin{name: name, label: item.NewItem(style.Render(name) + pad), text: name + pad}
+	}
+	return origins
+}
+
+// inputName returns the name of the input arg: its file name, or stdin
+func inputName(arg string) string {
+	if arg == stdinArg {
+		return "stdin"
+	}
+	return filepath.Base(arg)
+}
+
+// labeled returns itm prefixed by the origin's label, which stays in place when panning unless itm spans several
+// lines, whose first shows the label with the rest indented to match
+func (o *origin) labeled(itm item.Item) item.Item {
+	if single, ok := itm.(item.SingleItem); ok {
+		return item.NewConcatWithPinned(1, o.label, single)
+	}
+	lines := itm.LineBrokenItems()
+	labeled := make([]item.SingleItem, len(lines))
+	indent := strings.Repeat(" ", o.label.Width())
+	for i, line := range lines {
+		prefix := indent
+		if i == 0 {
+			prefix = o.label.Content()
+		}
+		labeled[i] = item.NewItem(prefix + line.Content())
+	}
+	return item.NewMultiLineItem(labeled...)
+}
+
+// cutLabel returns content without the label of any of origins it starts with, and the label cut
+func cutLabel(content string, origins []*origin) (string, string) {
+	for _, o := range origins {
+		if rest, ok := strings.CutPrefix(content, o.text); ok {
+			return rest, o.text
+		}
+	}
+	return content, ""
+}
@@ -73,7 +73,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				viewportHeight,
 				viewport.WithKeyMap[object](keyMap),
 				viewport.WithStyles[object](styles),
+				viewport.WithMouseSupport[object](true),
 			)
+			// 1 for the left border, 4 for content above viewport and 1 for the top border
+			m.viewport.SetMouseOrigin(1, 4+1)
 			m.viewport.SetObjects(m.lines)
 			m.viewport.SetSelectionEnabled(false)
 			m.viewport.SetWrapText(true)
@@ -122,6 +125,7 @@ func (m model) View() tea.View {
 	}
 	v := tea.NewView(content)
 	v.AltScreen = true
+	v.MouseMode = tea.MouseModeCellMotion
 	return v
 }
 
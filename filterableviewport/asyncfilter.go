@@ -0,0 +1,237 @@
+package filterableviewport
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// defaultAsyncFilterChunkSize is how many objects a background filter pass matches between progress messages
+const defaultAsyncFilterChunkSize = 10_000
+
+// FilterProgressMsg carries the matches of a chunk of objects from a background filter pass. Return it to the
+// filterable viewport's Update, which applies it and returns the command awaiting the next chunk.
+type FilterProgressMsg struct {
+	job   *filterJob
+	chunk filterChunk
+}
+
+// itemMatches are an object's matches in a filter pass. An object can match without matches in its content, e.g. on
+// a field that isn't shown.
+type itemMatches struct {
+	itemIdx int
+	matches []item.Match
+}
+
+// filterChunk is the result of matching a range of objects
+type filterChunk struct {
+	// matched are the matching objects in the range, in order
+	matched []itemMatches
+
+	// scanned is how many objects have been matched so far in the pass
+	scanned int
+
+	// done is true for the pass's last chunk
+	done bool
+}
+
+// filterJob is a filter pass running in a background goroutine
+type filterJob struct {
+	// numObjects is how many objects the pass matches. Objects appended during the pass are matched when it finishes.
+	numObjects int
+
+	// scanned is how many objects have been applied from received chunks
+	scanned int
+
+	// filterChanged and prevFocusedMatchIdx are the state before the pass, deciding which match is focused
+	filterChanged       bool
+	prevFocusedMatchIdx int
+
+	chunks chan filterChunk
+	cancel chan struct{}
+}
+
+// nextChunkCmd returns a command awaiting the job's next chunk, nil once the job is canceled
+func (j *filterJob) nextChunkCmd() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case chunk := <-j.chunks:
+			return FilterProgressMsg{job: j, chunk: chunk}
+		case <-j.cancel:
+			return nil
+		}
+	}
+}
+
+// run matches objects in chunks, sending each chunk's matches until done or canceled
+func (j *filterJob) run(match func(idx int) ([]item.Match, bool), chunkSize int) {
+	for start := 0; ; start += chunkSize {
+		end := min(start+chunkSize, j.numObjects)
+		var chunk filterChunk
+		for idx := start; idx < end; idx++ {
+			if matches, ok := match(idx); ok {
+				chunk.matched = append(chunk.matched, itemMatches{itemIdx: idx, matches: matches})
+			}
+		}
+		chunk.scanned = end
+		chunk.done = end == j.numObjects
+		select {
+		case j.chunks <- chunk:
+		case <-j.cancel:
+			return
+		}
+		if chunk.done {
+			return
+		}
+	}
+}
+
+// startFilterJob starts matching in the background when async filtering is on and there are enough objects,
+// returning false if the filter should run synchronously instead
+func (m *Model[T]) startFilterJob() bool {
+	filterValue := m.filterTextInput.Value()
+	if m.asyncFilterThreshold <= 0 || len(m.objects) < m.asyncFilterThreshold ||
+		m.filterMode == filterModeOff || filterValue == "" {
+		return false
+	}
+	matcher, err := m.getObjectMatcher(filterValue)
+	if err != nil || matcher == nil {
+		return false
+	}
+
+	// objects may be adjusted for the filter here
+	_, filterChanged, prevFocusedMatchIdx := m.resetMatches()
+	objects := m.objects
+	job := &filterJob{
+		numObjects:          len(objects),
+		filterChanged:       filterChanged,
+		prevFocusedMatchIdx: prevFocusedMatchIdx,
+		chunks:              make(chan filterChunk, 1),
+		cancel:              make(chan struct{}),
+	}
+	m.filterJob = job
+	go job.run(func(idx int) ([]item.Match, bool) {
+		return matcher(objects[idx])
+	}, m.asyncFilterChunkSize)
+	m.pendingCmd = job.nextChunkCmd()
+
+	m.applyMatchingObjects([]T{}, false)
+	return true
+}
+
+// cancelFilterJob stops the running background filter pass, if any
+func (m *Model[T]) cancelFilterJob() {
+	if m.filterJob != nil {
+		close(m.filterJob.cancel)
+		m.filterJob = nil
+	}
+}
+
+// handleFilterProgress applies a chunk of a background filter pass, returning the command awaiting the next one
+func (m *Model[T]) handleFilterProgress(msg FilterProgressMsg) tea.Cmd {
+	job := m.filterJob
+	if job == nil || msg.job != job {
+		// a canceled pass
+		return nil
+	}
+	job.scanned = msg.chunk.scanned
+
+	prevNumMatches, prevNumMatchingItems := len(m.allMatches), len(m.matchingItemIdxs)
+	prevFocusedMatchIdx := m.focusedMatchIdx
+	for _, matched := range msg.chunk.matched {
+		if m.maxMatchLimit > 0 && m.totalMatchesOnAllItems+len(matched.matches) > m.maxMatchLimit {
+			// no highlighting or navigation when the limit is exceeded
+			m.cancelFilterJob()
+			m.matchLimitExceeded = true
+			m.numMatchingItems = len(m.matchingItemIdxs) + 1
+			m.allMatches = []viewport.Highlight{}
+			m.focusedMatchIdx = -1
+			m.applyMatchingObjects(m.objects, false)
+			return nil
+		}
+		highlights := m.buildHighlightsFromMatches(matched.itemIdx, matched.matches, len(m.allMatches))
+		m.allMatches = append(m.allMatches, highlights...)
+		m.totalMatchesOnAllItems = len(m.allMatches)
+		m.itemIdxToFilteredIdx[matched.itemIdx] = len(m.matchingItemIdxs)
+		m.matchingItemIdxs = append(m.matchingItemIdxs, matched.itemIdx)
+	}
+
+	// focus the first match as soon as it arrives, or the previously focused one once there are enough matches
+	moveSelection := false
+	if m.focusedMatchIdx < 0 && len(m.allMatches) > 0 {
+		switch {
+		case job.filterChanged:
+			m.focusedMatchIdx = 0
+			moveSelection = true
+		case job.prevFocusedMatchIdx >= 0 && job.prevFocusedMatchIdx < len(m.allMatches):
+			m.focusedMatchIdx = job.prevFocusedMatchIdx
+		case msg.chunk.done:
+			m.focusedMatchIdx = 0
+		}
+	}
+
+	var cmd tea.Cmd
+	if msg.chunk.done {
+		m.filterJob = nil
+	} else {
+		cmd = job.nextChunkCmd()
+	}
+
+	// objects other than the matching ones don't change, so the viewport's objects only change when showing matches
+	// only. While the pass runs, only the chunk's matches are added, as setting them all for each chunk is quadratic,
+	// and once it's done they're all set again, e.g. to show context lines around them.
+	m.numMatchingItems = len(m.matchingItemIdxs)
+	if m.showMatchesOnly() {
+		if msg.chunk.done {
+			matchingObjects := make([]T, len(m.matchingItemIdxs))
+			for i, itemIdx := range m.matchingItemIdxs {
+				matchingObjects[i] = m.objects[itemIdx]
+			}
+			m.showMatchingObjects(matchingObjects)
+		} else if newItemIdxs := m.matchingItemIdxs[prevNumMatchingItems:]; len(newItemIdxs) > 0 {
+			newObjects := make([]T, len(newItemIdxs))
+			for i, itemIdx := range newItemIdxs {
+				newObjects[i] = m.objects[itemIdx]
+			}
+			m.vp.AppendObjects(newObjects)
+		}
+	}
+	if moveSelection {
+		m.setSelectionToCurrentMatch()
+	}
+	if msg.chunk.done || m.focusedMatchIdx < 0 || m.focusedMatchIdx != prevFocusedMatchIdx {
+		m.updateFocusedMatchHighlight()
+	} else {
+		m.appendMatchHighlights(prevNumMatches)
+	}
+	if moveSelection {
+		m.ensureCurrentMatchInView()
+	}
+	m.setFilterLine(m.renderFilterLine())
+
+	// match objects appended during the pass
+	if msg.chunk.done && len(m.objects) > job.numObjects && !m.matchLimitExceeded {
+		m.appendMatchesForNewObjects(job.numObjects, m.objects[job.numObjects:])
+	}
+	return cmd
+}
+
+// filterProgressText returns the progress of the running background filter pass
+func (m *Model[T]) filterProgressText() string {
+	job := m.filterJob
+	percent := 0
+	if job.numObjects > 0 {
+		percent = job.scanned * 100 / job.numObjects
+	}
+	return fmt.Sprintf("(filtering… %d%%)", percent)
+}
+
+// takePendingCmd returns the command started outside of a returned command, e.g. by a background filter pass, and
+// clears it
+func (m *Model[T]) takePendingCmd() tea.Cmd {
+	cmd := m.pendingCmd
+	m.pendingCmd = nil
+	return cmd
+}
@@ -0,0 +1,60 @@
+package filterableviewport
+
+// SetFilterContextLines sets how many items are shown above and below each match when showing matching items only,
+// see WithFilterContextLines
+func (m *Model[T]) SetFilterContextLines(n int) {
+	n = max(0, n)
+	if n == m.contextLines {
+		return
+	}
+	m.contextLines = n
+	if m.showMatchesOnly() {
+		m.updateMatchingItems()
+	}
+}
+
+// GetFilterContextLines returns how many items are shown above and below each match when showing matching items only
+func (m *Model[T]) GetFilterContextLines() int {
+	return m.contextLines
+}
+
+// showAllObjects shows every object in the viewport
+func (m *Model[T]) showAllObjects() {
+	m.vp.SetObjects(m.objects)
+	m.vp.SetSeparators(nil)
+}
+
+// showMatchingObjects shows matchingObjects, the objects at matchingItemIdxs, in the viewport. With context lines,
+// the objects around each match are shown with them, a separator beneath each group followed by a gap, and
+// itemIdxToFilteredIdx maps the matches to where they're shown.
+func (m *Model[T]) showMatchingObjects(matchingObjects []T) {
+	if m.contextLines == 0 || len(m.matchingItemIdxs) == 0 {
+		m.vp.SetObjects(matchingObjects)
+		m.vp.SetSeparators(nil)
+		return
+	}
+
+	shown := make([]bool, len(m.objects))
+	for _, itemIdx := range m.matchingItemIdxs {
+		for i := max(0, itemIdx-m.contextLines); i <= min(len(m.objects)-1, itemIdx+m.contextLines); i++ {
+			shown[i] = true
+		}
+	}
+
+	var objects []T
+	var separators []int
+	for itemIdx, isShown := range shown {
+		if !isShown {
+			continue
+		}
+		if len(objects) > 0 && !shown[itemIdx-1] {
+			separators = append(separators, len(objects)-1)
+		}
+		objects = append(objects, m.objects[itemIdx])
+		if _, ok := m.itemIdxToFilteredIdx[itemIdx]; ok {
+			m.itemIdxToFilteredIdx[itemIdx] = len(objects) - 1
+		}
+	}
+	m.vp.SetObjects(objects)
+	m.vp.SetSeparators(separators)
+}
@@ -0,0 +1,143 @@
+package filterableviewport
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// objectMatcher returns an object's matches in its content and whether it matches the filter. With field-scoped
+// terms, an object can match without any match in its content.
+type objectMatcher[T viewport.Object] func(obj T) (matches []item.Match, matched bool)
+
+// fieldTerm is a field-scoped part of a filter like "level:error"
+type fieldTerm struct {
+	name  string
+	value string
+}
+
+// parseFieldQuery splits filterText on whitespace into terms scoped to a known field name, like "level:error", and the
+// remaining free text. Terms with an empty value, e.g. while still being typed, are dropped.
+func parseFieldQuery(filterText string, isField func(name string) bool) (string, []fieldTerm) {
+	var free []string
+	var terms []fieldTerm
+	for _, token := range strings.Fields(filterText) {
+		name, value, ok := strings.Cut(token, ":")
+		if !ok || !isField(name) {
+			free = append(free, token)
+			continue
+		}
+		if value != "" {
+			terms = append(terms, fieldTerm{name: name, value: value})
+		}
+	}
+	return strings.Join(free, " "), terms
+}
+
+// isField returns true if name is a key of the field accessors
+func (m *Model[T]) isField(name string) bool {
+	_, ok := m.fieldAccessors[name]
+	return ok
+}
+
+// getObjectMatcher returns the matcher for filterValue in the active filter mode, nil if no mode is active. Without
+// field-scoped terms, it matches the item content against the whole filter value.
+func (m *Model[T]) getObjectMatcher(filterValue string) (objectMatcher[T], error) {
+	mode := m.GetActiveFilterMode()
+	if mode == nil {
+		return nil, nil
+	}
+
+	freeText, terms := filterValue, []fieldTerm(nil)
+	if len(m.fieldAccessors) > 0 {
+		freeText, terms = parseFieldQuery(filterValue, m.isField)
+	}
+
+	if len(terms) == 0 {
+		matcher, err := mode.NewMatcher(filterValue)
+		if err != nil || matcher == nil {
+			return nil, err
+		}
+		return func(obj T) ([]item.Match, bool) {
+			matches := m.extractMatches(obj, matcher)
+			return matches, len(matches) > 0
+		}, nil
+	}
+
+	var contentMatcher Matcher
+	if freeText != "" {
+		var err error
+		if contentMatcher, err = mode.NewMatcher(freeText); err != nil {
+			return nil, err
+		}
+	}
+	fieldMatchers := make([]Matcher, len(terms))
+	for i, term := range terms {
+		var err error
+		if fieldMatchers[i], err = mode.NewMatcher(term.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(obj T) ([]item.Match, bool) {
+		itm := obj.GetItem()
+		content := itm.ContentNoAnsi()
+		var ranges []item.ByteRange
+		for i, term := range terms {
+			value := m.fieldAccessors[term.name](obj)
+			var fieldRanges []item.ByteRange
+			if fieldMatchers[i] != nil {
+				fieldRanges = fieldMatchers[i].Match(value)
+			}
+			if len(fieldRanges) == 0 {
+				return nil, false
+			}
+			// highlight the matched parts of the field value where they show in the content
+			for _, r := range fieldRanges {
+				ranges = append(ranges, occurrences(content, value[r.Start:r.End])...)
+			}
+		}
+		if contentMatcher != nil {
+			contentRanges := contentMatcher.Match(content)
+			if len(contentRanges) == 0 {
+				return nil, false
+			}
+			ranges = append(ranges, contentRanges...)
+		}
+		return itm.ByteRangesToMatches(nonOverlapping(ranges)), true
+	}, nil
+}
+
+// occurrences returns the byte ranges of each non-overlapping occurrence of substr in s
+func occurrences(s, substr string) []item.ByteRange {
+	if substr == "" {
+		return nil
+	}
+	var ranges []item.ByteRange
+	for start := 0; ; {
+		i := strings.Index(s[start:], substr)
+		if i < 0 {
+			return ranges
+		}
+		r := item.ByteRange{Start: start + i, End: start + i + len(substr)}
+		ranges = append(ranges, r)
+		start = r.End
+	}
+}
+
+// nonOverlapping sorts ranges by start, dropping any that overlap an earlier one
+func nonOverlapping(ranges []item.ByteRange) []item.ByteRange {
+	slices.SortStableFunc(ranges, func(a, b item.ByteRange) int {
+		return a.Start - b.Start
+	})
+	res := ranges[:0]
+	for _, r := range ranges {
+		if len(res) > 0 && r.Start < res[len(res)-1].End {
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
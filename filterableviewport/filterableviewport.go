@@ -1,10 +1,14 @@
 package filterableviewport
 
 import (
+	"errors"
 	"fmt"
+	"regexp/syntax"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -113,6 +117,16 @@ func WithAdjustObjectsForFilter[T viewport.Object](fn func(filterText string, mo
 	}
 }
 
+// WithFieldAccessors lets filters scope terms to structured fields of objects. A term like "level:error", where level
+// is a key of accessors, matches objects whose accessor value matches "error" in the active filter mode, and is
+// highlighted where that value shows in the item. Other terms match the item content as usual, and an object must
+// match every term.
+func WithFieldAccessors[T viewport.Object](accessors map[string]func(T) string) Option[T] {
+	return func(m *Model[T]) {
+		m.fieldAccessors = accessors
+	}
+}
+
 // WithFilterModes sets the filter modes for the filterable viewport.
 // If not provided, New() defaults to DefaultFilterModes().
 func WithFilterModes[T viewport.Object](modes []FilterMode) Option[T] {
@@ -121,6 +135,15 @@ func WithFilterModes[T viewport.Object](modes []FilterMode) Option[T] {
 	}
 }
 
+// WithRegexCompiler sets the regex engine of the built-in regex and case-insensitive modes, e.g. one wrapping
+// regexp2 for lookarounds Go's regexp lacks. compile gets the pattern as typed, prefixed with "(?i)" in the
+// case-insensitive mode, and its errors show in the filter line. Defaults to Go's regexp via NewRegexMatcher.
+func WithRegexCompiler[T viewport.Object](compile func(pattern string) (Matcher, error)) Option[T] {
+	return func(m *Model[T]) {
+		m.regexCompiler = compile
+	}
+}
+
 // WithFilterLinePosition sets whether the filter line renders at the top (below header) or bottom (above footer)
 func WithFilterLinePosition[T viewport.Object](position FilterLinePosition) Option[T] {
 	return func(m *Model[T]) {
@@ -135,6 +158,24 @@ func WithFilterLinePrefix[T viewport.Object](prefix string) Option[T] {
 	}
 }
 
+// WithFilterLineTruncation sets which part of the filter line gives way first when it's wider than the viewport.
+// Defaults to TruncateEnd.
+func WithFilterLineTruncation[T viewport.Object](truncation FilterLineTruncation) Option[T] {
+	return func(m *Model[T]) {
+		m.filterLineTruncation = truncation
+	}
+}
+
+// WithAsyncFiltering runs filter passes over threshold or more objects in a background goroutine, keeping the UI
+// responsive on huge content. Matches stream in as FilterProgressMsgs while the filter line shows progress, and a
+// pass is canceled when the filter changes. Call FilterCmd after changing objects or the filter outside of Update.
+// 0, the default, always filters synchronously.
+func WithAsyncFiltering[T viewport.Object](threshold int) Option[T] {
+	return func(m *Model[T]) {
+		m.asyncFilterThreshold = threshold
+	}
+}
+
 // WithItemDescriptor sets a word describing the items (e.g. "logs", "events").
 // When set, match count text includes the total item count: "4/5 matches on 10 logs".
 // When empty (default), just "4/5 matches" is shown.
@@ -144,6 +185,34 @@ func WithItemDescriptor[T viewport.Object](descriptor string) Option[T] {
 	}
 }
 
+// WithHeaderHighlighting sets whether filter matches are also highlighted in the header set with SetHeader, e.g.
+// in a column header line. Header matches aren't counted or navigated to. Off by default.
+func WithHeaderHighlighting[T viewport.Object](highlightHeader bool) Option[T] {
+	return func(m *Model[T]) {
+		m.highlightHeader = highlightHeader
+	}
+}
+
+// WithFilterContextLines sets how many items are shown above and below each match when showing matching items only,
+// like grep -C, with a separator between groups that aren't contiguous. 0, the default, shows only the matches.
+func WithFilterContextLines[T viewport.Object](n int) Option[T] {
+	return func(m *Model[T]) {
+		m.contextLines = max(0, n)
+	}
+}
+
+// SetFilterLineTruncation sets which part of the filter line gives way first when it's wider than the viewport and
+// re-renders it
+func (m *Model[T]) SetFilterLineTruncation(truncation FilterLineTruncation) {
+	m.filterLineTruncation = truncation
+	m.setFilterLine(m.renderFilterLine())
+}
+
+// GetFilterLineWidths returns the cell widths of the parts of the filter line as last rendered
+func (m *Model[T]) GetFilterLineWidths() FilterLineWidths {
+	return m.filterLineWidths
+}
+
 // SetFilterLinePrefix updates the string prepended to the filter line and re-renders it.
 func (m *Model[T]) SetFilterLinePrefix(prefix string) {
 	m.filterLinePrefix = prefix
@@ -169,7 +238,8 @@ type Model[T viewport.Object] struct {
 	objects                  []T
 	filterModes              []FilterMode
 	filterModesByName        map[FilterModeName]int // name -> index in filterModes
-	activeFilterModeName     FilterModeName         // "" when no mode active
+	regexCompiler            func(pattern string) (Matcher, error)
+	activeFilterModeName     FilterModeName // "" when no mode active
 	lastActiveFilterModeName FilterModeName
 	styles                   Styles
 
@@ -187,6 +257,16 @@ type Model[T viewport.Object] struct {
 	maxMatchLimit              int // 0 = unlimited
 	matchLimitExceeded         bool
 	adjustObjectsForFilter     func(filterText string, mode FilterModeName) []T
+	fieldAccessors             map[string]func(T) string
+	matchingItemIdxs           []int // indexes of the objects matching the filter, in order
+	contextLines               int   // items shown above and below each match when showing matching items only
+	liveFilteringDisabled      bool  // true when objects exceed the viewport's soft limits
+	filterPending              bool  // true when filter text changed but matches weren't updated due to liveFilteringDisabled
+	filterErr                  error // why the filter text is invalid in the active mode, e.g. a bad regex, nil if valid
+
+	// softLimitUsage measures objects against the viewport's soft limits as they're added, nil to measure them all
+	// again
+	softLimitUsage *viewport.SoftLimitUsage[T]
 
 	verticalPad   int
 	horizontalPad int
@@ -194,6 +274,17 @@ type Model[T viewport.Object] struct {
 	searchHistory      []string // oldest at 0, newest at end
 	searchHistoryIdx   int      // index into searchHistory; == len(searchHistory) means "at draft"
 	searchHistoryDraft string   // current unsaved input preserved while browsing
+
+	header          []string
+	highlightHeader bool // true when filter matches are also highlighted in the header
+
+	filterLineTruncation FilterLineTruncation
+	filterLineWidths     FilterLineWidths // widths of the parts of the last rendered filter line
+
+	asyncFilterThreshold int        // objects at which filter passes run in the background, 0 to never
+	asyncFilterChunkSize int        // objects matched between progress messages of a background pass
+	filterJob            *filterJob // the running background filter pass, nil if none
+	pendingCmd           tea.Cmd    // command to return from the next Update, e.g. awaiting a background pass
 }
 
 // New creates a new filterable viewport model with default configuration
@@ -220,6 +311,7 @@ func New[T viewport.Object](vp *viewport.Model[T], opts ...Option[T]) *Model[T]
 		filterMode:                 filterModeOff,
 		prefixText:                 "",
 		emptyText:                  "No Filter",
+		asyncFilterChunkSize:       defaultAsyncFilterChunkSize,
 		objects:                    []T{},
 		filterModes:                DefaultFilterModes(),
 		activeFilterModeName:       "",
@@ -255,6 +347,10 @@ func New[T viewport.Object](vp *viewport.Model[T], opts ...Option[T]) *Model[T]
 		panic("filterableviewport: no filter modes set; use viewport.Model directly if filtering is not needed")
 	}
 
+	if m.regexCompiler != nil {
+		m.filterModes = withRegexCompiler(m.filterModes, m.regexCompiler)
+	}
+
 	// build name -> index lookup and validate uniqueness
 	m.filterModesByName = make(map[FilterModeName]int, len(m.filterModes))
 	for i, mode := range m.filterModes {
@@ -280,10 +376,69 @@ func (m *Model[T]) Init() tea.Cmd {
 
 // Update processes messages and updates the model state
 func (m *Model[T]) Update(msg tea.Msg) (*Model[T], tea.Cmd) {
+	m, cmd := m.update(msg)
+	if pendingCmd := m.takePendingCmd(); pendingCmd != nil {
+		return m, tea.Batch(cmd, pendingCmd)
+	}
+	return m, cmd
+}
+
+// FilterCmd returns the command awaiting a background filter pass started outside of Update, e.g. by SetObjects or
+// SetFilter with async filtering on, or nil if there's none
+func (m *Model[T]) FilterCmd() tea.Cmd {
+	return m.takePendingCmd()
+}
+
+// IsFiltering returns true while a background filter pass is running
+func (m *Model[T]) IsFiltering() bool {
+	return m.filterJob != nil
+}
+
+func (m *Model[T]) update(msg tea.Msg) (*Model[T], tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
-	if m.vp.IsCapturingInput() {
+	if msg, ok := msg.(FilterProgressMsg); ok {
+		return m, m.handleFilterProgress(msg)
+	}
+
+	switch msg := msg.(type) {
+	case SetFilterMsg:
+		m.SetFilter(msg.Value, msg.Mode)
+		return m, nil
+	case viewport.ScrollToMsg, viewport.SelectItemMsg:
+		// control messages reach the viewport even while editing the filter
+		m.vp, cmd = m.vp.Update(msg)
+		if len(m.allMatches) > 0 {
+			m.updateFocusedMatchHighlight()
+		}
+		return m, cmd
+	}
+
+	// the loading spinner keeps turning while the filter is edited
+	if _, ok := msg.(spinner.TickMsg); ok {
+		m.vp, cmd = m.vp.Update(msg)
+		return m, cmd
+	}
+
+	// the viewport's own messages, e.g. ending a key sequence or showing a smooth scroll frame, reach it whatever the
+	// filter mode
+	if viewport.IsInternalMsg(msg) {
+		m.vp, cmd = m.vp.Update(msg)
+		if len(m.allMatches) > 0 {
+			m.updateFocusedMatchHighlight()
+		}
+		return m, cmd
+	}
+
+	// the settled size applies whatever the filter mode, and the filter line is re-truncated to it
+	if _, ok := msg.(viewport.ResizeSettledMsg); ok {
+		m.vp, cmd = m.vp.Update(msg)
+		m.setFilterLine(m.renderFilterLine())
+		return m, cmd
+	}
+
+	if m.vp.IsCapturingInput() || !m.vp.GetEnabled() || !m.vp.Focused() {
 		m.vp, cmd = m.vp.Update(msg)
 		return m, cmd
 	}
@@ -369,9 +524,21 @@ func (m *Model[T]) Update(msg tea.Msg) (*Model[T], tea.Cmd) {
 			m.updateFocusedMatchHighlight()
 		}
 	} else {
-		m.filterTextInput, cmd = m.filterTextInput.Update(msg)
-		m.updateMatchingItems()
-		m.ensureCurrentMatchInView()
+		prevFilterValue := m.filterTextInput.Value()
+		cmd = m.updateFilterTextInput(msg)
+		if m.liveFilteringDisabled {
+			// too much content to re-filter on every keystroke, so filter when applied instead
+			if m.filterTextInput.Value() != prevFilterValue {
+				m.filterPending = true
+			}
+			m.setFilterLine(m.renderFilterLine())
+		} else if m.filterTextInput.Value() == prevFilterValue {
+			// e.g. a cursor blink or move: the matches and view are unchanged, only the filter line needs redrawing
+			m.setFilterLine(m.renderFilterLine())
+		} else {
+			m.updateMatchingItems()
+			m.ensureCurrentMatchInView()
+		}
 		cmds = append(cmds, cmd)
 	}
 
@@ -383,6 +550,11 @@ func (m *Model[T]) View() string {
 	return m.vp.View()
 }
 
+// Snapshot returns the view, filter line included, as a standalone HTML or SVG document. See viewport.Model.Snapshot.
+func (m *Model[T]) Snapshot(format viewport.SnapshotFormat) string {
+	return m.vp.Snapshot(format)
+}
+
 // GetWidth returns the width of the filterable viewport
 func (m *Model[T]) GetWidth() int {
 	return m.vp.GetWidth()
@@ -394,6 +566,41 @@ func (m *Model[T]) SetWidth(width int) {
 	m.setFilterLine(m.renderFilterLine())
 }
 
+// Resize sets the width and height of the filterable viewport, throttled by the viewport's resize throttle. Return the
+// command's message to Update so the final size is applied.
+func (m *Model[T]) Resize(width, height int) tea.Cmd {
+	cmd := m.vp.Resize(width, height)
+	m.setFilterLine(m.renderFilterLine())
+	return cmd
+}
+
+// SetEnabled sets whether the filterable viewport is enabled. While disabled, its content is dimmed and filter keys
+// are ignored along with the rest of its input.
+func (m *Model[T]) SetEnabled(enabled bool) {
+	m.vp.SetEnabled(enabled)
+}
+
+// GetEnabled returns whether the filterable viewport is enabled
+func (m *Model[T]) GetEnabled() bool {
+	return m.vp.GetEnabled()
+}
+
+// Focus gives the filterable viewport focus, so it handles key input. It's focused by default.
+func (m *Model[T]) Focus() {
+	m.vp.Focus()
+}
+
+// Blur takes focus from the filterable viewport. While blurred, filter keys are ignored along with the rest of its key
+// input, and the selection is styled with the viewport's BlurredStyle. A filter being edited carries on when refocused.
+func (m *Model[T]) Blur() {
+	m.vp.Blur()
+}
+
+// Focused returns whether the filterable viewport has focus
+func (m *Model[T]) Focused() bool {
+	return m.vp.Focused()
+}
+
 // GetHeight returns the height of the filterable viewport
 func (m *Model[T]) GetHeight() int {
 	return m.vp.GetHeight()
@@ -410,6 +617,9 @@ func (m *Model[T]) SetObjects(objects []T) {
 		objects = []T{}
 	}
 	m.objects = objects
+	m.softLimitUsage = nil
+	m.retainObjects()
+	m.addToSoftLimits(m.objects)
 	m.updateMatchingItems()
 }
 
@@ -420,6 +630,22 @@ func (m *Model[T]) AppendObjects(objects []T) {
 	}
 	startIdx := len(m.objects)
 	m.objects = append(m.objects, objects...)
+	m.addToSoftLimits(objects)
+	numDropped := m.retainObjects()
+
+	// dropping the oldest objects moves the rest, so they're all matched again
+	if numDropped > 0 {
+		m.updateMatchingItems()
+		return
+	}
+
+	// a running background pass matches appended objects when it finishes
+	if m.filterJob != nil {
+		if !m.showMatchesOnly() {
+			m.vp.AppendObjects(objects)
+		}
+		return
+	}
 
 	// if filter active and not at limit, do incremental update
 	if m.filterMode != filterModeOff &&
@@ -428,12 +654,36 @@ func (m *Model[T]) AppendObjects(objects []T) {
 		m.appendMatchesForNewObjects(startIdx, objects)
 	} else if m.matchLimitExceeded {
 		// already at limit, just update viewport with all objects
-		m.vp.SetObjects(m.objects)
+		m.showAllObjects()
 	} else {
 		m.updateMatchingItems()
 	}
 }
 
+// retainObjects drops the oldest objects beyond the viewport's retention limits, see viewport.WithMaxItems and
+// viewport.WithMaxBytes, returning how many were dropped
+func (m *Model[T]) retainObjects() int {
+	kept := viewport.RetainNewest(m.objects, m.vp.GetMaxItems(), m.vp.GetMaxBytes())
+	numDropped := len(m.objects) - len(kept)
+	if numDropped > 0 && m.softLimitUsage != nil {
+		m.softLimitUsage.Remove(m.objects[:numDropped], kept)
+		m.liveFilteringDisabled = m.softLimitUsage.Exceeded() != ""
+	}
+	m.objects = kept
+	return numDropped
+}
+
+// addToSoftLimits measures added, objects just added to m.objects, against the viewport's soft limits, updating
+// whether live filtering is disabled. All objects are measured again if the limits changed since they were measured.
+func (m *Model[T]) addToSoftLimits(added []T) {
+	if limits := m.vp.GetSoftLimits(); m.softLimitUsage == nil || m.softLimitUsage.Limits() != limits {
+		m.softLimitUsage = viewport.NewSoftLimitUsage[T](limits)
+		added = m.objects
+	}
+	m.softLimitUsage.Add(added)
+	m.liveFilteringDisabled = m.softLimitUsage.Exceeded() != ""
+}
+
 // FilterFocused returns true if the filter text input is focused
 func (m *Model[T]) FilterFocused() bool {
 	return m.filterTextInput.Focused()
@@ -471,6 +721,48 @@ func (m *Model[T]) GetFilterText() string {
 	return m.filterTextInput.Value()
 }
 
+// GetFilterError returns why the filter text is invalid in the active filter mode, e.g. a regex that doesn't compile,
+// or nil if it's valid. An invalid filter isn't applied.
+func (m *Model[T]) GetFilterError() error {
+	return m.filterErr
+}
+
+// GetMatchCount returns the number of filter matches across all objects. When the match limit is exceeded, matches
+// aren't tracked and this is the count reached before the limit.
+func (m *Model[T]) GetMatchCount() int {
+	return m.totalMatchesOnAllItems
+}
+
+// GetCurrentMatchIndex returns the 0-indexed focused match, moved by next/previous match navigation, or -1 if no
+// match is focused
+func (m *Model[T]) GetCurrentMatchIndex() int {
+	return m.focusedMatchIdx
+}
+
+// GetMatchingIndices returns the indexes of the objects matching the filter in the objects being filtered, in order,
+// e.g. to act on the filtered subset without matching again. Returns nil if nothing matches, including with no filter
+// applied, or when the match limit is exceeded. During a background filter pass, these are the matches found so far.
+func (m *Model[T]) GetMatchingIndices() []int {
+	if m.matchLimitExceeded || len(m.matchingItemIdxs) == 0 {
+		return nil
+	}
+	return slices.Clone(m.matchingItemIdxs)
+}
+
+// GetMatchingObjects returns the objects matching the filter, in order, e.g. to write them to a file or pipe them to
+// another command. Like GetMatchingIndices, returns nil if nothing matches or when the match limit is exceeded.
+func (m *Model[T]) GetMatchingObjects() []T {
+	idxs := m.GetMatchingIndices()
+	if idxs == nil {
+		return nil
+	}
+	objects := make([]T, len(idxs))
+	for i, idx := range idxs {
+		objects[i] = m.objects[idx]
+	}
+	return objects
+}
+
 // GetActiveFilterMode returns the currently active filter mode, or nil if none.
 func (m *Model[T]) GetActiveFilterMode() *FilterMode {
 	idx, ok := m.filterModesByName[m.activeFilterModeName]
@@ -512,7 +804,20 @@ func (m *Model[T]) SetBottomSticky(bottomSticky bool) {
 
 // SetHeader sets the viewport header lines
 func (m *Model[T]) SetHeader(header []string) {
+	m.header = header
 	m.vp.SetHeader(header)
+	m.updateHeaderHighlights()
+}
+
+// SetHeaderHighlighting sets whether filter matches are also highlighted in the header
+func (m *Model[T]) SetHeaderHighlighting(highlightHeader bool) {
+	m.highlightHeader = highlightHeader
+	m.updateHeaderHighlights()
+}
+
+// GetHeaderHighlighting returns whether filter matches are also highlighted in the header
+func (m *Model[T]) GetHeaderHighlighting() bool {
+	return m.highlightHeader
 }
 
 // SetSelectionComparator sets the function used to maintain selection across object updates
@@ -520,6 +825,13 @@ func (m *Model[T]) SetSelectionComparator(compareFn viewport.CompareFn[T]) {
 	m.vp.SetSelectionComparator(compareFn)
 }
 
+// SetFilterMsg sets the filter text and mode when passed to Update, as SetFilter does, so that parent programs and
+// tests can drive filtering purely through messages. An empty Value clears the filter.
+type SetFilterMsg struct {
+	Value string
+	Mode  FilterModeName
+}
+
 // SetFilter sets the filter text and mode programmatically.
 // Use the FilterModeName constants (e.g. FilterExact, FilterRegex) or your own custom names.
 func (m *Model[T]) SetFilter(value string, mode FilterModeName) {
@@ -548,6 +860,29 @@ func (m *Model[T]) SetMatchingItemsOnly(matchingItemsOnly bool) {
 	m.updateMatchingItems()
 }
 
+// DumpState returns a plain-text, unstyled description of the current filter state followed by the state of the
+// underlying viewport. See viewport.Model.DumpState.
+func (m *Model[T]) DumpState() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "objects: %d\n", len(m.objects))
+	switch {
+	case m.filterMode == filterModeOff || m.activeFilterModeName == "":
+		b.WriteString("filter: none\n")
+	default:
+		state := "applied"
+		if m.filterMode == filterModeEditing {
+			state = "editing"
+		}
+		fmt.Fprintf(&b, "filter: %s %q, %s\n", m.activeFilterModeName, m.filterTextInput.Value(), state)
+		if m.filterTextInput.Value() != "" {
+			fmt.Fprintf(&b, "matches: %s\n", strings.Trim(m.getMatchCountText(), "()"))
+		}
+	}
+	fmt.Fprintf(&b, "matching items only: %t\n", m.matchingItemsOnly)
+	b.WriteString(m.vp.DumpState())
+	return b.String()
+}
+
 // SetFilterableViewportStyles sets the styles for the filterable viewport
 func (m *Model[T]) SetFilterableViewportStyles(styles Styles) {
 	m.styles = styles
@@ -612,17 +947,26 @@ func (m *Model[T]) HalfPageDown() {
 
 // updateMatchingItems recalculates the matching items and updates match tracking
 func (m *Model[T]) updateMatchingItems() {
+	m.filterPending = false
+	m.cancelFilterJob()
+	if m.startFilterJob() {
+		return
+	}
 	matchingObjects, filterChanged := m.getMatchingObjectsAndUpdateMatches()
+	m.applyMatchingObjects(matchingObjects, filterChanged)
+}
 
+// applyMatchingObjects shows the matching objects and the current match state
+func (m *Model[T]) applyMatchingObjects(matchingObjects []T, filterChanged bool) {
 	if !m.matchLimitExceeded {
 		m.numMatchingItems = len(matchingObjects)
 	}
 
 	// when match limit exceeded, show all objects
 	if m.showMatchesOnly() {
-		m.vp.SetObjects(matchingObjects)
+		m.showMatchingObjects(matchingObjects)
 	} else {
-		m.vp.SetObjects(m.objects)
+		m.showAllObjects()
 	}
 
 	// when no matches found with an active filter and items are unwrapped, reset horizontal scroll
@@ -635,11 +979,44 @@ func (m *Model[T]) updateMatchingItems() {
 		m.setSelectionToCurrentMatch()
 	}
 	m.updateFocusedMatchHighlight()
+	m.updateHeaderHighlights()
 
 	// update the pre-footer line with the current filter state
 	m.setFilterLine(m.renderFilterLine())
 }
 
+// updateHeaderHighlights highlights filter matches in the header when header highlighting is on
+func (m *Model[T]) updateHeaderHighlights() {
+	filterValue := m.filterTextInput.Value()
+	mode := m.GetActiveFilterMode()
+	if !m.highlightHeader || m.filterMode == filterModeOff || filterValue == "" || mode == nil {
+		m.vp.SetHeaderHighlights(nil)
+		return
+	}
+	// field-scoped terms don't match the header
+	if len(m.fieldAccessors) > 0 {
+		filterValue, _ = parseFieldQuery(filterValue, m.isField)
+	}
+	matcher, err := mode.NewMatcher(filterValue)
+	if err != nil || matcher == nil || filterValue == "" {
+		m.vp.SetHeaderHighlights(nil)
+		return
+	}
+	var highlights []viewport.Highlight
+	for lineIdx, line := range m.header {
+		for _, byteRange := range matcher.Match(item.StripAnsi(line)) {
+			highlights = append(highlights, viewport.Highlight{
+				ItemIndex: lineIdx,
+				ItemHighlight: item.Highlight{
+					Style:                    m.styles.Match.Unfocused,
+					ByteRangeUnstyledContent: byteRange,
+				},
+			})
+		}
+	}
+	m.vp.SetHeaderHighlights(highlights)
+}
+
 // updateFocusedMatchHighlight sets a specific highlight for the currently focused match
 func (m *Model[T]) updateFocusedMatchHighlight() {
 	if m.focusedMatchIdx < 0 || m.focusedMatchIdx >= len(m.allMatches) {
@@ -679,69 +1056,94 @@ func (m *Model[T]) updateFocusedMatchHighlight() {
 
 	// otherwise, rebuild all highlights
 	highlights := make([]viewport.Highlight, len(m.allMatches))
-	for matchIdx, match := range m.allMatches {
-		itemIdx := match.ItemIndex
-		if m.matchingItemsOnly {
-			if filteredIdx, ok := m.itemIdxToFilteredIdx[itemIdx]; ok {
-				itemIdx = filteredIdx
-			} else {
-				panic("focused match item index not found in filtered items")
-			}
-		}
-		style := m.styles.Match.Unfocused
-		if matchIdx == m.focusedMatchIdx {
-			if m.vp.GetSelectionEnabled() && itemIdx == selectedIdx {
-				style = m.styles.Match.FocusedIfSelected
-			} else {
-				style = m.styles.Match.Focused
-			}
-		}
-		highlight := viewport.Highlight{
-			ItemIndex: itemIdx,
-			ItemHighlight: item.Highlight{
-				Style:                    style,
-				ByteRangeUnstyledContent: match.ItemHighlight.ByteRangeUnstyledContent,
-			},
-		}
-		highlights[matchIdx] = highlight
+	for matchIdx := range m.allMatches {
+		highlights[matchIdx] = m.matchHighlight(matchIdx, selectedIdx)
 	}
 
 	m.vp.SetHighlights(highlights)
 	m.previousFocusedMatchIdx = m.focusedMatchIdx
 }
 
+// appendMatchHighlights adds the highlights of the matches from allMatches[from:] to the viewport, keeping the
+// existing ones. The focused match must not be among them.
+func (m *Model[T]) appendMatchHighlights(from int) {
+	if from >= len(m.allMatches) {
+		return
+	}
+	selectedIdx := m.vp.GetSelectedItemIdx()
+	highlights := make([]viewport.Highlight, 0, len(m.allMatches)-from)
+	for matchIdx := from; matchIdx < len(m.allMatches); matchIdx++ {
+		highlights = append(highlights, m.matchHighlight(matchIdx, selectedIdx))
+	}
+	m.vp.AppendHighlights(highlights)
+}
+
+// matchHighlight returns the viewport highlight of the match at matchIdx, styled by whether it's focused
+func (m *Model[T]) matchHighlight(matchIdx, selectedIdx int) viewport.Highlight {
+	match := m.allMatches[matchIdx]
+	itemIdx := match.ItemIndex
+	if m.matchingItemsOnly {
+		if filteredIdx, ok := m.itemIdxToFilteredIdx[itemIdx]; ok {
+			itemIdx = filteredIdx
+		} else {
+			panic("focused match item index not found in filtered items")
+		}
+	}
+	style := m.styles.Match.Unfocused
+	if matchIdx == m.focusedMatchIdx {
+		if m.vp.GetSelectionEnabled() && itemIdx == selectedIdx {
+			style = m.styles.Match.FocusedIfSelected
+		} else {
+			style = m.styles.Match.Focused
+		}
+	}
+	return viewport.Highlight{
+		ItemIndex: itemIdx,
+		ItemHighlight: item.Highlight{
+			Style:                    style,
+			ByteRangeUnstyledContent: match.ItemHighlight.ByteRangeUnstyledContent,
+		},
+	}
+}
+
 func (m *Model[T]) renderFilterLine() string {
-	var filterContent string
+	var parts filterLineParts
 
 	switch m.filterMode {
 	case filterModeOff:
-		filterContent = m.emptyText
+		parts = filterLineParts{linePrefix: m.filterLinePrefix, prefix: m.emptyText}
 	case filterModeEditing, filterModeApplied:
 		if m.filterTextInput.Value() == "" && m.filterMode == filterModeApplied {
-			filterContent = m.emptyText
+			parts = filterLineParts{linePrefix: m.filterLinePrefix, prefix: m.emptyText}
 		} else {
-			filterContent = strings.Join(removeEmpty([]string{
-				m.getModeIndicator(),
-				m.prefixText,
-				m.filterTextInput.View(),
-				m.getTextAfterFilter(),
-				matchingItemsOnlyText(m.showMatchesOnly()),
-			}),
-				" ",
-			)
+			parts = filterLineParts{
+				linePrefix: m.filterLinePrefix,
+				mode:       m.getModeIndicator(),
+				prefix:     m.prefixText,
+				query:      m.filterTextInput.View(),
+				info: strings.Join(removeEmpty([]string{
+					m.getTextAfterFilter(),
+					matchingItemsOnlyText(m.showMatchesOnly()),
+				}), " "),
+			}
 		}
 	default:
 		panic(fmt.Sprintf("invalid filter mode: %d", m.filterMode))
 	}
 
-	filterLine := strings.Join(removeEmpty([]string{m.filterLinePrefix, filterContent}), " ")
-	filterItem := item.NewItem(filterLine)
+	if m.filterLineTruncation == TruncatePrefixFirst {
+		parts = parts.shrinkPrefixes(m.GetWidth())
+	}
+	filterItem := item.NewItem(parts.join())
 	res, _ := filterItem.Take(0, m.GetWidth(), "...", []item.Highlight{})
+	m.filterLineWidths = parts.widths(lipgloss.Width(res))
 	return res
 }
 
-// setFilterLine sets the rendered filter line on the appropriate viewport line based on position
+// setFilterLine sets the rendered filter line on the appropriate viewport line based on position. The filter line is
+// re-rendered on every filter state change, so the viewport's footer filter info is kept in sync here too.
 func (m *Model[T]) setFilterLine(line string) {
+	m.vp.SetFooterFilterInfo(m.footerFilterInfo())
 	switch m.filterLinePosition {
 	case FilterLineBottom:
 		m.vp.SetPreFooterLine(line)
@@ -750,6 +1152,29 @@ func (m *Model[T]) setFilterLine(line string) {
 	}
 }
 
+// footerFilterInfo returns the applied filter's state for the viewport's footer formatter, nil if no filter is applied
+func (m *Model[T]) footerFilterInfo() *viewport.FooterFilterInfo {
+	if m.filterMode == filterModeOff || m.filterTextInput.Value() == "" {
+		return nil
+	}
+	focusedMatch := m.focusedMatchIdx + 1
+	if m.focusedMatchIdx < 0 {
+		focusedMatch = 0
+	}
+	matchCount := m.totalMatchesOnAllItems
+	if m.matchLimitExceeded {
+		matchCount = m.maxMatchLimit
+	}
+	return &viewport.FooterFilterInfo{
+		Mode:               m.getModeIndicator(),
+		Query:              m.filterTextInput.Value(),
+		FocusedMatch:       focusedMatch,
+		MatchCount:         matchCount,
+		MatchLimitExceeded: m.matchLimitExceeded,
+		MatchingItemsOnly:  m.showMatchesOnly(),
+	}
+}
+
 func (m *Model[T]) getModeIndicator() string {
 	if mode := m.GetActiveFilterMode(); mode != nil {
 		return mode.Label
@@ -760,42 +1185,20 @@ func (m *Model[T]) getModeIndicator() string {
 // getMatchingObjectsAndUpdateMatches filters objects and updates match tracking.
 // Returns the matching objects and whether the filter value changed.
 func (m *Model[T]) getMatchingObjectsAndUpdateMatches() ([]T, bool) {
-	filterValue := m.filterTextInput.Value()
-	filterChanged := filterValue != m.lastFilterValue || m.activeFilterModeName != m.lastActiveFilterModeName
-	m.lastFilterValue = filterValue
-	m.lastActiveFilterModeName = m.activeFilterModeName
-
-	if filterChanged && m.adjustObjectsForFilter != nil {
-		modeName := m.activeFilterModeName
-		if modeName == "" && len(m.filterModes) > 0 {
-			modeName = m.filterModes[0].Name
-		}
-		if newObjects := m.adjustObjectsForFilter(filterValue, modeName); newObjects != nil {
-			m.objects = newObjects
-		}
-	}
-
-	m.allMatches = []viewport.Highlight{}
-	prevFocusedMatchIdx := m.focusedMatchIdx
-	m.focusedMatchIdx = -1
-	m.totalMatchesOnAllItems = 0
-	m.itemIdxToFilteredIdx = make(map[int]int)
-	m.matchLimitExceeded = false
+	filterValue, filterChanged, prevFocusedMatchIdx := m.resetMatches()
 
 	if m.filterMode == filterModeOff || filterValue == "" {
 		return m.objects, filterChanged
 	}
 
-	// get the MatchFunc from the active mode
-	var matchFn MatchFunc
-	if mode := m.GetActiveFilterMode(); mode != nil {
-		var err error
-		matchFn, err = mode.GetMatchFunc(filterValue)
-		if err != nil {
-			return []T{}, filterChanged
-		}
+	// get the matcher for the active mode
+	matcher, err := m.getObjectMatcher(filterValue)
+	if err != nil {
+		// show everything rather than apply a broken filter
+		m.filterErr = err
+		return m.objects, filterChanged
 	}
-	if matchFn == nil {
+	if matcher == nil {
 		return m.objects, filterChanged
 	}
 
@@ -806,9 +1209,9 @@ func (m *Model[T]) getMatchingObjectsAndUpdateMatches() ([]T, bool) {
 	itemsWithMatchesSet := make(map[int]bool)
 
 	for itemIdx := range m.objects {
-		matches := m.extractMatches(m.objects[itemIdx], matchFn)
+		matches, matched := matcher(m.objects[itemIdx])
 
-		if len(matches) > 0 {
+		if matched {
 			itemsWithMatchesSet[itemIdx] = true
 		}
 
@@ -836,18 +1239,16 @@ func (m *Model[T]) getMatchingObjectsAndUpdateMatches() ([]T, bool) {
 		return m.objects, filterChanged
 	}
 
-	filteredObjects := make([]T, 0, len(m.objects))
-	itemsWithMatches := make(map[int]bool)
-
-	for _, highlight := range highlights {
-		itemIdx := highlight.ItemIndex
-		if !itemsWithMatches[itemIdx] {
+	// matching items needn't have highlights, e.g. when matched on a field that isn't shown
+	filteredObjects := make([]T, 0, len(itemsWithMatchesSet))
+	for itemIdx := range m.objects {
+		if itemsWithMatchesSet[itemIdx] {
 			filteredObjects = append(filteredObjects, m.objects[itemIdx])
 			m.itemIdxToFilteredIdx[itemIdx] = len(filteredObjects) - 1
-			itemsWithMatches[itemIdx] = true
+			m.matchingItemIdxs = append(m.matchingItemIdxs, itemIdx)
 		}
-		m.allMatches = append(m.allMatches, highlight)
 	}
+	m.allMatches = append(m.allMatches, highlights...)
 
 	m.totalMatchesOnAllItems = len(m.allMatches)
 
@@ -870,22 +1271,44 @@ func (m *Model[T]) getMatchingObjectsAndUpdateMatches() ([]T, bool) {
 	return filteredObjects, filterChanged
 }
 
+// resetMatches clears the match state for a new filter pass, first adjusting the objects if the filter changed.
+// Returns the filter value, whether it changed since the last pass, and the focused match index before the reset.
+func (m *Model[T]) resetMatches() (string, bool, int) {
+	filterValue := m.filterTextInput.Value()
+	filterChanged := filterValue != m.lastFilterValue || m.activeFilterModeName != m.lastActiveFilterModeName
+	m.lastFilterValue = filterValue
+	m.lastActiveFilterModeName = m.activeFilterModeName
+
+	if filterChanged && m.adjustObjectsForFilter != nil {
+		modeName := m.activeFilterModeName
+		if modeName == "" && len(m.filterModes) > 0 {
+			modeName = m.filterModes[0].Name
+		}
+		if newObjects := m.adjustObjectsForFilter(filterValue, modeName); newObjects != nil {
+			m.objects = newObjects
+			m.softLimitUsage = nil
+		}
+	}
+
+	m.allMatches = []viewport.Highlight{}
+	prevFocusedMatchIdx := m.focusedMatchIdx
+	m.focusedMatchIdx = -1
+	m.totalMatchesOnAllItems = 0
+	m.itemIdxToFilteredIdx = make(map[int]int)
+	m.matchingItemIdxs = nil
+	m.matchLimitExceeded = false
+	m.filterErr = nil
+	return filterValue, filterChanged, prevFocusedMatchIdx
+}
+
 // appendMatchesForNewObjects processes only newly appended objects for matches
 // and incrementally updates match state without rescanning existing objects
 func (m *Model[T]) appendMatchesForNewObjects(startIdx int, newObjects []T) {
 	filterValue := m.filterTextInput.Value()
 
-	var matchFn MatchFunc
-	if mode := m.GetActiveFilterMode(); mode != nil {
-		var err error
-		matchFn, err = mode.GetMatchFunc(filterValue)
-		if err != nil {
-			// invalid match (e.g. bad regex), fallback to full update
-			m.updateMatchingItems()
-			return
-		}
-	}
-	if matchFn == nil {
+	matcher, err := m.getObjectMatcher(filterValue)
+	if err != nil || matcher == nil {
+		// invalid match (e.g. bad regex), fallback to full update
 		m.updateMatchingItems()
 		return
 	}
@@ -895,13 +1318,15 @@ func (m *Model[T]) appendMatchesForNewObjects(startIdx int, newObjects []T) {
 	prevNumMatchingItems := m.numMatchingItems
 	itemsWithMatchesSet := make(map[int]bool)
 	var newHighlights []viewport.Highlight
+	var newMatchingItemIdxs []int
 
 	for i, obj := range newObjects {
 		itemIdx := startIdx + i
-		matches := m.extractMatches(obj, matchFn)
+		matches, matched := matcher(obj)
 
-		if len(matches) > 0 {
+		if matched {
 			itemsWithMatchesSet[itemIdx] = true
+			newMatchingItemIdxs = append(newMatchingItemIdxs, itemIdx)
 		}
 
 		if m.maxMatchLimit > 0 && totalMatchCount+len(matches) > m.maxMatchLimit {
@@ -911,7 +1336,7 @@ func (m *Model[T]) appendMatchesForNewObjects(startIdx int, newObjects []T) {
 			m.focusedMatchIdx = -1
 			m.totalMatchesOnAllItems = totalMatchCount
 			m.numMatchingItems = prevNumMatchingItems + len(itemsWithMatchesSet)
-			m.vp.SetObjects(m.objects)
+			m.showAllObjects()
 			m.updateFocusedMatchHighlight()
 			// update the pre-footer line with the current filter state
 			m.setFilterLine(m.renderFilterLine())
@@ -927,27 +1352,21 @@ func (m *Model[T]) appendMatchesForNewObjects(startIdx int, newObjects []T) {
 
 	// append new matches to existing
 	m.allMatches = append(m.allMatches, newHighlights...)
+	m.matchingItemIdxs = append(m.matchingItemIdxs, newMatchingItemIdxs...)
 	m.totalMatchesOnAllItems = totalMatchCount
 	m.numMatchingItems = prevNumMatchingItems + len(itemsWithMatchesSet)
 
 	// update viewport objects
 	if m.showMatchesOnly() {
 		// build filtered objects list including new matching items
-		filteredObjects := make([]T, 0, m.numMatchingItems)
-		itemsWithMatches := make(map[int]bool)
-
-		for _, highlight := range m.allMatches {
-			itemIdx := highlight.ItemIndex
-			if !itemsWithMatches[itemIdx] {
-				filteredObjects = append(filteredObjects, m.objects[itemIdx])
-				m.itemIdxToFilteredIdx[itemIdx] = len(filteredObjects) - 1
-				itemsWithMatches[itemIdx] = true
-			}
+		filteredObjects := make([]T, 0, len(m.matchingItemIdxs))
+		for _, itemIdx := range m.matchingItemIdxs {
+			filteredObjects = append(filteredObjects, m.objects[itemIdx])
+			m.itemIdxToFilteredIdx[itemIdx] = len(filteredObjects) - 1
 		}
-		m.vp.SetObjects(filteredObjects)
+		m.showMatchingObjects(filteredObjects)
 	} else {
-		// already updated by append to m.objects
-		m.vp.SetObjects(m.objects)
+		m.vp.AppendObjects(newObjects)
 	}
 
 	m.updateFocusedMatchHighlight()
@@ -955,10 +1374,10 @@ func (m *Model[T]) appendMatchesForNewObjects(startIdx int, newObjects []T) {
 	m.setFilterLine(m.renderFilterLine())
 }
 
-// extractMatches extracts matches from an object using the provided MatchFunc
-func (m *Model[T]) extractMatches(obj T, matchFn MatchFunc) []item.Match {
+// extractMatches extracts matches from an object using the provided Matcher
+func (m *Model[T]) extractMatches(obj T, matcher Matcher) []item.Match {
 	itm := obj.GetItem()
-	byteRanges := matchFn(itm.ContentNoAnsi())
+	byteRanges := matcher.Match(itm.ContentNoAnsi())
 	return itm.ByteRangesToMatches(byteRanges)
 }
 
@@ -1012,9 +1431,27 @@ func (m *Model[T]) getTextAfterFilter() string {
 	if m.filterTextInput.Value() == "" {
 		return "type to filter"
 	}
+	if m.filterPending {
+		return "(enter to filter)"
+	}
+	if m.filterJob != nil {
+		return m.filterProgressText()
+	}
+	if m.filterErr != nil {
+		return m.styles.Error.Render("(" + filterErrorText(m.filterErr) + ")")
+	}
 	return m.getMatchCountText()
 }
 
+// filterErrorText describes err briefly enough for the filter line, dropping the repeated pattern from regex errors
+func filterErrorText(err error) string {
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		return "invalid regex: " + string(syntaxErr.Code)
+	}
+	return err.Error()
+}
+
 // getMatchCountText returns the formatted match count text
 func (m *Model[T]) getMatchCountText() string {
 	if m.matchLimitExceeded {
@@ -1023,7 +1460,8 @@ func (m *Model[T]) getMatchCountText() string {
 		}
 		return fmt.Sprintf("(%d+ matches)", m.maxMatchLimit)
 	}
-	if m.totalMatchesOnAllItems == 0 {
+	// items can match without any highlighted matches, e.g. on a field that isn't shown
+	if m.totalMatchesOnAllItems == 0 && m.numMatchingItems == 0 {
 		return "(no matches)"
 	}
 	currentMatch := m.focusedMatchIdx + 1
@@ -1082,6 +1520,47 @@ func (m *Model[T]) resetSearchHistoryBrowsing() {
 	m.searchHistoryDraft = ""
 }
 
+// updateFilterTextInput passes msg to the filter text input, moving and deleting by grapheme cluster rather than by
+// rune so the cursor never lands inside an emoji sequence or a character with combining marks
+func (m *Model[T]) updateFilterTextInput(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.filterTextInput, cmd = m.filterTextInput.Update(msg)
+		return cmd
+	}
+
+	value, cursor := m.filterTextInput.Value(), m.filterTextInput.Position()
+	tiKeyMap := m.filterTextInput.KeyMap
+	switch {
+	case key.Matches(keyMsg, tiKeyMap.DeleteWordBackward), key.Matches(keyMsg, tiKeyMap.WordBackward),
+		key.Matches(keyMsg, tiKeyMap.WordForward), key.Matches(keyMsg, tiKeyMap.DeleteWordForward):
+		// word-wise keys take precedence, as in the text input
+	case key.Matches(keyMsg, tiKeyMap.DeleteCharacterBackward):
+		m.setFilterTextInputValue(item.DeleteGraphemeBackward(value, cursor))
+		return nil
+	case key.Matches(keyMsg, tiKeyMap.DeleteCharacterForward):
+		m.setFilterTextInputValue(item.DeleteGraphemeForward(value, cursor))
+		return nil
+	case key.Matches(keyMsg, tiKeyMap.CharacterBackward):
+		m.filterTextInput.SetCursor(item.PrevGraphemeBoundary(value, cursor))
+		return nil
+	case key.Matches(keyMsg, tiKeyMap.CharacterForward):
+		m.filterTextInput.SetCursor(item.NextGraphemeBoundary(value, cursor))
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.filterTextInput, cmd = m.filterTextInput.Update(msg)
+	return cmd
+}
+
+// setFilterTextInputValue sets the filter text and the cursor's rune position in it
+func (m *Model[T]) setFilterTextInputValue(value string, cursor int) {
+	m.filterTextInput.SetValue(value)
+	m.filterTextInput.SetCursor(cursor)
+}
+
 func (m *Model[T]) navigateSearchHistoryPrev() {
 	if len(m.searchHistory) == 0 {
 		return
@@ -0,0 +1,161 @@
+package filterableviewport
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func newAsyncFilterViewport(options ...Option[object]) *Model[object] {
+	fv := makeFilterableViewport(
+		60,
+		5,
+		[]viewport.Option[object]{viewport.WithSelectionEnabled[object](true)},
+		append([]Option[object]{WithAsyncFiltering[object](1)}, options...),
+	)
+	fv.asyncFilterChunkSize = 2
+	fv.SetObjects(stringsToItems([]string{"apple", "banana", "cherry", "grape", "avocado"}))
+	return fv
+}
+
+// nextFilterProgress runs cmd, returning the FilterProgressMsg it delivers
+func nextFilterProgress(t *testing.T, cmd tea.Cmd) FilterProgressMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command awaiting filter progress")
+	}
+	msg, ok := cmd().(FilterProgressMsg)
+	if !ok {
+		t.Fatalf("expected FilterProgressMsg, got %T", msg)
+	}
+	return msg
+}
+
+// finishFilter applies progress messages until the background pass finishes
+func finishFilter(t *testing.T, fv *Model[object], cmd tea.Cmd) {
+	t.Helper()
+	for fv.IsFiltering() {
+		fv, cmd = fv.Update(nextFilterProgress(t, cmd))
+	}
+}
+
+func TestAsyncFilter_StreamsProgressThenMatches(t *testing.T) {
+	fv := newAsyncFilterViewport(WithMatchingItemsOnly[object](true))
+	fv.SetFilter("ap", FilterExact)
+	if !fv.IsFiltering() {
+		t.Fatal("expected a background filter pass")
+	}
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"",
+		"",
+		"",
+		"[exact] ap  (filtering… 0%) showing matches only",
+		"",
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	// the first chunk of apple and banana has the first match
+	fv, cmd := fv.Update(nextFilterProgress(t, fv.FilterCmd()))
+	expectedView = internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("ap") + selectedItemStyle.Render("ple"),
+		"",
+		"",
+		"[exact] ap  (filtering… 40%) showing matches only",
+		footerStyle.Render("100% (1/1)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	finishFilter(t, fv, cmd)
+	if fv.GetMatchCount() != 2 || fv.GetCurrentMatchIndex() != 0 {
+		t.Errorf("expected the first of 2 matches focused, got %d at %d", fv.GetMatchCount(), fv.GetCurrentMatchIndex())
+	}
+	expectedView = internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("ap") + selectedItemStyle.Render("ple"),
+		"gr" + unfocusedStyle.Render("ap") + "e",
+		"",
+		"[exact] ap  (1/2 matches on 2 items) showing matches only",
+		footerStyle.Render("50% (1/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
+
+func TestAsyncFilter_ChunksAppendMatches(t *testing.T) {
+	fv := newAsyncFilterViewport(WithMatchingItemsOnly[object](true))
+	fv.SetFilter("ap", FilterExact)
+	fv, cmd := fv.Update(nextFilterProgress(t, fv.FilterCmd()))
+
+	// the second chunk of cherry and grape adds grape and its match beneath apple
+	fv, _ = fv.Update(nextFilterProgress(t, cmd))
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("ap") + selectedItemStyle.Render("ple"),
+		"gr" + unfocusedStyle.Render("ap") + "e",
+		"",
+		"[exact] ap  (filtering… 80%) showing matches only",
+		footerStyle.Render("50% (1/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+	if got := len(fv.vp.GetHighlights()); got != 2 {
+		t.Errorf("expected 2 highlights, got %d", got)
+	}
+}
+
+func TestAsyncFilter_ContextLinesShownWhenDone(t *testing.T) {
+	fv := makeFilterableViewport(20, 16, nil, []Option[object]{
+		WithAsyncFiltering[object](1),
+		WithMatchingItemsOnly[object](true),
+		WithFilterContextLines[object](1),
+	})
+	fv.asyncFilterChunkSize = 2
+	fv.SetObjects(contextLinesItems())
+	fv.SetFilter("x", FilterExact)
+	finishFilter(t, fv, fv.FilterCmd())
+
+	expected := []string{"b", "x1", "c", "--", "f", "x2", "g", "x3", "h"}
+	if got := shownLines(fv); !slices.Equal(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestAsyncFilter_CanceledWhenFilterChanges(t *testing.T) {
+	fv := newAsyncFilterViewport()
+	fv.SetFilter("ap", FilterExact)
+	stale := fv.FilterCmd()
+
+	fv.SetFilter("an", FilterExact)
+	cmd := fv.FilterCmd()
+	if msg := stale(); msg != nil {
+		t.Errorf("expected the canceled pass to deliver nothing, got %T", msg)
+	}
+
+	finishFilter(t, fv, cmd)
+	if fv.GetMatchCount() != 2 {
+		t.Errorf("expected the matches of the new filter, got %d", fv.GetMatchCount())
+	}
+}
+
+func TestAsyncFilter_BelowThresholdIsSynchronous(t *testing.T) {
+	fv := newAsyncFilterViewport()
+	fv.asyncFilterThreshold = 10
+	fv.SetFilter("ap", FilterExact)
+	if fv.IsFiltering() || fv.FilterCmd() != nil {
+		t.Error("expected a synchronous filter pass below the threshold")
+	}
+	if fv.GetMatchCount() != 2 {
+		t.Errorf("expected 2 matches, got %d", fv.GetMatchCount())
+	}
+}
+
+func TestAsyncFilter_AppendedDuringPassMatchedAtEnd(t *testing.T) {
+	fv := newAsyncFilterViewport()
+	fv.SetFilter("ap", FilterExact)
+	cmd := fv.FilterCmd()
+	fv.AppendObjects(stringsToItems([]string{"papaya"}))
+
+	finishFilter(t, fv, cmd)
+	if fv.GetMatchCount() != 3 {
+		t.Errorf("expected the appended object matched once the pass finishes, got %d matches", fv.GetMatchCount())
+	}
+}
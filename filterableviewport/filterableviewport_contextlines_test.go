@@ -0,0 +1,92 @@
+package filterableviewport
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func contextLinesItems() []object {
+	return stringsToItems([]string{"a", "b", "x1", "c", "d", "e", "f", "x2", "g", "x3", "h"})
+}
+
+// shownLines returns the unstyled content lines in view, up to the first empty one
+func shownLines(fv *Model[object]) []string {
+	var lines []string
+	for _, line := range strings.Split(item.StripAnsi(fv.View()), "\n") {
+		line = strings.TrimRight(line, " ")
+		if line == "" {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestContextLines_ShowsItemsAroundMatches(t *testing.T) {
+	fv := makeFilterableViewport(20, 16, []viewport.Option[object]{viewport.WithSelectionEnabled[object](true)},
+		[]Option[object]{WithMatchingItemsOnly[object](true), WithFilterContextLines[object](1)})
+	fv.SetObjects(contextLinesItems())
+	applyFilter(fv, "x")
+
+	expected := []string{"b", "x1", "c", "--", "f", "x2", "g", "x3", "h"}
+	if got := shownLines(fv); !slices.Equal(got, expected) {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if seps := fv.vp.GetSeparators(); !slices.Equal(seps, []int{2}) {
+		t.Errorf("expected a separator beneath the first group, got %v", seps)
+	}
+
+	// the first match is selected, and matches are navigated between where they're shown
+	if sel := fv.GetSelectedItem(); sel == nil || sel.item.ContentNoAnsi() != "x1" {
+		t.Errorf("expected x1 selected, got %v", sel)
+	}
+	fv.Update(nextMatchKeyMsg)
+	if sel := fv.GetSelectedItem(); sel == nil || sel.item.ContentNoAnsi() != "x2" {
+		t.Errorf("expected x2 selected, got %v", sel)
+	}
+}
+
+func TestContextLines_SetFilterContextLines(t *testing.T) {
+	fv := makeFilterableViewport(20, 16, nil, []Option[object]{WithMatchingItemsOnly[object](true)})
+	fv.SetObjects(contextLinesItems())
+	applyFilter(fv, "x")
+	if got := shownLines(fv); !slices.Equal(got, []string{"x1", "x2", "x3"}) {
+		t.Fatalf("expected only the matches without context lines, got %q", got)
+	}
+
+	fv.SetFilterContextLines(2)
+	if got := fv.GetFilterContextLines(); got != 2 {
+		t.Errorf("expected 2 context lines, got %d", got)
+	}
+	// groups overlapping or touching merge, so every item is shown
+	if got := shownLines(fv); len(got) != 11 {
+		t.Errorf("expected all 11 items, got %q", got)
+	}
+	if seps := fv.vp.GetSeparators(); len(seps) != 0 {
+		t.Errorf("expected no separators between contiguous items, got %v", seps)
+	}
+
+	fv.SetMatchingItemsOnly(false)
+	if seps := fv.vp.GetSeparators(); len(seps) != 0 {
+		t.Errorf("expected no separators showing all items, got %v", seps)
+	}
+}
+
+func TestContextLines_AppendedObjects(t *testing.T) {
+	fv := makeFilterableViewport(20, 16, nil,
+		[]Option[object]{WithMatchingItemsOnly[object](true), WithFilterContextLines[object](1)})
+	fv.SetObjects(stringsToItems([]string{"x1", "a", "b"}))
+	applyFilter(fv, "x")
+	if got := shownLines(fv); !slices.Equal(got, []string{"x1", "a"}) {
+		t.Fatalf("expected x1 and a, got %q", got)
+	}
+
+	fv.AppendObjects(stringsToItems([]string{"c", "x2"}))
+	if got := shownLines(fv); !slices.Equal(got, []string{"x1", "a", "--", "c", "x2"}) {
+		t.Errorf("expected a separator between a and c, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package filterableviewport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestControl_SetFilterMsg(t *testing.T) {
+	fv := makeSearchHistoryFV()
+
+	fv, _ = fv.Update(SetFilterMsg{Value: "rav", Mode: FilterExact})
+	if got := fv.GetFilterText(); got != "rav" {
+		t.Errorf("expected filter %q, got %q", "rav", got)
+	}
+	if len(fv.allMatches) != 1 {
+		t.Errorf("expected 1 match, got %d", len(fv.allMatches))
+	}
+
+	fv, _ = fv.Update(SetFilterMsg{})
+	if got := fv.GetFilterText(); got != "" || len(fv.allMatches) != 0 {
+		t.Errorf("expected the filter to be cleared, got %q with %d matches", got, len(fv.allMatches))
+	}
+}
+
+func TestControl_SelectItemMsgWhileEditing(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, []viewport.Option[object]{viewport.WithSelectionEnabled[object](true)}, nil)
+	fv.SetObjects(stringsToItems([]string{"alpha", "bravo", "charlie"}))
+
+	fv, _ = fv.Update(filterKeyMsg)
+	fv, _ = fv.Update(viewport.SelectItemMsg{ItemIdx: 2})
+	if got := fv.GetSelectedItemIdx(); got != 2 {
+		t.Errorf("expected item 2 to be selected while editing the filter, got %d", got)
+	}
+}
+
+func TestControl_InternalMsgWhileEditing(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, []viewport.Option[object]{
+		viewport.WithSelectionEnabled[object](true),
+		viewport.WithKeyChordTimeout[object](time.Millisecond),
+	}, nil)
+	fv.SetObjects(stringsToItems([]string{"alpha", "bravo", "charlie"}))
+
+	fv, cmd := fv.Update(internal.MakeKeyMsg('z'))
+	fv, _ = fv.Update(filterKeyMsg)
+	if fv.vp.GetPendingKeys() != "z" {
+		t.Fatalf("expected z pending, got %q", fv.vp.GetPendingKeys())
+	}
+	fv, _ = fv.Update(cmd())
+	if got := fv.vp.GetPendingKeys(); got != "" {
+		t.Errorf("expected the key sequence to time out while editing the filter, got %q pending", got)
+	}
+}
@@ -0,0 +1,75 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestControlChars_MatchesHighlightedPastCaretNotation(t *testing.T) {
+	fv := makeFilterableViewport(
+		15,
+		4,
+		[]viewport.Option[object]{
+			viewport.WithShowControlChars[object](true),
+			viewport.WithStyles[object](viewport.Styles{
+				FooterStyle:       footerStyle,
+				SelectedItemStyle: selectedItemStyle,
+				ControlCharStyle:  lipgloss.NewStyle(),
+			}),
+		},
+		[]Option[object]{
+			WithMatchingItemsOnly[object](false),
+			WithEmptyText[object]("None"),
+		},
+	)
+	fv.SetObjects(stringsToItems([]string{"a\x00bc def", "x\rbc"}))
+
+	fv, _ = fv.Update(filterKeyMsg)
+	for _, c := range "bc" {
+		fv, _ = fv.Update(internal.MakeKeyMsg(c))
+	}
+	fv, _ = fv.Update(applyFilterKeyMsg)
+	expected := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"a^@" + focusedStyle.Render("bc") + " def",
+		"x^M" + unfocusedStyle.Render("bc"),
+		"[exact] bc  ...",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expected, fv.View())
+}
+
+func TestControlChars_MatchesHighlightedPastInvisibles(t *testing.T) {
+	fv := makeFilterableViewport(
+		20,
+		4,
+		[]viewport.Option[object]{
+			viewport.WithShowInvisibles[object](true),
+			viewport.WithStyles[object](viewport.Styles{
+				FooterStyle:       footerStyle,
+				SelectedItemStyle: selectedItemStyle,
+				InvisibleStyle:    lipgloss.NewStyle(),
+			}),
+		},
+		[]Option[object]{
+			WithMatchingItemsOnly[object](false),
+			WithEmptyText[object]("None"),
+		},
+	)
+	fv.SetObjects(stringsToItems([]string{"a\u200bbc def", "bc "}))
+
+	fv, _ = fv.Update(filterKeyMsg)
+	for _, c := range "bc" {
+		fv, _ = fv.Update(internal.MakeKeyMsg(c))
+	}
+	fv, _ = fv.Update(applyFilterKeyMsg)
+	expected := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"a<U+200B>" + focusedStyle.Render("bc") + " def",
+		unfocusedStyle.Render("bc") + "·",
+		"[exact] bc  (1/2 ...",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expected, fv.View())
+}
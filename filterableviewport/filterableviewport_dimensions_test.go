@@ -0,0 +1,38 @@
+package filterableviewport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestDimensions_DegenerateSizesDontPanic(t *testing.T) {
+	sizes := []int{-5, -1, 0, 1, 2}
+	for _, w := range sizes {
+		for _, h := range sizes {
+			t.Run(fmt.Sprintf("%dx%d", w, h), func(t *testing.T) {
+				fv := makeFilterableViewport(
+					w,
+					h,
+					[]viewport.Option[object]{viewport.WithWrapText[object](true)},
+					[]Option[object]{WithMatchingItemsOnly[object](true)},
+				)
+				fv.SetObjects(stringsToItems([]string{"apple", "banana", "cherry"}))
+				_ = fv.View()
+
+				fv, _ = fv.Update(filterKeyMsg)
+				for _, c := range "an" {
+					fv, _ = fv.Update(internal.MakeKeyMsg(c))
+				}
+				_ = fv.View()
+				fv, _ = fv.Update(applyFilterKeyMsg)
+				fv, _ = fv.Update(nextMatchKeyMsg)
+				fv.SetWidth(w)
+				fv.SetHeight(h)
+				_ = fv.View()
+			})
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestDumpState(t *testing.T) {
+	fv := makeFilterableViewport(
+		40,
+		4,
+		[]viewport.Option[object]{},
+		[]Option[object]{},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana"}))
+
+	expected := `objects: 2
+filter: none
+matching items only: false
+size: 40x4
+items: 2
+header lines: 0
+visible: items 1-2 of 2, 2 lines
+selection: disabled
+wrap: off, x offset 0
+sticky: top false, bottom false`
+	internal.CmpStr(t, expected, fv.DumpState())
+
+	fv, _ = fv.Update(filterKeyMsg)
+	fv, _ = fv.Update(internal.MakeKeyMsg('a'))
+	fv, _ = fv.Update(applyFilterKeyMsg)
+
+	expected = `objects: 2
+filter: exact "a", applied
+matches: 1/4 matches on 2 items
+matching items only: false
+size: 40x4
+items: 2
+header lines: 0
+visible: items 1-2 of 2, 2 lines
+selection: disabled
+wrap: off, x offset 0
+sticky: top false, bottom false`
+	internal.CmpStr(t, expected, fv.DumpState())
+}
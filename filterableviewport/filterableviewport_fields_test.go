@@ -0,0 +1,123 @@
+package filterableviewport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+// logFields treats the first word of an item as its level and the rest as its message, with the host looked up
+// from a field not shown in the item
+func logFields(hosts map[string]string) map[string]func(object) string {
+	return map[string]func(object) string{
+		"level": func(o object) string {
+			level, _, _ := strings.Cut(o.item.ContentNoAnsi(), " ")
+			return level
+		},
+		"msg": func(o object) string {
+			_, msg, _ := strings.Cut(o.item.ContentNoAnsi(), " ")
+			return msg
+		},
+		"host": func(o object) string {
+			return hosts[o.item.ContentNoAnsi()]
+		},
+	}
+}
+
+func TestFieldAccessors_ScopedAndFreeTerms(t *testing.T) {
+	fv := makeFilterableViewport(
+		40,
+		5,
+		[]viewport.Option[object]{},
+		[]Option[object]{WithFieldAccessors[object](logFields(nil)), WithMatchingItemsOnly[object](true)},
+	)
+	fv.SetObjects(stringsToItems([]string{
+		"error timeout on db",
+		"info error timeout",
+		"error disk full",
+	}))
+
+	applyFilter(fv, "level:error timeout")
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("error") + " " + unfocusedStyle.Render("timeout") + " on db",
+		"",
+		"",
+		"[exact] level:error timeout  (1/2 mat...",
+		footerStyle.Render("100% (1/1)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
+
+func TestFieldAccessors_HiddenFieldMatchesWithoutHighlights(t *testing.T) {
+	hosts := map[string]string{"error disk full": "web-1", "info ok": "db-1"}
+	fv := makeFilterableViewport(
+		70,
+		5,
+		[]viewport.Option[object]{},
+		[]Option[object]{WithFieldAccessors[object](logFields(hosts)), WithMatchingItemsOnly[object](true)},
+	)
+	fv.SetObjects(stringsToItems([]string{"error disk full", "info ok"}))
+
+	applyFilter(fv, "host:web")
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"error disk full",
+		"",
+		"",
+		"[exact] host:web  (0/0 matches on 1 items) showing matches only",
+		footerStyle.Render("100% (1/1)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
+
+func TestFieldAccessors_UnknownFieldIsFreeText(t *testing.T) {
+	fv := makeFilterableViewport(
+		60,
+		4,
+		[]viewport.Option[object]{},
+		[]Option[object]{WithFieldAccessors[object](logFields(nil)), WithMatchingItemsOnly[object](true)},
+	)
+	fv.SetObjects(stringsToItems([]string{"see http://x", "info ok"}))
+
+	applyFilter(fv, "http:")
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"see " + focusedStyle.Render("http:") + "//x",
+		"",
+		"[exact] http:  (1/1 matches on 1 items) showing matches only",
+		footerStyle.Render("100% (1/1)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
+
+func TestParseFieldQuery(t *testing.T) {
+	isField := func(name string) bool { return name == "level" || name == "msg" }
+	tests := []struct {
+		name      string
+		query     string
+		wantFree  string
+		wantTerms []fieldTerm
+	}{
+		{name: "free only", query: "disk full", wantFree: "disk full"},
+		{name: "field and free", query: "level:error  disk", wantFree: "disk", wantTerms: []fieldTerm{{"level", "error"}}},
+		{name: "two fields", query: "level:warn msg:time:out", wantTerms: []fieldTerm{{"level", "warn"}, {"msg", "time:out"}}},
+		{name: "empty value dropped", query: "level: disk", wantFree: "disk"},
+		{name: "unknown field", query: "host:web", wantFree: "host:web"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			free, terms := parseFieldQuery(tt.query, isField)
+			if free != tt.wantFree {
+				t.Errorf("expected free text %q, got %q", tt.wantFree, free)
+			}
+			if len(terms) != len(tt.wantTerms) {
+				t.Fatalf("expected terms %v, got %v", tt.wantTerms, terms)
+			}
+			for i := range terms {
+				if terms[i] != tt.wantTerms[i] {
+					t.Errorf("expected terms %v, got %v", tt.wantTerms, terms)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestFilterError_NotApplied(t *testing.T) {
+	fv := makeFilterableViewport(
+		80,
+		5,
+		[]viewport.Option[object]{},
+		[]Option[object]{
+			WithPrefixText[object]("Filter:"),
+			WithMatchingItemsOnly[object](true),
+			WithStyles[object](Styles{Match: matchStyles, Error: internal.RedFg}),
+		},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana", "cherry"}))
+	fv, _ = fv.Update(regexFilterKeyMsg)
+	for _, c := range "an(" {
+		fv, _ = fv.Update(internal.MakeKeyMsg(c))
+	}
+	if fv.GetFilterError() == nil {
+		t.Fatal("expected a filter error for an invalid regex")
+	}
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"apple",
+		"banana",
+		"cherry",
+		"[regex] Filter: an(" + cursorStyle.Render(" ") + " " + internal.RedFg.Render("(invalid regex: missing closing ))") + " showing matches only",
+		footerStyle.Render("100% (3/3)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	fv, _ = fv.Update(internal.MakeKeyMsg(')'))
+	if err := fv.GetFilterError(); err != nil {
+		t.Errorf("expected no filter error once the regex is fixed, got %v", err)
+	}
+	if count := fv.GetMatchCount(); count != 2 {
+		t.Errorf("expected 2 matches, got %d", count)
+	}
+}
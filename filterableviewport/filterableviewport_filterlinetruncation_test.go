@@ -0,0 +1,60 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func newTruncationViewport(truncation FilterLineTruncation) *Model[object] {
+	fv := makeFilterableViewport(
+		30,
+		4,
+		[]viewport.Option[object]{},
+		[]Option[object]{
+			WithFilterLinePrefix[object]("kube-system pods"),
+			WithFilterLineTruncation[object](truncation),
+		},
+	)
+	fv.SetObjects(stringsToItems([]string{"api-server", "scheduler"}))
+	applyFilter(fv, "api")
+	return fv
+}
+
+func TestFilterLineTruncation_End(t *testing.T) {
+	fv := newTruncationViewport(TruncateEnd)
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("api") + "-server",
+		"scheduler",
+		"kube-system pods [exact] ap...",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
+
+func TestFilterLineTruncation_PrefixFirst(t *testing.T) {
+	fv := newTruncationViewport(TruncatePrefixFirst)
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("api") + "-server",
+		"scheduler",
+		"[exact] api  (1/1 matches o...",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	// the text input leaves a cell after the query for the cursor
+	widths := fv.GetFilterLineWidths()
+	if widths.Prefix != 0 || widths.Query != len("[exact] api ") || widths.Total != 30 {
+		t.Errorf("expected the prefix dropped for the query, got %+v", widths)
+	}
+
+	fv.SetWidth(52)
+	expectedView = internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("api") + "-server",
+		"scheduler",
+		"kube-system... [exact] api  (1/1 matches on 1 items)",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
@@ -0,0 +1,45 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestFocus_BlurredIgnoresFilterKeys(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, []viewport.Option[object]{viewport.WithSelectionEnabled[object](true)}, nil)
+	fv.SetObjects(stringsToItems([]string{"alpha", "bravo", "charlie"}))
+
+	fv.Blur()
+	if fv.Focused() {
+		t.Fatal("expected the filterable viewport blurred")
+	}
+	fv, _ = fv.Update(filterKeyMsg)
+	if fv.FilterFocused() {
+		t.Error("expected the filter key ignored while blurred")
+	}
+	fv, _ = fv.Update(downKeyMsg)
+	if got := fv.GetSelectedItemIdx(); got != 0 {
+		t.Errorf("expected keys ignored while blurred, got selection %d", got)
+	}
+
+	fv.Focus()
+	fv, _ = fv.Update(filterKeyMsg)
+	if !fv.FilterFocused() {
+		t.Error("expected the filter key handled once focused")
+	}
+}
+
+func TestFocus_BlurWhileEditingFilter(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, nil, nil)
+	fv.SetObjects(stringsToItems([]string{"alpha", "bravo", "charlie"}))
+
+	fv, _ = fv.Update(filterKeyMsg)
+	fv, _ = fv.Update(regexFilterKeyMsg)
+	fv.Blur()
+	fv, _ = fv.Update(regexFilterKeyMsg)
+	fv.Focus()
+	if got := fv.GetFilterText(); got != "r" {
+		t.Errorf("expected typing ignored while blurred, got filter %q", got)
+	}
+}
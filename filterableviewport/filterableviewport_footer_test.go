@@ -0,0 +1,54 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestFilterFooterFormatter(t *testing.T) {
+	fv := makeFilterableViewport(
+		40,
+		5,
+		[]viewport.Option[object]{viewport.WithFooterFormatter[object](viewport.FilterFooterFormatter)},
+		[]Option[object]{},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana", "cherry"}))
+	if fv.vp.GetFooterFilterInfo() != nil {
+		t.Error("expected no footer filter info without a filter")
+	}
+
+	fv, _ = fv.Update(filterKeyMsg)
+	for _, c := range "an" {
+		fv, _ = fv.Update(internal.MakeKeyMsg(c))
+	}
+	fv, _ = fv.Update(applyFilterKeyMsg)
+
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"apple",
+		"b" + focusedStyle.Render("an") + unfocusedStyle.Render("an") + "a",
+		"cherry",
+		"[exact] an  (1/2 matches on 1 items)",
+		footerStyle.Render(`[exact] "an" match 1/2  (3/3)`),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	fv, _ = fv.Update(toggleMatchesKeyMsg)
+	if info := fv.vp.GetFooterFilterInfo(); info == nil || !info.MatchingItemsOnly {
+		t.Errorf("expected footer filter info showing matching items only, got %+v", info)
+	}
+
+	fv, _ = fv.Update(cancelFilterKeyMsg)
+	if fv.vp.GetFooterFilterInfo() != nil {
+		t.Error("expected footer filter info cleared with the filter")
+	}
+	expectedView = internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"apple",
+		"banana",
+		"cherry",
+		"No Filter",
+		footerStyle.Render("100% (3/3)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
@@ -0,0 +1,47 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestHeaderHighlighting(t *testing.T) {
+	fv := makeFilterableViewport(
+		40,
+		5,
+		[]viewport.Option[object]{},
+		[]Option[object]{WithHeaderHighlighting[object](true)},
+	)
+	fv.SetHeader([]string{"NAME STATUS"})
+	fv.SetObjects(stringsToItems([]string{"web running", "db stopped"}))
+
+	fv, _ = fv.Update(filterKeyMsg)
+	for _, c := range "STATUS" {
+		fv, _ = fv.Update(internal.MakeKeyMsg(c))
+	}
+	fv, _ = fv.Update(applyFilterKeyMsg)
+
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"NAME " + unfocusedStyle.Render("STATUS"),
+		"web running",
+		"db stopped",
+		"[exact] STATUS  (no matches)",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	fv.SetHeaderHighlighting(false)
+	if fv.GetHeaderHighlighting() {
+		t.Error("expected header highlighting off")
+	}
+	expectedView = internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"NAME STATUS",
+		"web running",
+		"db stopped",
+		"[exact] STATUS  (no matches)",
+		footerStyle.Render("100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
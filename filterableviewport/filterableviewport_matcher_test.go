@@ -0,0 +1,98 @@
+package filterableviewport
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// globMatcher matches whole lines against a shell glob
+type globMatcher struct {
+	pattern string
+}
+
+func (g globMatcher) Match(content string) []item.ByteRange {
+	if ok, _ := path.Match(g.pattern, content); ok {
+		return []item.ByteRange{{Start: 0, End: len(content)}}
+	}
+	return nil
+}
+
+func TestSubstringMatcher(t *testing.T) {
+	got := SubstringMatcher{Text: "ab"}.Match("abcab ab")
+	want := []item.ByteRange{{Start: 0, End: 2}, {Start: 3, End: 5}, {Start: 6, End: 8}}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := (SubstringMatcher{}).Match("abc"); got != nil {
+		t.Errorf("expected no matches for empty text, got %v", got)
+	}
+}
+
+func TestRegexMatcher(t *testing.T) {
+	matcher, err := NewRegexMatcher(`\d+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := matcher.Match("a1 b22")
+	want := []item.ByteRange{{Start: 1, End: 2}, {Start: 4, End: 6}}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, err := NewRegexMatcher("("); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestCustomMatcher(t *testing.T) {
+	globMode := FilterMode{
+		Name:  "glob",
+		Key:   key.NewBinding(key.WithKeys("g")),
+		Label: "[glob]",
+		GetMatcher: func(filterText string) (Matcher, error) {
+			return globMatcher{pattern: filterText}, nil
+		},
+		// ignored in favor of GetMatcher
+		GetMatchFunc: func(string) (MatchFunc, error) {
+			return nil, nil
+		},
+	}
+	fv := makeFilterableViewport(80, 6, nil, []Option[object]{WithFilterModes[object]([]FilterMode{globMode})})
+	fv.SetObjects(stringsToItems([]string{"main.go", "main_test.go", "README.md"}))
+
+	fv.SetFilter("*.go", "glob")
+	if fv.numMatchingItems != 2 {
+		t.Errorf("expected 2 matching items, got %d", fv.numMatchingItems)
+	}
+	if !globMode.Matches("*_test.go", "main_test.go") || globMode.Matches("*_test.go", "main.go") {
+		t.Error("expected Matches to use the glob matcher")
+	}
+}
+
+func TestNewMatcherFromMatchFunc(t *testing.T) {
+	mode := FilterMode{
+		GetMatchFunc: func(filterText string) (MatchFunc, error) {
+			return func(content string) []item.ByteRange {
+				if strings.HasSuffix(content, filterText) {
+					return []item.ByteRange{{Start: len(content) - len(filterText), End: len(content)}}
+				}
+				return nil
+			}, nil
+		},
+	}
+	matcher, err := mode.NewMatcher("c")
+	if err != nil || matcher == nil {
+		t.Fatalf("expected a matcher, got %v, %v", matcher, err)
+	}
+	if got := matcher.Match("abc"); len(got) != 1 || got[0] != (item.ByteRange{Start: 2, End: 3}) {
+		t.Errorf("expected a match at the end, got %v", got)
+	}
+
+	if matcher, err := (FilterMode{}).NewMatcher("c"); matcher != nil || err != nil {
+		t.Errorf("expected no matcher for a mode without match functions, got %v, %v", matcher, err)
+	}
+}
@@ -0,0 +1,49 @@
+package filterableviewport
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatchingObjects_FilteredSubset(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, nil, nil)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana", "cherry", "grape"}))
+	if idxs := fv.GetMatchingIndices(); idxs != nil {
+		t.Errorf("expected no matches without a filter, got %v", idxs)
+	}
+
+	applyFilter(fv, "ap")
+	if idxs := fv.GetMatchingIndices(); !slices.Equal(idxs, []int{0, 3}) {
+		t.Errorf("expected apple and grape to match, got %v", idxs)
+	}
+	objects := fv.GetMatchingObjects()
+	if len(objects) != 2 || objects[0].item.ContentNoAnsi() != "apple" || objects[1].item.ContentNoAnsi() != "grape" {
+		t.Errorf("expected apple and grape, got %v", objects)
+	}
+
+	// appended matches are included
+	fv.AppendObjects(stringsToItems([]string{"papaya"}))
+	if idxs := fv.GetMatchingIndices(); !slices.Equal(idxs, []int{0, 3, 4}) {
+		t.Errorf("expected the appended papaya to match, got %v", idxs)
+	}
+
+	// the returned indexes are a copy
+	fv.GetMatchingIndices()[0] = 99
+	if idxs := fv.GetMatchingIndices(); idxs[0] != 0 {
+		t.Errorf("expected modifying the result not to change the matches, got %v", idxs)
+	}
+
+	applyFilter(fv, "zzz")
+	if objects := fv.GetMatchingObjects(); objects != nil {
+		t.Errorf("expected no matching objects, got %v", objects)
+	}
+}
+
+func TestMatchingObjects_MatchLimitExceeded(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, nil, []Option[object]{WithMaxMatchLimit[object](1)})
+	fv.SetObjects(stringsToItems([]string{"apple", "grape"}))
+	applyFilter(fv, "ap")
+	if idxs := fv.GetMatchingIndices(); idxs != nil {
+		t.Errorf("expected matches untracked over the limit, got %v", idxs)
+	}
+}
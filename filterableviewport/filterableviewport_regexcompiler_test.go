@@ -0,0 +1,53 @@
+package filterableviewport
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestRegexCompiler(t *testing.T) {
+	var patterns []string
+	compile := func(pattern string) (Matcher, error) {
+		patterns = append(patterns, pattern)
+		if pattern == "bad" || pattern == "(?i)bad" {
+			return nil, errors.New("unsupported")
+		}
+		return globMatcher{pattern: "*an*"}, nil
+	}
+	fv := makeFilterableViewport(
+		60,
+		5,
+		[]viewport.Option[object]{},
+		[]Option[object]{WithRegexCompiler[object](compile)},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana", "cherry"}))
+
+	fv.SetFilter("x", FilterRegex)
+	if got := fv.GetMatchCount(); got != 1 {
+		t.Errorf("expected the compiled matcher to match banana, got %d matches", got)
+	}
+	fv.SetFilter("y", FilterCaseInsensitive)
+	fv.SetFilter("apple", FilterExact)
+	if len(patterns) == 0 || patterns[0] != "x" || patterns[len(patterns)-1] != "(?i)y" {
+		t.Errorf("expected the regex modes to compile x and (?i)y only, got %v", patterns)
+	}
+	if got := fv.GetMatchCount(); got != 1 {
+		t.Errorf("expected the exact mode to match apple, got %d matches", got)
+	}
+
+	fv.SetFilter("bad", FilterRegex)
+	if err := fv.GetFilterError(); err == nil || err.Error() != "unsupported" {
+		t.Errorf("expected the compiler's error, got %v", err)
+	}
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"apple",
+		"banana",
+		"cherry",
+		"[regex] bad (unsupported)",
+		footerStyle.Render("100% (3/3)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
@@ -0,0 +1,25 @@
+package filterableviewport
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestRetention_DropsOldestMatches(t *testing.T) {
+	fv := makeFilterableViewport(40, 10, []viewport.Option[object]{viewport.WithMaxItems[object](3)}, nil)
+	fv.SetObjects(stringsToItems([]string{"x1", "a", "x2", "b"}))
+	applyFilter(fv, "x")
+	if idxs := fv.GetMatchingIndices(); !slices.Equal(idxs, []int{1}) {
+		t.Errorf("expected only x2 of the newest 3 objects to match, got %v", idxs)
+	}
+
+	fv.AppendObjects(stringsToItems([]string{"x3", "c"}))
+	if idxs := fv.GetMatchingIndices(); !slices.Equal(idxs, []int{1}) {
+		t.Errorf("expected only x3 to match after x2 is dropped, got %v", idxs)
+	}
+	if got := fv.GetMatchCount(); got != 1 {
+		t.Errorf("expected 1 match, got %d", got)
+	}
+}
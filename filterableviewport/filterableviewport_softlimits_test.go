@@ -0,0 +1,38 @@
+package filterableviewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+func TestSoftLimitsDisableLiveFiltering(t *testing.T) {
+	fv := makeFilterableViewport(
+		60,
+		4,
+		[]viewport.Option[object]{viewport.WithSoftLimits[object](viewport.SoftLimits{MaxItems: 1})},
+		[]Option[object]{},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana"}))
+	fv, _ = fv.Update(filterKeyMsg)
+	fv, _ = fv.Update(internal.MakeKeyMsg('a'))
+
+	// matches aren't updated while typing
+	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		"apple",
+		"banana",
+		"[exact] a" + cursorStyle.Render(" ") + " (enter to filter)",
+		footerStyle.Render("large content (over 1 items)  100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+
+	fv, _ = fv.Update(applyFilterKeyMsg)
+	expectedView = internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
+		focusedStyle.Render("a") + "pple",
+		"b" + unfocusedStyle.Render("a") + "n" + unfocusedStyle.Render("a") + "n" + unfocusedStyle.Render("a"),
+		"[exact] a  (1/4 matches on 2 items)",
+		footerStyle.Render("large content (over 1 items)  100% (2/2)"),
+	})
+	internal.CmpStr(t, expectedView, fv.View())
+}
@@ -656,7 +656,7 @@ func TestRegexFilterValidPattern(t *testing.T) {
 
 func TestRegexFilterInvalidPattern(t *testing.T) {
 	fv := makeFilterableViewport(
-		50,
+		60,
 		4,
 		[]viewport.Option[object]{},
 		[]Option[object]{
@@ -669,7 +669,7 @@ func TestRegexFilterInvalidPattern(t *testing.T) {
 	expectedView := internal.Pad(fv.GetWidth(), fv.GetHeight(), []string{
 		"apple",
 		"banana",
-		"[regex] Filter: [" + cursorStyle.Render(" ") + " (no matches)",
+		"[regex] Filter: [" + cursorStyle.Render(" ") + " (invalid regex: missing closing ])",
 		footerStyle.Render("100% (2/2)"),
 	})
 	internal.CmpStr(t, expectedView, fv.View())
@@ -4593,3 +4593,99 @@ func TestNoMatchesResetsXOffsetWhenUnwrapped(t *testing.T) {
 	})
 	internal.CmpStr(t, expectedView, fv.View())
 }
+
+func TestDisabledIgnoresFilterKeys(t *testing.T) {
+	fv := makeFilterableViewport(
+		40,
+		4,
+		[]viewport.Option[object]{},
+		[]Option[object]{},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana"}))
+	fv.SetEnabled(false)
+	if fv.GetEnabled() {
+		t.Fatal("expected the filterable viewport disabled")
+	}
+
+	fv, _ = fv.Update(filterKeyMsg)
+	if fv.FilterFocused() {
+		t.Error("expected filter keys ignored while disabled")
+	}
+
+	fv.SetEnabled(true)
+	fv, _ = fv.Update(filterKeyMsg)
+	if !fv.FilterFocused() {
+		t.Error("expected filter keys handled once enabled")
+	}
+}
+
+func TestGetMatchCountAndCurrentMatchIndex(t *testing.T) {
+	fv := makeFilterableViewport(
+		40,
+		4,
+		[]viewport.Option[object]{},
+		[]Option[object]{},
+	)
+	fv.SetObjects(stringsToItems([]string{"apple", "banana"}))
+	if fv.GetMatchCount() != 0 || fv.GetCurrentMatchIndex() != -1 {
+		t.Errorf("expected no matches without a filter, got %d at %d", fv.GetMatchCount(), fv.GetCurrentMatchIndex())
+	}
+
+	applyFilter(fv, "a")
+	if fv.GetMatchCount() != 4 || fv.GetCurrentMatchIndex() != 0 {
+		t.Errorf("expected the first of 4 matches focused, got %d at %d", fv.GetMatchCount(), fv.GetCurrentMatchIndex())
+	}
+
+	fv, _ = fv.Update(nextMatchKeyMsg)
+	fv, _ = fv.Update(nextMatchKeyMsg)
+	if fv.GetCurrentMatchIndex() != 2 {
+		t.Errorf("expected next match navigation to focus match 2, got %d", fv.GetCurrentMatchIndex())
+	}
+	fv, _ = fv.Update(prevMatchKeyMsg)
+	if fv.GetCurrentMatchIndex() != 1 {
+		t.Errorf("expected previous match navigation to focus match 1, got %d", fv.GetCurrentMatchIndex())
+	}
+}
+
+func TestFilterInputEditsByGrapheme(t *testing.T) {
+	leftKeyMsg := tea.KeyPressMsg{Code: tea.KeyLeft}
+	rightKeyMsg := tea.KeyPressMsg{Code: tea.KeyRight}
+	backspaceKeyMsg := tea.KeyPressMsg{Code: tea.KeyBackspace}
+	deleteKeyMsg := tea.KeyPressMsg{Code: tea.KeyDelete}
+	thumbsUp := "\U0001F44D\U0001F3FD"
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+
+	fv := makeFilterableViewport(40, 4, []viewport.Option[object]{}, []Option[object]{})
+	fv.SetObjects(stringsToItems([]string{"a" + thumbsUp + "世" + family + "b"}))
+	fv, _ = fv.Update(filterKeyMsg)
+	fv, _ = fv.Update(tea.PasteMsg{Content: "a" + thumbsUp + "世" + family + "b"})
+
+	// move left over b and the zwj sequence, then delete the sequence as a whole
+	fv, _ = fv.Update(leftKeyMsg)
+	fv, _ = fv.Update(leftKeyMsg)
+	if pos := fv.filterTextInput.Position(); pos != 4 {
+		t.Fatalf("expected the cursor before the zwj sequence at rune 4, got %d", pos)
+	}
+	fv, _ = fv.Update(deleteKeyMsg)
+	if value := fv.GetFilterText(); value != "a"+thumbsUp+"世b" {
+		t.Errorf("expected the zwj sequence deleted, got %q", value)
+	}
+
+	// backspace over the CJK character then the skin-toned emoji
+	fv, _ = fv.Update(backspaceKeyMsg)
+	fv, _ = fv.Update(backspaceKeyMsg)
+	if value := fv.GetFilterText(); value != "ab" {
+		t.Errorf("expected the emoji deleted as a whole, got %q", value)
+	}
+
+	// insert mid-string and move right past it
+	fv, _ = fv.Update(tea.PasteMsg{Content: thumbsUp})
+	fv, _ = fv.Update(leftKeyMsg)
+	fv, _ = fv.Update(rightKeyMsg)
+	if pos := fv.filterTextInput.Position(); pos != 3 {
+		t.Errorf("expected the cursor after the inserted emoji at rune 3, got %d", pos)
+	}
+	if value := fv.GetFilterText(); value != "a"+thumbsUp+"b" {
+		t.Errorf("expected the emoji inserted mid-string, got %q", value)
+	}
+}
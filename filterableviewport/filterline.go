@@ -0,0 +1,80 @@
+package filterableviewport
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// FilterLineTruncation controls which part of the filter line gives way first when it's wider than the viewport
+type FilterLineTruncation int
+
+const (
+	// TruncateEnd cuts the filter line at the viewport width, dropping its end first
+	TruncateEnd FilterLineTruncation = iota
+
+	// TruncatePrefixFirst shortens the filter line prefix, then the prefix text, before cutting the end, keeping the
+	// filter mode and query visible on narrow viewports
+	TruncatePrefixFirst
+)
+
+// FilterLineWidths are the cell widths of the parts of a rendered filter line, before it's cut to the viewport width
+type FilterLineWidths struct {
+	// Prefix is the width of the filter line prefix and the prefix text, or of the empty text when no filter is set
+	Prefix int
+
+	// Query is the width of the filter mode indicator and the filter text input
+	Query int
+
+	// Info is the width of the match count and matching items only text
+	Info int
+
+	// Total is the width of the rendered filter line
+	Total int
+}
+
+// filterLineParts are the parts of the filter line, joined with spaces in order: linePrefix, mode, prefix, query, info
+type filterLineParts struct {
+	linePrefix string
+	mode       string
+	prefix     string
+	query      string
+	info       string
+}
+
+func (p filterLineParts) join() string {
+	return strings.Join(removeEmpty([]string{p.linePrefix, p.mode, p.prefix, p.query, p.info}), " ")
+}
+
+// shrinkPrefixes shortens linePrefix, then prefix, until the joined parts fit width, dropping a part that can't keep
+// more than its continuation indicator
+func (p filterLineParts) shrinkPrefixes(width int) filterLineParts {
+	for _, part := range []*string{&p.linePrefix, &p.prefix} {
+		overflow := lipgloss.Width(p.join()) - width
+		if overflow <= 0 {
+			break
+		}
+		*part = shrink(*part, lipgloss.Width(*part)-overflow)
+	}
+	return p
+}
+
+// shrink truncates s to width with a continuation indicator, or drops it if that leaves none of s
+func shrink(s string, width int) string {
+	const continuation = "..."
+	if width <= len(continuation) {
+		return ""
+	}
+	res, _ := item.NewItem(s).Take(0, width, continuation, nil)
+	return res
+}
+
+func (p filterLineParts) widths(total int) FilterLineWidths {
+	return FilterLineWidths{
+		Prefix: lipgloss.Width(strings.Join(removeEmpty([]string{p.linePrefix, p.prefix}), " ")),
+		Query:  lipgloss.Width(strings.Join(removeEmpty([]string{p.mode, p.query}), " ")),
+		Info:   lipgloss.Width(p.info),
+		Total:  total,
+	}
+}
@@ -1,9 +1,6 @@
 package filterableviewport
 
 import (
-	"regexp"
-	"strings"
-
 	"charm.land/bubbles/v2/key"
 	"github.com/robinovitch61/viewport/internal/fuzzy"
 	"github.com/robinovitch61/viewport/viewport/item"
@@ -26,7 +23,7 @@ const (
 )
 
 // MatchFunc extracts match byte ranges from ANSI-stripped item content.
-// Called once per item during a filter scan.
+// Called once per item during a filter scan. It's the function form of a Matcher.
 type MatchFunc func(content string) []item.ByteRange
 
 // FilterMode defines a user-configurable filter type.
@@ -38,76 +35,65 @@ type FilterMode struct {
 	Key key.Binding
 	// Label shown in filter line, e.g. "[exact]"
 	Label string
+	// GetMatcher is called once when the filter text changes. It returns the Matcher used for each item,
+	// or an error (e.g. invalid regex) to show no matches. Takes precedence over GetMatchFunc.
+	GetMatcher func(filterText string) (Matcher, error)
 	// GetMatchFunc is called once when the filter text changes. It returns a MatchFunc
 	// used for each item, or an error (e.g. invalid regex) to show no matches.
+	// Used when GetMatcher is nil.
 	GetMatchFunc func(filterText string) (MatchFunc, error)
 }
 
+// NewMatcher returns the Matcher for filterText from GetMatcher, or else from GetMatchFunc. The Matcher is nil if
+// the mode defines neither or returns none.
+func (fm FilterMode) NewMatcher(filterText string) (Matcher, error) {
+	if fm.GetMatcher != nil {
+		return fm.GetMatcher(filterText)
+	}
+	if fm.GetMatchFunc == nil {
+		return nil, nil
+	}
+	matchFn, err := fm.GetMatchFunc(filterText)
+	if err != nil || matchFn == nil {
+		return nil, err
+	}
+	return matchFn, nil
+}
+
 // Matches reports whether content matches the given query according to this
 // filter mode's matching logic.  It is a convenience wrapper around
-// GetMatchFunc for callers that only need a boolean result.
+// NewMatcher for callers that only need a boolean result.
 func (fm FilterMode) Matches(query, content string) bool {
 	if query == "" {
 		return true
 	}
-	matchFn, err := fm.GetMatchFunc(query)
-	if err != nil {
+	matcher, err := fm.NewMatcher(query)
+	if err != nil || matcher == nil {
 		return false
 	}
-	return len(matchFn(content)) > 0
+	return len(matcher.Match(content)) > 0
 }
 
-// ExactFilterMode returns a FilterMode that performs exact substring matching.
+// ExactFilterMode returns a FilterMode that performs exact substring matching with a SubstringMatcher.
 func ExactFilterMode(k key.Binding) FilterMode {
 	return FilterMode{
 		Name:  FilterExact,
 		Key:   k,
 		Label: "[exact]",
-		GetMatchFunc: func(filterText string) (MatchFunc, error) {
-			return func(content string) []item.ByteRange {
-				if filterText == "" {
-					return nil
-				}
-				var ranges []item.ByteRange
-				startIndex := 0
-				for {
-					foundIndex := strings.Index(content[startIndex:], filterText)
-					if foundIndex == -1 {
-						break
-					}
-					actualStart := startIndex + foundIndex
-					end := actualStart + len(filterText)
-					ranges = append(ranges, item.ByteRange{Start: actualStart, End: end})
-					startIndex = end
-				}
-				return ranges
-			}, nil
+		GetMatcher: func(filterText string) (Matcher, error) {
+			return SubstringMatcher{Text: filterText}, nil
 		},
 	}
 }
 
-// RegexFilterMode returns a FilterMode that performs regex matching.
+// RegexFilterMode returns a FilterMode that performs regex matching with a RegexMatcher.
 func RegexFilterMode(k key.Binding) FilterMode {
 	return FilterMode{
 		Name:  FilterRegex,
 		Key:   k,
 		Label: "[regex]",
-		GetMatchFunc: func(filterText string) (MatchFunc, error) {
-			re, err := regexp.Compile(filterText)
-			if err != nil {
-				return nil, err
-			}
-			return func(content string) []item.ByteRange {
-				regexMatches := re.FindAllStringIndex(content, -1)
-				if len(regexMatches) == 0 {
-					return nil
-				}
-				ranges := make([]item.ByteRange, 0, len(regexMatches))
-				for _, rm := range regexMatches {
-					ranges = append(ranges, item.ByteRange{Start: rm[0], End: rm[1]})
-				}
-				return ranges
-			}, nil
+		GetMatcher: func(filterText string) (Matcher, error) {
+			return NewRegexMatcher(filterText)
 		},
 	}
 }
@@ -120,26 +106,29 @@ func CaseInsensitiveFilterMode(k key.Binding) FilterMode {
 		Name:  FilterCaseInsensitive,
 		Key:   k,
 		Label: "[iregex]",
-		GetMatchFunc: func(filterText string) (MatchFunc, error) {
-			re, err := regexp.Compile("(?i)" + filterText)
-			if err != nil {
-				return nil, err
-			}
-			return func(content string) []item.ByteRange {
-				regexMatches := re.FindAllStringIndex(content, -1)
-				if len(regexMatches) == 0 {
-					return nil
-				}
-				ranges := make([]item.ByteRange, 0, len(regexMatches))
-				for _, rm := range regexMatches {
-					ranges = append(ranges, item.ByteRange{Start: rm[0], End: rm[1]})
-				}
-				return ranges
-			}, nil
+		GetMatcher: func(filterText string) (Matcher, error) {
+			return NewRegexMatcher("(?i)" + filterText)
 		},
 	}
 }
 
+// withRegexCompiler returns a copy of modes with the built-in regex and case-insensitive modes compiling patterns
+// with compile
+func withRegexCompiler(modes []FilterMode, compile func(pattern string) (Matcher, error)) []FilterMode {
+	modes = append([]FilterMode(nil), modes...)
+	for i := range modes {
+		switch modes[i].Name {
+		case FilterRegex:
+			modes[i].GetMatcher = compile
+		case FilterCaseInsensitive:
+			modes[i].GetMatcher = func(filterText string) (Matcher, error) {
+				return compile("(?i)" + filterText)
+			}
+		}
+	}
+	return modes
+}
+
 // FuzzyFilterMode returns a FilterMode that performs fuzzy matching similar to fzf.
 // Characters in the query must appear in order in the content but need not be contiguous.
 // Matching is case-insensitive. The highlighted range spans from the first to the last
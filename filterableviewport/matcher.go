@@ -0,0 +1,71 @@
+package filterableviewport
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// Matcher finds matches in ANSI-stripped item content, returning their byte ranges. Return one from a FilterMode's
+// GetMatcher to plug in custom matching, e.g. globs or expressions over JSON lines.
+type Matcher interface {
+	Match(content string) []item.ByteRange
+}
+
+// Match calls f, so that a MatchFunc is a Matcher
+func (f MatchFunc) Match(content string) []item.ByteRange {
+	return f(content)
+}
+
+// SubstringMatcher matches each non-overlapping occurrence of Text, matching nothing if it's empty
+type SubstringMatcher struct {
+	Text string
+}
+
+// Match returns the byte ranges of the occurrences of Text in content
+func (s SubstringMatcher) Match(content string) []item.ByteRange {
+	if s.Text == "" {
+		return nil
+	}
+	var ranges []item.ByteRange
+	startIndex := 0
+	for {
+		foundIndex := strings.Index(content[startIndex:], s.Text)
+		if foundIndex == -1 {
+			break
+		}
+		actualStart := startIndex + foundIndex
+		end := actualStart + len(s.Text)
+		ranges = append(ranges, item.ByteRange{Start: actualStart, End: end})
+		startIndex = end
+	}
+	return ranges
+}
+
+// RegexMatcher matches each non-overlapping match of Regexp
+type RegexMatcher struct {
+	Regexp *regexp.Regexp
+}
+
+// NewRegexMatcher compiles pattern into a RegexMatcher, returning an error if it's invalid
+func NewRegexMatcher(pattern string) (RegexMatcher, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return RegexMatcher{}, err
+	}
+	return RegexMatcher{Regexp: re}, nil
+}
+
+// Match returns the byte ranges of the matches of Regexp in content
+func (r RegexMatcher) Match(content string) []item.ByteRange {
+	regexMatches := r.Regexp.FindAllStringIndex(content, -1)
+	if len(regexMatches) == 0 {
+		return nil
+	}
+	ranges := make([]item.ByteRange, 0, len(regexMatches))
+	for _, rm := range regexMatches {
+		ranges = append(ranges, item.ByteRange{Start: rm[0], End: rm[1]})
+	}
+	return ranges
+}
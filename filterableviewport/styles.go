@@ -7,6 +7,9 @@ import (
 // Styles contains styling configuration for the filterable viewport
 type Styles struct {
 	Match MatchStyles
+
+	// Error styles the reason an invalid filter, e.g. a regex that doesn't compile, isn't applied
+	Error lipgloss.Style
 }
 
 // MatchStyles contains styles for matches in the filterable viewport
@@ -30,5 +33,6 @@ func DefaultMatchStyles() MatchStyles {
 func DefaultStyles() Styles {
 	return Styles{
 		Match: DefaultMatchStyles(),
+		Error: lipgloss.NewStyle().Foreground(lipgloss.Red),
 	}
 }
@@ -0,0 +1,59 @@
+// Package listadapter renders bubbles/list items with an existing list.ItemDelegate as viewport objects, easing
+// migration from bubbles/list to viewport.Model or filterableviewport.Model.
+package listadapter
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/list"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// Object is a list item rendered by a list.ItemDelegate, usable as a viewport.Object
+type Object struct {
+	// ListItem is the original list item
+	ListItem list.Item
+
+	item item.Item
+}
+
+// type assertion that Object implements viewport.Object
+var _ viewport.Object = Object{}
+
+// GetItem returns the delegate's rendering of the list item
+func (o Object) GetItem() item.Item {
+	return o.item
+}
+
+// Objects renders each of items with delegate, returning objects for a viewport. width is the list width passed to
+// the delegate, which most delegates truncate to; pass a large width to leave wrapping or panning to the viewport.
+// Items render as not selected, since the viewport styles its own selection. Items spanning multiple lines, and the
+// delegate's spacing between items, become multi-line items.
+func Objects(delegate list.ItemDelegate, items []list.Item, width int) []Object {
+	l := list.New(items, delegate, width, max(1, len(items))*max(1, delegate.Height()+delegate.Spacing()))
+	// point the list's selection past the last item so no item renders as selected
+	l.Paginator.PerPage = max(1, len(items))
+	l.Select(len(items))
+
+	spacing := strings.Repeat("\n", delegate.Spacing())
+	objects := make([]Object, len(items))
+	var b strings.Builder
+	for i := range items {
+		b.Reset()
+		delegate.Render(&b, l, i, items[i])
+		if i < len(items)-1 {
+			b.WriteString(spacing)
+		}
+		objects[i] = Object{ListItem: items[i], item: newItem(b.String())}
+	}
+	return objects
+}
+
+// newItem returns a SingleItem for single line content and a MultiLineItem otherwise
+func newItem(content string) item.Item {
+	if !strings.Contains(content, "\n") {
+		return item.NewItem(content)
+	}
+	return item.NewMultiLineItemFromString(content)
+}
@@ -0,0 +1,104 @@
+package listadapter
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+type listItem string
+
+func (i listItem) FilterValue() string { return string(i) }
+
+type testDelegate struct {
+	height, spacing int
+}
+
+func (d testDelegate) Height() int                             { return d.height }
+func (d testDelegate) Spacing() int                            { return d.spacing }
+func (d testDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d testDelegate) Render(w io.Writer, m list.Model, index int, itm list.Item) {
+	prefix := " "
+	if index == m.Index() {
+		prefix = ">"
+	}
+	_, _ = fmt.Fprintf(w, "%s%d. %s", prefix, index+1, itm.FilterValue())
+	for range d.height - 1 {
+		_, _ = fmt.Fprint(w, "\n   detail")
+	}
+}
+
+func TestObjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		delegate testDelegate
+		expected []string
+	}{
+		{
+			name:     "single line",
+			delegate: testDelegate{height: 1},
+			expected: []string{" 1. apple", " 2. banana"},
+		},
+		{
+			name:     "multi line with spacing",
+			delegate: testDelegate{height: 2, spacing: 1},
+			expected: []string{" 1. apple\n   detail\n", " 2. banana\n   detail"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := Objects(tt.delegate, []list.Item{listItem("apple"), listItem("banana")}, 80)
+			if len(objects) != len(tt.expected) {
+				t.Fatalf("expected %d objects, got %d", len(tt.expected), len(objects))
+			}
+			for i := range objects {
+				if actual := objects[i].GetItem().Content(); actual != tt.expected[i] {
+					t.Errorf("object %d: expected %q, got %q", i, tt.expected[i], actual)
+				}
+			}
+			if objects[1].ListItem != listItem("banana") {
+				t.Errorf("expected list item to be kept, got %v", objects[1].ListItem)
+			}
+		})
+	}
+}
+
+func TestObjectsInViewport(t *testing.T) {
+	w, h := 12, 4
+	vp := viewport.New[Object](w, h, viewport.WithWrapText[Object](true))
+	vp.SetObjects(Objects(testDelegate{height: 1}, []list.Item{listItem("apple"), listItem("blueberries")}, 80))
+
+	expectedView := internal.Pad(w, h, []string{
+		" 1. apple",
+		" 2. blueberr",
+		"ies",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestObjectsDefaultDelegate(t *testing.T) {
+	delegate := list.NewDefaultDelegate()
+	items := []list.Item{defaultItem{title: "Title", desc: "Description"}}
+	objects := Objects(delegate, items, 40)
+	if len(objects) != 1 {
+		t.Fatalf("expected 1 object, got %d", len(objects))
+	}
+	if actual := objects[0].GetItem().ContentNoAnsi(); actual != "  Title\n  Description" {
+		t.Errorf("unexpected content %q", actual)
+	}
+}
+
+type defaultItem struct {
+	title, desc string
+}
+
+func (i defaultItem) Title() string       { return i.title }
+func (i defaultItem) Description() string { return i.desc }
+func (i defaultItem) FilterValue() string { return i.title }
@@ -0,0 +1,20 @@
+package splitviewport
+
+import (
+	"charm.land/bubbles/v2/key"
+)
+
+// KeyMap defines the key bindings for the split viewport. Other keys go to the focused viewport.
+type KeyMap struct {
+	SwitchFocusKey key.Binding
+}
+
+// DefaultKeyMap returns a default keymap for the split viewport
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		SwitchFocusKey: key.NewBinding(
+			key.WithKeys("ctrl+w"),
+			key.WithHelp("ctrl+w", "switch pane"),
+		),
+	}
+}
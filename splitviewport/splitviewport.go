@@ -0,0 +1,336 @@
+// Package splitviewport composes two viewports side by side or stacked, with a key to switch which one has focus and
+// optionally synchronized scrolling, e.g. for side-by-side diffs or source/log layouts.
+package splitviewport
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport"
+)
+
+// Orientation controls how the two viewports are laid out
+type Orientation int
+
+const (
+	// Vertical splits the viewports side by side, the first on the left (default)
+	Vertical Orientation = iota
+
+	// Horizontal stacks the viewports, the first on top
+	Horizontal
+)
+
+// Pane identifies one of the two viewports
+type Pane int
+
+const (
+	// First is the left or top viewport
+	First Pane = iota
+
+	// Second is the right or bottom viewport
+	Second
+)
+
+// Option is a functional option for configuring the split viewport
+type Option[A, B viewport.Object] func(*Model[A, B])
+
+// WithOrientation sets whether the viewports are side by side or stacked
+func WithOrientation[A, B viewport.Object](orientation Orientation) Option[A, B] {
+	return func(m *Model[A, B]) {
+		m.orientation = orientation
+	}
+}
+
+// WithSyncScroll sets whether scrolling one viewport scrolls the other to match, see SetSyncScroll
+func WithSyncScroll[A, B viewport.Object](syncScroll bool) Option[A, B] {
+	return func(m *Model[A, B]) {
+		m.syncScroll = syncScroll
+	}
+}
+
+// WithKeyMap sets the key mapping for the split viewport
+func WithKeyMap[A, B viewport.Object](keyMap KeyMap) Option[A, B] {
+	return func(m *Model[A, B]) {
+		m.keyMap = keyMap
+	}
+}
+
+// WithStyles sets the styles for the split viewport
+func WithStyles[A, B viewport.Object](styles Styles) Option[A, B] {
+	return func(m *Model[A, B]) {
+		m.styles = styles
+	}
+}
+
+// Model is the state and logic for two viewports shown together
+type Model[A, B viewport.Object] struct {
+	first  *viewport.Model[A]
+	second *viewport.Model[B]
+
+	keyMap      KeyMap
+	styles      Styles
+	orientation Orientation
+	syncScroll  bool // true when each viewport follows the other's scroll position
+	focused     Pane // the viewport keys go to
+	width       int  // total width, including the divider
+	height      int  // total height, including the divider
+	originX     int  // screen column of the top left cell, for mouse support
+	originY     int  // screen row of the top left cell, for mouse support
+}
+
+// New creates a split viewport of the given total size showing first and second, which it resizes to fit
+func New[A, B viewport.Object](
+	width, height int,
+	first *viewport.Model[A],
+	second *viewport.Model[B],
+	opts ...Option[A, B],
+) *Model[A, B] {
+	m := &Model[A, B]{
+		first:       first,
+		second:      second,
+		keyMap:      DefaultKeyMap(),
+		styles:      DefaultStyles(),
+		orientation: Vertical,
+		focused:     First,
+		width:       max(0, width),
+		height:      max(0, height),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
+	}
+	m.SetFocusedPane(m.focused)
+	m.layout()
+	return m
+}
+
+// Init initializes the split viewport model
+func (m *Model[A, B]) Init() tea.Cmd {
+	return nil
+}
+
+// Update processes messages and updates the model state. Keys go to the focused viewport, mouse events to the
+// viewport under the pointer, and other messages, like the viewports' own ticks, to both.
+func (m *Model[A, B]) Update(msg tea.Msg) (*Model[A, B], tea.Cmd) {
+	var cmd tea.Cmd
+	// the viewport the other follows with synchronized scrolling
+	leader := m.focused
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keyMap.SwitchFocusKey) && !m.focusedCapturingInput() {
+			m.SetFocusedPane(1 - m.focused)
+			return m, nil
+		}
+		cmd = m.updatePane(m.focused, msg)
+	case tea.MouseMsg:
+		pane, ok := m.mousePane(msg)
+		if !ok {
+			return m, nil
+		}
+		if _, ok := msg.(tea.MouseClickMsg); ok {
+			m.SetFocusedPane(pane)
+		}
+		leader = pane
+		cmd = m.updatePane(pane, msg)
+	default:
+		var firstCmd, secondCmd tea.Cmd
+		m.first, firstCmd = m.first.Update(msg)
+		m.second, secondCmd = m.second.Update(msg)
+		cmd = tea.Batch(firstCmd, secondCmd)
+	}
+	if m.syncScroll {
+		m.sync(leader)
+	}
+	return m, cmd
+}
+
+// mousePane returns the viewport under the pointer, false if it's on the divider or outside. Motion and releases
+// belong to the focused viewport, so that drags carry on past the divider.
+func (m *Model[A, B]) mousePane(msg tea.MouseMsg) (Pane, bool) {
+	switch msg.(type) {
+	case tea.MouseMotionMsg, tea.MouseReleaseMsg:
+		return m.focused, true
+	}
+	mouse := msg.Mouse()
+	return m.paneAt(mouse.X-m.originX, mouse.Y-m.originY)
+}
+
+// paneAt returns the viewport at the column and row relative to the top left of the split viewport, false if the
+// position is on the divider or outside
+func (m *Model[A, B]) paneAt(col, row int) (Pane, bool) {
+	if col < 0 || row < 0 || col >= m.width || row >= m.height {
+		return First, false
+	}
+	pos, firstSize := col, m.first.GetWidth()
+	if m.orientation == Horizontal {
+		pos, firstSize = row, m.first.GetHeight()
+	}
+	switch {
+	case pos < firstSize:
+		return First, true
+	case pos > firstSize:
+		return Second, true
+	}
+	return First, false
+}
+
+// updatePane sends msg to the viewport pane
+func (m *Model[A, B]) updatePane(pane Pane, msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	if pane == First {
+		m.first, cmd = m.first.Update(msg)
+	} else {
+		m.second, cmd = m.second.Update(msg)
+	}
+	return cmd
+}
+
+// focusedCapturingInput returns true if the focused viewport takes keys as text, e.g. while entering a filename
+func (m *Model[A, B]) focusedCapturingInput() bool {
+	if m.focused == First {
+		return m.first.IsCapturingInput()
+	}
+	return m.second.IsCapturingInput()
+}
+
+// sync scrolls the viewport other than leader to leader's position, moving its selection along when both have
+// selection enabled
+func (m *Model[A, B]) sync(leader Pane) {
+	if leader == First {
+		syncViewport(m.first, m.second)
+	} else {
+		syncViewport(m.second, m.first)
+	}
+}
+
+// syncViewport scrolls to to from's position
+func syncViewport[F, T viewport.Object](from *viewport.Model[F], to *viewport.Model[T]) {
+	if from.GetSelectionEnabled() && to.GetSelectionEnabled() {
+		to.SetSelectedItemIdx(from.GetSelectedItemIdx())
+	}
+	to.SetTopItemIdxAndLineOffset(from.GetTopItemIdxAndLineOffset())
+	to.SetXOffset(from.GetXOffsetWidth())
+}
+
+// View renders the two viewports with a divider between them
+func (m *Model[A, B]) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+	if m.orientation == Horizontal {
+		divider := m.styles.Divider.Render(strings.Repeat("─", m.width))
+		return lipgloss.JoinVertical(lipgloss.Left, m.first.View(), divider, m.second.View())
+	}
+	divider := m.styles.Divider.Render(strings.TrimSuffix(strings.Repeat("│\n", m.height), "\n"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.first.View(), divider, m.second.View())
+}
+
+// layout sizes and positions the viewports to fill the split viewport, giving the second the extra line or column
+// when the space doesn't divide evenly
+func (m *Model[A, B]) layout() {
+	if m.orientation == Horizontal {
+		firstHeight := max(0, m.height-1) / 2
+		m.first.SetWidth(m.width)
+		m.first.SetHeight(firstHeight)
+		m.second.SetWidth(m.width)
+		m.second.SetHeight(max(0, m.height-1-firstHeight))
+		m.first.SetMouseOrigin(m.originX, m.originY)
+		m.second.SetMouseOrigin(m.originX, m.originY+firstHeight+1)
+		return
+	}
+	firstWidth := max(0, m.width-1) / 2
+	m.first.SetWidth(firstWidth)
+	m.first.SetHeight(m.height)
+	m.second.SetWidth(max(0, m.width-1-firstWidth))
+	m.second.SetHeight(m.height)
+	m.first.SetMouseOrigin(m.originX, m.originY)
+	m.second.SetMouseOrigin(m.originX+firstWidth+1, m.originY)
+}
+
+// First returns the left or top viewport
+func (m *Model[A, B]) First() *viewport.Model[A] {
+	return m.first
+}
+
+// Second returns the right or bottom viewport
+func (m *Model[A, B]) Second() *viewport.Model[B] {
+	return m.second
+}
+
+// SetWidth sets the total width, including the divider
+func (m *Model[A, B]) SetWidth(width int) {
+	m.width = max(0, width)
+	m.layout()
+}
+
+// GetWidth returns the total width
+func (m *Model[A, B]) GetWidth() int {
+	return m.width
+}
+
+// SetHeight sets the total height, including the divider
+func (m *Model[A, B]) SetHeight(height int) {
+	m.height = max(0, height)
+	m.layout()
+}
+
+// GetHeight returns the total height
+func (m *Model[A, B]) GetHeight() int {
+	return m.height
+}
+
+// SetOrientation sets whether the viewports are side by side or stacked
+func (m *Model[A, B]) SetOrientation(orientation Orientation) {
+	m.orientation = orientation
+	m.layout()
+}
+
+// GetOrientation returns whether the viewports are side by side or stacked
+func (m *Model[A, B]) GetOrientation() Orientation {
+	return m.orientation
+}
+
+// SetSyncScroll sets whether scrolling either viewport scrolls the other to the same top item, line offset and
+// horizontal pan, and moves its selection to the same item when both have selection enabled. The unfocused viewport
+// catches up right away when turned on.
+func (m *Model[A, B]) SetSyncScroll(syncScroll bool) {
+	m.syncScroll = syncScroll
+	if syncScroll {
+		m.sync(m.focused)
+	}
+}
+
+// GetSyncScroll returns whether the viewports scroll together
+func (m *Model[A, B]) GetSyncScroll() bool {
+	return m.syncScroll
+}
+
+// SetFocusedPane sets which viewport keys go to, focusing it and blurring the other
+func (m *Model[A, B]) SetFocusedPane(pane Pane) {
+	if pane != Second {
+		pane = First
+	}
+	m.focused = pane
+	if pane == First {
+		m.first.Focus()
+		m.second.Blur()
+	} else {
+		m.second.Focus()
+		m.first.Blur()
+	}
+}
+
+// GetFocusedPane returns which viewport keys go to
+func (m *Model[A, B]) GetFocusedPane() Pane {
+	return m.focused
+}
+
+// SetMouseOrigin sets the screen coordinates of the split viewport's top left cell, so that mouse events reach the
+// viewport under the pointer when it isn't drawn at the top left of the screen
+func (m *Model[A, B]) SetMouseOrigin(x, y int) {
+	m.originX, m.originY = x, y
+	m.layout()
+}
@@ -0,0 +1,227 @@
+package splitviewport
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+type object struct {
+	item item.Item
+}
+
+func (o object) GetItem() item.Item {
+	return o.item
+}
+
+var _ viewport.Object = object{}
+
+var (
+	downKeyMsg        = tea.KeyPressMsg{Code: tea.KeyDown, Text: "down"}
+	switchFocusKeyMsg = tea.KeyPressMsg{Code: 'w', Mod: tea.ModCtrl}
+
+	splitStyles = Styles{Divider: lipgloss.NewStyle()}
+)
+
+// newPane returns a viewport without a footer showing n numbered lines prefixed with prefix
+func newPane(prefix string, n int, options ...viewport.Option[object]) *viewport.Model[object] {
+	options = append([]viewport.Option[object]{
+		viewport.WithKeyMap[object](viewport.DefaultKeyMap()),
+		viewport.WithStyles[object](viewport.Styles{}),
+		viewport.WithContentOnly[object](true),
+	}, options...)
+	vp := viewport.New[object](0, 0, options...)
+	objects := make([]object, n)
+	for i := range objects {
+		objects[i] = object{item: item.NewItem(fmt.Sprintf("%s%d", prefix, i))}
+	}
+	vp.SetObjects(objects)
+	return vp
+}
+
+func newSplit(width, height int, options ...Option[object, object]) *Model[object, object] {
+	options = append([]Option[object, object]{WithStyles[object, object](splitStyles)}, options...)
+	return New(width, height, newPane("a", 10), newPane("b", 10), options...)
+}
+
+func TestView_Vertical(t *testing.T) {
+	m := newSplit(9, 3)
+	if m.First().GetWidth() != 4 || m.Second().GetWidth() != 4 || m.First().GetHeight() != 3 {
+		t.Errorf("expected two 4x3 viewports, got %dx%d and %dx%d",
+			m.First().GetWidth(), m.First().GetHeight(), m.Second().GetWidth(), m.Second().GetHeight())
+	}
+	expected := internal.Pad(9, 3, []string{
+		"a0  │b0",
+		"a1  │b1",
+		"a2  │b2",
+	})
+	internal.CmpStr(t, expected, m.View())
+}
+
+func TestView_Horizontal(t *testing.T) {
+	m := newSplit(4, 6, WithOrientation[object, object](Horizontal))
+	// the second viewport gets the extra line
+	expected := internal.Pad(4, 6, []string{
+		"a0",
+		"a1",
+		"────",
+		"b0",
+		"b1",
+		"b2",
+	})
+	internal.CmpStr(t, expected, m.View())
+
+	m.SetOrientation(Vertical)
+	if m.GetOrientation() != Vertical || m.First().GetHeight() != 6 {
+		t.Error("expected the viewports to be side by side")
+	}
+}
+
+func TestUpdate_SwitchFocus(t *testing.T) {
+	m := newSplit(9, 3)
+	m, _ = m.Update(downKeyMsg)
+	if top, _ := m.First().GetTopItemIdxAndLineOffset(); top != 1 {
+		t.Errorf("expected the first viewport to scroll, got top item %d", top)
+	}
+	if top, _ := m.Second().GetTopItemIdxAndLineOffset(); top != 0 {
+		t.Errorf("expected the second viewport not to scroll, got top item %d", top)
+	}
+
+	m, _ = m.Update(switchFocusKeyMsg)
+	if m.GetFocusedPane() != Second || !m.Second().Focused() || m.First().Focused() {
+		t.Fatal("expected the second viewport to have focus")
+	}
+	m, _ = m.Update(downKeyMsg)
+	m, _ = m.Update(downKeyMsg)
+	if top, _ := m.Second().GetTopItemIdxAndLineOffset(); top != 2 {
+		t.Errorf("expected the second viewport to scroll, got top item %d", top)
+	}
+	if top, _ := m.First().GetTopItemIdxAndLineOffset(); top != 1 {
+		t.Errorf("expected the first viewport not to scroll, got top item %d", top)
+	}
+}
+
+func TestUpdate_InternalMessagesReachTheirOwnPane(t *testing.T) {
+	options := []viewport.Option[object]{
+		viewport.WithSelectionEnabled[object](true),
+		viewport.WithKeyChordTimeout[object](time.Millisecond),
+	}
+	m := New(9, 3, newPane("a", 10, options...), newPane("b", 10, options...),
+		WithStyles[object, object](splitStyles))
+	zKeyMsg := internal.MakeKeyMsg('z')
+
+	m, firstCmd := m.Update(zKeyMsg)
+	m, _ = m.Update(switchFocusKeyMsg)
+	m, _ = m.Update(zKeyMsg)
+	if m.First().GetPendingKeys() != "z" || m.Second().GetPendingKeys() != "z" {
+		t.Fatal("expected a pending key sequence in both viewports")
+	}
+
+	// the first viewport's timeout ends only its own sequence
+	for _, msg := range runCmd(firstCmd) {
+		m, _ = m.Update(msg)
+	}
+	if m.First().GetPendingKeys() != "" {
+		t.Error("expected the first viewport's sequence to time out")
+	}
+	if m.Second().GetPendingKeys() != "z" {
+		t.Error("expected the second viewport's sequence to still be pending")
+	}
+}
+
+// runCmd runs cmd, descending into batches, and returns the messages it delivers
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, runCmd(c)...)
+		}
+		return msgs
+	}
+	if msg == nil {
+		return nil
+	}
+	return []tea.Msg{msg}
+}
+
+func TestUpdate_SyncScroll(t *testing.T) {
+	first := newPane("a", 10, viewport.WithSelectionEnabled[object](true))
+	second := newPane("b", 10, viewport.WithSelectionEnabled[object](true))
+	m := New(9, 3, first, second, WithSyncScroll[object, object](true), WithStyles[object, object](splitStyles))
+
+	for range 4 {
+		m, _ = m.Update(downKeyMsg)
+	}
+	if m.First().GetSelectedItemIdx() != 4 || m.Second().GetSelectedItemIdx() != 4 {
+		t.Errorf("expected both selections on item 4, got %d and %d",
+			m.First().GetSelectedItemIdx(), m.Second().GetSelectedItemIdx())
+	}
+	expected := internal.Pad(9, 3, []string{
+		"a2  │b2",
+		"a3  │b3",
+		"a4  │b4",
+	})
+	internal.CmpStr(t, expected, m.View())
+
+	// the focused viewport leads when turned back on
+	m.SetSyncScroll(false)
+	m, _ = m.Update(downKeyMsg)
+	if m.Second().GetSelectedItemIdx() != 4 {
+		t.Error("expected the second viewport not to follow with sync scroll off")
+	}
+	m.SetSyncScroll(true)
+	if m.Second().GetSelectedItemIdx() != 5 {
+		t.Errorf("expected the second viewport to catch up to item 5, got %d", m.Second().GetSelectedItemIdx())
+	}
+}
+
+func TestUpdate_Mouse(t *testing.T) {
+	first := newPane("a", 10, viewport.WithMouseSupport[object](true))
+	second := newPane("b", 10, viewport.WithMouseSupport[object](true))
+	m := New(9, 3, first, second, WithStyles[object, object](splitStyles))
+	m.SetMouseOrigin(1, 1)
+
+	// the wheel scrolls the viewport under the pointer without focusing it
+	m, _ = m.Update(tea.MouseWheelMsg{X: 7, Y: 1, Button: tea.MouseWheelDown})
+	if top, _ := m.Second().GetTopItemIdxAndLineOffset(); top == 0 {
+		t.Error("expected the second viewport to scroll")
+	}
+	if top, _ := m.First().GetTopItemIdxAndLineOffset(); top != 0 {
+		t.Error("expected the first viewport not to scroll")
+	}
+	if m.GetFocusedPane() != First {
+		t.Error("expected the first viewport to keep focus")
+	}
+
+	// clicking focuses the viewport, but not on the divider
+	m, _ = m.Update(tea.MouseClickMsg{X: 5, Y: 1, Button: tea.MouseLeft})
+	if m.GetFocusedPane() != First {
+		t.Error("expected a click on the divider not to switch focus")
+	}
+	m, _ = m.Update(tea.MouseClickMsg{X: 6, Y: 2, Button: tea.MouseLeft})
+	if m.GetFocusedPane() != Second {
+		t.Error("expected a click to focus the second viewport")
+	}
+}
+
+func TestZeroSize(t *testing.T) {
+	m := newSplit(0, 0)
+	if m.View() != "" {
+		t.Errorf("expected an empty view, got %q", m.View())
+	}
+	m.SetWidth(-1)
+	m.SetHeight(1)
+	if m.GetWidth() != 0 || m.View() != "" {
+		t.Error("expected negative widths to be treated as 0")
+	}
+}
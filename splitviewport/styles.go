@@ -0,0 +1,18 @@
+package splitviewport
+
+import (
+	"charm.land/lipgloss/v2"
+)
+
+// Styles contains styling configuration for the split viewport
+type Styles struct {
+	// Divider styles the line between the two viewports
+	Divider lipgloss.Style
+}
+
+// DefaultStyles returns a set of default styles for the split viewport
+func DefaultStyles() Styles {
+	return Styles{
+		Divider: lipgloss.NewStyle().Foreground(lipgloss.BrightBlack),
+	}
+}
@@ -0,0 +1,82 @@
+package viewport
+
+// batchUpdate is the work deferred by a batch update until it ends, see BeginUpdate
+type batchUpdate[T Object] struct {
+	// depth is how many BeginUpdate calls haven't been ended yet
+	depth int
+
+	// objects is the last objects set during the batch, valid if objectsSet
+	objects    []T
+	objectsSet bool
+
+	// appended is the objects appended during the batch, after objects if set
+	appended []T
+
+	// prepended is the objects prepended during the batch, before objects if set
+	prepended []T
+
+	// selectedIdx is the last selection set during the batch, nil if none
+	selectedIdx *int
+
+	// inView is the last portion of an item to bring into view during the batch, nil if none
+	inView *itemInViewRequest
+}
+
+// itemInViewRequest holds the arguments of a deferred EnsureItemInView
+type itemInViewRequest struct {
+	itemIdx, startWidth, endWidth, verticalPad, horizontalPad int
+}
+
+// batching returns true while a batch update is open
+func (m *Model[T]) batching() bool {
+	return m.content.batch != nil
+}
+
+// BeginUpdate starts a batch update. Until the matching EndUpdate, SetObjects, AppendObjects, PrependObjects,
+// SetSelectedItemIdx and EnsureItemInView are recorded instead of applied, so a sequence like setting the header and objects, selecting an
+// item and bringing it into view relays out, keeps sticky scrolling and adjusts the selection once rather than after
+// each call. Getters return the state from before the batch until it ends. Batches may be nested; only the outermost
+// EndUpdate applies the changes.
+func (m *Model[T]) BeginUpdate() {
+	if m.content.batch == nil {
+		m.content.batch = &batchUpdate[T]{}
+	}
+	m.content.batch.depth++
+}
+
+// EndUpdate ends a batch update started with BeginUpdate. When the outermost batch ends, the recorded changes are
+// applied in a fixed order regardless of the order of the calls: the objects, including appended and prepended ones,
+// then the selection, then bringing an item into view.
+func (m *Model[T]) EndUpdate() {
+	b := m.content.batch
+	if b == nil {
+		return
+	}
+	b.depth--
+	if b.depth > 0 {
+		return
+	}
+	m.content.batch = nil
+
+	if b.objectsSet {
+		objects := b.objects
+		if len(b.prepended) > 0 || len(b.appended) > 0 {
+			objects = append(append(append([]T{}, b.prepended...), b.objects...), b.appended...)
+		}
+		m.setObjects(objects)
+	} else {
+		m.appendObjects(b.appended)
+		m.prependObjects(b.prepended)
+	}
+	if b.selectedIdx != nil {
+		m.setSelectedItemIdx(*b.selectedIdx)
+	}
+	if r := b.inView; r != nil {
+		m.ensureItemInView(r.itemIdx, r.startWidth, r.endWidth, r.verticalPad, r.horizontalPad)
+	}
+}
+
+// IsUpdating returns true while a batch update started with BeginUpdate is open
+func (m *Model[T]) IsUpdating() bool {
+	return m.batching()
+}
@@ -0,0 +1,107 @@
+package viewport
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// BlockSelectedMsg is sent when a block selection is confirmed, with the text of the selected rectangle
+type BlockSelectedMsg struct {
+	// Lines is the unstyled text in the block's columns of each line, top to bottom. Lines shorter than the block
+	// are included as they are, without padding.
+	Lines []string
+}
+
+// blockSelection tracks a rectangular selection spanning the items from the anchor item to the selected item
+type blockSelection struct {
+	// active is true while selecting a block
+	active bool
+
+	// anchorItemIdx and anchorCol are where the block was started
+	anchorItemIdx int
+	anchorCol     int
+
+	// cursorCol is the column at the selected item's end of the block, moved with the Left and Right keys
+	cursorCol int
+}
+
+// blockBounds returns the inclusive item range and the half-open display column range of the block
+func (m *Model[T]) blockBounds() (fromIdx, toIdx, startCol, endCol int) {
+	b := m.config.blockSelection
+	anchorIdx := clampValZeroToMax(b.anchorItemIdx, m.content.numItems()-1)
+	selectedIdx := m.content.getSelectedIdx()
+	return min(anchorIdx, selectedIdx), max(anchorIdx, selectedIdx), min(b.anchorCol, b.cursorCol),
+		max(b.anchorCol, b.cursorCol) + 1
+}
+
+// blockByteRanges returns the byte ranges of each line of the unstyled content that fall within the display columns
+// [startCol, endCol)
+func blockByteRanges(content string, startCol, endCol int) []item.ByteRange {
+	var ranges []item.ByteRange
+	lineStart := 0
+	for _, line := range strings.Split(content, "\n") {
+		ranges = append(ranges, item.ByteRange{
+			Start: lineStart + item.ByteOffsetAtCell(line, startCol),
+			End:   lineStart + item.ByteOffsetAtCell(line, endCol),
+		})
+		lineStart += len(line) + 1
+	}
+	return ranges
+}
+
+// extractBlock returns the text in display columns [startCol, endCol) of each line of the items from fromIdx to toIdx
+func (m *Model[T]) extractBlock(fromIdx, toIdx, startCol, endCol int) []string {
+	var lines []string
+	for itemIdx := fromIdx; itemIdx <= toIdx; itemIdx++ {
+		content := m.content.itemAt(itemIdx).ContentNoAnsi()
+		for _, r := range blockByteRanges(content, startCol, endCol) {
+			lines = append(lines, content[r.Start:r.End])
+		}
+	}
+	return lines
+}
+
+// blockHighlightsForItem returns highlights for the part of the block in the item at itemIdx
+func (m *Model[T]) blockHighlightsForItem(itemIdx int) []item.Highlight {
+	if !m.config.blockSelection.active {
+		return nil
+	}
+	fromIdx, toIdx, startCol, endCol := m.blockBounds()
+	if itemIdx < fromIdx || itemIdx > toIdx {
+		return nil
+	}
+	var highlights []item.Highlight
+	for _, r := range blockByteRanges(m.content.itemAt(itemIdx).ContentNoAnsi(), startCol, endCol) {
+		if r.End > r.Start {
+			highlights = append(highlights, item.Highlight{
+				Style:                    m.display.styles.BlockSelectionStyle,
+				ByteRangeUnstyledContent: r,
+			})
+		}
+	}
+	return highlights
+}
+
+// moveBlockCursor moves the block's column at the selected item by delta, keeping it within the widest line in the
+// block and in view
+func (m *Model[T]) moveBlockCursor(delta int) {
+	fromIdx, toIdx, _, _ := m.blockBounds()
+	maxWidth := 0
+	for itemIdx := fromIdx; itemIdx <= toIdx; itemIdx++ {
+		maxWidth = max(maxWidth, maxSegmentWidth(m.content.itemAt(itemIdx)))
+	}
+	b := &m.config.blockSelection
+	b.cursorCol = max(0, min(maxWidth-1, b.cursorCol+delta))
+	m.ensureItemInView(m.content.getSelectedIdx(), b.cursorCol, b.cursorCol+1, 0, 0)
+}
+
+// confirmBlockSelection ends block selection, returning a command sending a BlockSelectedMsg with the block's text
+func (m *Model[T]) confirmBlockSelection() tea.Cmd {
+	msg := BlockSelectedMsg{Lines: m.GetBlockSelection()}
+	m.config.blockSelection = blockSelection{}
+	return func() tea.Msg {
+		return msg
+	}
+}
@@ -0,0 +1,152 @@
+package viewport
+
+import (
+	"slices"
+)
+
+// SetBookmarked sets whether the item at itemIdx is bookmarked. Bookmarks save places in the content to come back to
+// with NextBookmark and PrevBookmark. Bookmarked items are styled with BookmarkedItemStyle. The ToggleBookmark,
+// NextBookmark and PrevBookmark keys, disabled by default, toggle the bookmark of the current item and cycle through
+// bookmarks. On SetObjects, bookmarks are kept for objects matching a previously bookmarked object with the selection
+// comparator (see SetSelectionComparator), and cleared otherwise.
+func (m *Model[T]) SetBookmarked(itemIdx int, bookmarked bool) {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return
+	}
+	if bookmarked {
+		m.content.bookmarked[itemIdx] = struct{}{}
+	} else {
+		delete(m.content.bookmarked, itemIdx)
+	}
+}
+
+// IsBookmarked returns whether the item at itemIdx is bookmarked
+func (m *Model[T]) IsBookmarked(itemIdx int) bool {
+	_, ok := m.content.bookmarked[itemIdx]
+	return ok
+}
+
+// ToggleBookmark toggles the bookmark of the current item: the selected one when selection is enabled, otherwise the
+// one at the top of the view
+func (m *Model[T]) ToggleBookmark() {
+	if m.content.isEmpty() {
+		return
+	}
+	idx := m.currentItemIdx()
+	m.SetBookmarked(idx, !m.IsBookmarked(idx))
+}
+
+// GetBookmarkedItemIdxs returns the indexes of bookmarked items in ascending order
+func (m *Model[T]) GetBookmarkedItemIdxs() []int {
+	idxs := make([]int, 0, len(m.content.bookmarked))
+	for idx := range m.content.bookmarked {
+		idxs = append(idxs, idx)
+	}
+	slices.Sort(idxs)
+	return idxs
+}
+
+// GetBookmarkedItems returns the bookmarked objects in item order
+func (m *Model[T]) GetBookmarkedItems() []T {
+	idxs := m.GetBookmarkedItemIdxs()
+	objects := make([]T, len(idxs))
+	for i, idx := range idxs {
+		objects[i] = m.content.objectAt(idx)
+	}
+	return objects
+}
+
+// ClearBookmarks removes all bookmarks
+func (m *Model[T]) ClearBookmarks() {
+	m.content.bookmarked = make(map[int]struct{})
+}
+
+// NextBookmark goes to the first bookmark after the current item, wrapping around to the first. When selection is
+// enabled the bookmarked item is selected and scrolled into view, otherwise it's scrolled to the top of the view,
+// where it was bookmarked. Returns false if there are no bookmarks.
+func (m *Model[T]) NextBookmark() bool {
+	idxs := m.GetBookmarkedItemIdxs()
+	if len(idxs) == 0 {
+		return false
+	}
+	i, found := slices.BinarySearch(idxs, m.currentItemIdx())
+	if found {
+		i++
+	}
+	m.jumpToItem(idxs[i%len(idxs)])
+	return true
+}
+
+// PrevBookmark goes to the last bookmark before the current item, wrapping around to the last, like NextBookmark
+func (m *Model[T]) PrevBookmark() bool {
+	idxs := m.GetBookmarkedItemIdxs()
+	if len(idxs) == 0 {
+		return false
+	}
+	i, _ := slices.BinarySearch(idxs, m.currentItemIdx())
+	m.jumpToItem(idxs[(i-1+len(idxs))%len(idxs)])
+	return true
+}
+
+// currentItemIdx returns the index of the current item, which bookmarks are toggled on and bookmarks and hunks are
+// cycled from: the selected item when selection is enabled, otherwise the one at the top of the view
+func (m *Model[T]) currentItemIdx() int {
+	if m.navigation.selectionEnabled {
+		return m.content.getSelectedIdx()
+	}
+	return m.display.topItemIdx
+}
+
+// jumpToItem selects the item at idx and scrolls it into view when selection is enabled, and otherwise scrolls it to
+// the top of the view
+func (m *Model[T]) jumpToItem(idx int) {
+	if m.navigation.selectionEnabled {
+		m.setSelectedItemIdx(idx)
+		m.ensureItemInView(idx, 0, 0, 0, 0)
+		return
+	}
+	m.safelySetTopItemIdxAndOffset(idx, 0)
+}
+
+// bookmarkedObjects returns the bookmarked objects
+func (m *Model[T]) bookmarkedObjects() []T {
+	objects := make([]T, 0, len(m.content.bookmarked))
+	for idx := range m.content.bookmarked {
+		if idx < m.content.numItems() {
+			objects = append(objects, m.content.objectAt(idx))
+		}
+	}
+	return objects
+}
+
+// rebookmarkObjects bookmarks the items in the current objects that match previously bookmarked objects using the
+// selection comparator, clearing all bookmarks if there isn't one
+func (m *Model[T]) rebookmarkObjects(prevBookmarked []T) {
+	m.content.bookmarked = make(map[int]struct{})
+	if m.content.compareFn == nil || len(prevBookmarked) == 0 {
+		return
+	}
+	for i, obj := range m.content.objects {
+		for _, bookmarked := range prevBookmarked {
+			if m.content.compareFn(obj, bookmarked) {
+				m.content.bookmarked[i] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+// shiftBookmarks moves bookmarks down by n items after objects are prepended, or up by -n after the oldest are dropped,
+// dropping those moved before the first item
+func (m *Model[T]) shiftBookmarks(n int) {
+	if len(m.content.bookmarked) == 0 {
+		return
+	}
+	shifted := make(map[int]struct{}, len(m.content.bookmarked))
+	for idx := range m.content.bookmarked {
+		if idx+n >= 0 {
+			shifted[idx+n] = struct{}{}
+		}
+	}
+	m.content.bookmarked = shifted
+}
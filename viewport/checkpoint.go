@@ -0,0 +1,100 @@
+package viewport
+
+// DiffStatus is how an item differs from the checkpoint set with Checkpoint
+type DiffStatus int
+
+const (
+	// DiffUnchanged items have the same identity and content as an object in the checkpoint, or there's no checkpoint
+	DiffUnchanged DiffStatus = iota
+
+	// DiffAdded items have an identity not in the checkpoint
+	DiffAdded
+
+	// DiffChanged items have the identity of an object in the checkpoint but different content
+	DiffChanged
+)
+
+// IdentityFunc returns a key identifying an object across refreshes of the same data, e.g. a process ID or a file
+// path, so that its content can be compared with the checkpoint
+type IdentityFunc[T Object] func(object T) string
+
+// checkpoint is a snapshot of the objects to compare later content against
+type checkpoint[T Object] struct {
+	// identity keys objects, nil to key them by their unstyled content
+	identity IdentityFunc[T]
+
+	// objects is the snapshot, in order
+	objects []T
+
+	// contentByKey maps the key of each object in the snapshot to its unstyled content
+	contentByKey map[string]string
+
+	// changesOnly hides unchanged items when true
+	changesOnly bool
+
+	// unfiltered is every object before unchanged items were hidden, when changesOnly is true
+	unfiltered []T
+}
+
+// key returns the identity of obj
+func (c *checkpoint[T]) key(obj T) string {
+	if c.identity == nil {
+		return obj.GetItem().ContentNoAnsi()
+	}
+	return c.identity(obj)
+}
+
+// status returns how obj differs from the snapshot
+func (c *checkpoint[T]) status(obj T) DiffStatus {
+	content, ok := c.contentByKey[c.key(obj)]
+	if !ok {
+		return DiffAdded
+	}
+	if content != obj.GetItem().ContentNoAnsi() {
+		return DiffChanged
+	}
+	return DiffUnchanged
+}
+
+// filterChanges hides unchanged objects when showing changes only, remembering all objects so that they can be
+// shown again
+func (m *Model[T]) filterChanges(objects []T) []T {
+	c := m.content.checkpoint
+	if c == nil || !c.changesOnly {
+		return objects
+	}
+	c.unfiltered = objects
+	var changed []T
+	for _, obj := range objects {
+		if c.status(obj) != DiffUnchanged {
+			changed = append(changed, obj)
+		}
+	}
+	return changed
+}
+
+// diffStatus returns how the item at itemIdx differs from the checkpoint
+func (m *Model[T]) diffStatus(itemIdx int) DiffStatus {
+	c := m.content.checkpoint
+	if c == nil || itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return DiffUnchanged
+	}
+	return c.status(m.content.objectAt(itemIdx))
+}
+
+// diffStyledLine layers the style for the item's diff status, if any, under a rendered line of the item at itemIdx.
+// Returns false if the item is unchanged.
+func (m *Model[T]) diffStyledLine(itemIdx int, line string) (string, bool) {
+	if m.config.diffMode {
+		if style, ok := m.diffKindStyle(m.diffKind(itemIdx)); ok {
+			return styleUnstyled(line, style), true
+		}
+	}
+	switch m.diffStatus(itemIdx) {
+	case DiffAdded:
+		return styleUnstyled(line, m.display.styles.AddedItemStyle), true
+	case DiffChanged:
+		return styleUnstyled(line, m.display.styles.ChangedItemStyle), true
+	}
+	return line, false
+}
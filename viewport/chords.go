@@ -0,0 +1,179 @@
+package viewport
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// DefaultKeyChordTimeout is how long a key sequence waits for its next key unless set otherwise
+const DefaultKeyChordTimeout = time.Second
+
+// chordState tracks a key sequence partway through being pressed
+type chordState struct {
+	// timeout is how long the sequence waits for its next key
+	timeout time.Duration
+
+	// pending are the keys pressed so far, separated by spaces, empty if none
+	pending string
+
+	// last is the last key pressed in the sequence
+	last tea.KeyPressMsg
+
+	// seq identifies the pending sequence, so that only its own timeout ends it
+	seq int
+}
+
+// chordTimeoutMsg ends the pending key sequence identified by seq
+type chordTimeoutMsg struct {
+	id  int
+	seq int
+}
+
+// chordKeyMsg is a complete key sequence, matching bindings whose keys are the sequence
+type chordKeyMsg struct {
+	tea.KeyPressMsg
+
+	// keys are the keys of the sequence, separated by spaces
+	keys string
+}
+
+// String returns the keys of the sequence, separated by spaces
+func (c chordKeyMsg) String() string {
+	return c.keys
+}
+
+// WithKeyChordTimeout sets how long a key sequence like "g g" waits for its next key, see SetKeyChordTimeout
+func WithKeyChordTimeout[T Object](timeout time.Duration) Option[T] {
+	return func(m *Model[T]) {
+		m.SetKeyChordTimeout(timeout)
+	}
+}
+
+// SetKeyChordTimeout sets how long a key sequence, a binding with keys like "g g", waits for its next key,
+// DefaultKeyChordTimeout if 0. When it times out, the keys pressed so far act as a binding if they are one, so that
+// "g" can be bound alongside "g g", acting after the timeout unless a second "g" is pressed first. A key that
+// continues no sequence abandons it, acting on its own.
+func (m *Model[T]) SetKeyChordTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultKeyChordTimeout
+	}
+	m.navigation.chords.timeout = timeout
+}
+
+// GetKeyChordTimeout returns how long a key sequence waits for its next key
+func (m *Model[T]) GetKeyChordTimeout() time.Duration {
+	return m.navigation.chords.timeout
+}
+
+// GetPendingKeys returns the keys of a sequence pressed so far, separated by spaces, e.g. to show them like vim's
+// showcmd, empty if none are
+func (m *Model[T]) GetPendingKeys() string {
+	return m.navigation.chords.pending
+}
+
+// ScrollItemToTop scrolls so that the item at itemIdx is at the top of the view, or as near as the content allows
+func (m *Model[T]) ScrollItemToTop(itemIdx int) {
+	m.scrollItemToLine(itemIdx, 0)
+}
+
+// ScrollItemToCenter scrolls so that the item at itemIdx is in the middle of the view, or as near as the content
+// allows
+func (m *Model[T]) ScrollItemToCenter(itemIdx int) {
+	m.scrollItemToLine(itemIdx, (m.getNumContentLines()-m.numLinesForItem(itemIdx))/2)
+}
+
+// ScrollItemToBottom scrolls so that the item at itemIdx is at the bottom of the view, or as near as the content
+// allows
+func (m *Model[T]) ScrollItemToBottom(itemIdx int) {
+	m.scrollItemToLine(itemIdx, m.getNumContentLines()-m.numLinesForItem(itemIdx))
+}
+
+// scrollItemToLine scrolls so that the item at itemIdx starts linesAbove lines from the top of the view
+func (m *Model[T]) scrollItemToLine(itemIdx, linesAbove int) {
+	if m.content.isEmpty() {
+		return
+	}
+	itemIdx = clampValZeroToMax(itemIdx, m.content.numItems()-1)
+	topItemIdx, topItemLineOffset := itemIdx, 0
+	for linesAbove > 0 && topItemIdx > 0 {
+		topItemIdx--
+		numLines := m.numLinesForItem(topItemIdx)
+		if numLines >= linesAbove {
+			topItemLineOffset = numLines - linesAbove
+			break
+		}
+		linesAbove -= numLines
+	}
+	m.safelySetTopItemIdxAndOffset(topItemIdx, topItemLineOffset)
+}
+
+// chordKey returns the key message to handle for msg given the pending key sequence: msg itself, a chordKeyMsg
+// completing a sequence, or nil with a command timing out the sequence if msg continues one
+func (m *Model[T]) chordKey(msg tea.KeyPressMsg) (tea.Msg, tea.Cmd) {
+	c := &m.navigation.chords
+	keys := msg.String()
+	if c.pending != "" {
+		keys = c.pending + " " + keys
+	}
+	complete, continues := m.matchChord(keys)
+	if continues {
+		c.pending, c.last = keys, msg
+		c.seq++
+		id, seq := m.id, c.seq
+		return nil, tea.Tick(c.timeout, func(time.Time) tea.Msg {
+			return chordTimeoutMsg{id: id, seq: seq}
+		})
+	}
+	hadPending := c.pending != ""
+	c.pending = ""
+	switch {
+	case complete && hadPending:
+		return chordKeyMsg{KeyPressMsg: msg, keys: keys}, nil
+	case hadPending:
+		// the sequence is abandoned, and the key acts on its own
+		return m.chordKey(msg)
+	default:
+		return msg, nil
+	}
+}
+
+// timeOutChord ends the pending key sequence if msg is for it, returning the chordKeyMsg of the keys pressed so far
+// if they're a binding, otherwise nil
+func (m *Model[T]) timeOutChord(msg chordTimeoutMsg) tea.Msg {
+	c := &m.navigation.chords
+	if msg.seq != c.seq || c.pending == "" {
+		return nil
+	}
+	keys := c.pending
+	c.pending = ""
+	if complete, _ := m.matchChord(keys); complete {
+		return chordKeyMsg{KeyPressMsg: c.last, keys: keys}
+	}
+	return nil
+}
+
+// matchChord returns whether keys, separated by spaces, are the keys of an enabled binding, and whether they start
+// a longer sequence of one. Bindings acting on the selection don't match without selection, as they do nothing then.
+func (m *Model[T]) matchChord(keys string) (complete, continues bool) {
+	km := &m.navigation.keyMap
+	var skipped []*key.Binding
+	if !m.navigation.selectionEnabled {
+		skipped = append(km.Group(KeyGroupSelection), &km.CenterSelection, &km.SelectionToTop, &km.SelectionToBottom)
+	}
+	for _, group := range []KeyGroup{KeyGroupNavigation, KeyGroupSelection, KeyGroupFeatures} {
+		for _, b := range km.Group(group) {
+			if !b.Enabled() || slices.Contains(skipped, b) {
+				continue
+			}
+			for _, k := range b.Keys() {
+				complete = complete || k == keys
+				continues = continues || strings.HasPrefix(k, keys+" ")
+			}
+		}
+	}
+	return complete, continues
+}
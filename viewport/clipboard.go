@@ -0,0 +1,131 @@
+package viewport
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/atotto/clipboard"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// ClipboardTarget is where copied content is sent
+type ClipboardTarget int
+
+const (
+	// ClipboardOSC52 sets the terminal's clipboard with an OSC 52 escape sequence, which also works over SSH
+	ClipboardOSC52 ClipboardTarget = iota
+
+	// ClipboardSystem sets the clipboard of the system the program runs on
+	ClipboardSystem
+
+	// ClipboardBoth sets both the terminal's and the system's clipboard
+	ClipboardBoth
+)
+
+// CopiedMsg is sent when copying to the clipboard completes, e.g. for the host to show a confirmation. The viewport
+// shows the result in its footer when the message reaches its Update.
+type CopiedMsg struct {
+	// Description is what was copied, e.g. "3 items"
+	Description string
+
+	// Styled is true if the content was copied with its ANSI escape sequences
+	Styled bool
+
+	// Err is why copying failed, nil on success
+	Err error
+}
+
+// copyCmd returns a command copying text to the configured clipboard target
+func (m *Model[T]) copyCmd(text, description string) tea.Cmd {
+	return m.copyTextCmd(text, description, false)
+}
+
+// copyTextCmd returns a command copying text to the configured clipboard target, styled if it holds ANSI escape
+// sequences to keep
+func (m *Model[T]) copyTextCmd(text, description string, styled bool) tea.Cmd {
+	var cmds []tea.Cmd
+	target := m.config.clipboardTarget
+	if target == ClipboardOSC52 || target == ClipboardBoth {
+		cmds = append(cmds, tea.SetClipboard(text))
+	}
+	cmds = append(cmds, func() tea.Msg {
+		if target == ClipboardSystem || target == ClipboardBoth {
+			if err := clipboard.WriteAll(text); err != nil {
+				return CopiedMsg{Styled: styled, Err: err}
+			}
+		}
+		return CopiedMsg{Description: description, Styled: styled}
+	})
+	return tea.Batch(cmds...)
+}
+
+// itemsText returns the unstyled content of the items at itemIdxs, one object per line. With line joining enabled,
+// each item expands to all objects in its group.
+func (m *Model[T]) itemsText(itemIdxs []int) string {
+	var lines []string
+	for _, idx := range itemIdxs {
+		for _, obj := range m.GetGroupObjects(idx) {
+			lines = append(lines, obj.GetItem().ContentNoAnsi())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// itemsStyledText returns the content of the items at itemIdxs with their ANSI escape sequences, one object per line,
+// expanding groups as itemsText does
+func (m *Model[T]) itemsStyledText(itemIdxs []int) string {
+	var lines []string
+	for _, idx := range itemIdxs {
+		for _, obj := range m.GetGroupObjects(idx) {
+			lines = append(lines, obj.GetItem().Content())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// visibleText returns the unstyled text of the visible content lines, excluding the header, footer, selection
+// prefix, line numbers, diff markers and wrap indent and indicator, with trailing whitespace removed
+func (m *Model[T]) visibleText() (string, int) {
+	numContentLines := len(m.getVisibleContentItemIndexes())
+	if numContentLines == 0 {
+		return "", 0
+	}
+	start := len(m.getVisibleHeaderLines())
+	if m.showsPostHeaderLine() {
+		start++
+	}
+	lines := strings.Split(item.StripAnsi(m.View()), "\n")
+	lines = lines[start:min(len(lines), start+numContentLines)]
+
+	hasPrefix := m.navigation.selectionEnabled && m.display.styles.SelectionPrefix != ""
+	prefix := item.StripAnsi(m.display.styles.SelectionPrefix)
+	wrapPrefix := item.StripAnsi(m.wrapLayout().prefix)
+	numLineNumberCols := m.numLineNumberCols()
+	for i := range lines {
+		lines[i] = lines[i][min(len(lines[i]), numLineNumberCols):]
+		if m.config.diffMode {
+			lines[i] = lines[i][min(len(lines[i]), diffMarkerWidth):]
+		}
+		if hasPrefix {
+			if strings.HasPrefix(lines[i], prefix) {
+				lines[i] = lines[i][len(prefix):]
+			} else {
+				lines[i] = strings.TrimPrefix(lines[i], m.selectionPrefixPadding())
+			}
+		}
+		if i < len(m.display.wrapPrefixedRows) && m.display.wrapPrefixedRows[i] {
+			lines[i] = strings.TrimPrefix(lines[i], wrapPrefix)
+		}
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n"), len(lines)
+}
+
+// pluralize returns e.g. "1 item" or "3 items"
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
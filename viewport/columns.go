@@ -0,0 +1,160 @@
+package viewport
+
+import (
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// Row is implemented by objects rendered as table rows in column mode, see SetColumns. Objects that don't implement
+// it are rendered from their item as usual.
+type Row interface {
+	// GetCells returns the row's cells, one per column
+	GetCells() []item.SingleItem
+}
+
+// Alignment is how a cell is aligned within its column
+type Alignment int
+
+const (
+	// AlignLeft pads cells on the right
+	AlignLeft Alignment = iota
+
+	// AlignRight pads cells on the left, e.g. for numbers
+	AlignRight
+
+	// AlignCenter pads cells on both sides
+	AlignCenter
+)
+
+// Column configures a column in column mode
+type Column struct {
+	// MaxWidth truncates the column's cells to at most this many cells, 0 for no limit
+	MaxWidth int
+
+	// Align is how cells narrower than the column are aligned
+	Align Alignment
+}
+
+// ColumnLayout configures column mode
+type ColumnLayout struct {
+	// Columns configures each column by position. Columns beyond these are left aligned with no width limit.
+	Columns []Column
+
+	// Separator is rendered between columns, a single space if empty
+	Separator string
+
+	// PinnedColumns is the number of leading columns that stay in place when panning horizontally
+	PinnedColumns int
+}
+
+// columnState tracks column mode and the column widths of the rows in view
+type columnState struct {
+	layout ColumnLayout
+
+	// widths is the width of each column, the widest cell in it among the rows in view capped to its MaxWidth
+	widths []int
+
+	// truncation marks cells truncated to their column's MaxWidth
+	truncation string
+}
+
+// column returns the configuration of the column at idx
+func (c *columnState) column(idx int) Column {
+	if idx < len(c.layout.Columns) {
+		return c.layout.Columns[idx]
+	}
+	return Column{}
+}
+
+// fitWidths sets the column widths to fit the given rows
+func (c *columnState) fitWidths(rows []Row) {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row.GetCells() {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], cell.Width())
+		}
+	}
+	for i := range widths {
+		if maxWidth := c.column(i).MaxWidth; maxWidth > 0 {
+			widths[i] = min(widths[i], maxWidth)
+		}
+	}
+	c.widths = widths
+}
+
+// render lays out the row's cells in columns, truncating and aligning each cell to its column's width
+func (c *columnState) render(row Row) item.Item {
+	cells := row.GetCells()
+	separator := c.layout.Separator
+	if separator == "" {
+		separator = " "
+	}
+	parts := make([]item.SingleItem, 0, 2*len(cells))
+	for i, cell := range cells {
+		if i > 0 {
+			parts = append(parts, item.NewItem(separator))
+		}
+		parts = append(parts, c.fitCell(cell, i))
+	}
+	// each pinned column is pinned with the separator after it
+	return item.NewConcatWithPinned(2*c.layout.PinnedColumns, parts...)
+}
+
+// fitCell truncates and pads cell to the width of the column at idx
+func (c *columnState) fitCell(cell item.SingleItem, idx int) item.SingleItem {
+	if idx >= len(c.widths) {
+		return cell
+	}
+	width := c.widths[idx]
+	content := cell.Content()
+	if cell.Width() > width {
+		content, _ = cell.Take(0, width, c.truncation, nil)
+	}
+	pad := max(0, width-item.NewItem(content).Width())
+	if idx == len(c.widths)-1 && c.column(idx).Align == AlignLeft {
+		// no trailing padding after the last column
+		pad = 0
+	}
+	switch c.column(idx).Align {
+	case AlignRight:
+		content = strings.Repeat(" ", pad) + content
+	case AlignCenter:
+		content = strings.Repeat(" ", pad/2) + content + strings.Repeat(" ", pad-pad/2)
+	default:
+		content += strings.Repeat(" ", pad)
+	}
+	return item.NewItem(content)
+}
+
+// itemFor returns the item for obj, laid out in columns if column mode is on and obj is a Row
+func (cm *contentManager[T]) itemFor(obj T) item.Item {
+	if cm.columns != nil {
+		if row, ok := any(obj).(Row); ok {
+			return cm.columns.render(row)
+		}
+	}
+	return obj.GetItem()
+}
+
+// refreshColumnWidths fits the column widths to the rows that can be in view
+func (m *Model[T]) refreshColumnWidths() {
+	c := m.content.columns
+	if c == nil {
+		return
+	}
+	var rows []Row
+	if !m.content.isEmpty() {
+		startIdx := clampValZeroToMax(m.display.topItemIdx, m.content.numItems()-1)
+		endIdx := min(m.content.numItems(), startIdx+m.getNumContentLines())
+		for idx := startIdx; idx < endIdx; idx++ {
+			if row, ok := any(m.content.objectAt(idx)).(Row); ok {
+				rows = append(rows, row)
+			}
+		}
+	}
+	c.fitWidths(rows)
+}
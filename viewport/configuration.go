@@ -3,6 +3,9 @@ package viewport
 import (
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
 )
 
 // fileSaveState tracks the state of file saving operations
@@ -10,15 +13,6 @@ type fileSaveState struct {
 	// saving is true when a save operation is in progress
 	saving bool
 
-	// showingResult is true when displaying save result
-	showingResult bool
-
-	// resultMsg is the message to display (filename or error)
-	resultMsg string
-
-	// isError is true if resultMsg is an error message
-	isError bool
-
 	// enteringFilename is true when user is typing a filename
 	enteringFilename bool
 
@@ -45,15 +39,51 @@ type configuration struct {
 	// When non-empty, takes up one line of vertical space.
 	preFooterLine string
 
+	// contentOnly is true if the viewport renders just its items, with no header, post-header, pre-footer or footer
+	contentOnly bool
+
 	// saveDir is the directory where files are saved when the save key is pressed
 	saveDir string
 
 	// saveKey is the key binding for saving viewport content to a file
 	saveKey key.Binding
 
+	// saveFormat is the format the save key writes content in
+	saveFormat SaveFormat
+
 	// saveState tracks file saving state
 	saveState fileSaveState
 
+	// copyKey is the key binding for copying content to the clipboard
+	copyKey key.Binding
+
+	// clipboardTarget is where copied content is sent
+	clipboardTarget ClipboardTarget
+
+	// editKey is the key binding for editing the selected item inline
+	editKey key.Binding
+
+	// editState tracks inline editing of the selected item
+	editState editState
+
+	// linkHandler activates hyperlinks, nil to send a LinkActivatedMsg instead
+	linkHandler func(url string) tea.Cmd
+
+	// linkDetection is whether URLs and file:line paths in item text are detected as links
+	linkDetection bool
+
+	// goTo tracks the go-to input and flashing the item gone to
+	goTo goToState
+
+	// message tracks the notification shown over the footer
+	message messageState
+
+	// overscroll tracks the footer indicator shown when scrolling past the top or bottom
+	overscroll overscrollState
+
+	// undo holds the content changes that can be undone and redone
+	undo undoState
+
 	// selectionStyleOverridesItemStyle controls whether the selection style replaces the item's
 	// existing ANSI styling. When true (default), the selected item is stripped of its original
 	// styling and the selection style is applied to all non-highlighted regions. When false,
@@ -62,6 +92,100 @@ type configuration struct {
 
 	// progressBarEnabled controls whether the footer shows a Unicode progress bar in the footer
 	progressBarEnabled bool
+
+	// wrapMode controls where wrapped lines break when wrapText is true
+	wrapMode item.WrapMode
+
+	// wrapIndent is how many cells wrapped rows after an item's first are indented when wrapText is true
+	wrapIndent int
+
+	// wrapIndicator marks wrapped rows after an item's first when wrapText is true, after any wrapIndent
+	wrapIndicator string
+
+	// wrapIndicatorStyle styles wrapIndicator
+	wrapIndicatorStyle lipgloss.Style
+
+	// wordChars defines which runes make up a word for word-wise operations
+	wordChars item.WordChars
+
+	// softLimits are the content size thresholds beyond which expensive features are disabled
+	softLimits SoftLimits
+
+	// retention drops the oldest objects beyond a maximum count, size or age
+	retention retention
+
+	// timestamps is the state of the relative time gutter and the clock objects are aged by
+	timestamps timestampState
+
+	// softLimitUsage is the measured size of the current content
+	softLimitUsage softLimitUsage
+
+	// softLimitReason describes the soft limit the current content exceeds, "" if within limits
+	softLimitReason string
+
+	// blockSelection tracks the rectangle selected in block selection mode
+	blockSelection blockSelection
+
+	// visualSelection tracks the text selected in visual selection mode
+	visualSelection visualSelection
+
+	// mouse tracks mouse support and drag state
+	mouse mouseState
+
+	// wrapSuspended is true when wrapping was requested but is disabled because soft limits are exceeded
+	wrapSuspended bool
+
+	// searchIncludesHeader is true when the search also highlights matches in the header
+	searchIncludesHeader bool
+
+	// showControlChars renders control characters in items in caret notation instead of passing them through
+	showControlChars bool
+
+	// showInvisibles renders trailing whitespace, characters that take no space and control characters in items as
+	// visible glyphs
+	showInvisibles bool
+
+	// enabled is false while the viewport renders dimmed and ignores input, see SetEnabled
+	enabled bool
+
+	// focused is false while the viewport ignores key input and styles its selection with BlurredStyle, see Blur
+	focused bool
+
+	// disabledNote is centered over the content while the viewport is disabled
+	disabledNote string
+
+	// loading tracks the loading indicator, see SetLoading
+	loading loadingState
+
+	// peek tracks the selected item expanded while unwrapped, see SetPeek
+	peek peekState
+
+	// preview tracks the popup previewing text over the content, see Preview
+	preview previewState
+
+	// emptyState renders what's shown in the content area while there are no items, nil for blank lines
+	emptyState func(width, height int) string
+
+	// footerFormatter renders the footer text
+	footerFormatter FooterFormatter
+
+	// footerMetric is what the footer's scroll position measures
+	footerMetric FooterMetric
+
+	// scrollbar is whether a scrollbar is shown in a gutter on the right edge of the content
+	scrollbar bool
+
+	// minimap is whether a minimap of highlights is shown in a gutter on the right edge of the content
+	minimap bool
+
+	// diffMode is whether DiffObjects are rendered with diff markers and per-kind styles
+	diffMode bool
+
+	// lineNumbers is how items are numbered in a column left of the content
+	lineNumbers lineNumberState
+
+	// footerFilterInfo is the filter applied by a wrapping model, passed to footerFormatter. nil if none is applied.
+	footerFilterInfo *FooterFilterInfo
 }
 
 // newConfiguration creates a new configuration with default settings.
@@ -69,9 +193,19 @@ func newConfiguration() *configuration {
 	return &configuration{
 		wrapText:                         false,
 		footerEnabled:                    true,
+		footerFormatter:                  DefaultFooterFormatter,
+		enabled:                          true,
+		focused:                          true,
 		continuationIndicator:            "...",
 		saveDir:                          "",
 		saveKey:                          key.NewBinding(),
+		copyKey:                          key.NewBinding(),
+		editKey:                          key.NewBinding(),
 		selectionStyleOverridesItemStyle: true,
+		wordChars:                        item.DefaultWordChars(),
+		showControlChars:                 false,
+		goTo:                             newGoToState(),
+		timestamps:                       newTimestampState(),
+		loading:                          newLoadingState(),
 	}
 }
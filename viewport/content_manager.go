@@ -1,16 +1,30 @@
 package viewport
 
-import "github.com/robinovitch61/viewport/viewport/item"
+import (
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
 
 // contentManager manages the actual Item and selection state
 type contentManager[T Object] struct {
-	// objects is the viewport objects
+	// objects is the viewport objects, nil when source is set
 	objects []T
 
+	// source provides the objects by index instead of objects when set with SetItemSource, nil otherwise
+	source ItemSource[T]
+
+	// sourceLen is the length of source when last read, so the view is consistent while the source grows
+	sourceLen int
+
 	// header is the unselectable lines at the top of the viewport
 	// these lines wrap, but don't pan horizontally like other non-wrapped lines
 	header []string
 
+	// headerHighlights is what to highlight in the header, indexed by header line
+	headerHighlights []Highlight
+
 	// selectedIdx is the index of objects of the current selection (only relevant when selection is enabled)
 	selectedIdx int
 
@@ -23,6 +37,77 @@ type contentManager[T Object] struct {
 	// compareFn is an optional function to compare items for maintaining the selection when Item changes
 	// if set, the viewport will try to maintain the previous selected item when Item changes
 	compareFn CompareFn[T]
+
+	// joining groups continuation objects under their parent when line joining is enabled, nil otherwise
+	joining *lineJoining[T]
+
+	// tree hides the descendants of collapsed nodes when tree mode is on, nil otherwise
+	tree *treeState[T]
+
+	// search is the in-viewport search state
+	search searchState
+
+	// preprocessing transforms objects before they're displayed when a preprocessor is set, nil otherwise
+	preprocessing *preprocessing[T]
+
+	// itemStyleFunc optionally returns a style to layer under each unselected item's own styling
+	itemStyleFunc ItemStyleFunc[T]
+
+	// stickyItemFunc optionally returns whether an object is sticky, pinned under the header once scrolled past
+	stickyItemFunc func(T) bool
+
+	// sticky remembers the sticky item index last found, reset when objects are replaced or their indexes shift
+	sticky stickyMemo
+
+	// timestampFunc optionally returns the time of an object, for the max age, GoToTime and the time gutter
+	timestampFunc func(T) time.Time
+
+	// marked is the set of indexes of marked items
+	marked map[int]struct{}
+
+	// bookmarked is the set of indexes of bookmarked items
+	bookmarked map[int]struct{}
+
+	// expandedDetails is the set of indexes of items showing their detail items
+	expandedDetails map[int]struct{}
+
+	// separators is the set of indexes of items with a separator line beneath them
+	separators map[int]struct{}
+
+	// links is the registry of actionable regions within items
+	links linkState
+
+	// checkpoint is the snapshot items are compared against when set with Checkpoint, nil otherwise
+	checkpoint *checkpoint[T]
+
+	// columns lays out Row objects in columns when column mode is on, nil otherwise
+	columns *columnState
+
+	// sorting orders objects when a sort function is set with SetSortFunc, nil otherwise
+	sorting *sorting[T]
+
+	// batch records changes deferred until the end of a batch update, nil outside of one
+	batch *batchUpdate[T]
+
+	// controlCharStyle styles control characters rendered in caret notation, nil to pass them through as they are
+	controlCharStyle *lipgloss.Style
+
+	// invisibleStyle styles trailing whitespace and characters that take no space rendered as visible glyphs, nil to
+	// pass them through as they are
+	invisibleStyle *lipgloss.Style
+
+	// visualized remembers recently rendered items with their control and invisible characters made visible, cleared
+	// when their styles change
+	visualized itemCache[visualizedItem]
+
+	// hexView is which items are shown as hex dumps rather than text
+	hexView HexView
+
+	// tabWidth is the number of cells between tab stops that tabs expand to, 0 to pass them through as they are
+	tabWidth int
+
+	// wrapCache remembers how many rows recently wrapped items wrap to
+	wrapCache wrapCache
 }
 
 // newContentManager creates a new contentManager with empty initial state
@@ -32,12 +117,17 @@ func newContentManager[T Object]() *contentManager[T] {
 		header:                []string{},
 		selectedIdx:           0,
 		itemHighlightsByIndex: make(map[int][]item.Highlight),
+		search:                newSearchState(),
+		marked:                make(map[int]struct{}),
+		bookmarked:            make(map[int]struct{}),
+		expandedDetails:       make(map[int]struct{}),
+		links:                 newLinkState(),
 	}
 }
 
 // setSelectedIdx sets the selected item index
 func (cm *contentManager[T]) setSelectedIdx(idx int) {
-	cm.selectedIdx = clampValZeroToMax(idx, len(cm.objects)-1)
+	cm.selectedIdx = clampValZeroToMax(idx, cm.numItems()-1)
 }
 
 // getSelectedIdx returns the current selected item index
@@ -47,20 +137,137 @@ func (cm *contentManager[T]) getSelectedIdx() int {
 
 // getSelectedItem returns a pointer to the currently selected item, or nil if none selected
 func (cm *contentManager[T]) getSelectedItem() *T {
-	if cm.selectedIdx >= len(cm.objects) || cm.selectedIdx < 0 {
+	if cm.selectedIdx >= cm.numItems() || cm.selectedIdx < 0 {
 		return nil
 	}
+	if cm.source != nil {
+		obj := cm.source.At(cm.selectedIdx)
+		return &obj
+	}
 	return &cm.objects[cm.selectedIdx]
 }
 
+// objectAt returns the object at idx
+func (cm *contentManager[T]) objectAt(idx int) T {
+	if cm.source != nil {
+		return cm.source.At(idx)
+	}
+	return cm.objects[idx]
+}
+
+// itemAt returns the item to render for the object at idx
+func (cm *contentManager[T]) itemAt(idx int) item.Item {
+	if cm.tabWidth > 0 {
+		return item.ExpandTabs(cm.untabbedItemAt(idx), cm.tabWidth)
+	}
+	return cm.untabbedItemAt(idx)
+}
+
+// untabbedItemAt returns the item to render for the object at idx with its tabs as they are
+func (cm *contentManager[T]) untabbedItemAt(idx int) item.Item {
+	return cm.visualizedItemAt(idx).itm
+}
+
+// visualizedItemAt returns the item at idx with its control and invisible characters made visible if they're shown,
+// remembering it so that they're only found again once the item changes
+func (cm *contentManager[T]) visualizedItemAt(idx int) visualizedItem {
+	itm := hexDumpItem(cm.rawItemAt(idx), cm.hexView)
+	if cm.controlCharStyle == nil && cm.invisibleStyle == nil {
+		return visualizedItem{itm: itm}
+	}
+	content := itm.Content()
+	if v, ok := cm.visualized.get(idx); ok && v.source == content {
+		return v
+	}
+	v := visualizedItem{source: content, itm: itm}
+	if cm.controlCharStyle != nil {
+		if vis := item.VisualizeControlChars(v.itm, *cm.controlCharStyle); vis.Content() != v.itm.Content() {
+			v.controlCharsFrom = v.itm.ContentNoAnsi()
+			v.itm = vis
+		}
+	}
+	if cm.invisibleStyle != nil {
+		if vis := item.VisualizeInvisibles(v.itm, *cm.invisibleStyle); vis.Content() != v.itm.Content() {
+			v.invisiblesFrom = v.itm.ContentNoAnsi()
+			v.itm = vis
+		}
+	}
+	cm.visualized.store(idx, v)
+	return v
+}
+
+// rawItemAt returns the item at idx with its control characters as they are
+func (cm *contentManager[T]) rawItemAt(idx int) item.Item {
+	if cm.joining != nil && cm.source == nil {
+		return cm.joining.itemAt(idx, cm.objects[idx])
+	}
+	obj := cm.objectAt(idx)
+	itm := cm.itemFor(obj)
+	if _, ok := cm.expandedDetails[idx]; ok {
+		if details := detailItems(obj); len(details) > 0 {
+			itm = withDetails(itm, details)
+		}
+	}
+	if cm.tree != nil && cm.source == nil {
+		itm = cm.tree.itemAt(idx, itm)
+	}
+	if cm.hasSeparator(idx) {
+		itm = withSeparator(itm)
+	}
+	return itm
+}
+
+// allObjects returns every object set on the viewport, including those hidden by line joining or in collapsed tree
+// nodes. With an item source,
+// every object is read from it.
+func (cm *contentManager[T]) allObjects() []T {
+	if cm.source != nil {
+		objects := make([]T, cm.sourceLen)
+		for i := range objects {
+			objects[i] = cm.source.At(i)
+		}
+		return objects
+	}
+	if cm.joining != nil {
+		return cm.joining.sourceObjects
+	}
+	if cm.tree != nil {
+		return cm.tree.sourceObjects
+	}
+	return cm.objects
+}
+
+// unprocessedObjects returns every object set on the viewport, before any preprocessing or hiding of unchanged items
+func (cm *contentManager[T]) unprocessedObjects() []T {
+	if cm.preprocessing != nil {
+		return cm.preprocessing.unprocessed
+	}
+	return cm.unfilteredObjects()
+}
+
+// unfilteredObjects returns every object after preprocessing in the order it was set, including unchanged items
+// hidden when showing changes only
+func (cm *contentManager[T]) unfilteredObjects() []T {
+	if cm.checkpoint != nil && cm.checkpoint.changesOnly {
+		return cm.checkpoint.unfiltered
+	}
+	if cm.sorting != nil && cm.sorting.direction != SortNone {
+		return cm.sorting.unsorted
+	}
+	return cm.allObjects()
+}
+
 // numItems returns the total number of items
 func (cm *contentManager[T]) numItems() int {
+	if cm.source != nil {
+		return cm.sourceLen
+	}
 	return len(cm.objects)
 }
 
 // isEmpty returns true if there are no items
 func (cm *contentManager[T]) isEmpty() bool {
-	return len(cm.objects) == 0
+	return cm.numItems() == 0
 }
 
 // rebuildHighlightsCache rebuilds the internal highlight cache
@@ -78,6 +285,15 @@ func (cm *contentManager[T]) setHighlights(highlights []Highlight) {
 	cm.rebuildHighlightsCache()
 }
 
+// appendHighlights adds highlights, keeping the existing ones
+func (cm *contentManager[T]) appendHighlights(highlights []Highlight) {
+	cm.highlights = append(cm.highlights, highlights...)
+	for _, highlight := range highlights {
+		itemIdx := highlight.ItemIndex
+		cm.itemHighlightsByIndex[itemIdx] = append(cm.itemHighlightsByIndex[itemIdx], highlight.ItemHighlight)
+	}
+}
+
 // getHighlights returns all highlights
 func (cm *contentManager[T]) getHighlights() []Highlight {
 	return cm.highlights
@@ -85,5 +301,57 @@ func (cm *contentManager[T]) getHighlights() []Highlight {
 
 // getItemHighlightsForItem returns highlights for a specific item index
 func (cm *contentManager[T]) getItemHighlightsForItem(itemIndex int) []item.Highlight {
-	return cm.itemHighlightsByIndex[itemIndex]
+	highlights := cm.itemHighlightsByIndex[itemIndex]
+	if len(highlights) > 0 && cm.hexView != HexViewOff && showsHexDump(cm.rawItemAt(itemIndex).Content(), cm.hexView) {
+		// highlights are byte ranges of the content, not of its hex dump
+		return nil
+	}
+	if len(highlights) == 0 {
+		return highlights
+	}
+	v := cm.visualizedItemAt(itemIndex)
+	if cm.tabWidth == 0 && !v.changed() {
+		return highlights
+	}
+	// highlights are byte ranges of the content as it is, before any characters are made visible or tabs expanded
+	contentNoAnsi := v.itm.ContentNoAnsi()
+	expanded := make([]item.Highlight, len(highlights))
+	for i, h := range highlights {
+		expanded[i] = h
+		expanded[i].ByteRangeUnstyledContent = item.ExpandTabsByteRange(contentNoAnsi,
+			v.byteRange(h.ByteRangeUnstyledContent), cm.tabWidth)
+	}
+	return expanded
+}
+
+// visualizedItem is an item with its control and invisible characters made visible, see visualizedItemAt
+type visualizedItem struct {
+	// source is the content of the item before its characters were made visible, checked when it's remembered so
+	// that it's discarded if the item at its index changed
+	source string
+
+	itm item.Item
+
+	// controlCharsFrom is the unstyled content whose control characters were put in caret notation, "" if none were
+	controlCharsFrom string
+
+	// invisiblesFrom is the unstyled content whose invisible characters were shown as glyphs, "" if none were
+	invisiblesFrom string
+}
+
+// changed returns whether any characters were made visible
+func (v visualizedItem) changed() bool {
+	return v.controlCharsFrom != "" || v.invisiblesFrom != ""
+}
+
+// byteRange maps r, a byte range of the unstyled content before any characters were made visible, to the byte
+// range of the same content in v.itm
+func (v visualizedItem) byteRange(r item.ByteRange) item.ByteRange {
+	if v.controlCharsFrom != "" {
+		r = item.VisualizeControlCharsByteRange(v.controlCharsFrom, r)
+	}
+	if v.invisiblesFrom != "" {
+		r = item.VisualizeInvisiblesByteRange(v.invisiblesFrom, r)
+	}
+	return r
 }
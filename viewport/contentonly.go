@@ -0,0 +1,53 @@
+package viewport
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// SetContentOnly sets whether the viewport renders just its items, for embedding it in another component's chrome. In
+// content-only mode no lines are given to the header, post-header line, pre-footer line or footer, so the items fill
+// the viewport's height and View renders the same as ContentOnlyView. Prompts shown in the footer, like go-to and
+// save, aren't shown either.
+func (m *Model[T]) SetContentOnly(contentOnly bool) {
+	if m.config.contentOnly == contentOnly {
+		return
+	}
+	m.config.contentOnly = contentOnly
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// GetContentOnly returns whether the viewport renders just its items
+func (m *Model[T]) GetContentOnly() bool {
+	return m.config.contentOnly
+}
+
+// ContentOnlyView renders just the item area of the viewport, the lines View shows between the header and
+// post-header line above and the pre-footer line and footer below, so other components can embed it without
+// doubling up on chrome. It's as wide as the viewport and, in content-only mode, as tall.
+func (m *Model[T]) ContentOnlyView() string {
+	if m.display.bounds.height == 0 {
+		m.display.wrapPrefixedRows = nil
+		return ""
+	}
+	m.refreshColumnWidths()
+	lines := m.renderContentLines(m.getVisibleContentItemIndexes())
+	if len(lines) == 0 {
+		return ""
+	}
+	return lipgloss.NewStyle().Width(m.display.bounds.width).Render(strings.Join(lines, "\n"))
+}
+
+// showsPostHeaderLine returns true if the post-header line takes up a line of the view
+func (m *Model[T]) showsPostHeaderLine() bool {
+	return m.config.postHeaderLine != "" && !m.config.contentOnly
+}
+
+// showsPreFooterLine returns true if the pre-footer line takes up a line of the view
+func (m *Model[T]) showsPreFooterLine() bool {
+	return m.config.preFooterLine != "" && !m.config.contentOnly
+}
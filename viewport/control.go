@@ -0,0 +1,33 @@
+package viewport
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// Messages that drive the viewport when passed to Update, so that parent programs and tests can control it purely
+// through messages instead of calling setters between updates. They apply whatever mode the viewport is in.
+
+// ScrollToMsg brings the item at ItemIdx into view, as EnsureItemInView does, without changing the selection. Jumps of
+// more than a page are animated with smooth scrolling enabled.
+type ScrollToMsg struct {
+	ItemIdx int
+}
+
+// SelectItemMsg selects the item at ItemIdx and brings it into view, as SetSelectedItemIdx does, sending a
+// SelectionChangedMsg if the selection changes. Ignored with selection disabled.
+type SelectItemMsg struct {
+	ItemIdx int
+}
+
+// handleControlMsg applies msg if it's a control message, returning whether it was one
+func (m *Model[T]) handleControlMsg(msg tea.Msg) bool {
+	switch msg := msg.(type) {
+	case ScrollToMsg:
+		m.EnsureItemInView(msg.ItemIdx, 0, 0, 0, 0)
+	case SelectItemMsg:
+		m.SetSelectedItemIdx(msg.ItemIdx)
+	default:
+		return false
+	}
+	return true
+}
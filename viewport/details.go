@@ -0,0 +1,89 @@
+package viewport
+
+import (
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// detailIndent prefixes each line of an expanded object's detail items
+const detailIndent = "  "
+
+// DetailedObject is an Object with detail items, e.g. a stack trace or JSON payload attached to a log line. Expanded
+// with the ToggleExpand key or SetDetailsExpanded, its detail items are shown indented beneath its item.
+type DetailedObject interface {
+	Object
+	GetDetailItems() []item.Item
+}
+
+// detailItems returns the detail items of obj, nil if it has none
+func detailItems[T Object](obj T) []item.Item {
+	if detailed, ok := any(obj).(DetailedObject); ok {
+		return detailed.GetDetailItems()
+	}
+	return nil
+}
+
+// withDetails returns itm followed by the lines of details, each indented beneath it
+func withDetails(itm item.Item, details []item.Item) item.Item {
+	var lines []item.SingleItem
+	for _, seg := range itm.LineBrokenItems() {
+		lines = append(lines, item.NewItem(seg.Content()))
+	}
+	for _, detail := range details {
+		for _, seg := range detail.LineBrokenItems() {
+			lines = append(lines, item.NewItem(detailIndent+seg.Content()))
+		}
+	}
+	return item.NewMultiLineItem(lines...)
+}
+
+// expandedDetailObjects returns the objects with expanded details
+func (m *Model[T]) expandedDetailObjects() []T {
+	objects := make([]T, 0, len(m.content.expandedDetails))
+	for idx := range m.content.expandedDetails {
+		if idx < m.content.numItems() {
+			objects = append(objects, m.content.objectAt(idx))
+		}
+	}
+	return objects
+}
+
+// reexpandDetails expands the details of the items in the current objects that match previously expanded objects
+// using the selection comparator, collapsing all details if there isn't one
+func (m *Model[T]) reexpandDetails(prevExpanded []T) {
+	m.content.expandedDetails = make(map[int]struct{})
+	if m.content.compareFn == nil || len(prevExpanded) == 0 {
+		return
+	}
+	for i, obj := range m.content.objects {
+		for _, expanded := range prevExpanded {
+			if m.content.compareFn(obj, expanded) {
+				m.content.expandedDetails[i] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+// shiftExpandedDetails moves expanded details down by n items after objects are prepended, or up by -n after the oldest are dropped,
+// dropping those moved before the first item
+func (m *Model[T]) shiftExpandedDetails(n int) {
+	if len(m.content.expandedDetails) == 0 {
+		return
+	}
+	shifted := make(map[int]struct{}, len(m.content.expandedDetails))
+	for idx := range m.content.expandedDetails {
+		if idx+n >= 0 {
+			shifted[idx+n] = struct{}{}
+		}
+	}
+	m.content.expandedDetails = shifted
+}
+
+// afterDetailsChanged keeps the view and selection valid after items grow or shrink from toggling details
+func (m *Model[T]) afterDetailsChanged() {
+	m.refreshSearch()
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
@@ -0,0 +1,72 @@
+package viewport
+
+// SetObjectsDiffed replaces the objects like SetObjects, matching old and new objects by identity so that a view
+// refreshed by polling doesn't churn. The item at the top of the view stays there, so items added or removed above
+// or below it don't move what's in view, and the selection stays on the same object. If the top or selected item was
+// removed, the nearest following one still present takes its place, or else the nearest preceding one. Sticky top and
+// bottom apply as with SetObjects. Objects with the same identity after the first are matched as new ones. During a
+// batch update, or with a nil identity, it's SetObjects.
+func (m *Model[T]) SetObjectsDiffed(objects []T, identity IdentityFunc[T]) {
+	if m.content.batch != nil || identity == nil || m.content.isEmpty() {
+		m.SetObjects(objects)
+		return
+	}
+	if stayAtTop, stayAtBottom := m.stickyPosition(); stayAtTop || stayAtBottom {
+		m.setObjects(objects)
+		return
+	}
+
+	oldObjects, oldSource, oldNumItems := m.content.objects, m.content.source, m.content.numItems()
+	oldKey := func(idx int) string {
+		if oldSource != nil {
+			return identity(oldSource.At(idx))
+		}
+		return identity(oldObjects[idx])
+	}
+	oldTopItemIdx, oldTopItemLineOffset := m.display.topItemIdx, m.display.topItemLineOffset
+	oldSelectedIdx := m.content.getSelectedIdx()
+	selectionInView := m.navigation.selectionEnabled && m.selectionInViewInfo().numLinesSelectionInView > 0
+
+	m.setObjects(objects)
+	if m.content.isEmpty() {
+		return
+	}
+
+	newIdxByKey := make(map[string]int, m.content.numItems())
+	for i := range m.content.numItems() {
+		key := identity(m.content.objectAt(i))
+		if _, ok := newIdxByKey[key]; !ok {
+			newIdxByKey[key] = i
+		}
+	}
+	// survivor returns the new index of the old item at oldIdx, or else of the nearest one still present, and whether
+	// it's the same item
+	survivor := func(oldIdx int) (int, bool) {
+		for i := oldIdx; i < oldNumItems; i++ {
+			if newIdx, ok := newIdxByKey[oldKey(i)]; ok {
+				return newIdx, i == oldIdx
+			}
+		}
+		for i := oldIdx - 1; i >= 0; i-- {
+			if newIdx, ok := newIdxByKey[oldKey(i)]; ok {
+				return newIdx, false
+			}
+		}
+		return 0, false
+	}
+
+	topItemIdx, same := survivor(oldTopItemIdx)
+	topItemLineOffset := 0
+	if same {
+		topItemLineOffset = oldTopItemLineOffset
+	}
+	m.safelySetTopItemIdxAndOffset(topItemIdx, topItemLineOffset)
+
+	if m.navigation.selectionEnabled {
+		selectedIdx, _ := survivor(oldSelectedIdx)
+		m.content.setSelectedIdx(selectedIdx)
+		if selectionInView {
+			m.scrollSoSelectionInView()
+		}
+	}
+}
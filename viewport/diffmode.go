@@ -0,0 +1,146 @@
+package viewport
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// DiffKind is how a line of a diff relates to the compared content, reported by objects implementing DiffObject
+type DiffKind int
+
+const (
+	// DiffKindContext lines are the same on both sides
+	DiffKindContext DiffKind = iota
+
+	// DiffKindAdded lines are only on the new side
+	DiffKindAdded
+
+	// DiffKindRemoved lines are only on the old side
+	DiffKindRemoved
+
+	// DiffKindChanged lines differ between the sides
+	DiffKindChanged
+)
+
+// DiffObject is an Object that's a line of a diff. In diff mode, see SetDiffMode, its kind is shown by a marker left
+// of the content and by per-kind styles.
+type DiffObject interface {
+	Object
+	GetDiffKind() DiffKind
+}
+
+// diffMarkerWidth is the width of the column of diff markers in diff mode
+const diffMarkerWidth = 2
+
+// diffMarkers are the markers shown for each DiffKind in diff mode
+var diffMarkers = map[DiffKind]string{
+	DiffKindContext: "  ",
+	DiffKindAdded:   "+ ",
+	DiffKindRemoved: "- ",
+	DiffKindChanged: "~ ",
+}
+
+// SetDiffMode sets whether the viewport renders its content as a diff. Objects implementing DiffObject get a +, - or ~
+// marker in a column left of the content, and are styled with DiffAddedStyle, DiffRemovedStyle or DiffChangedStyle
+// when unselected. Runs of items that aren't context are hunks, moved between with the NextHunk and PrevHunk keys.
+// The marker column narrows the content, so wrapped items rewrap.
+func (m *Model[T]) SetDiffMode(enabled bool) {
+	if m.config.diffMode == enabled {
+		return
+	}
+	m.config.diffMode = enabled
+	m.afterGutterChanged()
+}
+
+// GetDiffMode returns whether diff mode is on
+func (m *Model[T]) GetDiffMode() bool {
+	return m.config.diffMode
+}
+
+// NextHunk goes to the first item of the next hunk after the current item, the selected one when selection is
+// enabled and otherwise the one at the top of the view. When selection is enabled, the item is selected and scrolled
+// into view, otherwise it's scrolled to the top of the view. Returns false if there's no later hunk or diff mode is
+// off.
+func (m *Model[T]) NextHunk() bool {
+	if !m.config.diffMode {
+		return false
+	}
+	for idx := m.currentItemIdx() + 1; idx < m.content.numItems(); idx++ {
+		if m.isHunkStart(idx) {
+			m.jumpToItem(idx)
+			return true
+		}
+	}
+	return false
+}
+
+// PrevHunk goes to the first item of the hunk before the current item, like NextHunk. If the current item is within
+// a hunk but not its first item, that hunk's first item is gone to.
+func (m *Model[T]) PrevHunk() bool {
+	if !m.config.diffMode {
+		return false
+	}
+	for idx := min(m.currentItemIdx(), m.content.numItems()) - 1; idx >= 0; idx-- {
+		if m.isHunkStart(idx) {
+			m.jumpToItem(idx)
+			return true
+		}
+	}
+	return false
+}
+
+// diffKind returns the DiffKind of the item at itemIdx, DiffKindContext if its object isn't a DiffObject
+func (m *Model[T]) diffKind(itemIdx int) DiffKind {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return DiffKindContext
+	}
+	if obj, ok := any(m.content.objectAt(itemIdx)).(DiffObject); ok {
+		return obj.GetDiffKind()
+	}
+	return DiffKindContext
+}
+
+// isHunkStart returns true if the item at itemIdx is the first of a run of items that aren't context
+func (m *Model[T]) isHunkStart(itemIdx int) bool {
+	return m.diffKind(itemIdx) != DiffKindContext && (itemIdx == 0 || m.diffKind(itemIdx-1) == DiffKindContext)
+}
+
+// diffKindStyle returns the style of kind, ok false for context
+func (m *Model[T]) diffKindStyle(kind DiffKind) (lipgloss.Style, bool) {
+	switch kind {
+	case DiffKindAdded:
+		return m.display.styles.DiffAddedStyle, true
+	case DiffKindRemoved:
+		return m.display.styles.DiffRemovedStyle, true
+	case DiffKindChanged:
+		return m.display.styles.DiffChangedStyle, true
+	}
+	return lipgloss.Style{}, false
+}
+
+// diffMarker returns the styled marker of the item at itemIdx in diff mode
+func (m *Model[T]) diffMarker(itemIdx int) string {
+	kind := m.diffKind(itemIdx)
+	marker, ok := diffMarkers[kind]
+	if !ok {
+		return diffMarkerPadding()
+	}
+	if style, ok := m.diffKindStyle(kind); ok {
+		return style.Render(marker)
+	}
+	return marker
+}
+
+// diffMarkerPadding returns blank space the width of a diff marker
+func diffMarkerPadding() string {
+	return strings.Repeat(" ", diffMarkerWidth)
+}
+
+// numDiffMarkerCols returns the width of the column of diff markers, 0 when diff mode is off
+func (m *Model[T]) numDiffMarkerCols() int {
+	if m.config.diffMode {
+		return diffMarkerWidth
+	}
+	return 0
+}
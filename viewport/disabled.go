@@ -0,0 +1,30 @@
+package viewport
+
+import (
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// disabledContentLines dims the rendered content lines, padded to the content height, and centers the disabled note
+// over them
+func (m *Model[T]) disabledContentLines(lines []string) []string {
+	numLines := max(len(lines), m.getNumContentLines())
+	res := make([]string, numLines)
+	for i := range res {
+		if i < len(lines) && lines[i] != "" {
+			res[i] = m.display.styles.DisabledStyle.Render(item.StripAnsi(lines[i]))
+		}
+	}
+
+	note := m.config.disabledNote
+	if note == "" || numLines == 0 {
+		return res
+	}
+	w := m.display.bounds.width
+	noteItem := item.NewItem(note)
+	truncated, _ := noteItem.Take(0, w, m.config.continuationIndicator, nil)
+	pad := max(0, (w-noteItem.Width())/2)
+	res[(numLines-1)/2] = strings.Repeat(" ", pad) + m.display.styles.DisabledNoteStyle.Render(truncated)
+	return res
+}
@@ -21,6 +21,32 @@ type displayManager struct {
 
 	// styles contains the styling configuration
 	styles Styles
+
+	// wrapPrefixedRows is whether each content row of the last render starts with the wrap indent and indicator
+	wrapPrefixedRows []bool
+
+	// resize throttles relayout from Resize
+	resize *resizeState
+
+	// lastInView is the state after the last EnsureItemInView, nil if there wasn't one
+	lastInView *itemInView
+
+	// wrapPrecompute wraps items just outside the view in the background
+	wrapPrecompute wrapPrecomputeState
+}
+
+// itemInView is the state after bringing an item portion into view, which doesn't change when repeating the same
+// request
+type itemInView struct {
+	request           itemInViewRequest
+	bounds            rectangle
+	numContentLines   int
+	wrapText          bool
+	numItems          int
+	itemContent       string
+	topItemIdx        int
+	topItemLineOffset int
+	xOffset           int
 }
 
 // newDisplayManager creates a new displayManager with the specified dimensions and styles
@@ -30,6 +56,7 @@ func newDisplayManager(width, height int, styles Styles) *displayManager {
 			width:  max(0, width),
 			height: max(0, height),
 		},
+		resize:            newResizeState(),
 		topItemIdx:        0,
 		topItemLineOffset: 0,
 		xOffset:           0,
@@ -0,0 +1,58 @@
+package viewport
+
+import (
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// ItemEditedMsg is sent when an inline edit of an item is confirmed. The viewport doesn't change its objects; the
+// caller applies the new content, e.g. with SetObjects.
+type ItemEditedMsg struct {
+	ItemIndex int
+	Content   string
+}
+
+// editState tracks inline editing of the selected item
+type editState struct {
+	// editing is true while the selected item is replaced by the text input
+	editing bool
+
+	// input holds the edited content
+	input textinput.Model
+}
+
+// startEditing replaces the selected item with a text input pre-filled with its unstyled content
+func (m *Model[T]) startEditing() tea.Cmd {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() || m.config.editState.editing {
+		return nil
+	}
+	selectedIdx := m.content.getSelectedIdx()
+	m.ensureItemInView(selectedIdx, 0, 0, 0, 0)
+
+	m.config.editState = editState{editing: true, input: textinput.New()}
+	m.config.editState.input.Prompt = ""
+	// width first so that the value scrolls to the cursor at its end
+	m.setEditInputWidth()
+	m.config.editState.input.SetValue(m.content.rawItemAt(selectedIdx).ContentNoAnsi())
+	m.config.editState.input.CursorEnd()
+	return m.config.editState.input.Focus()
+}
+
+// confirmEditing ends editing, returning a command sending an ItemEditedMsg with the edited content
+func (m *Model[T]) confirmEditing() tea.Cmd {
+	msg := ItemEditedMsg{
+		ItemIndex: m.content.getSelectedIdx(),
+		Content:   m.config.editState.input.Value(),
+	}
+	m.config.editState = editState{}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// setEditInputWidth fits the text input to the content width, leaving a cell for the cursor at the end of the value
+func (m *Model[T]) setEditInputWidth() {
+	if m.config.editState.editing {
+		m.config.editState.input.SetWidth(max(1, m.contentWidth()-1))
+	}
+}
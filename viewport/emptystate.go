@@ -0,0 +1,42 @@
+package viewport
+
+import (
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// WithEmptyState sets what's shown in the content area while there are no items, see SetEmptyState
+func WithEmptyState[T Object](render func(width, height int) string) Option[T] {
+	return func(m *Model[T]) {
+		m.SetEmptyState(render)
+	}
+}
+
+// SetEmptyState sets the function rendering what's shown in the content area while there are no items, e.g.
+// "No results. Press / to filter.", instead of blank lines. It's called on each render with the width and height of
+// the content area, and its lines are centered in it, truncated to fit. The loading indicator shows instead while
+// loading, see SetLoading. Pass nil to show blank lines.
+func (m *Model[T]) SetEmptyState(render func(width, height int) string) {
+	m.config.emptyState = render
+}
+
+// emptyStateLines renders the empty state centered in the content lines, padded to the content height
+func (m *Model[T]) emptyStateLines(lines []string) []string {
+	numLines := max(len(lines), m.getNumContentLines())
+	w := m.display.bounds.width
+	if numLines == 0 || w == 0 {
+		return lines
+	}
+	rendered := strings.Split(m.config.emptyState(w, numLines), "\n")
+	rendered = rendered[:min(len(rendered), numLines)]
+	res := make([]string, numLines)
+	top := (numLines - len(rendered)) / 2
+	for i, line := range rendered {
+		lineItem := item.NewItem(line)
+		truncated, _ := lineItem.Take(0, w, m.config.continuationIndicator, nil)
+		pad := max(0, (w-lineItem.Width())/2)
+		res[top+i] = strings.Repeat(" ", pad) + truncated
+	}
+	return res
+}
@@ -0,0 +1,248 @@
+package viewport
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// DefaultFollowInterval is how often a FileFollower checks its file for changes unless set otherwise
+	DefaultFollowInterval = 250 * time.Millisecond
+
+	// fileFollowerChunkSize is how many bytes a FileFollower reads at a time
+	fileFollowerChunkSize = 64 << 10
+)
+
+// FileLinesMsg carries the lines a FileFollower read from its file, see FileFollower.Next
+type FileLinesMsg struct {
+	// Path is the path of the file followed
+	Path string
+
+	// Lines are the complete lines read, without their line endings
+	Lines []string
+
+	// Truncated is true if the file shrank, e.g. when emptied by copytruncate log rotation, before Lines were read
+	// from its start again
+	Truncated bool
+
+	// Reopened is true if the file at Path was replaced, e.g. by log rotation, before Lines were read. Lines include
+	// the end of the old file, then the start of the new one.
+	Reopened bool
+
+	// Err is why the file couldn't be read, nil on success. Following continues with Next regardless.
+	Err error
+}
+
+// FileFollower reads lines as they're written to a file, like tail -F: it keeps reading the file as it grows, starts
+// over from the top when it's truncated, and when another file replaces it at its path, as with log rotation, reads
+// the rest of the old file and then the new one. A file that doesn't exist yet is waited for. Feed the lines to the
+// viewport with AppendObjects, with follow mode or sticky bottom to keep the newest in view.
+type FileFollower struct {
+	// path is the path of the file followed
+	path string
+
+	// interval is how often the file is checked for changes
+	interval time.Duration
+
+	// done is closed by Close, stopping the command returned by Next
+	done chan struct{}
+
+	// mu guards the fields below, shared by Close on the Update goroutine and Poll in the command returned by Next
+	mu sync.Mutex
+
+	// closed is true once Close is called, after which Poll doesn't reopen the file
+	closed bool
+
+	// f is the file read, nil until it's first opened
+	f *os.File
+
+	// offset is how many bytes of f have been read
+	offset int64
+
+	// partial is the start of a line read without its line ending yet
+	partial []byte
+
+	// failed is true if the last Poll failed, so that Next waits before trying again
+	failed bool
+}
+
+// NewFileFollower returns a FileFollower of the file at path, reading it from the start and then checking for changes
+// every interval, DefaultFollowInterval if 0. Close it when done.
+func NewFileFollower(path string, interval time.Duration) *FileFollower {
+	if interval <= 0 {
+		interval = DefaultFollowInterval
+	}
+	return &FileFollower{path: path, interval: interval, done: make(chan struct{})}
+}
+
+// Next returns a command waiting for the next lines written to the file, then returning them in a FileLinesMsg,
+// also sent when the file is truncated or replaced or can't be read. Call Next again on each FileLinesMsg to keep
+// following. After Close, the command stops waiting and returns nil.
+func (f *FileFollower) Next() tea.Cmd {
+	return func() tea.Msg {
+		if f.lastPollFailed() && !f.wait() {
+			return nil
+		}
+		for {
+			msg := f.Poll()
+			if errors.Is(msg.Err, fs.ErrClosed) {
+				return nil
+			}
+			if len(msg.Lines) > 0 || msg.Truncated || msg.Reopened || msg.Err != nil {
+				return msg
+			}
+			if !f.wait() {
+				return nil
+			}
+		}
+	}
+}
+
+// Poll reads the lines written to the file since the last Poll without waiting, for use outside of a Bubble Tea
+// program. An unterminated last line is held back until its line ending is written, or the file is replaced. After
+// Close, Poll returns fs.ErrClosed.
+func (f *FileFollower) Poll() FileLinesMsg {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	msg := f.poll()
+	f.failed = msg.Err != nil
+	return msg
+}
+
+// poll is Poll with mu held
+func (f *FileFollower) poll() FileLinesMsg {
+	msg := FileLinesMsg{Path: f.path}
+	if f.closed {
+		msg.Err = fs.ErrClosed
+		return msg
+	}
+	if f.f == nil {
+		if !f.open(&msg) {
+			return msg
+		}
+	}
+
+	info, err := f.f.Stat()
+	if err != nil {
+		msg.Err = err
+		return msg
+	}
+	if info.Size() < f.offset {
+		msg.Truncated = true
+		f.offset, f.partial = 0, nil
+	}
+	if msg.Err = f.read(&msg); msg.Err != nil {
+		return msg
+	}
+
+	current, err := os.Stat(f.path)
+	if err != nil || os.SameFile(info, current) {
+		// while rotating, the path may briefly not exist; keep reading the old file until it's replaced
+		return msg
+	}
+	// the old file was read to its end above, so its last line is complete
+	if len(f.partial) > 0 {
+		msg.Lines = append(msg.Lines, lineContent(f.partial))
+	}
+	_ = f.f.Close()
+	f.f, f.offset, f.partial = nil, 0, nil
+	msg.Reopened = true
+	if f.open(&msg) {
+		msg.Err = f.read(&msg)
+	}
+	return msg
+}
+
+// Close closes the file followed and stops following it, so a command returned by Next returns nil instead of
+// waiting for more lines
+func (f *FileFollower) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+	close(f.done)
+	if f.f == nil {
+		return nil
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
+
+// lastPollFailed returns whether the last Poll failed, so that Next waits before trying again
+func (f *FileFollower) lastPollFailed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.failed
+}
+
+// wait waits for the interval, returning false if Close is called meanwhile
+func (f *FileFollower) wait() bool {
+	timer := time.NewTimer(f.interval)
+	defer timer.Stop()
+	select {
+	case <-f.done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// open opens the file at the path, returning false if it isn't there yet or can't be opened, recording why on msg
+func (f *FileFollower) open(msg *FileLinesMsg) bool {
+	file, err := os.Open(f.path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			msg.Err = err
+		}
+		return false
+	}
+	f.f = file
+	return true
+}
+
+// read appends the complete lines from the offset to the end of the file to msg
+func (f *FileFollower) read(msg *FileLinesMsg) error {
+	buf := make([]byte, fileFollowerChunkSize)
+	for {
+		n, err := f.f.ReadAt(buf, f.offset)
+		f.offset += int64(n)
+		data := buf[:n]
+		for {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				break
+			}
+			if len(f.partial) > 0 {
+				f.partial = append(f.partial, data[:i]...)
+				msg.Lines = append(msg.Lines, lineContent(f.partial))
+				f.partial = f.partial[:0]
+			} else {
+				msg.Lines = append(msg.Lines, lineContent(data[:i]))
+			}
+			data = data[i+1:]
+		}
+		f.partial = append(f.partial, data...)
+		if errors.Is(err, io.EOF) || n == 0 {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// lineContent returns the content of a line without its line ending
+func lineContent(line []byte) string {
+	return strings.TrimSuffix(string(line), "\r")
+}
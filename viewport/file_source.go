@@ -0,0 +1,276 @@
+package viewport
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	// fileSourceBlockLines is how many lines share one indexed offset, so the index takes 8 bytes per block of lines
+	// rather than per line
+	fileSourceBlockLines = 64
+
+	// fileSourceCachedBlocks is how many blocks of lines read are kept in memory, enough for a view and its
+	// surroundings
+	fileSourceCachedBlocks = 32
+
+	// fileSourceChunkSize is how many bytes are read at a time while indexing
+	fileSourceChunkSize = 1 << 20
+
+	// fileSourceCheckBytes is how many of the last bytes indexed Refresh reads again to check the file wasn't rewritten
+	fileSourceCheckBytes = 64
+)
+
+// FileSource is an ItemSource reading the lines of a file on demand, so a file far larger than memory can be viewed.
+// Refresh indexes where lines start in the file, holding one offset per block of lines, and At reads just the block
+// holding the line, keeping a few recently read blocks in memory. Lines end with "\n" or "\r\n", and the last may be
+// unterminated. It's safe to call Refresh, e.g. in the background while a large file is indexed, concurrently with Len
+// and At.
+type FileSource[T Object] struct {
+	// newObject returns the object for the content of a line
+	newObject func(line string) T
+
+	// refreshMu makes calls to Refresh take turns
+	refreshMu sync.Mutex
+
+	// mu guards the fields below it
+	mu sync.Mutex
+
+	// f is the file read
+	f *os.File
+
+	// blockStarts is the offset of the first line of each block of fileSourceBlockLines lines
+	blockStarts []int64
+
+	// numTerminated is the number of lines indexed that end in a newline
+	numTerminated int
+
+	// tailStart is the offset after the last newline indexed, where an unterminated last line starts
+	tailStart int64
+
+	// indexed is how many bytes of the file are indexed
+	indexed int64
+
+	// indexedTail is the last bytes indexed, up to fileSourceCheckBytes of them
+	indexedTail []byte
+
+	// cache holds the lines of recently read blocks by block index, cacheOrder the blocks in the order they were
+	// read
+	cache      map[int][]string
+	cacheOrder []int
+
+	// err is the first error reading the file in At
+	err error
+}
+
+// NewFileSource opens the file at path as a FileSource returning newObject of the content of each line, indexing it
+// with Refresh. For a large file, indexing takes a while, so e.g. open it with OpenFileSource and call Refresh in the
+// background instead, refreshing the viewport as lines are indexed. Close the source when done.
+func NewFileSource[T Object](path string, newObject func(line string) T) (*FileSource[T], error) {
+	s, err := OpenFileSource(path, newObject)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Refresh(); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// OpenFileSource opens the file at path as a FileSource returning newObject of the content of each line, without
+// indexing it: it has no lines until Refresh is called. Close the source when done.
+func OpenFileSource[T Object](path string, newObject func(line string) T) (*FileSource[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSource[T]{
+		newObject:   newObject,
+		f:           f,
+		blockStarts: []int64{0},
+		cache:       make(map[int][]string),
+	}, nil
+}
+
+// Len returns the number of lines indexed
+func (s *FileSource[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.numLines()
+}
+
+// At returns the object of the line at index i, reading its block of lines from the file unless recently read. On an
+// error reading the file, the line is empty and Err returns the error.
+func (s *FileSource[T]) At(i int) T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	block := i / fileSourceBlockLines
+	lines, ok := s.cache[block]
+	if !ok {
+		var err error
+		lines, err = s.readBlock(block)
+		if err != nil && s.err == nil {
+			s.err = err
+		}
+		s.cacheBlock(block, lines)
+	}
+	if offset := i % fileSourceBlockLines; offset < len(lines) {
+		return s.newObject(lines[offset])
+	}
+	return s.newObject("")
+}
+
+// Refresh indexes the lines written to the file since it was last indexed, e.g. to follow a growing log, after which
+// the viewport's RefreshItemSource shows them. Lines are available to Len and At as each chunk of the file is
+// indexed. If the file shrank or the last bytes indexed changed, e.g. when truncated by log rotation and written to
+// again, it's indexed again from the start. A file rewritten with the same bytes where the last ones indexed were
+// isn't noticed.
+func (s *FileSource[T]) Refresh() error {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	info, err := s.f.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+
+	s.mu.Lock()
+	if size < s.indexed || !s.tailUnchanged() {
+		s.blockStarts = []int64{0}
+		s.numTerminated, s.tailStart, s.indexed = 0, 0, 0
+		s.indexedTail = nil
+		s.clearCache()
+	}
+	offset := s.indexed
+	s.mu.Unlock()
+
+	buf := make([]byte, fileSourceChunkSize)
+	for offset < size {
+		n, err := s.f.ReadAt(buf[:min(int64(len(buf)), size-offset)], offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if n == 0 {
+			break
+		}
+		s.mu.Lock()
+		s.index(buf[:n], offset)
+		s.mu.Unlock()
+		offset += int64(n)
+	}
+	return nil
+}
+
+// Err returns the first error reading the file for At, nil if none
+func (s *FileSource[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close closes the file
+func (s *FileSource[T]) Close() error {
+	return s.f.Close()
+}
+
+// numLines returns the number of lines indexed, including an unterminated last line
+func (s *FileSource[T]) numLines() int {
+	if s.indexed > s.tailStart {
+		return s.numTerminated + 1
+	}
+	return s.numTerminated
+}
+
+// index records the lines starting in chunk, read from offset, which follows the bytes already indexed
+func (s *FileSource[T]) index(chunk []byte, offset int64) {
+	// the last block read may have gained lines, or its unterminated last line grown, but the blocks before it are
+	// complete
+	s.forgetBlocksFrom(max(0, s.numLines()-1) / fileSourceBlockLines)
+	pos := 0
+	for {
+		i := bytes.IndexByte(chunk[pos:], '\n')
+		if i < 0 {
+			break
+		}
+		pos += i + 1
+		s.numTerminated++
+		s.tailStart = offset + int64(pos)
+		if s.numTerminated%fileSourceBlockLines == 0 {
+			s.blockStarts = append(s.blockStarts, s.tailStart)
+		}
+	}
+	s.indexed = offset + int64(len(chunk))
+	s.indexedTail = append(s.indexedTail, chunk[max(0, len(chunk)-fileSourceCheckBytes):]...)
+	s.indexedTail = s.indexedTail[max(0, len(s.indexedTail)-fileSourceCheckBytes):]
+}
+
+// tailUnchanged returns whether the file still holds the last bytes indexed where they were read
+func (s *FileSource[T]) tailUnchanged() bool {
+	if len(s.indexedTail) == 0 {
+		return true
+	}
+	buf := make([]byte, len(s.indexedTail))
+	if _, err := s.f.ReadAt(buf, s.indexed-int64(len(buf))); err != nil {
+		return false
+	}
+	return bytes.Equal(buf, s.indexedTail)
+}
+
+// readBlock returns the lines of block read from the file, up to the end of the bytes indexed
+func (s *FileSource[T]) readBlock(block int) ([]string, error) {
+	if block >= len(s.blockStarts) {
+		return nil, nil
+	}
+	start := s.blockStarts[block]
+	r := bufio.NewReader(io.NewSectionReader(s.f, start, s.indexed-start))
+	numLines := min(fileSourceBlockLines, s.numLines()-block*fileSourceBlockLines)
+	lines := make([]string, 0, max(0, numLines))
+	for len(lines) < numLines {
+		line, err := r.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return lines, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+		if err != nil {
+			break
+		}
+	}
+	return lines, nil
+}
+
+// cacheBlock keeps the lines of block in memory, forgetting the block read longest ago if the cache is full
+func (s *FileSource[T]) cacheBlock(block int, lines []string) {
+	if len(s.cacheOrder) >= fileSourceCachedBlocks {
+		delete(s.cache, s.cacheOrder[0])
+		s.cacheOrder = s.cacheOrder[1:]
+	}
+	s.cache[block] = lines
+	s.cacheOrder = append(s.cacheOrder, block)
+}
+
+// clearCache forgets every block read
+func (s *FileSource[T]) clearCache() {
+	clear(s.cache)
+	s.cacheOrder = s.cacheOrder[:0]
+}
+
+// forgetBlocksFrom forgets the blocks read at or after block
+func (s *FileSource[T]) forgetBlocksFrom(block int) {
+	kept := s.cacheOrder[:0]
+	for _, b := range s.cacheOrder {
+		if b < block {
+			kept = append(kept, b)
+		} else {
+			delete(s.cache, b)
+		}
+	}
+	s.cacheOrder = kept
+}
@@ -0,0 +1,51 @@
+package viewport
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// followIndicator is shown in the footer while following
+const followIndicator = "FOLLOWING"
+
+// FollowStateChangedMsg is sent when follow mode engages or disengages because the user scrolled to or away from the
+// bottom
+type FollowStateChangedMsg struct {
+	Following bool
+}
+
+// followState tracks follow mode, which keeps the bottom of the content in view as it's appended to
+type followState struct {
+	// enabled is true when follow mode is on
+	enabled bool
+
+	// following is the state last reported in a FollowStateChangedMsg
+	following bool
+}
+
+// stickyBottom returns whether the view should stay at the bottom when content changes while at the bottom
+func (m *Model[T]) stickyBottom() bool {
+	return m.navigation.bottomSticky || m.navigation.follow.enabled
+}
+
+// isAtBottom returns whether the last item is selected, or with selection disabled, whether the content is scrolled
+// to the bottom
+func (m *Model[T]) isAtBottom() bool {
+	if m.navigation.selectionEnabled {
+		return m.content.isEmpty() || m.content.getSelectedIdx() == m.content.numItems()-1
+	}
+	return m.isScrolledToBottom()
+}
+
+// syncFollowing returns a command sending a FollowStateChangedMsg if following changed since last reported
+func (m *Model[T]) syncFollowing() tea.Cmd {
+	f := &m.navigation.follow
+	following := m.IsFollowing()
+	// mid-animation, the view isn't yet where it's heading
+	if !f.enabled || following == f.following || m.navigation.smoothScroll.active {
+		return nil
+	}
+	f.following = following
+	return func() tea.Msg {
+		return FollowStateChangedMsg{Following: following}
+	}
+}
@@ -0,0 +1,172 @@
+package viewport
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FooterMetric is what the footer's scroll position measures
+type FooterMetric int
+
+const (
+	// FooterMetricItems measures the scroll position in items (default)
+	FooterMetricItems FooterMetric = iota
+
+	// FooterMetricLines measures the scroll position in display lines, counting each row of a wrapped item, so the
+	// percentage reflects how much content remains when items wrap heavily. Every item is measured on each render.
+	FooterMetricLines
+)
+
+// FooterFilterInfo describes a filter applied to the viewport's content. The viewport doesn't filter on its own;
+// a wrapping model like filterableviewport supplies it with SetFooterFilterInfo.
+type FooterFilterInfo struct {
+	// Mode is the label of the filter mode, e.g. "[exact]"
+	Mode string
+
+	// Query is the filter text
+	Query string
+
+	// FocusedMatch is the 1-indexed focused match, 0 if none is focused
+	FocusedMatch int
+
+	// MatchCount is the total number of matches
+	MatchCount int
+
+	// MatchLimitExceeded is true if matching stopped at MatchCount before covering all content
+	MatchLimitExceeded bool
+
+	// MatchingItemsOnly is true if only items with matches are shown
+	MatchingItemsOnly bool
+}
+
+// FooterInfo is the state a FooterFormatter renders into the footer
+type FooterInfo struct {
+	// Percent is how far the viewport is scrolled through its content
+	Percent int
+
+	// Current is the 1-indexed selected item, or the bottom visible item if selection is disabled
+	Current int
+
+	// Total is the number of items
+	Total int
+
+	// Line is the 1-indexed display line at the bottom of the view when the footer metric is FooterMetricLines, 0
+	// otherwise
+	Line int
+
+	// TotalLines is the number of display lines of all items when the footer metric is FooterMetricLines, 0 otherwise
+	TotalLines int
+
+	// TreeTotal is the number of tree nodes including those hidden in collapsed nodes when tree mode is on, 0 otherwise
+	TreeTotal int
+
+	// Following is true if the viewport is following new content
+	Following bool
+
+	// Overscroll is the indicator shown after trying to scroll past the top or bottom, empty otherwise, see
+	// SetOverscrollIndicator
+	Overscroll string
+
+	// SoftLimitNotice lists features disabled by soft limits, empty if none are
+	SoftLimitNotice string
+
+	// Filter is the applied filter, nil if none is applied
+	Filter *FooterFilterInfo
+}
+
+// FooterFormatter renders the footer text from info. The result is truncated to the viewport width and styled with
+// FooterStyle.
+type FooterFormatter func(info FooterInfo) string
+
+// DefaultFooterFormatter renders the scroll position, e.g. "50% (3/6)", or "50% (line 40 of 80)" when measured in
+// lines, after any overscroll indicator, following indicator and soft limit notice, followed by how many tree nodes are visible in tree
+// mode, e.g. "6/10 visible". It ignores filter state.
+func DefaultFooterFormatter(info FooterInfo) string {
+	position := fmt.Sprintf("%d%% (%d/%d)", info.Percent, info.Current, info.Total)
+	if info.TotalLines > 0 {
+		position = fmt.Sprintf("%d%% (line %d of %d)", info.Percent, info.Line, info.TotalLines)
+	}
+	return joinFooterParts(
+		info.Overscroll,
+		followingText(info.Following),
+		info.SoftLimitNotice,
+		position,
+		treeVisibleText(info),
+	)
+}
+
+// FilterFooterFormatter renders the same footer as DefaultFooterFormatter until a filter is applied, then switches to
+// the filter's mode, query, and matches, e.g. `[exact] "err" match 3/47  (5/9)`.
+func FilterFooterFormatter(info FooterInfo) string {
+	f := info.Filter
+	if f == nil {
+		return DefaultFooterFormatter(info)
+	}
+	matches := "no matches"
+	if f.MatchLimitExceeded {
+		matches = fmt.Sprintf("%d+ matches", f.MatchCount)
+	} else if f.MatchCount > 0 {
+		matches = fmt.Sprintf("match %d/%d", f.FocusedMatch, f.MatchCount)
+	}
+	matchingOnly := ""
+	if f.MatchingItemsOnly {
+		matchingOnly = "matching only"
+	}
+	return joinFooterParts(
+		info.Overscroll,
+		followingText(info.Following),
+		strings.Join(removeEmptyParts(f.Mode, fmt.Sprintf("%q", f.Query), matches), " "),
+		matchingOnly,
+		fmt.Sprintf("(%d/%d)", info.Current, info.Total),
+	)
+}
+
+func treeVisibleText(info FooterInfo) string {
+	if info.TreeTotal == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d visible", info.Total, info.TreeTotal)
+}
+
+func followingText(following bool) string {
+	if following {
+		return followIndicator
+	}
+	return ""
+}
+
+// joinFooterParts joins the non-empty parts with two spaces
+func joinFooterParts(parts ...string) string {
+	return strings.Join(removeEmptyParts(parts...), "  ")
+}
+
+func removeEmptyParts(parts ...string) []string {
+	var res []string
+	for _, p := range parts {
+		if p != "" {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// footerLinePosition returns the 1-indexed display line at the bottom of the view showing numVisibleLines content
+// lines, and the number of display lines of all items
+func (m *Model[T]) footerLinePosition(numVisibleLines int) (int, int) {
+	linesAbove, total := m.linesAboveView()
+	return min(linesAbove+numVisibleLines, total), total
+}
+
+// linesAboveView returns the number of content lines above the view and in total
+func (m *Model[T]) linesAboveView() (int, int) {
+	linesAbove := m.display.topItemLineOffset
+	total := 0
+	for i := range m.content.numItems() {
+		numLines := m.numLinesForItem(i)
+		if i < m.display.topItemIdx {
+			linesAbove += numLines
+		}
+		total += numLines
+	}
+	return linesAbove, total
+}
@@ -0,0 +1,156 @@
+package viewport
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// goToPrompt precedes the go-to input in the footer
+const goToPrompt = "Go to: "
+
+// goToState tracks the go-to input, where the user types an item number or percentage to jump to
+type goToState struct {
+	// active is true while the user is typing
+	active bool
+
+	// input is the text input for the item number or percentage
+	input textinput.Model
+
+	// flashDuration is how long the item gone to is styled with FlashStyle, 0 for no flash
+	flashDuration time.Duration
+
+	// flashItemIdx is the index of the flashing item, -1 if none
+	flashItemIdx int
+
+	// flashSeq identifies the latest flash, so that clearing an earlier one doesn't clear it
+	flashSeq int
+}
+
+func newGoToState() goToState {
+	return goToState{flashItemIdx: -1}
+}
+
+// clearFlashMsg ends the flash with the same seq
+type clearFlashMsg struct {
+	id  int
+	seq int
+}
+
+// GoToItem brings the item at idx into view as EnsureItemInView does, selecting it when selection is enabled. With a
+// flash duration set, the item is styled with FlashStyle for that long, and the returned command ends the flash;
+// otherwise it's nil. The command also animates the scroll when smooth scrolling is enabled. Out of range indexes are
+// clamped to the content.
+func (m *Model[T]) GoToItem(idx int) tea.Cmd {
+	if m.content.isEmpty() {
+		return nil
+	}
+	prevTopItemIdx, prevTopItemLineOffset := m.display.topItemIdx, m.display.topItemLineOffset
+	idx = clampValZeroToMax(idx, m.content.numItems()-1)
+	if m.navigation.selectionEnabled {
+		m.setSelectedItemIdx(idx)
+	}
+	m.ensureItemInView(idx, 0, 0, 0, 0)
+	return tea.Batch(m.startSmoothScroll(prevTopItemIdx, prevTopItemLineOffset), m.flashItem(idx))
+}
+
+// GoToPercent goes to the item percent of the way through the content with GoToItem, 0 being the first item and 100
+// the last
+func (m *Model[T]) GoToPercent(percent float64) tea.Cmd {
+	if m.content.isEmpty() {
+		return nil
+	}
+	percent = min(max(percent, 0), 100)
+	return m.GoToItem(int(math.Round(percent / 100 * float64(m.content.numItems()-1))))
+}
+
+// SetGoToFlash sets how long the item gone to with GoToItem, GoToPercent or the GoTo key flashes, styled with
+// FlashStyle. 0, the default, disables flashing.
+func (m *Model[T]) SetGoToFlash(duration time.Duration) {
+	m.config.goTo.flashDuration = max(0, duration)
+}
+
+// flashItem starts flashing the item at idx, returning the command ending the flash, nil if flashing is disabled
+func (m *Model[T]) flashItem(idx int) tea.Cmd {
+	g := &m.config.goTo
+	if g.flashDuration <= 0 {
+		return nil
+	}
+	g.flashItemIdx = idx
+	g.flashSeq++
+	id, seq := m.id, g.flashSeq
+	return tea.Tick(g.flashDuration, func(time.Time) tea.Msg {
+		return clearFlashMsg{id: id, seq: seq}
+	})
+}
+
+// isFlashing returns true if the item at idx is flashing
+func (m *Model[T]) isFlashing(idx int) bool {
+	return m.config.goTo.flashItemIdx >= 0 && m.config.goTo.flashItemIdx == idx
+}
+
+// startGoTo shows the go-to input in the footer
+func (m *Model[T]) startGoTo() tea.Cmd {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.Placeholder = "line or %"
+	if m.content.timestampFunc != nil {
+		ti.Placeholder = "line, % or hh:mm"
+	}
+	ti.CharLimit = 32
+	ti.SetWidth(max(1, m.display.bounds.width-len(goToPrompt)-1))
+	ti.Focus()
+	m.config.goTo.input = ti
+	m.config.goTo.active = true
+	return textinput.Blink
+}
+
+// confirmGoTo goes to the item number, percentage or time typed in the go-to input
+func (m *Model[T]) confirmGoTo() tea.Cmd {
+	m.config.goTo.active = false
+	value := strings.TrimSpace(m.config.goTo.input.Value())
+	if value == "" {
+		return nil
+	}
+	if strings.Contains(value, ":") {
+		t, ok := m.parseGoToTime(value)
+		if !ok {
+			return m.showResult(fmt.Sprintf("Invalid time: %s", value), true)
+		}
+		return m.GoToTime(t)
+	}
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil || math.IsNaN(p) {
+			return m.showResult(fmt.Sprintf("Invalid percentage: %s", value), true)
+		}
+		return m.GoToPercent(p)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return m.showResult(fmt.Sprintf("Invalid line number: %s", value), true)
+	}
+	// numbers are 1-based, like the footer's
+	return m.GoToItem(n - 1)
+}
+
+// updateGoTo handles msg while the go-to input is shown
+func (m *Model[T]) updateGoTo(msg tea.Msg) tea.Cmd {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.Code {
+		case tea.KeyEnter:
+			return m.confirmGoTo()
+		case tea.KeyEscape:
+			m.config.goTo.active = false
+			return nil
+		}
+	}
+	var cmd tea.Cmd
+	m.config.goTo.input, cmd = m.config.goTo.input.Update(msg)
+	return cmd
+}
@@ -0,0 +1,52 @@
+package viewport
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// numGutterCols returns the number of columns of the gutter on the right edge of the content, holding the time
+// gutter, minimap and scrollbar when shown
+func (m *Model[T]) numGutterCols() int {
+	n := m.numTimeGutterCols()
+	if m.config.minimap {
+		n++
+	}
+	if m.config.scrollbar {
+		n++
+	}
+	return n
+}
+
+// withGutter returns the first height content lines of the view showing itemIndexes, padded with empty lines if
+// there are fewer, each followed by its row of the gutter: the item ages, the minimap, then the scrollbar
+func (m *Model[T]) withGutter(lines []string, height int, itemIndexes []int) []string {
+	var columns [][]string
+	if m.numTimeGutterCols() > 0 {
+		columns = append(columns, m.timeGutterColumn(height, itemIndexes))
+	}
+	if m.config.minimap {
+		columns = append(columns, m.minimapColumn(height))
+	}
+	if m.config.scrollbar {
+		columns = append(columns, m.scrollbarColumn(height, itemIndexes))
+	}
+
+	gutterStart := max(0, m.display.bounds.width-m.numGutterCols())
+	res := make([]string, height)
+	for i := range res {
+		var b strings.Builder
+		if i < len(lines) {
+			b.WriteString(lines[i])
+			b.WriteString(strings.Repeat(" ", max(0, gutterStart-lipgloss.Width(lines[i]))))
+		} else {
+			b.WriteString(strings.Repeat(" ", gutterStart))
+		}
+		for _, column := range columns {
+			b.WriteString(column[i])
+		}
+		res[i] = b.String()
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package viewport
+
+import (
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// HexView is which items are shown as hex dumps rather than text
+type HexView int
+
+const (
+	// HexViewOff shows every item as text
+	HexViewOff HexView = iota
+
+	// HexViewBinary shows items that look like binary data as hex dumps, see item.LooksBinary, and others as text
+	HexViewBinary
+
+	// HexViewAll shows every item as a hex dump
+	HexViewAll
+)
+
+// next returns the hex view after v when cycling with the ToggleHexView key
+func (v HexView) next() HexView {
+	return (v + 1) % 3
+}
+
+// WithHexView sets which items are shown as hex dumps, see SetHexView
+func WithHexView[T Object](view HexView) Option[T] {
+	return func(m *Model[T]) {
+		m.SetHexView(view)
+	}
+}
+
+// SetHexView sets which items are shown as hex dumps rather than text, e.g. HexViewBinary to keep binary data in the
+// input from garbling the screen. A hex dump shows the bytes of an item like hexdump -C, over as many lines as it
+// needs: the offset of each line's first byte, up to 16 bytes in hex, and those bytes as ASCII. Highlights don't apply
+// to hex dumps. The ToggleHexView key, disabled by default, cycles between the views. HexViewOff by default.
+func (m *Model[T]) SetHexView(view HexView) {
+	if view == m.content.hexView {
+		return
+	}
+	m.content.hexView = view
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, 0)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// GetHexView returns which items are shown as hex dumps
+func (m *Model[T]) GetHexView() HexView {
+	return m.content.hexView
+}
+
+// showsHexDump returns whether view shows an item with content as a hex dump
+func showsHexDump(content string, view HexView) bool {
+	switch view {
+	case HexViewBinary:
+		return item.LooksBinary(content)
+	case HexViewAll:
+		return true
+	}
+	return false
+}
+
+// hexDumpItem returns itm as a hex dump if view shows it as one, otherwise itm
+func hexDumpItem(itm item.Item, view HexView) item.Item {
+	if view == HexViewOff {
+		return itm
+	}
+	if content := itm.Content(); showsHexDump(content, view) {
+		return item.HexDump(content)
+	}
+	return itm
+}
@@ -0,0 +1,253 @@
+package viewport
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ansiPalette is the CSS color of each of the 16 basic ANSI colors, xterm's defaults
+var ansiPalette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+const (
+	// htmlForeground and htmlBackground are the CSS colors of text without a color set
+	htmlForeground = "#e5e5e5"
+	htmlBackground = "#000000"
+)
+
+// sgrStyle is the text styling set by SGR escape sequences, with colors as CSS colors, "" for the default
+type sgrStyle struct {
+	fg, bg                                                 string
+	bold, faint, italic, underline, strikethrough, reverse bool
+}
+
+// styledRun is a stretch of text with the same styling
+type styledRun struct {
+	text  string
+	style sgrStyle
+}
+
+// styledRuns splits line into runs of text with the same styling, dropping escape sequences other than SGR
+func styledRuns(line string) []styledRun {
+	var runs []styledRun
+	var style sgrStyle
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			runs = append(runs, styledRun{text: text.String(), style: style})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(line); {
+		if line[i] != '\x1b' {
+			text.WriteByte(line[i])
+			i++
+			continue
+		}
+		if i+1 >= len(line) {
+			break
+		}
+		switch line[i+1] {
+		case '[':
+			end := i + 2
+			for end < len(line) && (line[end] < 0x40 || line[end] > 0x7e) {
+				end++
+			}
+			if end < len(line) && line[end] == 'm' {
+				flush()
+				style = style.apply(line[i+2 : end])
+			}
+			i = min(end+1, len(line))
+		case ']', 'P', '_', '^':
+			// OSC, DCS, APC and PM strings end with BEL or ST
+			end := i + 2
+			for end < len(line) && line[end] != '\a' && (line[end] != '\x1b' || end+1 >= len(line) || line[end+1] != '\\') {
+				end++
+			}
+			if end < len(line) && line[end] == '\a' {
+				i = end + 1
+			} else {
+				i = min(end+2, len(line))
+			}
+		default:
+			i += 2
+		}
+	}
+	flush()
+	return runs
+}
+
+// apply returns the style after the SGR sequence with params, e.g. "1;31"
+func (s sgrStyle) apply(params string) sgrStyle {
+	parts := strings.Split(params, ";")
+	for i := 0; i < len(parts); i++ {
+		sub := strings.Split(parts[i], ":")
+		code, _ := strconv.Atoi(sub[0])
+		switch {
+		case code == 0:
+			s = sgrStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = len(sub) == 1 || sub[1] != "0"
+		case code == 7:
+			s.reverse = true
+		case code == 9:
+			s.strikethrough = true
+		case code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.reverse = false
+		case code == 29:
+			s.strikethrough = false
+		case 30 <= code && code <= 37:
+			s.fg = ansiPalette[code-30]
+		case code == 39:
+			s.fg = ""
+		case 40 <= code && code <= 47:
+			s.bg = ansiPalette[code-40]
+		case code == 49:
+			s.bg = ""
+		case 90 <= code && code <= 97:
+			s.fg = ansiPalette[code-90+8]
+		case 100 <= code && code <= 107:
+			s.bg = ansiPalette[code-100+8]
+		case code == 38 || code == 48:
+			var color string
+			if len(sub) > 1 {
+				// colon-separated, with an optional color space id before true color components
+				args := sub[1:]
+				if args[0] == "2" && len(args) > 4 {
+					args = append([]string{"2"}, args[len(args)-3:]...)
+				}
+				color, _ = extendedColor(args)
+			} else {
+				var used int
+				color, used = extendedColor(parts[i+1:])
+				i += used
+			}
+			if code == 38 {
+				s.fg = color
+			} else {
+				s.bg = color
+			}
+		}
+	}
+	return s
+}
+
+// extendedColor returns the CSS color of the 256-color or true color SGR arguments following 38 or 48, and how many
+// of them it used
+func extendedColor(args []string) (string, int) {
+	if len(args) >= 2 && args[0] == "5" {
+		n, _ := strconv.Atoi(args[1])
+		return color256(n), 2
+	}
+	if len(args) >= 4 && args[0] == "2" {
+		r, _ := strconv.Atoi(args[1])
+		g, _ := strconv.Atoi(args[2])
+		b, _ := strconv.Atoi(args[3])
+		return fmt.Sprintf("#%02x%02x%02x", r&0xff, g&0xff, b&0xff), 4
+	}
+	return "", len(args)
+}
+
+// color256 returns the CSS color of the 256-color palette entry n
+func color256(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[n/6%6], levels[n%6])
+	default:
+		gray := 8 + 10*(n-232)
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// colors returns the foreground and background colors the style shows, swapped when reversed
+func (s sgrStyle) colors() (fg, bg string) {
+	if s.reverse {
+		return cmp.Or(s.bg, htmlBackground), cmp.Or(s.fg, htmlForeground)
+	}
+	return s.fg, s.bg
+}
+
+// css returns the inline CSS declarations of the style, "" if it's unstyled
+func (s sgrStyle) css() string {
+	var decls []string
+	fg, bg := s.colors()
+	if fg != "" {
+		decls = append(decls, "color:"+fg)
+	}
+	if bg != "" {
+		decls = append(decls, "background-color:"+bg)
+	}
+	if s.bold {
+		decls = append(decls, "font-weight:bold")
+	}
+	if s.faint {
+		decls = append(decls, "opacity:0.5")
+	}
+	if s.italic {
+		decls = append(decls, "font-style:italic")
+	}
+	if decoration := s.textDecoration(); decoration != "" {
+		decls = append(decls, "text-decoration:"+decoration)
+	}
+	return strings.Join(decls, ";")
+}
+
+// textDecoration returns the CSS text decoration of the style, "" for none
+func (s sgrStyle) textDecoration() string {
+	switch {
+	case s.underline && s.strikethrough:
+		return "underline line-through"
+	case s.underline:
+		return "underline"
+	case s.strikethrough:
+		return "line-through"
+	}
+	return ""
+}
+
+// htmlDocument renders lines, which may hold ANSI styling, as a standalone HTML document
+func htmlDocument(lines []string) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<style>\n")
+	fmt.Fprintf(&b, "body { margin: 0; background-color: %s; }\n", htmlBackground)
+	fmt.Fprintf(&b, "pre { margin: 0; padding: 1em; color: %s; font-family: monospace; }\n", htmlForeground)
+	b.WriteString("</style>\n</head>\n<body>\n<pre>")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		for _, run := range styledRuns(line) {
+			text := html.EscapeString(run.text)
+			if css := run.style.css(); css != "" {
+				fmt.Fprintf(&b, `<span style="%s">%s</span>`, css, text)
+			} else {
+				b.WriteString(text)
+			}
+		}
+	}
+	b.WriteString("</pre>\n</body>\n</html>\n")
+	return b.String()
+}
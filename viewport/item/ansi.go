@@ -1,6 +1,7 @@
 package item
 
 import (
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -38,6 +39,7 @@ type highlightRange struct {
 	startByte int
 	endByte   int
 	style     lipgloss.Style
+	priority  int
 }
 
 // RST is the ansi escape sequence for resetting styles
@@ -135,7 +137,7 @@ func getNonAnsiBytes(s string, startIdx, numBytes int) string {
 // Parameters:
 //   - styledSegment: the text segment to highlight, which may contain ANSI codes
 //   - highlights: a list of Highlight structs defining the styledLine byte offsets and styles to apply
-//     NOTE: highlight byte ranges should not overlap
+//     where they overlap, the highest priority shows, see Highlight.Priority
 //   - plainLineSegmentStartByte: byte offset where styledSegment starts in full line without ansi codes
 //   - plainLineSegmentEndByte: byte offset where styledSegment ends in full line without ansi codes
 //
@@ -160,6 +162,7 @@ func highlightString(
 				startByte: startByte,
 				endByte:   endByte,
 				style:     highlight.Style,
+				priority:  highlight.Priority,
 			})
 		}
 	}
@@ -177,6 +180,8 @@ func highlightString(
 		}
 	}
 
+	applicableHighlights = resolveOverlaps(applicableHighlights)
+
 	var result strings.Builder
 	// pre-allocation based on highlight density (~50 bytes per highlight for styling)
 	estimatedSize := len(styledSegment) + len(applicableHighlights)*50
@@ -246,12 +251,6 @@ func highlightString(
 				nonAnsiBytes += len(plainText)
 				highlightIdx++
 
-				// skip to next highlight that doesn't overlap
-				for highlightIdx < len(applicableHighlights) &&
-					applicableHighlights[highlightIdx].startByte < nonAnsiBytes {
-					highlightIdx++
-				}
-
 				highlighted = true
 				continue
 			}
@@ -273,6 +272,64 @@ func highlightString(
 	return removeEmptyAnsiSequences(result.String())
 }
 
+// resolveOverlaps returns highlights, sorted by start, split so that none overlap. Where they did, the highest
+// priority keeps the bytes, then the one starting first, then the one earlier in highlights. Empty ranges are dropped.
+func resolveOverlaps(highlights []highlightRange) []highlightRange {
+	overlapping := false
+	for i := 1; i < len(highlights); i++ {
+		if highlights[i].startByte < highlights[i-1].endByte {
+			overlapping = true
+			break
+		}
+	}
+	if !overlapping {
+		return highlights
+	}
+
+	byPrecedence := slices.Clone(highlights)
+	slices.SortStableFunc(byPrecedence, func(a, b highlightRange) int {
+		if a.priority != b.priority {
+			return b.priority - a.priority
+		}
+		return a.startByte - b.startByte
+	})
+
+	// claim bytes in order of precedence, each highlight keeping the parts not already claimed
+	var claimed []highlightRange
+	for _, h := range byPrecedence {
+		pieces := []highlightRange{h}
+		for _, c := range claimed {
+			var remaining []highlightRange
+			for _, p := range pieces {
+				if p.endByte <= c.startByte || p.startByte >= c.endByte {
+					remaining = append(remaining, p)
+					continue
+				}
+				if p.startByte < c.startByte {
+					before := p
+					before.endByte = c.startByte
+					remaining = append(remaining, before)
+				}
+				if p.endByte > c.endByte {
+					after := p
+					after.startByte = c.endByte
+					remaining = append(remaining, after)
+				}
+			}
+			pieces = remaining
+		}
+		for _, p := range pieces {
+			if p.endByte > p.startByte {
+				claimed = append(claimed, p)
+			}
+		}
+	}
+	slices.SortFunc(claimed, func(a, b highlightRange) int {
+		return a.startByte - b.startByte
+	})
+	return claimed
+}
+
 func simplifyAnsiCodes(ansis []string) []string {
 	if len(ansis) == 0 {
 		return []string{}
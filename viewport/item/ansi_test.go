@@ -381,6 +381,55 @@ func TestHighlightString(t *testing.T) {
 	}
 }
 
+func TestHighlightStringOverlapping(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		highlights []Highlight
+		expected   string
+	}{
+		{
+			name: "disjoint",
+			highlights: []Highlight{
+				{Style: internal.RedFg, ByteRangeUnstyledContent: ByteRange{Start: 0, End: 2}},
+				{Style: internal.BlueFg, ByteRangeUnstyledContent: ByteRange{Start: 4, End: 6}},
+			},
+			expected: internal.RedFg.Render("ab") + internal.GreenBg.Render("cd") + internal.BlueFg.Render("ef") +
+				internal.GreenBg.Render("gh"),
+		},
+		{
+			name: "later highlight continues after earlier one",
+			highlights: []Highlight{
+				{Style: internal.RedFg, ByteRangeUnstyledContent: ByteRange{Start: 0, End: 4}},
+				{Style: internal.BlueFg, ByteRangeUnstyledContent: ByteRange{Start: 2, End: 6}},
+			},
+			expected: internal.RedFg.Render("abcd") + internal.BlueFg.Render("ef") + internal.GreenBg.Render("gh"),
+		},
+		{
+			name: "covered highlight dropped",
+			highlights: []Highlight{
+				{Style: internal.RedFg, ByteRangeUnstyledContent: ByteRange{Start: 0, End: 6}},
+				{Style: internal.BlueFg, ByteRangeUnstyledContent: ByteRange{Start: 1, End: 3}},
+				{Style: internal.BlueFg, ByteRangeUnstyledContent: ByteRange{Start: 2, End: 7}},
+			},
+			expected: internal.RedFg.Render("abcdef") + internal.BlueFg.Render("g") + internal.GreenBg.Render("h"),
+		},
+		{
+			name: "higher priority shows over earlier start",
+			highlights: []Highlight{
+				{Style: internal.RedFg, ByteRangeUnstyledContent: ByteRange{Start: 0, End: 6}},
+				{Style: internal.BlueFg, ByteRangeUnstyledContent: ByteRange{Start: 2, End: 4}, Priority: 1},
+			},
+			expected: internal.RedFg.Render("ab") + internal.BlueFg.Render("cd") + internal.RedFg.Render("ef") +
+				internal.GreenBg.Render("gh"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := highlightString(internal.GreenBg.Render("abcdefgh"), tt.highlights, 0, 8)
+			internal.CmpStr(t, tt.expected, result)
+		})
+	}
+}
+
 func TestAnsi_getNonAnsiBytes(t *testing.T) {
 	tests := []struct {
 		name         string
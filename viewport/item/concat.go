@@ -63,6 +63,29 @@ func (m ConcatItem) Width() int {
 	return m.totalWidth
 }
 
+// GraphemeCount returns the number of grapheme clusters in the concatenated content of all items.
+func (m ConcatItem) GraphemeCount() int {
+	n := 0
+	for _, it := range m.items {
+		n += it.GraphemeCount()
+	}
+	return n
+}
+
+// WidthAt returns the cell offset at which the grapheme cluster containing byteOffset starts. Offsets past the end
+// map to Width.
+func (m ConcatItem) WidthAt(byteOffset int) int {
+	if len(m.items) == 0 {
+		return 0
+	}
+	itemByteOffsets, itemWidthOffsets := m.computeItemOffsets()
+	if byteOffset >= itemByteOffsets[len(m.items)] {
+		return m.Width()
+	}
+	itemIdx, localByte := m.findItemForByteOffset(byteOffset, itemByteOffsets)
+	return itemWidthOffsets[itemIdx] + m.items[itemIdx].WidthAt(localByte)
+}
+
 // Content returns the concatenated content of all items.
 func (m ConcatItem) Content() string {
 	if len(m.items) == 0 {
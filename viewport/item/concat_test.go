@@ -85,6 +85,53 @@ func TestConcatItem_Content(t *testing.T) {
 	}
 }
 
+func TestConcatItem_GraphemeCount(t *testing.T) {
+	for _, eq := range getEquivalentItems() {
+		for _, item := range eq {
+			if item.GraphemeCount() != eq[0].GraphemeCount() {
+				t.Errorf("expected %d, got %d for item %s", eq[0].GraphemeCount(), item.GraphemeCount(), item.repr())
+			}
+		}
+	}
+	if actual := NewConcat(NewItem("a世"), NewItem("👨‍👩‍👧b")).GraphemeCount(); actual != 4 {
+		t.Errorf("expected 4, got %d", actual)
+	}
+}
+
+func TestConcatItem_WidthAt(t *testing.T) {
+	for _, eq := range getEquivalentItems() {
+		for _, item := range eq {
+			for byteOffset := range len(item.ContentNoAnsi()) + 1 {
+				if item.WidthAt(byteOffset) != eq[0].WidthAt(byteOffset) {
+					t.Errorf("expected %d, got %d at byte %d for item %s", eq[0].WidthAt(byteOffset),
+						item.WidthAt(byteOffset), byteOffset, item.repr())
+				}
+			}
+		}
+	}
+
+	// a (1w, 1b), 世 (2w, 3b), 👨‍👩‍👧 (2w, 18b), b (1w, 1b)
+	concat := NewConcat(NewItem("a世"), NewItem("👨‍👩‍👧b"))
+	tests := []struct {
+		name       string
+		byteOffset int
+		expected   int
+	}{
+		{name: "inside wide rune", byteOffset: 2, expected: 1},
+		{name: "start of second item", byteOffset: 4, expected: 3},
+		{name: "inside emoji zwj sequence", byteOffset: 10, expected: 3},
+		{name: "after emoji zwj sequence", byteOffset: 22, expected: 5},
+		{name: "past end", byteOffset: 100, expected: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := concat.WidthAt(tt.byteOffset); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestConcatItem_Take(t *testing.T) {
 	tests := []struct {
 		name           string
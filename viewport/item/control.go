@@ -0,0 +1,107 @@
+package item
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// isControlByte returns whether b is a C0 control character or DEL, other than tab
+func isControlByte(b byte) bool {
+	return (b < 0x20 && b != '\t') || b == 0x7f
+}
+
+// caretNotation returns the caret notation of the control byte b, e.g. "^M" for a carriage return
+func caretNotation(b byte) string {
+	return "^" + string(rune(b^0x40))
+}
+
+// hasControlBytes returns whether s has control characters other than those starting ANSI escape sequences
+func hasControlBytes(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if isControlByte(s[i]) && (s[i] != '\x1b' || i+1 >= len(s) || s[i+1] != '[') {
+			return true
+		}
+	}
+	return false
+}
+
+// VisualizeControlChars returns itm with its control characters, e.g. carriage returns, NUL bytes or escapes that
+// don't start an ANSI style sequence, rendered in caret notation (^M, ^@, ^[) in the given style so that they can't
+// disturb the layout. Tabs are left as they are. Items without control characters are returned unchanged, as are
+// items other than SingleItem and MultiLineItem. See VisualizeControlCharsByteRange to map byte ranges of the item's
+// ContentNoAnsi to those of the returned item's.
+func VisualizeControlChars(itm Item, style lipgloss.Style) Item {
+	switch it := itm.(type) {
+	case SingleItem:
+		return visualizeControlCharsSingle(it, style)
+	case MultiLineItem:
+		if !hasControlBytes(it.Content()) {
+			return it
+		}
+		lines := make([]SingleItem, len(it.items))
+		for i, line := range it.items {
+			lines[i] = visualizeControlCharsSingle(line, style)
+		}
+		return NewMultiLineItem(lines...)
+	}
+	return itm
+}
+
+// VisualizeControlCharsByteRange maps r, a byte range of the unstyled content contentNoAnsi, to the byte range of the
+// same content with its control characters in caret notation by VisualizeControlChars. A range covering a control
+// character covers its caret notation. Newlines are taken to separate the lines of a MultiLineItem, so are left as
+// they are.
+func VisualizeControlCharsByteRange(contentNoAnsi string, r ByteRange) ByteRange {
+	start, end := r.Start, r.End
+	for i := 0; i < min(r.End, len(contentNoAnsi)); i++ {
+		if c := contentNoAnsi[i]; !isControlByte(c) || c == '\n' {
+			continue
+		}
+		// each control byte is replaced by two bytes of caret notation
+		if i < r.Start {
+			start++
+		}
+		end++
+	}
+	return ByteRange{Start: start, End: end}
+}
+
+// visualizeControlCharsSingle renders the control characters of a single line in caret notation
+func visualizeControlCharsSingle(it SingleItem, style lipgloss.Style) SingleItem {
+	line := it.Content()
+	if !hasControlBytes(line) {
+		return it
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(line) + 16)
+	ansiRanges := it.ansiCodeIndexes
+	// active is the styling in effect, reapplied after each styled caret notation resets it
+	var active []string
+	for i := 0; i < len(line); {
+		if len(ansiRanges) > 0 && int(ansiRanges[0][0]) == i {
+			code := line[ansiRanges[0][0]:ansiRanges[0][1]]
+			if isResetCode(code) {
+				active = active[:0]
+			} else {
+				active = append(active, code)
+			}
+			builder.WriteString(code)
+			i = int(ansiRanges[0][1])
+			ansiRanges = ansiRanges[1:]
+			continue
+		}
+		if isControlByte(line[i]) {
+			builder.WriteString(style.Render(caretNotation(line[i])))
+			for _, code := range active {
+				builder.WriteString(code)
+			}
+			i++
+			continue
+		}
+		builder.WriteByte(line[i])
+		i++
+	}
+	return NewItem(builder.String())
+}
@@ -0,0 +1,91 @@
+package item
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestVisualizeControlChars(t *testing.T) {
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	tests := []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{
+			name:     "no control characters",
+			item:     NewItem("plain\ttext"),
+			expected: "plain\ttext",
+		},
+		{
+			name:     "carriage return and nul",
+			item:     NewItem("a\rb\x00"),
+			expected: "a" + red.Render("^M") + "b" + red.Render("^@"),
+		},
+		{
+			name:     "lone escape and del",
+			item:     NewItem("\x1bx\x7f"),
+			expected: red.Render("^[") + "x" + red.Render("^?"),
+		},
+		{
+			name:     "styling reapplied after caret",
+			item:     NewItem("\x1b[1mab\rc\x1b[m"),
+			expected: "\x1b[1mab" + red.Render("^M") + "\x1b[1mc\x1b[m",
+		},
+		{
+			name:     "multi-line",
+			item:     NewMultiLineItem(NewItem("ok"), NewItem("x\r")),
+			expected: "ok\nx" + red.Render("^M"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VisualizeControlChars(tt.item, red).Content()
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestVisualizeControlCharsByteRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		r        ByteRange
+		expected ByteRange
+	}{
+		{
+			name:     "before control characters",
+			content:  "ab\rc",
+			r:        ByteRange{Start: 0, End: 2},
+			expected: ByteRange{Start: 0, End: 2},
+		},
+		{
+			name:     "after a nul",
+			content:  "a\x00bc def",
+			r:        ByteRange{Start: 2, End: 4},
+			expected: ByteRange{Start: 3, End: 5},
+		},
+		{
+			name:     "covering a carriage return",
+			content:  "x\rbc",
+			r:        ByteRange{Start: 1, End: 3},
+			expected: ByteRange{Start: 1, End: 4},
+		},
+		{
+			name:     "newlines separate lines",
+			content:  "ok\nx\rbc",
+			r:        ByteRange{Start: 5, End: 7},
+			expected: ByteRange{Start: 6, End: 8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VisualizeControlCharsByteRange(tt.content, tt.r); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
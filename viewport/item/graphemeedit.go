@@ -0,0 +1,107 @@
+package item
+
+import (
+	"unicode/utf8"
+
+	"github.com/clipperhouse/displaywidth"
+)
+
+// Grapheme-aware editing of single-line text input. Cursors are rune positions, as used by text inputs like bubbles'
+// textinput, but edits only ever land between grapheme clusters, so emoji sequences, flags, CJK characters, and
+// characters with combining marks move and delete as a whole.
+
+// graphemeBoundaries returns the rune positions between the grapheme clusters of s, including 0 and the rune count
+func graphemeBoundaries(s string) []int {
+	boundaries := []int{0}
+	pos := 0
+	g := displaywidth.StringGraphemes(s)
+	for g.Next() {
+		pos += utf8.RuneCountInString(g.Value())
+		boundaries = append(boundaries, pos)
+	}
+	return boundaries
+}
+
+// GraphemeStart returns the rune position of the start of the grapheme cluster containing cursor, i.e. cursor if it's
+// already between clusters. Cursors outside s are clamped to it.
+func GraphemeStart(s string, cursor int) int {
+	res := 0
+	for _, b := range graphemeBoundaries(s) {
+		if b > cursor {
+			break
+		}
+		res = b
+	}
+	return res
+}
+
+// PrevGraphemeBoundary returns the rune position one grapheme cluster left of cursor, 0 at the start of s
+func PrevGraphemeBoundary(s string, cursor int) int {
+	res := 0
+	for _, b := range graphemeBoundaries(s) {
+		if b >= cursor {
+			break
+		}
+		res = b
+	}
+	return res
+}
+
+// NextGraphemeBoundary returns the rune position one grapheme cluster right of cursor, the rune count at the end of s
+func NextGraphemeBoundary(s string, cursor int) int {
+	boundaries := graphemeBoundaries(s)
+	for _, b := range boundaries {
+		if b > cursor {
+			return b
+		}
+	}
+	return boundaries[len(boundaries)-1]
+}
+
+// InsertAtGrapheme inserts text at cursor, moved to the start of its grapheme cluster, returning the new text and
+// the cursor after the insertion
+func InsertAtGrapheme(s string, cursor int, text string) (string, int) {
+	runes := []rune(s)
+	start := GraphemeStart(s, cursor)
+	inserted := []rune(text)
+	res := make([]rune, 0, len(runes)+len(inserted))
+	res = append(res, runes[:start]...)
+	res = append(res, inserted...)
+	res = append(res, runes[start:]...)
+	return string(res), start + len(inserted)
+}
+
+// DeleteGraphemeBackward deletes the grapheme cluster left of cursor, like backspace, returning the new text and
+// cursor
+func DeleteGraphemeBackward(s string, cursor int) (string, int) {
+	cursor = clampCursor(s, cursor)
+	start, end := GraphemeStart(s, cursor), cursor
+	if start < cursor {
+		// cursor is inside a cluster: delete the cluster it's in
+		end = NextGraphemeBoundary(s, start)
+	} else {
+		start = PrevGraphemeBoundary(s, cursor)
+	}
+	return deleteRunes(s, start, end), start
+}
+
+// DeleteGraphemeForward deletes the grapheme cluster right of cursor, like delete, returning the new text and cursor
+func DeleteGraphemeForward(s string, cursor int) (string, int) {
+	start := GraphemeStart(s, cursor)
+	end := NextGraphemeBoundary(s, start)
+	return deleteRunes(s, start, end), start
+}
+
+// deleteRunes removes the runes of s in [start, end)
+func deleteRunes(s string, start, end int) string {
+	if start >= end {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:start]) + string(runes[end:])
+}
+
+// clampCursor clamps cursor to the rune positions of s
+func clampCursor(s string, cursor int) int {
+	return min(max(cursor, 0), utf8.RuneCountInString(s))
+}
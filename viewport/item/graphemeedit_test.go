@@ -0,0 +1,85 @@
+package item
+
+import (
+	"testing"
+)
+
+const (
+	thumbsUp = "\U0001F44D\U0001F3FD"                       // thumbs up with a skin tone modifier, 2 runes
+	family   = "\U0001F468\u200d\U0001F469\u200d\U0001F467" // zwj sequence, 5 runes
+	accented = "e\u0301"                                    // e with a combining acute accent, 2 runes
+)
+
+func TestGraphemeBoundaries(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		cursor   int
+		start    int
+		prev     int
+		next     int
+		inserted string
+		insCur   int
+	}{
+		{name: "empty", s: "", cursor: 0, start: 0, prev: 0, next: 0, inserted: "x", insCur: 1},
+		{name: "ascii middle", s: "abc", cursor: 1, start: 1, prev: 0, next: 2, inserted: "axbc", insCur: 2},
+		{name: "cjk", s: "世界", cursor: 1, start: 1, prev: 0, next: 2, inserted: "世x界", insCur: 2},
+		{name: "after modifier sequence", s: "a" + thumbsUp + "b", cursor: 3, start: 3, prev: 1, next: 4, inserted: "a" + thumbsUp + "xb", insCur: 4},
+		{name: "inside modifier sequence", s: "a" + thumbsUp + "b", cursor: 2, start: 1, prev: 1, next: 3, inserted: "ax" + thumbsUp + "b", insCur: 2},
+		{name: "before zwj sequence", s: "a" + family, cursor: 1, start: 1, prev: 0, next: 6, inserted: "ax" + family, insCur: 2},
+		{name: "inside zwj sequence", s: "a" + family, cursor: 4, start: 1, prev: 1, next: 6, inserted: "ax" + family, insCur: 2},
+		{name: "combining mark", s: accented + "x", cursor: 2, start: 2, prev: 0, next: 3, inserted: accented + "xx", insCur: 3},
+		{name: "past end", s: "ab", cursor: 5, start: 2, prev: 2, next: 2, inserted: "abx", insCur: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := GraphemeStart(tt.s, tt.cursor); actual != tt.start {
+				t.Errorf("GraphemeStart: expected %d, got %d", tt.start, actual)
+			}
+			if actual := PrevGraphemeBoundary(tt.s, tt.cursor); actual != tt.prev {
+				t.Errorf("PrevGraphemeBoundary: expected %d, got %d", tt.prev, actual)
+			}
+			if actual := NextGraphemeBoundary(tt.s, tt.cursor); actual != tt.next {
+				t.Errorf("NextGraphemeBoundary: expected %d, got %d", tt.next, actual)
+			}
+			inserted, cursor := InsertAtGrapheme(tt.s, tt.cursor, "x")
+			if inserted != tt.inserted || cursor != tt.insCur {
+				t.Errorf("InsertAtGrapheme: expected %q at %d, got %q at %d", tt.inserted, tt.insCur, inserted, cursor)
+			}
+		})
+	}
+}
+
+func TestDeleteGrapheme(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		cursor     int
+		backward   string
+		backCur    int
+		forward    string
+		forwardCur int
+	}{
+		{name: "empty", s: "", cursor: 0, backward: "", backCur: 0, forward: "", forwardCur: 0},
+		{name: "ascii start", s: "abc", cursor: 0, backward: "abc", backCur: 0, forward: "bc", forwardCur: 0},
+		{name: "ascii end", s: "abc", cursor: 3, backward: "ab", backCur: 2, forward: "abc", forwardCur: 3},
+		{name: "cjk middle", s: "世界人", cursor: 2, backward: "世人", backCur: 1, forward: "世界", forwardCur: 2},
+		{name: "after modifier sequence", s: "a" + thumbsUp + "b", cursor: 3, backward: "ab", backCur: 1, forward: "a" + thumbsUp, forwardCur: 3},
+		{name: "before modifier sequence", s: "a" + thumbsUp + "b", cursor: 1, backward: thumbsUp + "b", backCur: 0, forward: "ab", forwardCur: 1},
+		{name: "inside zwj sequence", s: "a" + family + "b", cursor: 3, backward: "ab", backCur: 1, forward: "ab", forwardCur: 1},
+		{name: "after combining mark", s: "x" + accented, cursor: 3, backward: "x", backCur: 1, forward: "x" + accented, forwardCur: 3},
+		{name: "past end", s: "a" + family, cursor: 10, backward: "a", backCur: 1, forward: "a" + family, forwardCur: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if s, cursor := DeleteGraphemeBackward(tt.s, tt.cursor); s != tt.backward || cursor != tt.backCur {
+				t.Errorf("DeleteGraphemeBackward: expected %q at %d, got %q at %d", tt.backward, tt.backCur, s, cursor)
+			}
+			if s, cursor := DeleteGraphemeForward(tt.s, tt.cursor); s != tt.forward || cursor != tt.forwardCur {
+				t.Errorf("DeleteGraphemeForward: expected %q at %d, got %q at %d", tt.forward, tt.forwardCur, s, cursor)
+			}
+		})
+	}
+}
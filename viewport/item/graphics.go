@@ -0,0 +1,67 @@
+package item
+
+import (
+	"fmt"
+)
+
+// GraphicsItem implements Item for an image drawn by an opaque terminal graphics sequence, e.g. sixel or the kitty
+// graphics protocol, that covers a fixed block of cells. It's line broken into one line per row of cells. Its
+// content is a placeholder on the first row, which is what renders when the image can't be drawn whole. The viewport
+// writes the sequence unmodified in place of the placeholder when every row of the image is visible and unclipped.
+type GraphicsItem struct {
+	MultiLineItem
+	sequence    string
+	placeholder string
+	cols, rows  int
+}
+
+// type assertion that GraphicsItem implements Item
+var _ Item = GraphicsItem{}
+
+// NewGraphicsItem creates a GraphicsItem for sequence, which draws an image cols cells wide and rows cells high.
+// placeholder is shown instead of the image when it's clipped, e.g. "[image: chart.png]".
+func NewGraphicsItem(sequence string, cols, rows int, placeholder string) GraphicsItem {
+	cols, rows = max(0, cols), max(1, rows)
+	lines := make([]SingleItem, rows)
+	lines[0] = NewItem(placeholder)
+	for i := 1; i < rows; i++ {
+		lines[i] = NewItem("")
+	}
+	return GraphicsItem{
+		MultiLineItem: NewMultiLineItem(lines...),
+		sequence:      sequence,
+		placeholder:   placeholder,
+		cols:          cols,
+		rows:          rows,
+	}
+}
+
+// Sequence returns the terminal graphics sequence that draws the image
+func (g GraphicsItem) Sequence() string {
+	return g.sequence
+}
+
+// Placeholder returns the text shown instead of the image when it's clipped
+func (g GraphicsItem) Placeholder() string {
+	return g.placeholder
+}
+
+// Cols returns the width of the image in cells
+func (g GraphicsItem) Cols() int {
+	return g.cols
+}
+
+// Rows returns the height of the image in cells
+func (g GraphicsItem) Rows() int {
+	return g.rows
+}
+
+// repr returns a string representation of the GraphicsItem for debugging.
+func (g GraphicsItem) repr() string {
+	return fmt.Sprintf("Graphics(%dx%d, %q)", g.cols, g.rows, g.placeholder)
+}
+
+// String returns the content for fmt.Stringer compatibility.
+func (g GraphicsItem) String() string {
+	return fmt.Sprintf("GraphicsItem{cols=%d, rows=%d}", g.cols, g.rows)
+}
@@ -0,0 +1,75 @@
+package item
+
+import (
+	"testing"
+)
+
+func TestNewGraphicsItem(t *testing.T) {
+	tests := []struct {
+		name             string
+		cols, rows       int
+		placeholder      string
+		expectedRows     []string
+		expectedNumLines int
+	}{
+		{
+			name:             "placeholder on first row",
+			cols:             6,
+			rows:             2,
+			placeholder:      "[img]",
+			expectedRows:     []string{"[img]", ""},
+			expectedNumLines: 2,
+		},
+		{
+			name:             "placeholder wider than image",
+			cols:             2,
+			rows:             1,
+			placeholder:      "[img]",
+			expectedRows:     []string{"[img]"},
+			expectedNumLines: 1,
+		},
+		{
+			name:             "at least one row",
+			cols:             3,
+			rows:             0,
+			placeholder:      "",
+			expectedRows:     []string{""},
+			expectedNumLines: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGraphicsItem("\x1bPq#0~\x1b\\", tt.cols, tt.rows, tt.placeholder)
+			rows := g.LineBrokenItems()
+			if len(rows) != len(tt.expectedRows) {
+				t.Fatalf("expected %d rows, got %d", len(tt.expectedRows), len(rows))
+			}
+			for i, row := range rows {
+				if row.Content() != tt.expectedRows[i] {
+					t.Errorf("row %d: expected %q, got %q", i, tt.expectedRows[i], row.Content())
+				}
+			}
+			if n := NumLineBrokenItems(g); n != tt.expectedNumLines {
+				t.Errorf("expected %d line broken items, got %d", tt.expectedNumLines, n)
+			}
+			if g.Sequence() != "\x1bPq#0~\x1b\\" {
+				t.Errorf("unexpected sequence %q", g.Sequence())
+			}
+		})
+	}
+}
+
+func TestGraphicsItem_GraphemeCountAndWidthAt(t *testing.T) {
+	g := NewGraphicsItem("\x1bPq#0~\x1b\\", 6, 2, "[img]")
+	// the placeholder, the newline and the empty second row
+	if actual := g.GraphemeCount(); actual != 6 {
+		t.Errorf("expected 6 grapheme clusters, got %d", actual)
+	}
+	if actual := g.WidthAt(2); actual != 2 {
+		t.Errorf("expected 2 at byte 2, got %d", actual)
+	}
+	if actual := g.WidthAt(100); actual != 5 {
+		t.Errorf("expected the width past the end, got %d", actual)
+	}
+}
@@ -0,0 +1,70 @@
+package item
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// hexBytesPerLine is the number of bytes on each line of a hex dump
+const hexBytesPerLine = 16
+
+// LooksBinary returns whether content looks like binary data rather than text: it has a NUL byte, isn't valid UTF-8,
+// or more than a tenth of its bytes are control characters other than whitespace and escapes
+func LooksBinary(content string) bool {
+	if strings.IndexByte(content, 0) >= 0 || !utf8.ValidString(content) {
+		return true
+	}
+	numControl := 0
+	for i := 0; i < len(content); i++ {
+		switch b := content[i]; b {
+		case '\t', '\n', '\r', '\f', '\b', '\x1b':
+		default:
+			if isControlByte(b) {
+				numControl++
+			}
+		}
+	}
+	return numControl*10 > len(content)
+}
+
+// HexDump returns an item showing the bytes of content like hexdump -C, each line showing the offset of its first
+// byte, up to 16 bytes in hex, and those bytes as ASCII with a . for each that isn't printable
+func HexDump(content string) MultiLineItem {
+	numLines := max(1, (len(content)+hexBytesPerLine-1)/hexBytesPerLine)
+	lines := make([]SingleItem, numLines)
+	for i := range numLines {
+		start := i * hexBytesPerLine
+		lines[i] = NewItem(hexDumpLine(start, content[start:min(len(content), start+hexBytesPerLine)]))
+	}
+	return NewMultiLineItem(lines...)
+}
+
+// hexDumpLine returns the hex dump line of chunk, the bytes starting at offset
+func hexDumpLine(offset int, chunk string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%08x ", offset)
+	if len(chunk) == 0 {
+		return strings.TrimRight(b.String(), " ")
+	}
+	for i := range hexBytesPerLine {
+		if i%8 == 0 {
+			b.WriteByte(' ')
+		}
+		if i < len(chunk) {
+			fmt.Fprintf(&b, "%02x ", chunk[i])
+		} else {
+			b.WriteString("   ")
+		}
+	}
+	b.WriteString(" |")
+	for i := 0; i < len(chunk); i++ {
+		if c := chunk[i]; c >= 0x20 && c < 0x7f {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('.')
+		}
+	}
+	b.WriteByte('|')
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package item
+
+import (
+	"testing"
+)
+
+func TestLooksBinary(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{name: "text", content: "hello world", expected: false},
+		{name: "styled text", content: "\x1b[31mred\x1b[m\ttab", expected: false},
+		{name: "unicode", content: "héllo 世界", expected: false},
+		{name: "nul byte", content: "ab\x00cd", expected: true},
+		{name: "invalid utf-8", content: "ab\xffcd", expected: true},
+		{name: "few control bytes", content: "a stray bell\a in text", expected: false},
+		{name: "many control bytes", content: "\x01\x02ab\x03", expected: true},
+		{name: "empty", content: "", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LooksBinary(tt.content); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHexDump(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "empty",
+			content:  "",
+			expected: "00000000",
+		},
+		{
+			name:     "partial line",
+			content:  "Hi\x00\n",
+			expected: "00000000  48 69 00 0a                                       |Hi..|",
+		},
+		{
+			name:    "multiple lines",
+			content: "0123456789abcdef\xff",
+			expected: "00000000  30 31 32 33 34 35 36 37  38 39 61 62 63 64 65 66  |0123456789abcdef|\n" +
+				"00000010  ff                                                |.|",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HexDump(tt.content).Content(); got != tt.expected {
+				t.Errorf("expected\n%q\ngot\n%q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package item
+
+import (
+	"strings"
+)
+
+// hyperlinkIntroducer starts an OSC 8 hyperlink escape sequence, \x1b]8;params;URL followed by BEL or ST. An empty URL
+// ends the hyperlink.
+const hyperlinkIntroducer = "\x1b]8;"
+
+// Hyperlink is an OSC 8 hyperlink in an item's content
+type Hyperlink struct {
+	// URL is the hyperlink's target
+	URL string
+
+	// ByteRange is the range of the hyperlink's text in the item's unstyled content
+	ByteRange ByteRange
+}
+
+// extractHyperlinks returns the OSC 8 hyperlinks in line, with byte ranges in line's content without escape
+// sequences. A hyperlink left open runs to the end of the line, and one with no text is skipped.
+func extractHyperlinks(line string) []Hyperlink {
+	if !strings.Contains(line, hyperlinkIntroducer) {
+		return nil
+	}
+	var res []Hyperlink
+	url, start := "", 0
+	// offset converts a byte offset in line to one in its content without escape sequences
+	offset := func(i int) int {
+		return len(StripAnsi(stripNonSGR(line[:i])))
+	}
+	closeLink := func(end int) {
+		if url != "" && end > start {
+			res = append(res, Hyperlink{URL: url, ByteRange: ByteRange{Start: start, End: end}})
+		}
+		url = ""
+	}
+
+	for i := 0; i < len(line); {
+		j := strings.Index(line[i:], hyperlinkIntroducer)
+		if j < 0 {
+			break
+		}
+		seqStart := i + j
+		paramsStart := seqStart + len(hyperlinkIntroducer)
+		seqEnd, bodyEnd := len(line), len(line)
+		if k := strings.IndexAny(line[paramsStart:], "\x07\x1b"); k >= 0 {
+			bodyEnd = paramsStart + k
+			seqEnd = bodyEnd + 1
+			if line[bodyEnd] == '\x1b' {
+				seqEnd = min(len(line), bodyEnd+2)
+			}
+		}
+		_, target, _ := strings.Cut(line[paramsStart:bodyEnd], ";")
+
+		closeLink(offset(seqStart))
+		if target != "" {
+			url, start = target, offset(seqEnd)
+		}
+		i = seqEnd
+	}
+	closeLink(offset(len(line)))
+	return res
+}
+
+// Hyperlinks returns the OSC 8 hyperlinks in the content of it, with byte ranges in its unstyled content
+func Hyperlinks(it Item) []Hyperlink {
+	switch it := it.(type) {
+	case SingleItem:
+		return it.hyperlinks
+	case *SingleItem:
+		return it.hyperlinks
+	case MultiLineItem:
+		// lines are joined with \n
+		return offsetHyperlinks(it.items, 1)
+	case *MultiLineItem:
+		return offsetHyperlinks(it.items, 1)
+	case ConcatItem:
+		return offsetHyperlinks(it.items, 0)
+	case *ConcatItem:
+		return offsetHyperlinks(it.items, 0)
+	}
+	return nil
+}
+
+// offsetHyperlinks returns the hyperlinks of items as if their unstyled content was joined with sepLen bytes between
+// each item's
+func offsetHyperlinks(items []SingleItem, sepLen int) []Hyperlink {
+	var res []Hyperlink
+	offset := 0
+	for _, it := range items {
+		for _, h := range it.hyperlinks {
+			h.ByteRange.Start += offset
+			h.ByteRange.End += offset
+			res = append(res, h)
+		}
+		offset += len(it.lineNoAnsi) + sepLen
+	}
+	return res
+}
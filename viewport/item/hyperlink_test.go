@@ -0,0 +1,68 @@
+package item
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHyperlinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		expected []Hyperlink
+	}{
+		{
+			name:     "no hyperlinks",
+			item:     NewItem("plain \x1b[31mred\x1b[m"),
+			expected: nil,
+		},
+		{
+			name: "st terminated",
+			item: NewItem("see \x1b]8;;https://example.com\x1b\\docs\x1b]8;;\x1b\\ here"),
+			expected: []Hyperlink{
+				{URL: "https://example.com", ByteRange: ByteRange{Start: 4, End: 8}},
+			},
+		},
+		{
+			name: "bel terminated with params and styling",
+			item: NewItem("\x1b]8;id=1;https://a.example\x07\x1b[31mä\x1b[m\x1b]8;;\x07 \x1b]8;;https://b.example\x07b\x1b]8;;\x07"),
+			expected: []Hyperlink{
+				{URL: "https://a.example", ByteRange: ByteRange{Start: 0, End: 2}},
+				{URL: "https://b.example", ByteRange: ByteRange{Start: 3, End: 4}},
+			},
+		},
+		{
+			name: "left open runs to the end",
+			item: NewItem("a \x1b]8;;https://example.com\x07link"),
+			expected: []Hyperlink{
+				{URL: "https://example.com", ByteRange: ByteRange{Start: 2, End: 6}},
+			},
+		},
+		{
+			name:     "no text",
+			item:     NewItem("a\x1b]8;;https://example.com\x07\x1b]8;;\x07b"),
+			expected: nil,
+		},
+		{
+			name: "multi-line item",
+			item: NewMultiLineItem(NewItem("first"), NewItem("\x1b]8;;https://example.com\x07second\x1b]8;;\x07")),
+			expected: []Hyperlink{
+				{URL: "https://example.com", ByteRange: ByteRange{Start: 6, End: 12}},
+			},
+		},
+		{
+			name: "concat item",
+			item: NewConcat(NewItem("1 "), NewItem("\x1b]8;;https://example.com\x07x\x1b]8;;\x07")),
+			expected: []Hyperlink{
+				{URL: "https://example.com", ByteRange: ByteRange{Start: 2, End: 3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Hyperlinks(tt.item); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
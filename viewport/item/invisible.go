@@ -0,0 +1,138 @@
+package item
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	// trailingSpaceGlyph and trailingTabGlyph show trailing spaces and tabs
+	trailingSpaceGlyph = "·"
+	trailingTabGlyph   = "→"
+)
+
+// isZeroWidthInvisible returns whether r is a character that takes no space and shows nothing, e.g. a zero-width space
+// or byte order mark
+func isZeroWidthInvisible(r rune) bool {
+	switch r {
+	case '\u00ad', '\u200b', '\u200c', '\u200d', '\u200e', '\u200f', '\u2060', '\ufeff':
+		return true
+	}
+	return false
+}
+
+// hasZeroWidthInvisibles returns whether s has characters that take no space and show nothing
+func hasZeroWidthInvisibles(s string) bool {
+	return strings.ContainsFunc(s, isZeroWidthInvisible)
+}
+
+// invisibleGlyph returns the visible glyph shown for the invisible character r
+func invisibleGlyph(r rune) string {
+	switch r {
+	case ' ':
+		return trailingSpaceGlyph
+	case '\t':
+		return trailingTabGlyph
+	}
+	return fmt.Sprintf("<U+%04X>", r)
+}
+
+// VisualizeInvisibles returns itm with its trailing spaces and tabs shown as · and →, and characters that take no
+// space, like zero-width spaces, joiners and byte order marks, shown as their code point, e.g. <U+200B>, in the given
+// style, like an editor rendering whitespace. Control characters are left as they are, see VisualizeControlChars.
+// Items without invisible characters are returned unchanged, as are items other than SingleItem and MultiLineItem.
+func VisualizeInvisibles(itm Item, style lipgloss.Style) Item {
+	switch it := itm.(type) {
+	case SingleItem:
+		return visualizeInvisiblesSingle(it, style)
+	case MultiLineItem:
+		lines := make([]SingleItem, len(it.items))
+		changed := false
+		for i, line := range it.items {
+			lines[i] = visualizeInvisiblesSingle(line, style)
+			changed = changed || lines[i].Content() != line.Content()
+		}
+		if !changed {
+			return it
+		}
+		return NewMultiLineItem(lines...)
+	}
+	return itm
+}
+
+// VisualizeInvisiblesByteRange maps r, a byte range of the unstyled content contentNoAnsi, to the byte range of the
+// same content with its invisible characters shown by VisualizeInvisibles. A range covering an invisible character
+// covers its glyph. Newlines are taken to separate the lines of a MultiLineItem, each with its own trailing whitespace.
+func VisualizeInvisiblesByteRange(contentNoAnsi string, r ByteRange) ByteRange {
+	start, end := r.Start, r.End
+	// lineStart is the byte offset in contentNoAnsi of the line being mapped
+	lineStart := 0
+	for lineStart < min(r.End, len(contentNoAnsi)) {
+		line := contentNoAnsi[lineStart:]
+		if n := strings.IndexByte(line, '\n'); n >= 0 {
+			line = line[:n]
+		}
+		trailingStart := len(strings.TrimRight(line, " \t"))
+		for i := 0; i < len(line) && lineStart+i < r.End; {
+			c, size := utf8.DecodeRuneInString(line[i:])
+			if i >= trailingStart || isZeroWidthInvisible(c) {
+				extra := len(invisibleGlyph(c)) - size
+				if lineStart+i < r.Start {
+					start += extra
+				}
+				end += extra
+			}
+			i += size
+		}
+		lineStart += len(line) + 1
+	}
+	return ByteRange{Start: start, End: end}
+}
+
+// visualizeInvisiblesSingle shows the invisible characters of a single line
+func visualizeInvisiblesSingle(it SingleItem, style lipgloss.Style) SingleItem {
+	line := it.Content()
+	noAnsi := it.ContentNoAnsi()
+	// trailingStart is the byte offset in the unstyled content at which trailing whitespace starts
+	trailingStart := len(strings.TrimRight(noAnsi, " \t"))
+	if trailingStart == len(noAnsi) && !hasZeroWidthInvisibles(noAnsi) {
+		return it
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(line) + 16)
+	ansiRanges := it.ansiCodeIndexes
+	// active is the styling in effect, reapplied after each styled glyph resets it
+	var active []string
+	// noAnsiOffset is the byte offset in the unstyled content of the byte at i
+	noAnsiOffset := 0
+	for i := 0; i < len(line); {
+		if len(ansiRanges) > 0 && int(ansiRanges[0][0]) == i {
+			code := line[ansiRanges[0][0]:ansiRanges[0][1]]
+			if isResetCode(code) {
+				active = active[:0]
+			} else {
+				active = append(active, code)
+			}
+			builder.WriteString(code)
+			i = int(ansiRanges[0][1])
+			ansiRanges = ansiRanges[1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if noAnsiOffset >= trailingStart || isZeroWidthInvisible(r) {
+			builder.WriteString(style.Render(invisibleGlyph(r)))
+			for _, code := range active {
+				builder.WriteString(code)
+			}
+		} else {
+			builder.WriteString(line[i : i+size])
+		}
+		i += size
+		noAnsiOffset += size
+	}
+	return NewItem(builder.String())
+}
@@ -0,0 +1,91 @@
+package item
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestVisualizeInvisibles(t *testing.T) {
+	faint := lipgloss.NewStyle().Faint(true)
+	tests := []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{
+			name:     "no invisibles",
+			item:     NewItem("a b\tc"),
+			expected: "a b\tc",
+		},
+		{
+			name:     "trailing whitespace",
+			item:     NewItem("ab \t "),
+			expected: "ab" + faint.Render("·") + faint.Render("→") + faint.Render("·"),
+		},
+		{
+			name:     "zero-width characters",
+			item:     NewItem("a\u200bb\ufeff"),
+			expected: "a" + faint.Render("<U+200B>") + "b" + faint.Render("<U+FEFF>"),
+		},
+		{
+			name:     "styling reapplied after glyph",
+			item:     NewItem("\x1b[1ma\u200dc \x1b[m"),
+			expected: "\x1b[1ma" + faint.Render("<U+200D>") + "\x1b[1mc" + faint.Render("·") + "\x1b[1m\x1b[m",
+		},
+		{
+			name:     "multi-line",
+			item:     NewMultiLineItem(NewItem("ok"), NewItem("x ")),
+			expected: "ok\nx" + faint.Render("·"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := VisualizeInvisibles(tt.item, faint).Content()
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestVisualizeInvisiblesByteRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		r        ByteRange
+		expected ByteRange
+	}{
+		{
+			name:     "no invisibles",
+			content:  "a b\tc",
+			r:        ByteRange{Start: 2, End: 5},
+			expected: ByteRange{Start: 2, End: 5},
+		},
+		{
+			name:     "after a zero-width space",
+			content:  "a\u200bbc def",
+			r:        ByteRange{Start: 4, End: 6},
+			expected: ByteRange{Start: 9, End: 11},
+		},
+		{
+			name:     "covering trailing spaces",
+			content:  "ab  ",
+			r:        ByteRange{Start: 0, End: 4},
+			expected: ByteRange{Start: 0, End: 6},
+		},
+		{
+			name:     "newlines separate lines",
+			content:  "x \nab ",
+			r:        ByteRange{Start: 3, End: 5},
+			expected: ByteRange{Start: 4, End: 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VisualizeInvisiblesByteRange(tt.content, tt.r); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
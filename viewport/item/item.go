@@ -13,6 +13,13 @@ type Item interface {
 	// ContentNoAnsi returns the underlying complete string without ANSI escape codes that style the string
 	ContentNoAnsi() string
 
+	// GraphemeCount returns the number of grapheme clusters, i.e. user-perceived characters, in ContentNoAnsi
+	GraphemeCount() int
+
+	// WidthAt returns the cell offset at which the grapheme cluster containing byteOffset, a byte offset in
+	// ContentNoAnsi, starts. Offsets past the end map to Width.
+	WidthAt(byteOffset int) int
+
 	// Take takes a substring (line) of the content with a specified widthToLeft and taking takeWidth.
 	// continuation replaces the start and end if the content exceeds the bounds.
 	// highlights is a list of highlights to apply to the taken content.
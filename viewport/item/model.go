@@ -24,4 +24,8 @@ type Match struct {
 type Highlight struct {
 	Style                    lipgloss.Style
 	ByteRangeUnstyledContent ByteRange
+
+	// Priority decides which highlight shows where highlights overlap, higher over lower. Among equal priorities the
+	// one starting first shows, and the rest of a later one shows after it ends.
+	Priority int
 }
@@ -41,11 +41,87 @@ func NewMultiLineItem(items ...SingleItem) MultiLineItem {
 	}
 }
 
+// NewMultiLineItemFromString creates a new MultiLineItem from content that may contain newlines, with one
+// line-broken item per line. "\r\n" line endings are treated as "\n". ANSI styles still active at the end of a
+// line are reapplied at the start of the next, so styling that spans lines renders on each of them.
+func NewMultiLineItemFromString(content string) MultiLineItem {
+	if strings.Contains(content, "\r\n") {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+	lines := strings.Split(content, "\n")
+	items := make([]SingleItem, len(lines))
+	var active string
+	for i, line := range lines {
+		items[i] = NewItem(active + line)
+		active = activeAnsiAtEnd(items[i])
+	}
+	return NewMultiLineItem(items...)
+}
+
+// activeAnsiAtEnd returns the ANSI codes still in effect at the end of the item's line
+func activeAnsiAtEnd(l SingleItem) string {
+	if len(l.ansiCodeIndexes) == 0 {
+		return ""
+	}
+	codes := make([]string, len(l.ansiCodeIndexes))
+	for i, r := range l.ansiCodeIndexes {
+		codes[i] = l.line[r[0]:r[1]]
+	}
+	var builder strings.Builder
+	for _, code := range simplifyAnsiCodes(codes) {
+		if !isResetCode(code) {
+			builder.WriteString(code)
+		}
+	}
+	return builder.String()
+}
+
+// NumLineBrokenItems returns the number of line-broken items in itm, i.e. len(itm.LineBrokenItems()),
+// without allocating.
+func NumLineBrokenItems(itm Item) int {
+	switch v := itm.(type) {
+	case MultiLineItem:
+		return max(1, len(v.items))
+	case *MultiLineItem:
+		return max(1, len(v.items))
+	case GraphicsItem:
+		return max(1, len(v.items))
+	}
+	return 1
+}
+
 // Width returns the total width in cells across all line-broken items.
 func (m MultiLineItem) Width() int {
 	return m.totalWidth
 }
 
+// GraphemeCount returns the number of grapheme clusters in the content of all items, counting the newlines between
+// them.
+func (m MultiLineItem) GraphemeCount() int {
+	if len(m.items) == 0 {
+		return 0
+	}
+	n := len(m.items) - 1
+	for _, it := range m.items {
+		n += it.GraphemeCount()
+	}
+	return n
+}
+
+// WidthAt returns the cell offset, across all line-broken items like Width, at which the grapheme cluster containing
+// byteOffset starts. A newline maps to the start of the line after it, and offsets past the end map to Width.
+func (m MultiLineItem) WidthAt(byteOffset int) int {
+	if len(m.items) == 0 {
+		return 0
+	}
+	lineByteOffsets, lineWidthOffsets := m.computeOffsets()
+	if byteOffset >= lineByteOffsets[len(m.items)] {
+		return m.Width()
+	}
+	lineIdx, localByte := m.findLineForByteOffset(byteOffset, lineByteOffsets)
+	return lineWidthOffsets[lineIdx] + m.items[lineIdx].WidthAt(localByte)
+}
+
 // Content returns the content of all items joined with newlines.
 func (m MultiLineItem) Content() string {
 	if m.content != "" {
@@ -45,6 +45,55 @@ func TestMultiLineItem_Width(t *testing.T) {
 	}
 }
 
+func TestMultiLineItem_GraphemeCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    []SingleItem
+		expected int
+	}{
+		{name: "empty", items: nil, expected: 0},
+		{name: "single item", items: []SingleItem{NewItem("hello")}, expected: 5},
+		{name: "newlines count", items: []SingleItem{NewItem("ab"), NewItem(""), NewItem("c")}, expected: 5},
+		{name: "emoji zwj sequence", items: []SingleItem{NewItem("a世"), NewItem("👨‍👩‍👧b")}, expected: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := NewMultiLineItem(tt.items...).GraphemeCount(); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMultiLineItem_WidthAt(t *testing.T) {
+	// a (1w, 1b), 世 (2w, 3b), \n (1b), 👨‍👩‍👧 (2w, 18b), b (1w, 1b)
+	m := NewMultiLineItem(NewItem("a世"), NewItem("👨‍👩‍👧b"))
+	tests := []struct {
+		name       string
+		byteOffset int
+		expected   int
+	}{
+		{name: "start", byteOffset: 0, expected: 0},
+		{name: "inside wide rune", byteOffset: 2, expected: 1},
+		{name: "newline starts the next line", byteOffset: 4, expected: 3},
+		{name: "inside emoji zwj sequence on second line", byteOffset: 10, expected: 3},
+		{name: "after emoji zwj sequence", byteOffset: 23, expected: 5},
+		{name: "past end", byteOffset: 100, expected: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := m.WidthAt(tt.byteOffset); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+	if actual := NewMultiLineItem().WidthAt(0); actual != 0 {
+		t.Errorf("expected 0 for an empty item, got %d", actual)
+	}
+}
+
 func TestMultiLineItem_Content(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -162,6 +211,58 @@ func TestMultiLineItem_LineBrokenItems(t *testing.T) {
 	}
 }
 
+func TestNewMultiLineItemFromString(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "no newline",
+			content:  "hello",
+			expected: []string{"hello"},
+		},
+		{
+			name:     "newlines",
+			content:  "hello\nworld\n",
+			expected: []string{"hello", "world", ""},
+		},
+		{
+			name:     "crlf",
+			content:  "hello\r\nworld",
+			expected: []string{"hello", "world"},
+		},
+		{
+			name:     "style carried across lines",
+			content:  "\x1b[31mhello\nworld\x1b[m\nplain",
+			expected: []string{"\x1b[31mhello", "\x1b[31mworld\x1b[m", "plain"},
+		},
+		{
+			name:     "reset style not carried",
+			content:  "\x1b[31mhello\x1b[m\nworld",
+			expected: []string{"\x1b[31mhello\x1b[m", "world"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMultiLineItemFromString(tt.content)
+			broken := m.LineBrokenItems()
+			if len(broken) != len(tt.expected) {
+				t.Fatalf("expected %d line-broken items, got %d", len(tt.expected), len(broken))
+			}
+			for i := range broken {
+				if broken[i].Content() != tt.expected[i] {
+					t.Errorf("line %d: expected %q, got %q", i, tt.expected[i], broken[i].Content())
+				}
+			}
+			if NumLineBrokenItems(m) != len(tt.expected) {
+				t.Errorf("expected NumLineBrokenItems %d, got %d", len(tt.expected), NumLineBrokenItems(m))
+			}
+		})
+	}
+}
+
 func TestMultiLineItem_Take_Panics(t *testing.T) {
 	m := NewMultiLineItem(NewItem("hello"), NewItem("world"))
 	defer func() {
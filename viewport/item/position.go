@@ -0,0 +1,48 @@
+package item
+
+import (
+	"github.com/clipperhouse/displaywidth"
+)
+
+// ByteOffsetAtCell returns the byte offset in content, a single line without ANSI codes, of the grapheme cluster
+// rendered at the given cell offset. A cell in the middle of a wide cluster maps to the start of that cluster. Cells
+// past the end of content map to len(content).
+func ByteOffsetAtCell(content string, cell int) int {
+	if cell <= 0 {
+		return 0
+	}
+	byteOffset, cellsToLeft := 0, 0
+	g := displaywidth.StringGraphemes(content)
+	for g.Next() {
+		cellsToLeft += g.Width()
+		if cellsToLeft > cell {
+			return byteOffset
+		}
+		byteOffset += len(g.Value())
+	}
+	return len(content)
+}
+
+// CellAtByteOffset returns the cell offset in content, a single line without ANSI codes, at which the grapheme
+// cluster starting at byteOffset is rendered. It's the inverse of ByteOffsetAtCell. A byte in the middle of a cluster,
+// e.g. in an emoji ZWJ sequence, maps to the cell after that cluster, and offsets past the end of content map to its
+// width.
+func CellAtByteOffset(content string, byteOffset int) int {
+	i, cell := 0, 0
+	g := displaywidth.StringGraphemes(content)
+	for g.Next() && i < byteOffset {
+		cell += g.Width()
+		i += len(g.Value())
+	}
+	return cell
+}
+
+// GraphemeCount returns the number of grapheme clusters, i.e. user-perceived characters, in content
+func GraphemeCount(content string) int {
+	n := 0
+	g := displaywidth.StringGraphemes(content)
+	for g.Next() {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,80 @@
+package item
+
+import (
+	"testing"
+)
+
+func TestByteOffsetAtCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		cell     int
+		expected int
+	}{
+		{name: "start", content: "hello", cell: 0, expected: 0},
+		{name: "middle", content: "hello", cell: 3, expected: 3},
+		{name: "past end", content: "hello", cell: 10, expected: 5},
+		{name: "negative", content: "hello", cell: -1, expected: 0},
+		{name: "empty", content: "", cell: 2, expected: 0},
+		{name: "wide rune first cell", content: "a世b", cell: 1, expected: 1},
+		{name: "wide rune second cell", content: "a世b", cell: 2, expected: 1},
+		{name: "after wide rune", content: "a世b", cell: 3, expected: 4},
+		{name: "emoji zwj sequence second cell", content: "a👨‍👩‍👧b", cell: 2, expected: 1},
+		{name: "after emoji zwj sequence", content: "a👨‍👩‍👧b", cell: 3, expected: 19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := ByteOffsetAtCell(tt.content, tt.cell); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCellAtByteOffset(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		byteOffset int
+		expected   int
+	}{
+		{name: "start", content: "hello", byteOffset: 0, expected: 0},
+		{name: "middle", content: "hello", byteOffset: 3, expected: 3},
+		{name: "past end", content: "hello", byteOffset: 10, expected: 5},
+		{name: "wide rune", content: "a世b", byteOffset: 1, expected: 1},
+		{name: "inside wide rune", content: "a世b", byteOffset: 2, expected: 3},
+		{name: "after wide rune", content: "a世b", byteOffset: 4, expected: 3},
+		{name: "inside emoji zwj sequence", content: "a👨‍👩‍👧b", byteOffset: 8, expected: 3},
+		{name: "after emoji zwj sequence", content: "a👨‍👩‍👧b", byteOffset: 19, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := CellAtByteOffset(tt.content, tt.byteOffset); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{name: "empty", content: "", expected: 0},
+		{name: "simple", content: "hello", expected: 5},
+		{name: "emoji zwj sequence", content: "a👨‍👩‍👧b", expected: 3},
+		{name: "combining marks", content: "é̂x", expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := GraphemeCount(tt.content); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package item
 
 import (
 	"fmt"
+	"math/bits"
 	"regexp"
 	"strings"
 	"unicode/utf8"
@@ -12,13 +13,15 @@ import (
 // SingleItem provides functionality to get sequential strings of a specified terminal cell width, accounting
 // for the ansi escape codes styling the line.
 type SingleItem struct {
-	line                 string     // underlying string with ansi codes. utf-8 encoded bytes
-	lineNoAnsi           string     // line without ansi codes. utf-8 encoded bytes
-	lineNoAnsiRuneWidths []uint8    // packed terminal cell widths, 4 widths per byte (2 bits each)
-	ansiCodeIndexes      [][]uint32 // slice of startByte, endByte indexes of ansi codes
-	numNoAnsiRunes       int        // number of runes in lineNoAnsi
-	totalWidth           int        // total width in terminal cells
-	fillStyle            string     // ANSI code to use when filling remaining width (emulates \x1b[K])
+	line                 string      // underlying string with ansi codes. utf-8 encoded bytes
+	lineNoAnsi           string      // line without ansi codes. utf-8 encoded bytes
+	lineNoAnsiRuneWidths []uint8     // packed terminal cell widths, 4 widths per byte (2 bits each)
+	clusterContinuations []uint8     // bit per rune, set if it continues a grapheme cluster. nil if none do
+	ansiCodeIndexes      [][]uint32  // slice of startByte, endByte indexes of ansi codes
+	numNoAnsiRunes       int         // number of runes in lineNoAnsi
+	totalWidth           int         // total width in terminal cells
+	fillStyle            string      // ANSI code to use when filling remaining width (emulates \x1b[K])
+	hyperlinks           []Hyperlink // OSC 8 hyperlinks, stripped from line
 
 	sparsity                        int      // interval for which to store cumulative cell width
 	sparseRuneIdxToNoAnsiByteOffset []uint32 // rune idx to byte offset of lineNoAnsi, stored every sparsity runes
@@ -75,6 +78,7 @@ func NewItem(line string) SingleItem {
 		line = strings.ReplaceAll(line, "\x1b[K", "")
 	}
 
+	hyperlinks := extractHyperlinks(line)
 	line = stripNonSGR(line)
 
 	if len(line) <= 0 {
@@ -88,9 +92,10 @@ func NewItem(line string) SingleItem {
 	}
 
 	item := SingleItem{
-		line:      line,
-		sparsity:  sparsity,
-		fillStyle: fillStyle,
+		line:       line,
+		sparsity:   sparsity,
+		fillStyle:  fillStyle,
+		hyperlinks: hyperlinks,
 	}
 
 	item.ansiCodeIndexes = findAnsiByteRanges(line)
@@ -127,11 +132,7 @@ func NewItem(line string) SingleItem {
 	var currentOffset uint32
 	var cumWidth uint32
 	runeIdx := 0
-	for byteOffset := 0; byteOffset < len(item.lineNoAnsi); {
-		r, runeNumBytes := utf8.DecodeRuneInString(item.lineNoAnsi[byteOffset:])
-		rw := displaywidth.Rune(r)
-		width := clampIntToUint8(rw)
-
+	addRune := func(width uint8, runeNumBytes int) {
 		// pack 4 widths per byte (2 bits each)
 		packedIdx := runeIdx / 4
 		bitPos := (runeIdx % 4) * 2
@@ -149,13 +150,50 @@ func NewItem(line string) SingleItem {
 		}
 		currentOffset += clampIntToUint32(runeNumBytes)
 		runeIdx++
-		byteOffset += runeNumBytes
+	}
+
+	if isSingleByteClusters(item.lineNoAnsi) {
+		for i := 0; i < len(item.lineNoAnsi); i++ {
+			addRune(clampIntToUint8(displaywidth.Rune(rune(item.lineNoAnsi[i]))), 1)
+		}
+	} else {
+		// each grapheme cluster's width is given to its first rune, so that clusters like emoji ZWJ sequences and
+		// characters with combining marks are measured, and taken, as a whole
+		g := displaywidth.StringGraphemes(item.lineNoAnsi)
+		for g.Next() {
+			cluster := g.Value()
+			for i := 0; i < len(cluster); {
+				_, runeNumBytes := utf8.DecodeRuneInString(cluster[i:])
+				var width uint8
+				if i == 0 {
+					width = clampIntToUint8(g.Width())
+				} else {
+					if item.clusterContinuations == nil {
+						item.clusterContinuations = make([]uint8, (numRunes+7)/8)
+					}
+					item.clusterContinuations[runeIdx/8] |= 1 << (runeIdx % 8)
+				}
+				addRune(width, runeNumBytes)
+				i += runeNumBytes
+			}
+		}
 	}
 	item.numNoAnsiRunes = runeIdx
 
 	return item
 }
 
+// isSingleByteClusters returns whether every byte of s is a grapheme cluster of its own, i.e. s is ASCII without
+// carriage returns, which cluster with a following line feed
+func isSingleByteClusters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf || s[i] == '\r' {
+			return false
+		}
+	}
+	return true
+}
+
 // Width returns the total width in terminal cells.
 func (l SingleItem) Width() int {
 	if len(l.line) == 0 {
@@ -164,6 +202,29 @@ func (l SingleItem) Width() int {
 	return l.totalWidth
 }
 
+// GraphemeCount returns the number of grapheme clusters, i.e. user-perceived characters, in the content without ANSI
+// codes. An emoji ZWJ sequence like a family or a character with combining marks counts once.
+func (l SingleItem) GraphemeCount() int {
+	n := l.numNoAnsiRunes
+	for _, b := range l.clusterContinuations {
+		n -= bits.OnesCount8(b)
+	}
+	return n
+}
+
+// WidthAt returns the cell offset at which the grapheme cluster containing byteOffset, a byte offset in
+// ContentNoAnsi, starts. Offsets past the end map to Width.
+func (l SingleItem) WidthAt(byteOffset int) int {
+	if byteOffset >= len(l.lineNoAnsi) {
+		return l.Width()
+	}
+	runeIdx := l.clusterStartRuneIdx(l.getRuneIndexAtByteOffset(byteOffset))
+	if runeIdx == 0 {
+		return 0
+	}
+	return int(l.getCumulativeWidthAtRuneIdx(runeIdx - 1))
+}
+
 // Content returns the underlying string content
 func (l SingleItem) Content() string {
 	return l.line
@@ -216,7 +277,7 @@ func (l SingleItem) Take(
 
 	// if only zero-width runes were written, return ""
 	for i := 0; i < runesWritten; i++ {
-		if displaywidth.Rune(l.runeAt(startRuneIdx+i)) > 0 {
+		if l.getRuneWidth(startRuneIdx+i) > 0 {
 			break
 		}
 		if i == runesWritten-1 {
@@ -224,12 +285,11 @@ func (l SingleItem) Take(
 		}
 	}
 
-	// write the subsequent zero-width runes, e.g. the accent on an 'e'
+	// write the subsequent zero-width runes, e.g. the accent on an 'e' or the rest of an emoji ZWJ sequence
 	if result.Len() > 0 {
 		for ; leftRuneIdx < l.numNoAnsiRunes; leftRuneIdx++ {
-			r := l.runeAt(leftRuneIdx)
-			if displaywidth.Rune(r) == 0 {
-				result.WriteRune(r)
+			if l.getRuneWidth(leftRuneIdx) == 0 {
+				result.WriteRune(l.runeAt(leftRuneIdx))
 			} else {
 				break
 			}
@@ -252,7 +312,7 @@ func (l SingleItem) Take(
 	}
 	res = highlightString(
 		res,
-		highlights,
+		l.clusterHighlights(highlights),
 		int(startByteOffset),
 		endByteOffset,
 	)
@@ -379,6 +439,56 @@ func (l SingleItem) getRuneIndexAtByteOffset(byteOffset int) int {
 	return right
 }
 
+// continuesCluster returns whether the rune at runeIdx continues the grapheme cluster of the rune before it
+func (l SingleItem) continuesCluster(runeIdx int) bool {
+	if l.clusterContinuations == nil || runeIdx < 0 || runeIdx >= l.numNoAnsiRunes {
+		return false
+	}
+	return l.clusterContinuations[runeIdx/8]&(1<<(runeIdx%8)) != 0
+}
+
+// clusterStartRuneIdx returns the index of the first rune of the grapheme cluster containing the rune at runeIdx
+func (l SingleItem) clusterStartRuneIdx(runeIdx int) int {
+	for runeIdx > 0 && l.continuesCluster(runeIdx) {
+		runeIdx--
+	}
+	return runeIdx
+}
+
+// clusterHighlights returns highlights with their byte ranges widened to whole grapheme clusters, so that styling
+// never splits one
+func (l SingleItem) clusterHighlights(highlights []Highlight) []Highlight {
+	if l.clusterContinuations == nil || len(highlights) == 0 {
+		return highlights
+	}
+	res := make([]Highlight, len(highlights))
+	for i, h := range highlights {
+		r := h.ByteRangeUnstyledContent
+		if r.Start > 0 && r.Start < len(l.lineNoAnsi) {
+			startRuneIdx := l.clusterStartRuneIdx(l.getRuneIndexAtByteOffset(r.Start))
+			r.Start = int(l.getByteOffsetAtRuneIdx(startRuneIdx))
+		}
+		if r.End > 0 && r.End < len(l.lineNoAnsi) {
+			endRuneIdx := l.getRuneIndexAtByteOffset(r.End)
+			if int(l.getByteOffsetAtRuneIdx(endRuneIdx)) < r.End {
+				// r.End is in the middle of a rune
+				endRuneIdx++
+			}
+			for endRuneIdx < l.numNoAnsiRunes && l.continuesCluster(endRuneIdx) {
+				endRuneIdx++
+			}
+			if endRuneIdx < l.numNoAnsiRunes {
+				r.End = int(l.getByteOffsetAtRuneIdx(endRuneIdx))
+			} else {
+				r.End = len(l.lineNoAnsi)
+			}
+		}
+		h.ByteRangeUnstyledContent = r
+		res[i] = h
+	}
+	return res
+}
+
 // getRuneWidth extracts the width of a rune from the packed array
 func (l SingleItem) getRuneWidth(runeIdx int) uint8 {
 	if runeIdx < 0 || runeIdx >= l.numNoAnsiRunes {
@@ -469,9 +579,9 @@ func (l SingleItem) ByteRangesToMatches(byteRanges []ByteRange) []Match {
 	return matches
 }
 
-// byteRangeToWidthRange converts a byte range to a width range for a SingleItem.
+// byteRangeToWidthRange converts a byte range to a width range for a SingleItem, widened to whole grapheme clusters.
 func (l SingleItem) byteRangeToWidthRange(startByte, endByte int) (startWidth, endWidth int) {
-	startRuneIdx := l.getRuneIndexAtByteOffset(startByte)
+	startRuneIdx := l.clusterStartRuneIdx(l.getRuneIndexAtByteOffset(startByte))
 	endRuneIdx := l.getRuneIndexAtByteOffset(endByte)
 
 	if startRuneIdx > 0 {
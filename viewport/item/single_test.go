@@ -81,6 +81,57 @@ func TestSingle_Content(t *testing.T) {
 	}
 }
 
+func TestSingle_GraphemeCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		expected int
+	}{
+		{name: "empty", s: "", expected: 0},
+		{name: "simple", s: "hello", expected: 5},
+		{name: "ansi", s: "\x1b[38;2;255;0;0mhi" + RST, expected: 2},
+		{name: "emoji zwj sequence", s: "a👨‍👩‍👧b", expected: 3},
+		{name: "flag", s: "🇺🇸", expected: 1},
+		{name: "combining marks", s: "é̂", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := NewItem(tt.s).GraphemeCount(); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSingle_WidthAt(t *testing.T) {
+	tests := []struct {
+		name       string
+		s          string
+		byteOffset int
+		expected   int
+	}{
+		{name: "start", s: "hello", byteOffset: 0, expected: 0},
+		{name: "middle", s: "hello", byteOffset: 3, expected: 3},
+		{name: "past end", s: "hello", byteOffset: 10, expected: 5},
+		{name: "ansi", s: "\x1b[31mab" + RST + "c", byteOffset: 2, expected: 2},
+		{name: "inside wide rune", s: "a世b", byteOffset: 2, expected: 1},
+		{name: "after wide rune", s: "a世b", byteOffset: 4, expected: 3},
+		// a (1b), 👨‍👩‍👧 (2w, 18b), b (1b)
+		{name: "emoji zwj sequence", s: "a👨‍👩‍👧b", byteOffset: 1, expected: 1},
+		{name: "inside emoji zwj sequence", s: "a👨‍👩‍👧b", byteOffset: 8, expected: 1},
+		{name: "after emoji zwj sequence", s: "a👨‍👩‍👧b", byteOffset: 19, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := NewItem(tt.s).WidthAt(tt.byteOffset); actual != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestSingle_Take(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -867,6 +918,64 @@ func TestSingle_Take(t *testing.T) {
 				"中é",
 			},
 		},
+		{
+			name: "emoji zwj sequence is taken whole",
+			// 👨‍👩‍👧 is a single 2w grapheme cluster of 5 runes
+			s:            "a👨‍👩‍👧b",
+			width:        2,
+			continuation: "",
+			numTakes:     3,
+			expected: []string{
+				"a",
+				"👨‍👩‍👧",
+				"b",
+			},
+		},
+		{
+			name:         "flags are taken whole",
+			s:            "🇺🇸🇨🇦",
+			width:        3,
+			continuation: "",
+			numTakes:     2,
+			expected: []string{
+				"🇺🇸",
+				"🇨🇦",
+			},
+		},
+		{
+			name:         "combining marks stay with their base",
+			s:            "é̂x",
+			width:        1,
+			continuation: "",
+			numTakes:     2,
+			expected: []string{
+				"é̂",
+				"x",
+			},
+		},
+		{
+			name:         "continuation replaces a whole emoji zwj sequence",
+			s:            "a👨‍👩‍👧bc",
+			width:        4,
+			continuation: "..",
+			startWidth:   1,
+			numTakes:     1,
+			expected: []string{
+				"..bc",
+			},
+		},
+		{
+			name:           "highlight inside emoji zwj sequence styles the whole sequence",
+			s:              "a👨‍👩‍👧b",
+			width:          4,
+			continuation:   "",
+			toHighlight:    "👩",
+			highlightStyle: internal.RedBg,
+			numTakes:       1,
+			expected: []string{
+				"a" + internal.RedBg.Render("👨‍👩‍👧") + "b",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1963,6 +2072,20 @@ func TestSingleItem_ByteRangesToMatches(t *testing.T) {
 				},
 			},
 		},
+		{
+			// a (1b), 👨‍👩‍👧 (2w, 18b), b (1b)
+			name: "range inside emoji zwj sequence widens to the whole sequence",
+			s:    "a👨‍👩‍👧b",
+			byteRanges: []ByteRange{
+				{Start: 8, End: 12},
+			},
+			expected: []Match{
+				{
+					ByteRange:  ByteRange{Start: 8, End: 12},
+					WidthRange: WidthRange{Start: 1, End: 3},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -65,6 +65,21 @@ func overflowsRight(s string, endByteIdx int, substr string) (bool, int) {
 	return false, 0
 }
 
+// clusterRuneWidths returns the width of each rune of s: the width of its grapheme cluster for the first rune of a
+// cluster, and 0 for the rest, so that a cluster is replaced as a whole. ANSI escape sequences are zero width.
+func clusterRuneWidths(s string) []int {
+	widths := make([]int, 0, len(s))
+	g := displaywidth.Options{ControlSequences: true}.StringGraphemes(s)
+	for g.Next() {
+		w := g.Width()
+		for range g.Value() {
+			widths = append(widths, w)
+			w = 0
+		}
+	}
+	return widths
+}
+
 func replaceStartWithContinuation(s string, continuationRunes []rune) string {
 	if len(s) == 0 || len(continuationRunes) == 0 {
 		return s
@@ -73,6 +88,7 @@ func replaceStartWithContinuation(s string, continuationRunes []rune) string {
 	var sb strings.Builder
 	ansiCodeIndexes := findAnsiRuneRanges(s)
 	runes := []rune(s)
+	runeWidths := clusterRuneWidths(s)
 
 	for runeIdx := 0; runeIdx < len(runes); {
 		if len(ansiCodeIndexes) > 0 {
@@ -88,7 +104,7 @@ func replaceStartWithContinuation(s string, continuationRunes []rune) string {
 			}
 		}
 		if len(continuationRunes) > 0 {
-			rWidth := displaywidth.Rune(runes[runeIdx])
+			rWidth := runeWidths[runeIdx]
 
 			// if rune is wider than remaining continuation width, cut off the continuation
 			remainingContinuationWidth := 0
@@ -111,7 +127,7 @@ func replaceStartWithContinuation(s string, continuationRunes []rune) string {
 			// skip subsequent zero-width runes that are not ansi sequences
 			nextIdx := runeIdx + 1
 			for nextIdx < len(runes) {
-				nextRWidth := displaywidth.Rune(runes[nextIdx])
+				nextRWidth := runeWidths[nextIdx]
 				if nextRWidth == 0 && nextIdx < len(runes) && !runesHaveAnsiPrefix(runes[nextIdx:]) {
 					runeIdx++
 					nextIdx = runeIdx + 1
@@ -137,6 +153,9 @@ func replaceEndWithContinuation(s string, continuationRunes []rune) string {
 	var runesToPrepend []rune
 	ansiCodeIndexes := findAnsiRuneRanges(s)
 	runes := []rune(s)
+	runeWidths := clusterRuneWidths(s)
+	// zero-width runes continuing a grapheme cluster, kept if the rest of the cluster is
+	var pendingZeroWidthRunes []rune
 
 	for runeIdx := len(runes) - 1; runeIdx >= 0; {
 		if len(ansiCodeIndexes) > 0 {
@@ -149,11 +168,17 @@ func replaceEndWithContinuation(s string, continuationRunes []rune) string {
 				// skip ansi
 				runeIdx = codeStart - 1
 				ansiCodeIndexes = ansiCodeIndexes[:len(ansiCodeIndexes)-1]
+				pendingZeroWidthRunes = nil
 				continue
 			}
 		}
 		if len(continuationRunes) > 0 {
-			rWidth := displaywidth.Rune(runes[runeIdx])
+			rWidth := runeWidths[runeIdx]
+			if rWidth == 0 {
+				pendingZeroWidthRunes = append(pendingZeroWidthRunes, runes[runeIdx])
+				runeIdx--
+				continue
+			}
 
 			// if rune is wider than remaining continuation width, cut off the continuation
 			remainingContinuationWidth := 0
@@ -161,9 +186,11 @@ func replaceEndWithContinuation(s string, continuationRunes []rune) string {
 				remainingContinuationWidth += displaywidth.Rune(cr)
 			}
 			if rWidth > remainingContinuationWidth {
+				runesToPrepend = append(runesToPrepend, pendingZeroWidthRunes...)
 				runesToPrepend = append(runesToPrepend, runes[runeIdx])
 				continuationRunes = nil
 			}
+			pendingZeroWidthRunes = nil
 
 			// replace current rune with continuation runes
 			for rWidth > 0 && len(continuationRunes) > 0 {
@@ -407,6 +407,12 @@ func TestString_replaceStartWithContinuation(t *testing.T) {
 			continuation: "...",
 			expected:     "." + internal.RedBg.Render("..") + "中é",
 		},
+		{
+			name:         "emoji zwj sequence",
+			s:            "👨‍👩‍👧ab",
+			continuation: "..",
+			expected:     "..ab",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -503,6 +509,18 @@ func TestString_replaceEndWithContinuation(t *testing.T) {
 			continuation: "...",
 			expected:     "A" + internal.RedBg.Render("💖..") + ".",
 		},
+		{
+			name:         "emoji zwj sequence",
+			s:            "ab👨‍👩‍👧",
+			continuation: "..",
+			expected:     "ab..",
+		},
+		{
+			name:         "emoji zwj sequence wider than continuation",
+			s:            "ab👨‍👩‍👧",
+			continuation: ".",
+			expected:     "ab👨‍👩‍👧",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
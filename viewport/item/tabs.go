@@ -0,0 +1,113 @@
+package item
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/displaywidth"
+)
+
+// tabStop is a tab in unstyled content and the number of spaces it expands to
+type tabStop struct {
+	byteOffset int
+	numSpaces  int
+}
+
+// tabStops returns the tabs in the unstyled content s, each expanding to the spaces up to the next multiple of
+// tabWidth cells from the start of its line
+func tabStops(s string, tabWidth int) []tabStop {
+	var stops []tabStop
+	byteOffset, col := 0, 0
+	g := displaywidth.StringGraphemes(s)
+	for g.Next() {
+		cluster := g.Value()
+		switch {
+		case cluster == "\t":
+			n := tabWidth - col%tabWidth
+			stops = append(stops, tabStop{byteOffset: byteOffset, numSpaces: n})
+			col += n
+		case strings.HasSuffix(cluster, "\n"):
+			col = 0
+		default:
+			col += g.Width()
+		}
+		byteOffset += len(cluster)
+	}
+	return stops
+}
+
+// ExpandTabs returns itm with its tabs replaced by the spaces up to the next multiple of tabWidth cells from the start
+// of their line, so that they render the same in every terminal and count toward the item's width. Tabs in ANSI escape
+// sequences are left as they are, and styling spans the spaces as it did the tab. Items without tabs, or with a
+// tabWidth below 1, are returned unchanged, as are items other than SingleItem and MultiLineItem. See
+// ExpandTabsByteRange to map byte ranges of the item's ContentNoAnsi to those of the returned item's.
+func ExpandTabs(itm Item, tabWidth int) Item {
+	if tabWidth < 1 || !strings.Contains(itm.Content(), "\t") {
+		return itm
+	}
+	switch it := itm.(type) {
+	case SingleItem:
+		return expandTabsSingle(it, tabWidth)
+	case MultiLineItem:
+		lines := make([]SingleItem, len(it.items))
+		for i, line := range it.items {
+			lines[i] = expandTabsSingle(line, tabWidth)
+		}
+		return NewMultiLineItem(lines...)
+	}
+	return itm
+}
+
+// ExpandTabsByteRange maps r, a byte range of the unstyled content contentNoAnsi, to the byte range of the same
+// content with its tabs expanded by ExpandTabs. A range covering a tab covers all of its spaces.
+func ExpandTabsByteRange(contentNoAnsi string, r ByteRange, tabWidth int) ByteRange {
+	if tabWidth < 1 || !strings.Contains(contentNoAnsi, "\t") {
+		return r
+	}
+	start, end := r.Start, r.End
+	for _, stop := range tabStops(contentNoAnsi, tabWidth) {
+		extra := stop.numSpaces - 1
+		if stop.byteOffset < r.Start {
+			start += extra
+		}
+		if stop.byteOffset < r.End {
+			end += extra
+		}
+	}
+	return ByteRange{Start: start, End: end}
+}
+
+// expandTabsSingle replaces the tabs of a single line with spaces
+func expandTabsSingle(it SingleItem, tabWidth int) SingleItem {
+	line := it.Content()
+	if !strings.Contains(line, "\t") {
+		return it
+	}
+	stops := tabStops(it.ContentNoAnsi(), tabWidth)
+
+	var builder strings.Builder
+	builder.Grow(len(line) + len(stops)*(tabWidth-1))
+	ansiRanges := it.ansiCodeIndexes
+	// noAnsiOffset is the byte offset in the unstyled content of the byte at i
+	noAnsiOffset := 0
+	for i := 0; i < len(line); {
+		if len(ansiRanges) > 0 && int(ansiRanges[0][0]) == i {
+			builder.WriteString(line[ansiRanges[0][0]:ansiRanges[0][1]])
+			i = int(ansiRanges[0][1])
+			ansiRanges = ansiRanges[1:]
+			continue
+		}
+		if len(stops) > 0 && stops[0].byteOffset == noAnsiOffset {
+			builder.WriteString(strings.Repeat(" ", stops[0].numSpaces))
+			stops = stops[1:]
+			i++
+			noAnsiOffset++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		builder.WriteString(line[i : i+size])
+		i += size
+		noAnsiOffset += size
+	}
+	return NewItem(builder.String())
+}
@@ -0,0 +1,103 @@
+package item
+
+import (
+	"testing"
+)
+
+func TestExpandTabs(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		tabWidth int
+		expected string
+	}{
+		{
+			name:     "no tabs",
+			item:     NewItem("plain"),
+			tabWidth: 4,
+			expected: "plain",
+		},
+		{
+			name:     "not expanded",
+			item:     NewItem("a\tb"),
+			tabWidth: 0,
+			expected: "a\tb",
+		},
+		{
+			name:     "to next tab stop",
+			item:     NewItem("a\tbcde\tf\t"),
+			tabWidth: 4,
+			expected: "a   bcde    f   ",
+		},
+		{
+			name:     "wide runes",
+			item:     NewItem("世\tx"),
+			tabWidth: 4,
+			expected: "世  x",
+		},
+		{
+			name:     "styling spans the spaces",
+			item:     NewItem("\x1b[31ma\tb\x1b[m\tc"),
+			tabWidth: 4,
+			expected: "\x1b[31ma   b\x1b[m   c",
+		},
+		{
+			name:     "multi-line tab stops from the start of each line",
+			item:     NewMultiLineItem(NewItem("ab\tc"), NewItem("\td")),
+			tabWidth: 4,
+			expected: "ab  c\n    d",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := ExpandTabs(tt.item, tt.tabWidth)
+			if got := expanded.Content(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if got, want := expanded.Width(), NewMultiLineItemFromString(tt.expected).Width(); got != want {
+				t.Errorf("expected width %d, got %d", want, got)
+			}
+		})
+	}
+}
+
+func TestExpandTabsByteRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		r        ByteRange
+		expected ByteRange
+	}{
+		{
+			name:     "before tabs",
+			content:  "ab\tc",
+			r:        ByteRange{Start: 0, End: 2},
+			expected: ByteRange{Start: 0, End: 2},
+		},
+		{
+			name:     "after a tab",
+			content:  "ab\tc",
+			r:        ByteRange{Start: 3, End: 4},
+			expected: ByteRange{Start: 4, End: 5},
+		},
+		{
+			name:     "covering a tab",
+			content:  "a\tb",
+			r:        ByteRange{Start: 0, End: 3},
+			expected: ByteRange{Start: 0, End: 5},
+		},
+		{
+			name:     "second line",
+			content:  "a\tb\n\tc",
+			r:        ByteRange{Start: 5, End: 6},
+			expected: ByteRange{Start: 10, End: 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExpandTabsByteRange(tt.content, tt.r, 4); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package item
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordChars defines which runes are considered part of a word for word-wise operations such as
+// wrapping at word boundaries with WrapWords, which breaks rows after any other rune.
+// Letters, digits and underscores are always word runes. Extra adds further runes, e.g. "-./" so
+// that paths and dotted identifiers are treated as a single word.
+type WordChars struct {
+	Extra string
+}
+
+// DefaultWordChars returns the default word definition: letters, digits and underscores.
+func DefaultWordChars() WordChars {
+	return WordChars{}
+}
+
+// IsWordRune reports whether r is part of a word
+func (w WordChars) IsWordRune(r rune) bool {
+	if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) {
+		return true
+	}
+	return w.Extra != "" && strings.ContainsRune(w.Extra, r)
+}
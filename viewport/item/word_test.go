@@ -0,0 +1,32 @@
+package item
+
+import (
+	"testing"
+)
+
+func TestWordChars_IsWordRune(t *testing.T) {
+	tests := []struct {
+		name      string
+		wordChars WordChars
+		r         rune
+		expected  bool
+	}{
+		{name: "letter", wordChars: DefaultWordChars(), r: 'a', expected: true},
+		{name: "digit", wordChars: DefaultWordChars(), r: '7', expected: true},
+		{name: "underscore", wordChars: DefaultWordChars(), r: '_', expected: true},
+		{name: "unicode letter", wordChars: DefaultWordChars(), r: '世', expected: true},
+		{name: "space", wordChars: DefaultWordChars(), r: ' ', expected: false},
+		{name: "dash default", wordChars: DefaultWordChars(), r: '-', expected: false},
+		{name: "dash extra", wordChars: WordChars{Extra: "-./"}, r: '-', expected: true},
+		{name: "slash extra", wordChars: WordChars{Extra: "-./"}, r: '/', expected: true},
+		{name: "space not in extra", wordChars: WordChars{Extra: "-./"}, r: ' ', expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := tt.wordChars.IsWordRune(tt.r); actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
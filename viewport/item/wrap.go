@@ -0,0 +1,253 @@
+package item
+
+import (
+	"regexp"
+	"slices"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/clipperhouse/displaywidth"
+)
+
+// WrapMode controls where wrapped lines break
+type WrapMode int
+
+const (
+	// WrapChars breaks lines at exactly the wrap width (default)
+	WrapChars WrapMode = iota
+
+	// WrapTokens breaks lines at the wrap width unless that would split a URL, UUID or other long
+	// token, in which case the line breaks before the token instead
+	WrapTokens
+
+	// WrapWords breaks lines at whitespace or after a rune that isn't part of a word, so that words longer than a
+	// row are the only ones split. Whitespace at a break ends the row rather than starting the next. See WordChars
+	// for which runes make up a word.
+	WrapWords
+)
+
+// longTokenMinWidth is the width in cells at or above which a run of non-whitespace is treated as a token
+// that WrapTokens avoids breaking
+const longTokenMinWidth = 20
+
+var (
+	urlRegex  = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s]+`)
+	uuidRegex = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+)
+
+// NumWrappedRows returns the number of terminal rows needed to render itm at wrapWidth with the given mode.
+// Each line-broken item wraps independently.
+func NumWrappedRows(itm Item, wrapWidth int, mode WrapMode) int {
+	return NumWrappedRowsIndented(itm, wrapWidth, 0, mode, DefaultWordChars())
+}
+
+// NumWrappedRowsIndented is NumWrappedRows with continuation rows indented by indent cells and words defined by
+// wordChars. See WrapRowStartsIndented.
+func NumWrappedRowsIndented(itm Item, wrapWidth, indent int, mode WrapMode, wordChars WordChars) int {
+	if (mode == WrapChars && indent <= 0) || wrapWidth <= 0 {
+		return itm.NumWrappedLines(wrapWidth)
+	}
+	segments := itm.LineBrokenItems()
+	if len(segments) == 0 {
+		return 1
+	}
+	total := 0
+	for _, seg := range segments {
+		total += len(WrapRowStartsIndented(seg, wrapWidth, indent, mode, wordChars))
+	}
+	return total
+}
+
+// WrapRowStarts returns the cell offsets at which each wrapped row of a single-line item begins when
+// wrapped at wrapWidth with the given mode. A row ends where the next begins, or at the item's width.
+// For multi-line items, call this on each of LineBrokenItems().
+func WrapRowStarts(itm Item, wrapWidth int, mode WrapMode) []int {
+	return WrapRowStartsIndented(itm, wrapWidth, 0, mode, DefaultWordChars())
+}
+
+// WrapRowStartsIndented is WrapRowStarts with every row after the first indented by indent cells, leaving
+// wrapWidth-indent cells for their content. indent is clamped to leave at least one cell. With WrapWords, wordChars
+// defines the words that rows avoid splitting.
+func WrapRowStartsIndented(itm Item, wrapWidth, indent int, mode WrapMode, wordChars WordChars) []int {
+	if wrapWidth <= 0 {
+		return nil
+	}
+	indent = max(0, min(indent, wrapWidth-1))
+	totalWidth := itm.Width()
+	if (mode == WrapChars && indent == 0) || totalWidth <= wrapWidth {
+		n := itm.NumWrappedLines(wrapWidth)
+		starts := make([]int, n)
+		for i := range starts {
+			starts[i] = i * wrapWidth
+		}
+		return starts
+	}
+
+	layout := newClusterLayout(itm.ContentNoAnsi())
+	var protected []WidthRange
+	if mode == WrapTokens {
+		protected = layout.protectedCellRanges()
+	}
+
+	starts := []int{0}
+	pos := 0
+	rowWidth := wrapWidth
+	for totalWidth-pos > rowWidth {
+		brk := layout.snapToBoundary(pos + rowWidth)
+		if brk <= pos {
+			// a single cluster wider than the wrap width, take it anyway to guarantee progress
+			brk = layout.nextBoundary(pos)
+		}
+		switch mode {
+		case WrapTokens:
+			// break before the outermost protected range that would otherwise be split
+			tokenStart := brk
+			for _, r := range protected {
+				if r.Start < brk && brk < r.End && r.Start > pos {
+					tokenStart = min(tokenStart, r.Start)
+				}
+			}
+			brk = tokenStart
+		case WrapWords:
+			brk = layout.wordBreak(pos, brk, wordChars)
+		}
+		if brk >= totalWidth {
+			// only whitespace remains, which ends the row
+			break
+		}
+		starts = append(starts, brk)
+		pos = brk
+		rowWidth = wrapWidth - indent
+	}
+	return starts
+}
+
+// clusterLayout records the cell position of each grapheme cluster boundary in a string without ANSI codes, so that
+// rows never break inside a cluster like an emoji ZWJ sequence
+type clusterLayout struct {
+	content     string
+	byteOffsets []int // byte offset of each cluster, plus len(content)
+	cellOffsets []int // cell offset of each cluster, plus total width
+}
+
+func newClusterLayout(content string) clusterLayout {
+	l := clusterLayout{content: content}
+	byteOffset, cell := 0, 0
+	g := displaywidth.StringGraphemes(content)
+	for g.Next() {
+		l.byteOffsets = append(l.byteOffsets, byteOffset)
+		l.cellOffsets = append(l.cellOffsets, cell)
+		byteOffset += len(g.Value())
+		cell += g.Width()
+	}
+	l.byteOffsets = append(l.byteOffsets, len(content))
+	l.cellOffsets = append(l.cellOffsets, cell)
+	return l
+}
+
+// cellAtByte returns the cell offset of the cluster starting at or containing byteOffset
+func (l clusterLayout) cellAtByte(byteOffset int) int {
+	lo, hi := 0, len(l.byteOffsets)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if l.byteOffsets[mid] <= byteOffset {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return l.cellOffsets[lo]
+}
+
+// snapToBoundary returns the largest cluster boundary cell offset that is <= cell
+func (l clusterLayout) snapToBoundary(cell int) int {
+	best := 0
+	for _, c := range l.cellOffsets {
+		if c > cell {
+			break
+		}
+		best = c
+	}
+	return best
+}
+
+// nextBoundary returns the smallest cluster boundary cell offset that is > cell
+func (l clusterLayout) nextBoundary(cell int) int {
+	for _, c := range l.cellOffsets {
+		if c > cell {
+			return c
+		}
+	}
+	return l.cellOffsets[len(l.cellOffsets)-1]
+}
+
+// isSpace returns whether the cluster at index i is whitespace
+func (l clusterLayout) isSpace(i int) bool {
+	if i < 0 || i >= len(l.byteOffsets)-1 {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(l.content[l.byteOffsets[i]:])
+	return unicode.IsSpace(r)
+}
+
+// isSeparator returns whether the cluster at index i separates words, so that a row may end after it
+func (l clusterLayout) isSeparator(i int, wordChars WordChars) bool {
+	if i < 0 || i >= len(l.byteOffsets)-1 {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(l.content[l.byteOffsets[i]:])
+	return !wordChars.IsWordRune(r)
+}
+
+// wordBreak returns where a row starting at cell pos should end instead of at brk, a cluster boundary, to avoid
+// splitting a word: past any whitespace at brk, or else after the last separator in the row. Returns brk when the
+// row is a single word.
+func (l clusterLayout) wordBreak(pos, brk int, wordChars WordChars) int {
+	i, _ := slices.BinarySearch(l.cellOffsets, brk)
+	if l.isSpace(i) {
+		for l.isSpace(i) {
+			i++
+		}
+		return l.cellOffsets[i]
+	}
+	for j := i; j > 0 && l.cellOffsets[j] > pos; j-- {
+		if l.isSeparator(j-1, wordChars) {
+			return l.cellOffsets[j]
+		}
+	}
+	return brk
+}
+
+// protectedCellRanges returns the cell ranges of URLs, UUIDs and long whitespace-delimited tokens
+func (l clusterLayout) protectedCellRanges() []WidthRange {
+	var ranges []WidthRange
+	for _, re := range []*regexp.Regexp{urlRegex, uuidRegex} {
+		for _, loc := range re.FindAllStringIndex(l.content, -1) {
+			ranges = append(ranges, WidthRange{Start: l.cellAtByte(loc[0]), End: l.cellAtByte(loc[1])})
+		}
+	}
+
+	tokenStart := -1
+	for i := 0; i < len(l.byteOffsets); i++ {
+		atEnd := i == len(l.byteOffsets)-1
+		isSpace := false
+		if !atEnd {
+			r, _ := utf8.DecodeRuneInString(l.content[l.byteOffsets[i]:])
+			isSpace = unicode.IsSpace(r)
+		}
+		if !atEnd && !isSpace {
+			if tokenStart == -1 {
+				tokenStart = i
+			}
+			continue
+		}
+		if tokenStart != -1 {
+			start, end := l.cellOffsets[tokenStart], l.cellOffsets[i]
+			if end-start >= longTokenMinWidth {
+				ranges = append(ranges, WidthRange{Start: start, End: end})
+			}
+			tokenStart = -1
+		}
+	}
+	return ranges
+}
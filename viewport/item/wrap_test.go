@@ -0,0 +1,218 @@
+package item
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWrapRowStarts(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wrapWidth int
+		mode      WrapMode
+		expected  []int
+	}{
+		{
+			name:      "chars fits",
+			content:   "hello",
+			wrapWidth: 10,
+			mode:      WrapChars,
+			expected:  []int{0},
+		},
+		{
+			name:      "chars splits at width",
+			content:   "see https://example.com/a",
+			wrapWidth: 10,
+			mode:      WrapChars,
+			expected:  []int{0, 10, 20},
+		},
+		{
+			name:      "tokens breaks before url",
+			content:   "see https://example.com/a",
+			wrapWidth: 25,
+			mode:      WrapTokens,
+			expected:  []int{0},
+		},
+		{
+			name:      "tokens breaks before url that fits on next row",
+			content:   "see https://ex.com/a",
+			wrapWidth: 18,
+			mode:      WrapTokens,
+			expected:  []int{0, 4},
+		},
+		{
+			name:      "tokens splits url longer than width after breaking before it",
+			content:   "see https://example.com/a",
+			wrapWidth: 10,
+			mode:      WrapTokens,
+			expected:  []int{0, 4, 14, 24},
+		},
+		{
+			name:      "tokens breaks before uuid",
+			content:   "id=1 123e4567-e89b-12d3-a456-426614174000 ok",
+			wrapWidth: 40,
+			mode:      WrapTokens,
+			expected:  []int{0, 5},
+		},
+		{
+			name:      "tokens breaks before long token",
+			content:   "x abcdefghijklmnopqrstuvwxyz",
+			wrapWidth: 26,
+			mode:      WrapTokens,
+			expected:  []int{0, 2},
+		},
+		{
+			name:      "tokens leaves short words to char wrapping",
+			content:   "the second line",
+			wrapWidth: 7,
+			mode:      WrapTokens,
+			expected:  []int{0, 7, 14},
+		},
+		{
+			name:      "tokens never splits a wide rune",
+			content:   "a世界世界",
+			wrapWidth: 4,
+			mode:      WrapTokens,
+			expected:  []int{0, 3, 7},
+		},
+		{
+			name:      "words breaks after whitespace",
+			content:   "the second line",
+			wrapWidth: 12,
+			mode:      WrapWords,
+			expected:  []int{0, 11},
+		},
+		{
+			name:      "words drops whitespace at the break",
+			content:   "the second line",
+			wrapWidth: 10,
+			mode:      WrapWords,
+			expected:  []int{0, 11},
+		},
+		{
+			name:      "words splits a word longer than a row",
+			content:   "a abcdefghijkl",
+			wrapWidth: 5,
+			mode:      WrapWords,
+			expected:  []int{0, 2, 7, 12},
+		},
+		{
+			name:      "words ignores trailing whitespace",
+			content:   "hello world   ",
+			wrapWidth: 11,
+			mode:      WrapWords,
+			expected:  []int{0},
+		},
+		{
+			name:      "words never splits a wide rune",
+			content:   "世界 世界世界",
+			wrapWidth: 7,
+			mode:      WrapWords,
+			expected:  []int{0, 5, 11},
+		},
+		{
+			name:      "words keeps emoji zwj sequences whole",
+			content:   "a👨‍👩‍👧👨‍👩‍👧 b",
+			wrapWidth: 4,
+			mode:      WrapWords,
+			expected:  []int{0, 3},
+		},
+		{
+			name:      "zero width",
+			content:   "hello",
+			wrapWidth: 0,
+			mode:      WrapTokens,
+			expected:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := WrapRowStarts(NewItem(tt.content), tt.wrapWidth, tt.mode)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWrapRowStartsIndented(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wrapWidth int
+		indent    int
+		mode      WrapMode
+		expected  []int
+	}{
+		{
+			name:      "chars",
+			content:   "abcdefghijklmn",
+			wrapWidth: 6,
+			indent:    2,
+			mode:      WrapChars,
+			expected:  []int{0, 6, 10},
+		},
+		{
+			name:      "words",
+			content:   "one two three four",
+			wrapWidth: 9,
+			indent:    2,
+			mode:      WrapWords,
+			expected:  []int{0, 8, 14},
+		},
+		{
+			name:      "indent capped to leave a cell",
+			content:   "abcd",
+			wrapWidth: 2,
+			indent:    5,
+			mode:      WrapChars,
+			expected:  []int{0, 2, 3},
+		},
+		{
+			name:      "fits",
+			content:   "abc",
+			wrapWidth: 5,
+			indent:    2,
+			mode:      WrapWords,
+			expected:  []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := WrapRowStartsIndented(NewItem(tt.content), tt.wrapWidth, tt.indent, tt.mode, DefaultWordChars())
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWrapRowStartsIndented_WordChars(t *testing.T) {
+	path := NewItem("path/to/some/file")
+	actual := WrapRowStartsIndented(path, 10, 0, WrapWords, DefaultWordChars())
+	if !reflect.DeepEqual(actual, []int{0, 8}) {
+		t.Errorf("expected a break after the last separator in the row, got %v", actual)
+	}
+	actual = WrapRowStartsIndented(path, 10, 0, WrapWords, WordChars{Extra: "/"})
+	if !reflect.DeepEqual(actual, []int{0, 10}) {
+		t.Errorf("expected the path split as a single word, got %v", actual)
+	}
+}
+
+func TestNumWrappedRows(t *testing.T) {
+	single := NewItem("see https://example.com/a")
+	if actual := NumWrappedRows(single, 10, WrapChars); actual != 3 {
+		t.Errorf("chars: expected 3, got %d", actual)
+	}
+	if actual := NumWrappedRows(single, 10, WrapTokens); actual != 4 {
+		t.Errorf("tokens: expected 4, got %d", actual)
+	}
+
+	multi := NewMultiLineItem(NewItem("see https://ex.com/a"), NewItem(""), NewItem("ok"))
+	if actual := NumWrappedRows(multi, 18, WrapTokens); actual != 4 {
+		t.Errorf("multi-line tokens: expected 4, got %d", actual)
+	}
+}
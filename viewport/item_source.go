@@ -0,0 +1,35 @@
+package viewport
+
+// ItemSource provides objects to the viewport by index, so that only the objects in or near view are materialized.
+// Implementations can read lazily from e.g. a large file or a database. At is only called with 0 <= i < Len().
+type ItemSource[T Object] interface {
+	// Len returns the number of objects
+	Len() int
+
+	// At returns the object at index i
+	At(i int) T
+}
+
+// refreshSource reads the length of the item source again, keeping the view at the bottom if it was there and sticky
+func (m *Model[T]) refreshSource() {
+	prevNumItems := m.content.numItems()
+	stayAtBottom := m.stickyBottom() && m.isAtBottom()
+	m.content.sourceLen = max(0, m.content.source.Len())
+
+	if m.content.numItems() < prevNumItems {
+		m.content.resetStickyMemo()
+		m.refreshSearch()
+	} else {
+		m.appendSearchMatches(prevNumItems)
+	}
+
+	if stayAtBottom {
+		m.GoToBottom()
+		return
+	}
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.content.setSelectedIdx(m.content.getSelectedIdx())
+		m.scrollSoSelectionInView()
+	}
+}
@@ -0,0 +1,60 @@
+package viewport
+
+import (
+	"container/list"
+)
+
+// maxItemCacheEntries bounds the number of items an itemCache remembers values for, so that a virtualized source with
+// millions of items only holds entries for the items recently in or near view
+const maxItemCacheEntries = 10000
+
+// itemCache remembers a value per item index, evicting the least recently used entries beyond maxItemCacheEntries.
+// The zero value is an empty cache.
+type itemCache[V any] struct {
+	// entries maps item indexes to their elements in lru, whose values are *itemCacheEntry
+	entries map[int]*list.Element
+
+	// lru orders entries from most to least recently used
+	lru list.List
+}
+
+// itemCacheEntry is the value of the item at itemIdx
+type itemCacheEntry[V any] struct {
+	itemIdx int
+	value   V
+}
+
+// get returns the value of the item at itemIdx, marking it most recently used, false if there's none
+func (c *itemCache[V]) get(itemIdx int) (V, bool) {
+	elem, ok := c.entries[itemIdx]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*itemCacheEntry[V]).value, true
+}
+
+// store sets the value of the item at itemIdx, evicting the least recently used entry if the cache is full
+func (c *itemCache[V]) store(itemIdx int, value V) {
+	if elem, ok := c.entries[itemIdx]; ok {
+		elem.Value.(*itemCacheEntry[V]).value = value
+		c.lru.MoveToFront(elem)
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[int]*list.Element)
+	}
+	if c.lru.Len() >= maxItemCacheEntries {
+		oldest := c.lru.Back()
+		delete(c.entries, oldest.Value.(*itemCacheEntry[V]).itemIdx)
+		c.lru.Remove(oldest)
+	}
+	c.entries[itemIdx] = c.lru.PushFront(&itemCacheEntry[V]{itemIdx: itemIdx, value: value})
+}
+
+// clear forgets every entry
+func (c *itemCache[V]) clear() {
+	c.entries = nil
+	c.lru.Init()
+}
@@ -4,7 +4,8 @@ import (
 	"charm.land/bubbles/v2/key"
 )
 
-// KeyMap contains viewport key bindings
+// KeyMap contains viewport key bindings. A binding's keys may be sequences of keys separated by spaces, like "g g",
+// pressed one after the other within the key chord timeout, see SetKeyChordTimeout.
 type KeyMap struct {
 	PageDown     key.Binding
 	PageUp       key.Binding
@@ -14,8 +15,144 @@ type KeyMap struct {
 	Down         key.Binding
 	Left         key.Binding
 	Right        key.Binding
-	Top          key.Binding
-	Bottom       key.Binding
+
+	// PanToStart and PanToEnd pan to the start of lines and to the end of the longest visible line when unwrapped
+	PanToStart key.Binding
+	PanToEnd   key.Binding
+
+	Top    key.Binding
+	Bottom key.Binding
+
+	// CenterSelection, SelectionToTop and SelectionToBottom scroll the selected item to the middle, top or bottom of
+	// the view, like vim's zz, zt and zb
+	CenterSelection   key.Binding
+	SelectionToTop    key.Binding
+	SelectionToBottom key.Binding
+
+	// ToggleExpand expands or collapses the selected group when line joining is enabled, the selected node in tree
+	// mode, or otherwise the selected item's details when its object is a DetailedObject
+	ToggleExpand key.Binding
+
+	// ToggleMarked marks or unmarks the selected item. It's disabled by default. See SetMarked.
+	ToggleMarked key.Binding
+
+	// Peek expands the selected item across as many rows as it needs while unwrapped, until the selection moves. It's
+	// disabled by default. See SetPeek.
+	Peek key.Binding
+
+	// ToggleSort cycles the sort direction when a sort function is set, see SetSortFunc
+	ToggleSort key.Binding
+
+	// ToggleHexView cycles between showing items as text, binary items as hex dumps and every item as a hex dump. It's
+	// disabled by default. See SetHexView.
+	ToggleHexView key.Binding
+
+	// BlockSelect starts selecting a rectangle of text at the selected item. It's disabled by default. See
+	// StartBlockSelection.
+	BlockSelect key.Binding
+
+	// VisualSelect starts selecting text character by character at the selected item. It's disabled by default. See
+	// StartVisualSelection.
+	VisualSelect key.Binding
+
+	// MoveItemUp and MoveItemDown reorder the selected item in move mode, see SetMoveMode
+	MoveItemUp   key.Binding
+	MoveItemDown key.Binding
+
+	// CopySelected copies the unstyled content of the selected item to the clipboard, and CopySelectedStyled copies it
+	// with its ANSI escape sequences, each sending a CopiedMsg. Both are disabled by default, like copying with
+	// WithClipboard, which also sets the clipboard used.
+	CopySelected       key.Binding
+	CopySelectedStyled key.Binding
+
+	// Undo and Redo revert and reapply content changes when undo is enabled, see SetUndoLimit
+	Undo key.Binding
+	Redo key.Binding
+
+	// NextSearchMatch and PrevSearchMatch move between search matches set with SetSearch
+	NextSearchMatch key.Binding
+	PrevSearchMatch key.Binding
+
+	// NextLink and PrevLink cycle focus among visible links set with SetLinks, and ActivateLink activates the focused one
+	NextLink     key.Binding
+	PrevLink     key.Binding
+	ActivateLink key.Binding
+
+	// OpenLink activates the focused link, or else the first link in the selected item, e.g. an OSC 8 hyperlink
+	OpenLink key.Binding
+
+	// ToggleBookmark bookmarks the current item, and NextBookmark and PrevBookmark cycle through bookmarks. They're
+	// disabled by default. See SetBookmarked.
+	ToggleBookmark key.Binding
+	NextBookmark   key.Binding
+	PrevBookmark   key.Binding
+
+	// NextHunk and PrevHunk move between hunks of changed lines in diff mode, see SetDiffMode
+	NextHunk key.Binding
+	PrevHunk key.Binding
+
+	// GoTo shows an input for an item number or percentage to go to. It's disabled by default. See GoToItem and
+	// GoToPercent.
+	GoTo key.Binding
+}
+
+// KeyGroup is a set of related KeyMap bindings that can be enabled or disabled together
+type KeyGroup int
+
+const (
+	// KeyGroupNavigation scrolls and pans: PageDown, PageUp, HalfPageUp, HalfPageDown, Up, Down, Left, Right,
+	// PanToStart, PanToEnd, Top, Bottom, CenterSelection, SelectionToTop and SelectionToBottom
+	KeyGroupNavigation KeyGroup = iota
+
+	// KeyGroupSelection acts on selected and marked items: ToggleMarked, Peek, BlockSelect, VisualSelect,
+	// MoveItemUp, MoveItemDown, CopySelected and CopySelectedStyled
+	KeyGroupSelection
+
+	// KeyGroupFeatures drives optional features: ToggleExpand, ToggleSort, ToggleHexView, Undo, Redo, NextSearchMatch,
+	// PrevSearchMatch, NextLink, PrevLink, ActivateLink, OpenLink, ToggleBookmark, NextBookmark, PrevBookmark, NextHunk,
+	// PrevHunk and GoTo
+	KeyGroupFeatures
+)
+
+// Group returns the bindings in group, e.g. to list them in help
+func (k *KeyMap) Group(group KeyGroup) []*key.Binding {
+	switch group {
+	case KeyGroupNavigation:
+		return []*key.Binding{
+			&k.PageDown, &k.PageUp, &k.HalfPageUp, &k.HalfPageDown, &k.Up, &k.Down, &k.Left, &k.Right,
+			&k.PanToStart, &k.PanToEnd, &k.Top, &k.Bottom, &k.CenterSelection, &k.SelectionToTop, &k.SelectionToBottom,
+		}
+	case KeyGroupSelection:
+		return []*key.Binding{
+			&k.ToggleMarked, &k.Peek, &k.BlockSelect, &k.VisualSelect, &k.MoveItemUp, &k.MoveItemDown,
+			&k.CopySelected, &k.CopySelectedStyled,
+		}
+	case KeyGroupFeatures:
+		return []*key.Binding{
+			&k.ToggleExpand, &k.ToggleSort, &k.ToggleHexView, &k.Undo, &k.Redo, &k.NextSearchMatch, &k.PrevSearchMatch,
+			&k.NextLink, &k.PrevLink, &k.ActivateLink, &k.OpenLink, &k.ToggleBookmark, &k.NextBookmark, &k.PrevBookmark,
+			&k.NextHunk, &k.PrevHunk, &k.GoTo,
+		}
+	default:
+		return nil
+	}
+}
+
+// SetGroupEnabled enables or disables every binding in group. Disabled bindings don't match any key.
+func (k *KeyMap) SetGroupEnabled(group KeyGroup, enabled bool) {
+	for _, b := range k.Group(group) {
+		b.SetEnabled(enabled)
+	}
+}
+
+// GroupEnabled returns true if any binding in group is enabled
+func (k *KeyMap) GroupEnabled(group KeyGroup) bool {
+	for _, b := range k.Group(group) {
+		if b.Enabled() {
+			return true
+		}
+	}
+	return false
 }
 
 // DefaultKeyMap returns a set of default key bindings for the viewport
@@ -53,6 +190,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("right"),
 			key.WithHelp("→", "right"),
 		),
+		PanToStart: key.NewBinding(
+			key.WithKeys("home"),
+			key.WithHelp("home", "pan to start"),
+		),
+		PanToEnd: key.NewBinding(
+			key.WithKeys("end"),
+			key.WithHelp("end", "pan to end"),
+		),
 		Top: key.NewBinding(
 			key.WithKeys("g", "ctrl+g"),
 			key.WithHelp("g", "top"),
@@ -61,5 +206,128 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("G"),
 			key.WithHelp("G", "bottom"),
 		),
+		CenterSelection: key.NewBinding(
+			key.WithKeys("z z"),
+			key.WithHelp("zz", "center selection"),
+		),
+		SelectionToTop: key.NewBinding(
+			key.WithKeys("z t"),
+			key.WithHelp("zt", "selection to top"),
+		),
+		SelectionToBottom: key.NewBinding(
+			key.WithKeys("z b"),
+			key.WithHelp("zb", "selection to bottom"),
+		),
+		ToggleExpand: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "expand/collapse"),
+		),
+		ToggleMarked: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("space", "mark"),
+			key.WithDisabled(),
+		),
+		Peek: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "peek"),
+			key.WithDisabled(),
+		),
+		ToggleSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "sort"),
+		),
+		ToggleHexView: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "hex view"),
+			key.WithDisabled(),
+		),
+		BlockSelect: key.NewBinding(
+			key.WithKeys("ctrl+v"),
+			key.WithHelp("ctrl+v", "block select"),
+			key.WithDisabled(),
+		),
+		VisualSelect: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "visual select"),
+			key.WithDisabled(),
+		),
+		MoveItemUp: key.NewBinding(
+			key.WithKeys("alt+up", "alt+k"),
+			key.WithHelp("alt+↑", "move item up"),
+		),
+		MoveItemDown: key.NewBinding(
+			key.WithKeys("alt+down", "alt+j"),
+			key.WithHelp("alt+↓", "move item down"),
+		),
+		CopySelected: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy item"),
+			key.WithDisabled(),
+		),
+		CopySelectedStyled: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy styled item"),
+			key.WithDisabled(),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("ctrl+z"),
+			key.WithHelp("ctrl+z", "undo"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+y", "ctrl+shift+z"),
+			key.WithHelp("ctrl+y", "redo"),
+		),
+		NextSearchMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevSearchMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "prev match"),
+		),
+		NextLink: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next link"),
+		),
+		PrevLink: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev link"),
+		),
+		ActivateLink: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "open link"),
+		),
+		OpenLink: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "open link"),
+		),
+		ToggleBookmark: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "bookmark"),
+			key.WithDisabled(),
+		),
+		NextBookmark: key.NewBinding(
+			key.WithKeys("'"),
+			key.WithHelp("'", "next bookmark"),
+			key.WithDisabled(),
+		),
+		PrevBookmark: key.NewBinding(
+			key.WithKeys("\""),
+			key.WithHelp("\"", "prev bookmark"),
+			key.WithDisabled(),
+		),
+		NextHunk: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "next hunk"),
+		),
+		PrevHunk: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "prev hunk"),
+		),
+		GoTo: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "go to"),
+			key.WithDisabled(),
+		),
 	}
 }
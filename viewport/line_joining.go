@@ -0,0 +1,177 @@
+package viewport
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// lineJoining groups objects matching a continuation heuristic (e.g. indented stack trace lines) under
+// the preceding parent object, so that each group is displayed and selected as a single item
+type lineJoining[T Object] struct {
+	// isContinuation reports whether an object continues the object before it
+	isContinuation func(T) bool
+
+	// sourceObjects is every object set on the viewport, before joining
+	sourceObjects []T
+
+	// groups has one entry per displayed object
+	groups []joinedGroup
+
+	// indicatorStyle styles the "(+N)" indicator shown on collapsed groups
+	indicatorStyle lipgloss.Style
+}
+
+// joinedGroup is a parent object and its continuation objects
+type joinedGroup struct {
+	// start and end are the range of sourceObjects in the group. start is the parent
+	start, end int
+
+	// expanded is true when the continuation objects are shown beneath the parent
+	expanded bool
+
+	// rendered caches the item for the group, nil until first rendered
+	rendered item.Item
+}
+
+func newLineJoining[T Object](isContinuation func(T) bool, indicatorStyle lipgloss.Style) *lineJoining[T] {
+	return &lineJoining[T]{
+		isContinuation: isContinuation,
+		indicatorStyle: indicatorStyle,
+	}
+}
+
+// join groups objects, returning the parent of each group. Groups whose parent was expanded before are
+// kept expanded, matching parents with compareFn if non-nil or by position otherwise.
+func (lj *lineJoining[T]) join(objects []T, compareFn CompareFn[T]) []T {
+	var expandedParents []T
+	expandedStarts := make(map[int]bool)
+	for _, g := range lj.groups {
+		if g.expanded {
+			expandedParents = append(expandedParents, lj.sourceObjects[g.start])
+			expandedStarts[g.start] = true
+		}
+	}
+
+	lj.sourceObjects = objects
+	lj.groups = lj.groups[:0]
+	parents := make([]T, 0, len(objects))
+	for i := range objects {
+		if i > 0 && len(lj.groups) > 0 && lj.isContinuation(objects[i]) {
+			lj.groups[len(lj.groups)-1].end = i + 1
+			continue
+		}
+		lj.groups = append(lj.groups, joinedGroup{start: i, end: i + 1})
+		parents = append(parents, objects[i])
+	}
+
+	for i := range lj.groups {
+		g := &lj.groups[i]
+		if compareFn == nil {
+			g.expanded = expandedStarts[g.start]
+			continue
+		}
+		for _, p := range expandedParents {
+			if compareFn(objects[g.start], p) {
+				g.expanded = true
+				break
+			}
+		}
+	}
+	return parents
+}
+
+// appendObjects adds objects after the existing ones, returning the parents of any new groups. Leading
+// continuation objects extend the last existing group.
+func (lj *lineJoining[T]) appendObjects(objects []T) []T {
+	start := len(lj.sourceObjects)
+	lj.sourceObjects = append(lj.sourceObjects, objects...)
+	var parents []T
+	for i, obj := range objects {
+		idx := start + i
+		if idx > 0 && len(lj.groups) > 0 && lj.isContinuation(obj) {
+			g := &lj.groups[len(lj.groups)-1]
+			g.end = idx + 1
+			g.rendered = nil
+			continue
+		}
+		lj.groups = append(lj.groups, joinedGroup{start: idx, end: idx + 1})
+		parents = append(parents, obj)
+	}
+	return parents
+}
+
+// itemAt returns the item to render for the group at idx
+func (lj *lineJoining[T]) itemAt(idx int, parent T) item.Item {
+	if idx < 0 || idx >= len(lj.groups) {
+		return parent.GetItem()
+	}
+	g := &lj.groups[idx]
+	if g.end-g.start <= 1 {
+		return parent.GetItem()
+	}
+	if g.rendered != nil {
+		return g.rendered
+	}
+
+	var lines []item.SingleItem
+	if g.expanded {
+		for _, obj := range lj.sourceObjects[g.start:g.end] {
+			for _, seg := range obj.GetItem().LineBrokenItems() {
+				lines = append(lines, item.NewItem(seg.Content()))
+			}
+		}
+	} else {
+		for _, seg := range parent.GetItem().LineBrokenItems() {
+			lines = append(lines, item.NewItem(seg.Content()))
+		}
+		indicator := lj.indicatorStyle.Render(fmt.Sprintf(" (+%d)", g.end-g.start-1))
+		last := len(lines) - 1
+		lines[last] = item.NewItem(lines[last].Content() + indicator)
+	}
+
+	if len(lines) == 1 {
+		g.rendered = lines[0]
+	} else {
+		g.rendered = item.NewMultiLineItem(lines...)
+	}
+	return g.rendered
+}
+
+// groupObjects returns the parent and continuation objects of the group at idx
+func (lj *lineJoining[T]) groupObjects(idx int) []T {
+	if idx < 0 || idx >= len(lj.groups) {
+		return nil
+	}
+	g := lj.groups[idx]
+	return lj.sourceObjects[g.start:g.end]
+}
+
+// setExpanded sets whether the group at idx shows its continuation objects
+func (lj *lineJoining[T]) setExpanded(idx int, expanded bool) {
+	if idx < 0 || idx >= len(lj.groups) {
+		return
+	}
+	g := &lj.groups[idx]
+	if g.expanded != expanded {
+		g.expanded = expanded
+		g.rendered = nil
+	}
+}
+
+// isExpanded returns whether the group at idx shows its continuation objects
+func (lj *lineJoining[T]) isExpanded(idx int) bool {
+	if idx < 0 || idx >= len(lj.groups) {
+		return false
+	}
+	return lj.groups[idx].expanded
+}
+
+// setIndicatorStyle sets the style of the collapsed group indicator
+func (lj *lineJoining[T]) setIndicatorStyle(style lipgloss.Style) {
+	lj.indicatorStyle = style
+	for i := range lj.groups {
+		lj.groups[i].rendered = nil
+	}
+}
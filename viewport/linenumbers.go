@@ -0,0 +1,88 @@
+package viewport
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LineNumberMode is how items are numbered in the line number column, see SetLineNumbers
+type LineNumberMode int
+
+const (
+	// LineNumbersOff shows no line numbers
+	LineNumbersOff LineNumberMode = iota
+
+	// LineNumbersAbsolute numbers each item by its position, counting from the start number
+	LineNumbersAbsolute
+
+	// LineNumbersRelative numbers each item by its distance from the selected item, or from the top of the view
+	// without selection, which shows its absolute number, like vim's relativenumber
+	LineNumbersRelative
+)
+
+// lineNumberState is how items are numbered
+type lineNumberState struct {
+	mode LineNumberMode
+
+	// startAt is the absolute number of the first item
+	startAt int
+}
+
+// WithLineNumbers sets how items are numbered in a column left of the content, numbering the first item startAt. See
+// SetLineNumbers.
+func WithLineNumbers[T Object](mode LineNumberMode, startAt int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetLineNumbers(mode, startAt)
+	}
+}
+
+// SetLineNumbers sets how items are numbered in a column left of the content, the first item being number startAt,
+// usually 1. Each item's number is right aligned on its first line and styled with LineNumberStyle. The column is as
+// wide as the largest number and grows with the content, narrowing it, so wrapped items rewrap. LineNumbersOff
+// removes the column.
+func (m *Model[T]) SetLineNumbers(mode LineNumberMode, startAt int) {
+	if m.config.lineNumbers == (lineNumberState{mode: mode, startAt: startAt}) {
+		return
+	}
+	m.config.lineNumbers = lineNumberState{mode: mode, startAt: startAt}
+	m.afterGutterChanged()
+}
+
+// GetLineNumbers returns how items are numbered and the number of the first item
+func (m *Model[T]) GetLineNumbers() (LineNumberMode, int) {
+	return m.config.lineNumbers.mode, m.config.lineNumbers.startAt
+}
+
+// numLineNumberCols returns the width of the line number column, the widest number and a space, 0 without line
+// numbers
+func (m *Model[T]) numLineNumberCols() int {
+	ln := m.config.lineNumbers
+	if ln.mode == LineNumbersOff {
+		return 0
+	}
+	last := ln.startAt + max(0, m.content.numItems()-1)
+	return max(len(strconv.Itoa(ln.startAt)), len(strconv.Itoa(last))) + 1
+}
+
+// lineNumber returns the styled number of the item at itemIdx, padded to the width of the line number column
+func (m *Model[T]) lineNumber(itemIdx int) string {
+	ln := m.config.lineNumbers
+	n := ln.startAt + itemIdx
+	if ln.mode == LineNumbersRelative {
+		base := m.display.topItemIdx
+		if m.navigation.selectionEnabled {
+			base = m.content.getSelectedIdx()
+		}
+		if itemIdx != base {
+			n = max(itemIdx-base, base-itemIdx)
+		}
+	}
+	width := m.numLineNumberCols()
+	return m.display.styles.LineNumberStyle.Render(fmt.Sprintf("%*d", width-1, n)) + " "
+}
+
+// lineNumberPadding returns blank space the width of the line number column
+func (m *Model[T]) lineNumberPadding() string {
+	return strings.Repeat(" ", m.numLineNumberCols())
+}
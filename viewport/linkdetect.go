@@ -0,0 +1,108 @@
+package viewport
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// LinkKind is where a link comes from
+type LinkKind int
+
+const (
+	// LinkKindCustom links are set with SetLinks
+	LinkKindCustom LinkKind = iota
+
+	// LinkKindHyperlink links are OSC 8 hyperlinks in an item's escape sequences
+	LinkKindHyperlink
+
+	// LinkKindURL links are URLs detected in an item's text, see SetLinkDetection
+	LinkKindURL
+
+	// LinkKindFilePath links are file:line paths detected in an item's text, see SetLinkDetection
+	LinkKindFilePath
+)
+
+// DetectedLink is a link detected in the text of an item in view, see GetDetectedLinks
+type DetectedLink struct {
+	ItemIndex int
+	Link      Link
+}
+
+var (
+	// urlRegex matches http and https URLs
+	urlRegex = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+	// filePathRegex matches file paths with an extension followed by a line number and optional column, e.g.
+	// viewport/viewport.go:120 or ./main.go:7:3
+	filePathRegex = regexp.MustCompile(`(?:\.{0,2}/)?(?:[\w.-]+/)*[\w-][\w.-]*\.\w+:\d+(?::\d+)?`)
+)
+
+// urlTrailingPunctuation is trimmed from the end of detected URLs, as it more likely ends the sentence around them
+const urlTrailingPunctuation = ".,;:!?)]}'\""
+
+// SetLinkDetection sets whether URLs and file:line paths in item text are detected as links. Detected links are
+// styled with DetectedLinkStyle, cycled with the NextLink and PrevLink keys along with other links, and activated
+// with the ActivateLink and OpenLink keys. Activating a URL calls the handler set with SetLinkHandler if any, while
+// other links send a LinkActivatedMsg with the link's text as its ID.
+func (m *Model[T]) SetLinkDetection(enabled bool) {
+	m.config.linkDetection = enabled
+}
+
+// GetLinkDetection returns whether URLs and file:line paths are detected as links
+func (m *Model[T]) GetLinkDetection() bool {
+	return m.config.linkDetection
+}
+
+// GetDetectedLinks returns the links detected in the text of the items in view, in order, e.g. to list them for the
+// user to pick from. It's empty unless link detection is on.
+func (m *Model[T]) GetDetectedLinks() []DetectedLink {
+	if !m.config.linkDetection {
+		return nil
+	}
+	var res []DetectedLink
+	for _, ref := range m.visibleLinks() {
+		link := m.itemLinks(ref.itemIdx)[ref.linkIdx]
+		if link.Kind == LinkKindURL || link.Kind == LinkKindFilePath {
+			res = append(res, DetectedLink{ItemIndex: ref.itemIdx, Link: link})
+		}
+	}
+	return res
+}
+
+// detectLinks returns the URLs and file:line paths in content, ordered by position. File paths within URLs aren't
+// detected separately.
+func detectLinks(content string) []Link {
+	if !strings.Contains(content, ":") {
+		return nil
+	}
+	var links []Link
+	for _, r := range urlRegex.FindAllStringIndex(content, -1) {
+		url := strings.TrimRight(content[r[0]:r[1]], urlTrailingPunctuation)
+		links = append(links, Link{
+			ID:        url,
+			ByteRange: item.ByteRange{Start: r[0], End: r[0] + len(url)},
+			URL:       url,
+			Kind:      LinkKindURL,
+		})
+	}
+	urlLinks := len(links)
+	for _, r := range filePathRegex.FindAllStringIndex(content, -1) {
+		inURL := false
+		for _, l := range links[:urlLinks] {
+			if r[0] < l.ByteRange.End && r[1] > l.ByteRange.Start {
+				inURL = true
+				break
+			}
+		}
+		if !inURL {
+			links = append(links, Link{
+				ID:        content[r[0]:r[1]],
+				ByteRange: item.ByteRange{Start: r[0], End: r[1]},
+				Kind:      LinkKindFilePath,
+			})
+		}
+	}
+	return sortedLinks(links)
+}
@@ -0,0 +1,255 @@
+package viewport
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// Link is an actionable region within an item, e.g. a URL or an inline button
+type Link struct {
+	// ID identifies the link in the LinkActivatedMsg sent when it's activated
+	ID string
+
+	// ByteRange is the range of the link in the item's unstyled content
+	ByteRange item.ByteRange
+
+	// URL is the target of a hyperlink found in the item's OSC 8 escape sequences or of a detected URL, which is also
+	// its ID. It's empty for other links.
+	URL string
+
+	// Kind is where the link comes from, LinkKindCustom for links set with SetLinks
+	Kind LinkKind
+}
+
+// LinkActivatedMsg is sent when the focused link is activated, e.g. with the ActivateLink key, unless it's a
+// hyperlink handled by the handler set with SetLinkHandler
+type LinkActivatedMsg struct {
+	ItemIndex int
+	ID        string
+
+	// URL is the link's URL if it's a hyperlink, empty otherwise
+	URL string
+}
+
+// linkRef identifies a link by its item index and its index in the item's links
+type linkRef struct {
+	itemIdx int
+	linkIdx int
+}
+
+// linkState tracks the registered links and which is focused
+type linkState struct {
+	// byItem maps an item index to its links, ordered by position
+	byItem map[int][]Link
+
+	// focused is the focused link, nil if none
+	focused *linkRef
+}
+
+func newLinkState() linkState {
+	return linkState{byItem: make(map[int][]Link)}
+}
+
+// focusedLink returns the focused link, if it still exists
+func (m *Model[T]) focusedLink() (linkRef, Link, bool) {
+	f := m.content.links.focused
+	if f == nil || f.itemIdx >= m.content.numItems() {
+		return linkRef{}, Link{}, false
+	}
+	links := m.itemLinks(f.itemIdx)
+	if f.linkIdx >= len(links) {
+		return linkRef{}, Link{}, false
+	}
+	return *f, links[f.linkIdx], true
+}
+
+// itemLinks returns the links in the item at itemIdx, those set with SetLinks, its hyperlinks and, with link
+// detection on, the links detected in its text, ordered by position
+func (m *Model[T]) itemLinks(itemIdx int) []Link {
+	links := m.content.links.byItem[itemIdx]
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return links
+	}
+	itm := m.content.rawItemAt(itemIdx)
+	hyperlinks := item.Hyperlinks(itm)
+	var detected []Link
+	if m.config.linkDetection {
+		detected = detectLinks(itm.ContentNoAnsi())
+	}
+	if len(hyperlinks) == 0 && len(detected) == 0 {
+		return links
+	}
+	merged := slices.Clone(links)
+	for _, h := range hyperlinks {
+		merged = append(merged, Link{ID: h.URL, ByteRange: h.ByteRange, URL: h.URL, Kind: LinkKindHyperlink})
+	}
+	// text within a hyperlink or a link set with SetLinks isn't detected again
+	for _, d := range detected {
+		if !slices.ContainsFunc(merged, func(l Link) bool {
+			return d.ByteRange.Start < l.ByteRange.End && d.ByteRange.End > l.ByteRange.Start
+		}) {
+			merged = append(merged, d)
+		}
+	}
+	return sortedLinks(merged)
+}
+
+// visibleLinks returns the links in items that are in view, in order
+func (m *Model[T]) visibleLinks() []linkRef {
+	var refs []linkRef
+	prevItemIdx := -1
+	for _, itemIdx := range m.getVisibleContentItemIndexes() {
+		if itemIdx == prevItemIdx {
+			continue
+		}
+		prevItemIdx = itemIdx
+		for linkIdx := range m.itemLinks(itemIdx) {
+			refs = append(refs, linkRef{itemIdx: itemIdx, linkIdx: linkIdx})
+		}
+	}
+	return refs
+}
+
+// cycleLink focuses the visible link delta positions from the focused one, wrapping around. If the focused link isn't
+// visible, the first visible link is focused when moving forward and the last when moving back.
+func (m *Model[T]) cycleLink(delta int) {
+	refs := m.visibleLinks()
+	if len(refs) == 0 {
+		return
+	}
+	next := 0
+	if delta < 0 {
+		next = len(refs) - 1
+	}
+	if focused, _, ok := m.focusedLink(); ok {
+		if i := slices.Index(refs, focused); i >= 0 {
+			next = (i + delta + len(refs)) % len(refs)
+		}
+	}
+	m.focusLink(refs[next])
+}
+
+// focusLink focuses the link at ref, panning it into view and selecting its item
+func (m *Model[T]) focusLink(ref linkRef) {
+	m.content.links.focused = &ref
+	link := m.itemLinks(ref.itemIdx)[ref.linkIdx]
+	matches := m.content.itemAt(ref.itemIdx).ByteRangesToMatches([]item.ByteRange{link.ByteRange})
+	if len(matches) > 0 {
+		m.ensureItemInView(ref.itemIdx, matches[0].WidthRange.Start, matches[0].WidthRange.End, 0, 0)
+	}
+	if m.navigation.selectionEnabled && m.content.getSelectedIdx() != ref.itemIdx {
+		m.content.setSelectedIdx(ref.itemIdx)
+	}
+}
+
+// linkHighlightsForItem returns highlights for the links in the item at itemIdx
+func (m *Model[T]) linkHighlightsForItem(itemIdx int) []item.Highlight {
+	links := m.itemLinks(itemIdx)
+	if len(links) == 0 {
+		return nil
+	}
+	focused, _, hasFocus := m.focusedLink()
+	highlights := make([]item.Highlight, len(links))
+	for i, link := range links {
+		style := m.display.styles.LinkStyle
+		if link.Kind == LinkKindURL || link.Kind == LinkKindFilePath {
+			style = m.display.styles.DetectedLinkStyle
+		}
+		if hasFocus && focused == (linkRef{itemIdx: itemIdx, linkIdx: i}) {
+			style = m.display.styles.FocusedLinkStyle
+		}
+		highlights[i] = item.Highlight{Style: style, ByteRangeUnstyledContent: link.ByteRange}
+	}
+	return highlights
+}
+
+// sortedLinks returns a copy of links ordered by position
+func sortedLinks(links []Link) []Link {
+	sorted := slices.Clone(links)
+	slices.SortStableFunc(sorted, func(a, b Link) int {
+		return a.ByteRange.Start - b.ByteRange.Start
+	})
+	return sorted
+}
+
+// shiftLinks moves links down by n items after objects are prepended, or up by -n after the oldest are dropped,
+// dropping those moved before the first item
+func (m *Model[T]) shiftLinks(n int) {
+	l := &m.content.links
+	if len(l.byItem) == 0 {
+		return
+	}
+	shifted := make(map[int][]Link, len(l.byItem))
+	for idx, links := range l.byItem {
+		if idx+n >= 0 {
+			shifted[idx+n] = links
+		}
+	}
+	l.byItem = shifted
+	if l.focused != nil {
+		l.focused.itemIdx += n
+		if l.focused.itemIdx < 0 {
+			l.focused = nil
+		}
+	}
+}
+
+// activateLinkCmd returns a command activating the focused link, nil if none is focused
+func (m *Model[T]) activateLinkCmd() tea.Cmd {
+	ref, link, ok := m.focusedLink()
+	if !ok {
+		return nil
+	}
+	return m.activateCmd(ref.itemIdx, link)
+}
+
+// activateCmd returns a command activating link in the item at itemIdx: the link handler's command for a hyperlink
+// when one is set, otherwise one sending a LinkActivatedMsg
+func (m *Model[T]) activateCmd(itemIdx int, link Link) tea.Cmd {
+	if link.URL != "" && m.config.linkHandler != nil {
+		return m.config.linkHandler(link.URL)
+	}
+	msg := LinkActivatedMsg{ItemIndex: itemIdx, ID: link.ID, URL: link.URL}
+	return func() tea.Msg {
+		return msg
+	}
+}
+
+// openLinkCmd returns a command activating the focused link, or if none is focused, the first link in the selected
+// item, nil if there's neither
+func (m *Model[T]) openLinkCmd() tea.Cmd {
+	if cmd := m.activateLinkCmd(); cmd != nil {
+		return cmd
+	}
+	if !m.navigation.selectionEnabled || m.content.isEmpty() {
+		return nil
+	}
+	selectedIdx := m.content.getSelectedIdx()
+	links := m.itemLinks(selectedIdx)
+	if len(links) == 0 {
+		return nil
+	}
+	m.content.links.focused = &linkRef{itemIdx: selectedIdx}
+	return m.activateCmd(selectedIdx, links[0])
+}
+
+// clickedLinkCmd returns a command activating the link at row and col of View(), focusing it and selecting its item,
+// nil if there's none
+func (m *Model[T]) clickedLinkCmd(row, col int) tea.Cmd {
+	itemIdx, byteOffset, ok := m.GetItemAtScreenPosition(row, col)
+	if !ok {
+		return nil
+	}
+	for i, link := range m.itemLinks(itemIdx) {
+		if byteOffset >= link.ByteRange.Start && byteOffset < link.ByteRange.End {
+			m.content.links.focused = &linkRef{itemIdx: itemIdx, linkIdx: i}
+			if m.navigation.selectionEnabled {
+				m.setSelectedItemIdx(itemIdx)
+			}
+			return m.activateCmd(itemIdx, link)
+		}
+	}
+	return nil
+}
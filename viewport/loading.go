@@ -0,0 +1,109 @@
+package viewport
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// loadingState tracks the loading indicator
+type loadingState struct {
+	// active is true while loading
+	active bool
+
+	// message is shown after the spinner, empty for none
+	message string
+
+	// spinner animates while loading
+	spinner spinner.Model
+}
+
+func newLoadingState() loadingState {
+	return loadingState{spinner: spinner.New(spinner.WithSpinner(spinner.MiniDot))}
+}
+
+// WithLoadingMessage sets the message shown after the spinner while loading, see SetLoading
+func WithLoadingMessage[T Object](message string) Option[T] {
+	return func(m *Model[T]) {
+		m.SetLoadingMessage(message)
+	}
+}
+
+// WithLoadingSpinner sets the spinner animated while loading, see SetLoading
+func WithLoadingSpinner[T Object](s spinner.Spinner) Option[T] {
+	return func(m *Model[T]) {
+		m.SetLoadingSpinner(s)
+	}
+}
+
+// SetLoading sets whether content is loading, e.g. while waiting on slow or streamed data. While loading, a spinner
+// and the message set with SetLoadingMessage are centered in the content area, styled with LoadingStyle, if there's
+// no content yet, or otherwise lead the footer. Starting to load returns the command animating the spinner, which
+// keeps ticking through Update until loading stops; otherwise the command is nil.
+func (m *Model[T]) SetLoading(loading bool) tea.Cmd {
+	l := &m.config.loading
+	if loading == l.active {
+		return nil
+	}
+	l.active = loading
+	if !loading {
+		return nil
+	}
+	return l.spinner.Tick
+}
+
+// GetLoading returns whether content is loading
+func (m *Model[T]) GetLoading() bool {
+	return m.config.loading.active
+}
+
+// SetLoadingMessage sets the message shown after the spinner while loading, e.g. "Fetching logs...". Empty for none.
+func (m *Model[T]) SetLoadingMessage(message string) {
+	m.config.loading.message = message
+}
+
+// GetLoadingMessage returns the message shown after the spinner while loading
+func (m *Model[T]) GetLoadingMessage() string {
+	return m.config.loading.message
+}
+
+// SetLoadingSpinner sets the spinner animated while loading, spinner.MiniDot by default
+func (m *Model[T]) SetLoadingSpinner(s spinner.Spinner) {
+	m.config.loading.spinner.Spinner = s
+}
+
+// updateLoading advances the spinner on its tick while loading, returning the command for its next tick
+func (m *Model[T]) updateLoading(msg spinner.TickMsg) tea.Cmd {
+	l := &m.config.loading
+	if !l.active {
+		return nil
+	}
+	var cmd tea.Cmd
+	l.spinner, cmd = l.spinner.Update(msg)
+	return cmd
+}
+
+// loadingText returns the spinner's frame followed by the loading message, unstyled
+func (m *Model[T]) loadingText() string {
+	l := m.config.loading
+	// some spinners pad their frames to a constant width
+	return strings.TrimSpace(strings.TrimRight(l.spinner.View(), " ") + " " + l.message)
+}
+
+// loadingContentLines centers the loading indicator in the content lines, padded to the content height
+func (m *Model[T]) loadingContentLines(lines []string) []string {
+	numLines := max(len(lines), m.getNumContentLines())
+	if numLines == 0 {
+		return lines
+	}
+	res := make([]string, numLines)
+	copy(res, lines)
+	w := m.display.bounds.width
+	loadingItem := item.NewItem(m.loadingText())
+	truncated, _ := loadingItem.Take(0, w, m.config.continuationIndicator, nil)
+	pad := max(0, (w-loadingItem.Width())/2)
+	res[(numLines-1)/2] = strings.Repeat(" ", pad) + m.display.styles.LoadingStyle.Render(truncated)
+	return res
+}
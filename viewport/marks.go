@@ -0,0 +1,57 @@
+package viewport
+
+import (
+	"slices"
+)
+
+// markedObjects returns the marked objects, in item order
+func (m *Model[T]) markedObjects() []T {
+	idxs := m.markedItemIdxs()
+	objects := make([]T, len(idxs))
+	for i, idx := range idxs {
+		objects[i] = m.content.objectAt(idx)
+	}
+	return objects
+}
+
+// markedItemIdxs returns the indexes of marked items in ascending order
+func (m *Model[T]) markedItemIdxs() []int {
+	idxs := make([]int, 0, len(m.content.marked))
+	for idx := range m.content.marked {
+		idxs = append(idxs, idx)
+	}
+	slices.Sort(idxs)
+	return idxs
+}
+
+// remarkObjects marks the items in the current objects that match previously marked objects using the selection
+// comparator, clearing all marks if there isn't one
+func (m *Model[T]) remarkObjects(prevMarked []T) {
+	m.content.marked = make(map[int]struct{})
+	if m.content.compareFn == nil || len(prevMarked) == 0 {
+		return
+	}
+	for i, obj := range m.content.objects {
+		for _, marked := range prevMarked {
+			if m.content.compareFn(obj, marked) {
+				m.content.marked[i] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+// shiftMarks moves marks down by n items after objects are prepended, or up by -n after the oldest are dropped,
+// dropping those moved before the first item
+func (m *Model[T]) shiftMarks(n int) {
+	if len(m.content.marked) == 0 {
+		return
+	}
+	shifted := make(map[int]struct{}, len(m.content.marked))
+	for idx := range m.content.marked {
+		if idx+n >= 0 {
+			shifted[idx+n] = struct{}{}
+		}
+	}
+	m.content.marked = shifted
+}
@@ -0,0 +1,65 @@
+package viewport
+
+import (
+	"slices"
+)
+
+// NextItemMatching moves to the first item after the current one whose object satisfies pred, e.g. to bind a key
+// that jumps to the next error. The current item is the selected one when selection is enabled, otherwise the last
+// one moved to with NextItemMatching or PrevItemMatching if it's still in view, or else the item at the top of the
+// view. The item moved to is selected when selection is enabled and scrolled into view with the padding set with
+// SetMatchingItemPadding. Returns false, changing nothing, if no later item matches.
+func (m *Model[T]) NextItemMatching(pred func(T) bool) bool {
+	return m.moveToItemMatching(pred, 1)
+}
+
+// PrevItemMatching is NextItemMatching, moving to the last item before the current one whose object satisfies pred
+func (m *Model[T]) PrevItemMatching(pred func(T) bool) bool {
+	return m.moveToItemMatching(pred, -1)
+}
+
+// SetMatchingItemPadding sets how many lines of context NextItemMatching and PrevItemMatching leave around the item
+// they scroll into view, where possible
+func (m *Model[T]) SetMatchingItemPadding(lines int) {
+	m.navigation.matchingItemPadding = max(0, lines)
+}
+
+// GetMatchingItemPadding returns the padding set with SetMatchingItemPadding
+func (m *Model[T]) GetMatchingItemPadding() int {
+	return m.navigation.matchingItemPadding
+}
+
+// moveToItemMatching moves to the nearest item in direction dir, 1 or -1, from the current item whose object
+// satisfies pred, returning false if there isn't one
+func (m *Model[T]) moveToItemMatching(pred func(T) bool, dir int) bool {
+	if pred == nil || m.content.isEmpty() {
+		return false
+	}
+	for idx := m.matchingOriginIdx(dir) + dir; idx >= 0 && idx < m.content.numItems(); idx += dir {
+		if !pred(m.content.objectAt(idx)) {
+			continue
+		}
+		if m.navigation.selectionEnabled {
+			m.setSelectedItemIdx(idx)
+		}
+		m.navigation.matchingItemIdx = idx
+		m.ensureItemInView(idx, 0, 0, m.navigation.matchingItemPadding, 0)
+		return true
+	}
+	return false
+}
+
+// matchingOriginIdx returns the index searching for a matching item in direction dir starts after
+func (m *Model[T]) matchingOriginIdx(dir int) int {
+	if m.navigation.selectionEnabled {
+		return m.content.getSelectedIdx()
+	}
+	if idx := m.navigation.matchingItemIdx; idx >= 0 && slices.Contains(m.getVisibleContentItemIndexes(), idx) {
+		return idx
+	}
+	if dir > 0 {
+		// the top item itself can match
+		return m.display.topItemIdx - 1
+	}
+	return m.display.topItemIdx
+}
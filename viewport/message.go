@@ -0,0 +1,73 @@
+package viewport
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// resultMessageDuration is how long the results of saving, copying, undoing and going to items show
+const resultMessageDuration = 4 * time.Second
+
+// messageState tracks the notification shown over the footer
+type messageState struct {
+	// text is the notification shown, empty if none is
+	text string
+
+	// isError is true if text describes an error, styled with ErrorMessageStyle rather than MessageStyle
+	isError bool
+
+	// seq identifies the latest notification, so that only its clearMessageMsg clears it
+	seq int
+}
+
+// clearMessageMsg clears the notification identified by seq
+type clearMessageMsg struct {
+	id  int
+	seq int
+}
+
+// ShowMessage shows text in place of the footer for duration, styled with MessageStyle, e.g. to confirm an action
+// taken by the host. It replaces any message shown, like the results of saving and copying, which are shown the same
+// way. The returned command clears it; a duration of 0 shows it until ClearMessage or the next message.
+func (m *Model[T]) ShowMessage(text string, duration time.Duration) tea.Cmd {
+	return m.showMessage(text, false, duration)
+}
+
+// ShowErrorMessage is ShowMessage for errors, styled with ErrorMessageStyle
+func (m *Model[T]) ShowErrorMessage(text string, duration time.Duration) tea.Cmd {
+	return m.showMessage(text, true, duration)
+}
+
+// ClearMessage clears the message shown, restoring the footer
+func (m *Model[T]) ClearMessage() {
+	m.config.message.text = ""
+	m.config.message.isError = false
+	m.config.message.seq++
+}
+
+// GetMessage returns the message shown in place of the footer, empty if none is
+func (m *Model[T]) GetMessage() string {
+	return m.config.message.text
+}
+
+// showMessage shows text in place of the footer, returning the command clearing it after duration, nil if it shows
+// until replaced
+func (m *Model[T]) showMessage(text string, isError bool, duration time.Duration) tea.Cmd {
+	msg := &m.config.message
+	msg.text, msg.isError = text, isError
+	msg.seq++
+	if duration <= 0 {
+		return nil
+	}
+	id, seq := m.id, msg.seq
+	return tea.Tick(duration, func(time.Time) tea.Msg {
+		return clearMessageMsg{id: id, seq: seq}
+	})
+}
+
+// showResult shows msg, the result of an action taken with a key, returning the command that clears it after some
+// seconds
+func (m *Model[T]) showResult(msg string, isError bool) tea.Cmd {
+	return m.showMessage(msg, isError, resultMessageDuration)
+}
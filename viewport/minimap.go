@@ -0,0 +1,86 @@
+package viewport
+
+import (
+	"slices"
+)
+
+const (
+	// minimapTrack and minimapMark are the characters of the minimap's rows without and with marked items
+	minimapTrack = " "
+	minimapMark  = "•"
+)
+
+// minimapMarkedItems returns the sorted indexes of the items with highlights or search matches
+func (m *Model[T]) minimapMarkedItems() []int {
+	var idxs []int
+	for _, h := range m.content.getHighlights() {
+		idxs = append(idxs, h.ItemIndex)
+	}
+	for _, match := range m.content.search.matches {
+		idxs = append(idxs, match.itemIdx)
+	}
+	slices.Sort(idxs)
+	return slices.Compact(idxs)
+}
+
+// minimapRowStart returns the first item of row of a minimap of height rows, each standing for an equal share of
+// numItems items. A row's items run up to the next row's first item; rows can have none when there are fewer items
+// than rows.
+func minimapRowStart(row, height, numItems int) int {
+	return (row*numItems + height - 1) / height
+}
+
+// minimapColumn returns the rows of the minimap over height rows
+func (m *Model[T]) minimapColumn(height int) []string {
+	track := m.display.styles.MinimapStyle.Render(minimapTrack)
+	mark := m.display.styles.MinimapMarkStyle.Render(minimapMark)
+	res := make([]string, height)
+	for i := range res {
+		res[i] = track
+	}
+	numItems := m.content.numItems()
+	if numItems == 0 {
+		return res
+	}
+	for _, itemIdx := range m.minimapMarkedItems() {
+		if itemIdx >= 0 && itemIdx < numItems {
+			res[itemIdx*height/numItems] = mark
+		}
+	}
+	return res
+}
+
+// minimapRowAt returns the minimap row at the row and column relative to the top left of the viewport, false if the
+// position isn't on the minimap
+func (m *Model[T]) minimapRowAt(row, col int) (int, bool) {
+	if !m.config.minimap || col != m.display.bounds.width-m.numGutterCols()+m.numTimeGutterCols() {
+		return 0, false
+	}
+	contentRow := row - len(m.getVisibleHeaderLines())
+	if m.showsPostHeaderLine() {
+		contentRow--
+	}
+	if contentRow < 0 || contentRow >= m.getNumContentLines() {
+		return 0, false
+	}
+	return contentRow, true
+}
+
+// jumpToMinimapRow centers the first marked item of the minimap row in view, or the row's first item if none are
+// marked, selecting it when selection is enabled
+func (m *Model[T]) jumpToMinimapRow(row int) {
+	numItems, height := m.content.numItems(), m.getNumContentLines()
+	if numItems == 0 || height == 0 {
+		return
+	}
+	start, end := minimapRowStart(row, height, numItems), minimapRowStart(row+1, height, numItems)
+	target := min(start, numItems-1)
+	marked := m.minimapMarkedItems()
+	if i, _ := slices.BinarySearch(marked, start); i < len(marked) && marked[i] < end {
+		target = marked[i]
+	}
+	if m.navigation.selectionEnabled {
+		m.setSelectedItemIdx(target)
+	}
+	m.ensureItemInView(target, 0, 0, height, 0)
+}
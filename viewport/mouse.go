@@ -0,0 +1,121 @@
+package viewport
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// mouseWheelLines is the number of lines or items scrolled per mouse wheel step
+	mouseWheelLines = 3
+
+	// mouseWheelCols is the number of columns panned per horizontal mouse wheel step
+	mouseWheelCols = 6
+)
+
+// mouseState tracks mouse configuration and an in-progress drag
+type mouseState struct {
+	// enabled is true if the viewport handles mouse messages
+	enabled bool
+
+	// originX and originY are the screen coordinates of the viewport's top left cell
+	originX, originY int
+
+	// dragging is true between a left click on content and the following release
+	dragging bool
+
+	// dragLastY is the screen row of the last drag event
+	dragLastY int
+}
+
+// mouseLinkCmd returns a command activating the link left-clicked by msg, nil if msg isn't such a click
+func (m *Model[T]) mouseLinkCmd(msg tea.MouseMsg) tea.Cmd {
+	click, ok := msg.(tea.MouseClickMsg)
+	if !ok || !m.config.mouse.enabled || click.Button != tea.MouseLeft {
+		return nil
+	}
+	return m.clickedLinkCmd(click.Y-m.config.mouse.originY, click.X-m.config.mouse.originX)
+}
+
+// handleMouse processes a mouse message, returning true if it was handled
+func (m *Model[T]) handleMouse(msg tea.MouseMsg) bool {
+	if !m.config.mouse.enabled {
+		return false
+	}
+	mouse := msg.Mouse()
+	switch msg.(type) {
+	case tea.MouseWheelMsg:
+		return m.handleMouseWheel(mouse)
+
+	case tea.MouseClickMsg:
+		if mouse.Button != tea.MouseLeft {
+			return false
+		}
+		row, col := mouse.Y-m.config.mouse.originY, mouse.X-m.config.mouse.originX
+		if minimapRow, ok := m.minimapRowAt(row, col); ok {
+			m.jumpToMinimapRow(minimapRow)
+			return true
+		}
+		itemIdx, _, ok := m.GetItemAtScreenPosition(row, col)
+		if !ok {
+			return false
+		}
+		m.config.mouse.dragging = true
+		m.config.mouse.dragLastY = mouse.Y
+		if m.navigation.selectionEnabled {
+			m.setSelectedItemIdx(itemIdx)
+		}
+		return true
+
+	case tea.MouseMotionMsg:
+		if !m.config.mouse.dragging || mouse.Button != tea.MouseLeft {
+			return false
+		}
+		// dragging up pulls content up, scrolling down
+		linesDown := m.config.mouse.dragLastY - mouse.Y
+		m.config.mouse.dragLastY = mouse.Y
+		m.scrollDownLines(linesDown)
+		if m.navigation.selectionEnabled {
+			m.keepSelectionVisible(linesDown > 0)
+		}
+		return true
+
+	case tea.MouseReleaseMsg:
+		wasDragging := m.config.mouse.dragging
+		m.config.mouse.dragging = false
+		return wasDragging
+	}
+	return false
+}
+
+// handleMouseWheel scrolls vertically, or horizontally for horizontal wheel events or with shift held
+func (m *Model[T]) handleMouseWheel(mouse tea.Mouse) bool {
+	horizontal := mouse.Mod.Contains(tea.ModShift)
+	switch {
+	case mouse.Button == tea.MouseWheelLeft || (horizontal && mouse.Button == tea.MouseWheelUp):
+		m.scrollHorizontal(m.navigation.left(mouseWheelCols))
+	case mouse.Button == tea.MouseWheelRight || (horizontal && mouse.Button == tea.MouseWheelDown):
+		m.scrollHorizontal(m.navigation.right(mouseWheelCols))
+	case mouse.Button == tea.MouseWheelUp:
+		m.scrollVertical(m.navigation.up(mouseWheelLines))
+	case mouse.Button == tea.MouseWheelDown:
+		m.scrollVertical(m.navigation.down(mouseWheelLines))
+	default:
+		return false
+	}
+	return true
+}
+
+// keepSelectionVisible moves the selection to the nearest visible item if it has scrolled out of view
+func (m *Model[T]) keepSelectionVisible(scrolledDown bool) {
+	itemIndexes := m.getVisibleContentItemIndexes()
+	if len(itemIndexes) == 0 {
+		return
+	}
+	selectedIdx := m.content.getSelectedIdx()
+	first, last := itemIndexes[0], itemIndexes[len(itemIndexes)-1]
+	if scrolledDown && selectedIdx < first {
+		m.content.setSelectedIdx(first)
+	} else if !scrolledDown && selectedIdx > last {
+		m.content.setSelectedIdx(last)
+	}
+}
@@ -0,0 +1,125 @@
+package viewport
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// ItemMovedMsg is sent when an item is moved in move mode. From and To are the item's indexes before and after.
+type ItemMovedMsg struct {
+	From int
+	To   int
+}
+
+// canMoveItems returns whether items can be reordered. Preprocessed and joined objects don't map one-to-one to the
+// caller's objects, and an item source is read-only, so they can't be.
+func (m *Model[T]) canMoveItems() bool {
+	return m.content.source == nil && m.content.preprocessing == nil && m.content.joining == nil && !m.isSorted()
+}
+
+// movedIdx returns where the item at idx ends up when the item at from moves to to
+func movedIdx(idx, from, to int) int {
+	switch {
+	case idx == from:
+		return to
+	case from < to && from < idx && idx <= to:
+		return idx - 1
+	case to < from && to <= idx && idx < from:
+		return idx + 1
+	}
+	return idx
+}
+
+// moveItem moves the item at from to to, carrying its selection, marks, bookmarks, expanded details, links and
+// highlights along
+func (m *Model[T]) moveItem(from, to int) {
+	obj := m.content.objects[from]
+	m.content.objects = slices.Insert(slices.Delete(m.content.objects, from, from+1), to, obj)
+	m.content.resetStickyMemo()
+
+	if len(m.content.marked) > 0 {
+		marked := make(map[int]struct{}, len(m.content.marked))
+		for idx := range m.content.marked {
+			marked[movedIdx(idx, from, to)] = struct{}{}
+		}
+		m.content.marked = marked
+	}
+
+	if len(m.content.bookmarked) > 0 {
+		bookmarked := make(map[int]struct{}, len(m.content.bookmarked))
+		for idx := range m.content.bookmarked {
+			bookmarked[movedIdx(idx, from, to)] = struct{}{}
+		}
+		m.content.bookmarked = bookmarked
+	}
+
+	if len(m.content.expandedDetails) > 0 {
+		expanded := make(map[int]struct{}, len(m.content.expandedDetails))
+		for idx := range m.content.expandedDetails {
+			expanded[movedIdx(idx, from, to)] = struct{}{}
+		}
+		m.content.expandedDetails = expanded
+	}
+
+	// the selected item is still selected after moving
+	if m.navigation.prevSelectedIdx >= 0 {
+		m.navigation.prevSelectedIdx = movedIdx(m.navigation.prevSelectedIdx, from, to)
+	}
+
+	l := &m.content.links
+	if len(l.byItem) > 0 {
+		byItem := make(map[int][]Link, len(l.byItem))
+		for idx, links := range l.byItem {
+			byItem[movedIdx(idx, from, to)] = links
+		}
+		l.byItem = byItem
+		if l.focused != nil {
+			l.focused.itemIdx = movedIdx(l.focused.itemIdx, from, to)
+		}
+	}
+
+	if highlights := m.content.getHighlights(); len(highlights) > 0 {
+		moved := make([]Highlight, len(highlights))
+		for i, h := range highlights {
+			h.ItemIndex = movedIdx(h.ItemIndex, from, to)
+			moved[i] = h
+		}
+		m.content.setHighlights(moved)
+	}
+
+	m.refreshSearch()
+	if m.navigation.selectionEnabled {
+		m.content.setSelectedIdx(movedIdx(m.content.getSelectedIdx(), from, to))
+		m.scrollSoSelectionInView()
+	}
+}
+
+// moveSelectedCmd moves the selected item by delta, returning a command sending an ItemMovedMsg, nil if it can't move
+func (m *Model[T]) moveSelectedCmd(delta int) tea.Cmd {
+	from := m.content.getSelectedIdx()
+	to := from + delta
+	if !m.navigation.moveMode || !m.navigation.selectionEnabled || !m.canMoveItems() || to < 0 || to >= m.content.numItems() {
+		return nil
+	}
+	m.moveItem(from, to)
+	m.recordMove(from, to)
+	return func() tea.Msg {
+		return ItemMovedMsg{From: from, To: to}
+	}
+}
+
+// selectedItemStyle returns the style for the selected item, MovingItemStyle in move mode
+func (m *Model[T]) selectedItemStyle() lipgloss.Style {
+	if !m.config.focused {
+		return m.display.styles.BlurredStyle
+	}
+	if m.navigation.moveMode && m.canMoveItems() {
+		return m.display.styles.MovingItemStyle
+	}
+	if m.isFlashing(m.content.getSelectedIdx()) {
+		return m.display.styles.FlashStyle
+	}
+	return m.display.styles.SelectedItemStyle
+}
@@ -18,6 +18,38 @@ type navigationManager struct {
 
 	// bottomSticky is true when selection should remain at the bottom until user manually scrolls up
 	bottomSticky bool
+
+	// follow tracks follow mode, which extends bottomSticky with an indicator and state change messages
+	follow followState
+
+	// moveMode is true when the MoveItemUp and MoveItemDown keys reorder the selected item
+	moveMode bool
+
+	// pan tracks the horizontal pan step and acceleration
+	pan panState
+
+	// prevSelectedIdx is the selected item index when Update started, -1 if nothing was selected, kept on the same
+	// item if it's moved
+	prevSelectedIdx int
+
+	// matchingItemPadding is the lines of context left around items moved to with NextItemMatching and
+	// PrevItemMatching
+	matchingItemPadding int
+
+	// subLine tracks the line-level cursor in sub-line selection mode
+	subLine subLineState
+
+	// matchingItemIdx is the item last moved to with NextItemMatching or PrevItemMatching, -1 if none
+	matchingItemIdx int
+
+	// smoothScroll animates jumps of more than a page
+	smoothScroll smoothScrollState
+
+	// chords tracks key sequences bound like "g g"
+	chords chordState
+
+	// pageScroll is what scrolling by a page or half a page does to the selection
+	pageScroll PageScrollSelection
 }
 
 // newNavigationManager creates a new navigationManager with the specified key mappings.
@@ -27,6 +59,9 @@ func newNavigationManager(keyMap KeyMap) *navigationManager {
 		selectionEnabled: false,
 		topSticky:        false,
 		bottomSticky:     false,
+		pan:              newPanState(),
+		matchingItemIdx:  -1,
+		chords:           chordState{timeout: DefaultKeyChordTimeout},
 	}
 }
 
@@ -56,6 +91,10 @@ const (
 	actionTop
 	// actionBottom represents moving to the bottom.
 	actionBottom
+	// actionPanToStart represents panning to the start of lines.
+	actionPanToStart
+	// actionPanToEnd represents panning to the end of the longest visible line.
+	actionPanToEnd
 )
 
 // navigationContext contains the context needed for navigation calculations
@@ -84,12 +123,22 @@ func (nm navigationManager) processKeyMsg(msg tea.KeyMsg, ctx navigationContext)
 
 	case key.Matches(msg, nm.keyMap.Left):
 		if !ctx.wrapText {
-			return nm.left(ctx.dimensions.width / 4)
+			return nm.left(nm.panStep(ctx))
 		}
 
 	case key.Matches(msg, nm.keyMap.Right):
 		if !ctx.wrapText {
-			return nm.right(ctx.dimensions.width / 4)
+			return nm.right(nm.panStep(ctx))
+		}
+
+	case key.Matches(msg, nm.keyMap.PanToStart):
+		if !ctx.wrapText {
+			return navigationResult{action: actionPanToStart}
+		}
+
+	case key.Matches(msg, nm.keyMap.PanToEnd):
+		if !ctx.wrapText {
+			return navigationResult{action: actionPanToEnd}
 		}
 
 	case key.Matches(msg, nm.keyMap.HalfPageUp):
@@ -114,6 +163,14 @@ func (nm navigationManager) processKeyMsg(msg tea.KeyMsg, ctx navigationContext)
 	return navigationResult{action: actionNone}
 }
 
+// panStep returns how many columns the Left and Right keys pan before acceleration
+func (nm navigationManager) panStep(ctx navigationContext) int {
+	if nm.pan.step > 0 {
+		return nm.pan.step
+	}
+	return ctx.dimensions.width / 4
+}
+
 func (nm navigationManager) up(numLines int) navigationResult {
 	return navigationResult{action: actionUp, scrollAmount: -numLines, selectionAmount: -numLines}
 }
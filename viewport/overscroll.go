@@ -0,0 +1,119 @@
+package viewport
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// overscrollState tracks the footer indicator shown when scrolling past the top or bottom
+type overscrollState struct {
+	// top and bottom are the indicators shown when scrolling past the top and bottom
+	top, bottom string
+
+	// duration is how long the indicator shows, 0 to disable it
+	duration time.Duration
+
+	// showing is true while the indicator shows, and indicator is the one shown
+	showing   bool
+	indicator string
+
+	// seq identifies the latest overscroll, so that only its clearOverscrollMsg hides the indicator
+	seq int
+}
+
+// clearOverscrollMsg hides the overscroll indicator shown for the overscroll identified by seq
+type clearOverscrollMsg struct {
+	id  int
+	seq int
+}
+
+// scrollPosition is where the viewport is scrolled to and what's selected
+type scrollPosition struct {
+	topItemIdx, topItemLineOffset   int
+	selectedIdx, selectedLineOffset int
+}
+
+// WithOverscrollIndicator sets the indicators shown in the footer when scrolling past the top or bottom, see
+// SetOverscrollIndicator
+func WithOverscrollIndicator[T Object](top, bottom string, duration time.Duration) Option[T] {
+	return func(m *Model[T]) {
+		m.SetOverscrollIndicator(top, bottom, duration)
+	}
+}
+
+// SetOverscrollIndicator sets the indicators shown in the footer for duration when a key or the mouse wheel tries to
+// scroll past the top or bottom, e.g. "TOP" and "END". The footer is styled with OverscrollStyle meanwhile, so empty
+// indicators just flash it. FooterInfo.Overscroll passes the indicator to custom footer formatters. A duration of 0,
+// the default, disables the indicator.
+func (m *Model[T]) SetOverscrollIndicator(top, bottom string, duration time.Duration) {
+	o := &m.config.overscroll
+	o.top, o.bottom, o.duration = top, bottom, max(0, duration)
+	if o.duration == 0 {
+		o.showing, o.indicator = false, ""
+	}
+}
+
+// GetOverscrollIndicator returns the indicators shown when scrolling past the top and bottom, and for how long
+func (m *Model[T]) GetOverscrollIndicator() (top, bottom string, duration time.Duration) {
+	o := m.config.overscroll
+	return o.top, o.bottom, o.duration
+}
+
+// overscrollIndicator returns the overscroll indicator shown, empty if none is
+func (m *Model[T]) overscrollIndicator() string {
+	if !m.config.overscroll.showing {
+		return ""
+	}
+	return m.config.overscroll.indicator
+}
+
+// scrollPosition returns where the viewport is scrolled to and what's selected
+func (m *Model[T]) scrollPosition() scrollPosition {
+	p := scrollPosition{topItemIdx: m.display.topItemIdx, topItemLineOffset: m.display.topItemLineOffset}
+	if m.navigation.selectionEnabled {
+		p.selectedIdx = m.content.getSelectedIdx()
+		if m.subLineActive() {
+			p.selectedLineOffset = m.selectedLineOffset()
+		}
+	}
+	return p
+}
+
+// showOverscroll shows the overscroll indicator if the navigation action tried to scroll from prev but nothing moved,
+// returning the command hiding it, nil if it isn't shown
+func (m *Model[T]) showOverscroll(action navigationAction, prev scrollPosition) tea.Cmd {
+	o := &m.config.overscroll
+	if o.duration <= 0 || m.content.isEmpty() || m.scrollPosition() != prev {
+		return nil
+	}
+	switch action {
+	case actionUp, actionHalfPageUp, actionPageUp, actionTop:
+		o.indicator = o.top
+	case actionDown, actionHalfPageDown, actionPageDown, actionBottom:
+		o.indicator = o.bottom
+	default:
+		return nil
+	}
+	o.showing = true
+	o.seq++
+	id, seq := m.id, o.seq
+	return tea.Tick(o.duration, func(time.Time) tea.Msg {
+		return clearOverscrollMsg{id: id, seq: seq}
+	})
+}
+
+// wheelAction returns the vertical navigation action of a mouse wheel event, actionNone if it isn't one
+func wheelAction(mouse tea.Mouse) navigationAction {
+	if mouse.Mod.Contains(tea.ModShift) {
+		return actionNone
+	}
+	switch mouse.Button {
+	case tea.MouseWheelUp:
+		return actionUp
+	case tea.MouseWheelDown:
+		return actionDown
+	default:
+		return actionNone
+	}
+}
@@ -0,0 +1,36 @@
+package viewport
+
+// PageScrollSelection is what scrolling by a page or half a page does to the selection, see SetPageScrollSelection
+type PageScrollSelection int
+
+const (
+	// PageScrollMovesSelection moves the selection as far as the content scrolls, the default
+	PageScrollMovesSelection PageScrollSelection = iota
+
+	// PageScrollKeepsSelectionInView scrolls the view only, leaving the selection on its item unless that scrolls out
+	// of view, in which case the selection moves to the nearest item in view
+	PageScrollKeepsSelectionInView
+
+	// PageScrollLeavesSelection scrolls the view only, leaving the selection on its item even out of view, like
+	// scrolling in less. Moving the selection scrolls back to it.
+	PageScrollLeavesSelection
+)
+
+// WithPageScrollSelection sets what scrolling by a page or half a page does to the selection, see
+// SetPageScrollSelection
+func WithPageScrollSelection[T Object](mode PageScrollSelection) Option[T] {
+	return func(m *Model[T]) {
+		m.SetPageScrollSelection(mode)
+	}
+}
+
+// SetPageScrollSelection sets what the PageDown, PageUp, HalfPageDown and HalfPageUp keys do to the selection when
+// selection is enabled: move it with the content, the default, or scroll the view without it
+func (m *Model[T]) SetPageScrollSelection(mode PageScrollSelection) {
+	m.navigation.pageScroll = mode
+}
+
+// GetPageScrollSelection returns what scrolling by a page or half a page does to the selection
+func (m *Model[T]) GetPageScrollSelection() PageScrollSelection {
+	return m.navigation.pageScroll
+}
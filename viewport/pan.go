@@ -0,0 +1,61 @@
+package viewport
+
+import "time"
+
+// panRepeatWindow is how soon a pan must follow the last in the same direction to count as a repeat, e.g. from a
+// held key, when accelerating
+const panRepeatWindow = 300 * time.Millisecond
+
+// panState tracks the horizontal pan step and acceleration of repeated pans
+type panState struct {
+	// step is how many columns the Left and Right keys pan, 0 for a quarter of the viewport width
+	step int
+
+	// maxMultiplier is the most the step is multiplied by on repeated pans, 1 or less for no acceleration
+	maxMultiplier int
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
+
+	// lastAction and lastTime are the direction and time of the last pan
+	lastAction navigationAction
+	lastTime   time.Time
+
+	// multiplier is the current step multiplier, growing by one on each repeated pan
+	multiplier int
+
+	// selectedOnly is true if panning moves only the selected item
+	selectedOnly bool
+}
+
+func newPanState() panState {
+	return panState{now: time.Now, multiplier: 1}
+}
+
+// accelerate scales the scroll amount of a Left or Right key pan by the multiplier for repeated pans in the same
+// direction
+func (p *panState) accelerate(navResult navigationResult) navigationResult {
+	now := p.now()
+	repeated := navResult.action == p.lastAction && now.Sub(p.lastTime) <= panRepeatWindow
+	p.lastAction, p.lastTime = navResult.action, now
+	if !repeated || p.maxMultiplier <= 1 {
+		p.multiplier = 1
+		return navResult
+	}
+	p.multiplier = min(p.multiplier+1, p.maxMultiplier)
+	navResult.scrollAmount *= p.multiplier
+	return navResult
+}
+
+// panSelectedOnly returns whether panning moves only the selected item, which requires selection
+func (m *Model[T]) panSelectedOnly() bool {
+	return m.navigation.pan.selectedOnly && m.navigation.selectionEnabled
+}
+
+// xOffsetOf returns how many cells the item at itemIdx is panned right when wrapping is disabled
+func (m *Model[T]) xOffsetOf(itemIdx int) int {
+	if m.panSelectedOnly() && itemIdx != m.content.getSelectedIdx() {
+		return 0
+	}
+	return m.display.xOffset
+}
@@ -0,0 +1,62 @@
+package viewport
+
+// peekState tracks the selected item expanded while unwrapped, see SetPeek
+type peekState struct {
+	// active is true while peeking
+	active bool
+
+	// itemIdx is the index of the item peeked at
+	itemIdx int
+}
+
+// SetPeek sets whether the selected item is expanded across as many rows as it needs, as if wrapped, while other
+// items stay unwrapped, e.g. to inspect one long line without wrapping everything. The Peek key, disabled by
+// default, toggles it. Peeking stops when the selection moves, and has no effect while wrapping or when selection is
+// disabled.
+func (m *Model[T]) SetPeek(peek bool) {
+	if !peek {
+		m.stopPeek()
+		return
+	}
+	if m.config.wrapText || !m.navigation.selectionEnabled || m.content.isEmpty() {
+		return
+	}
+	m.config.peek = peekState{active: true, itemIdx: m.content.getSelectedIdx()}
+	m.scrollSoSelectionInView()
+}
+
+// GetPeek returns whether the selected item is expanded by peeking
+func (m *Model[T]) GetPeek() bool {
+	return m.peekedIdx() >= 0
+}
+
+// peekedIdx returns the index of the item expanded by peeking, -1 if none is
+func (m *Model[T]) peekedIdx() int {
+	p := m.config.peek
+	if !p.active || m.config.wrapText || m.selectedIdxIfEnabled() != p.itemIdx {
+		return -1
+	}
+	return p.itemIdx
+}
+
+// wrapsItem returns whether the item at itemIdx is wrapped, either because wrapping is enabled or it's peeked at
+func (m *Model[T]) wrapsItem(itemIdx int) bool {
+	return m.config.wrapText || (itemIdx >= 0 && itemIdx == m.peekedIdx())
+}
+
+// stopPeek stops peeking, keeping the top item's line offset within its now unwrapped lines
+func (m *Model[T]) stopPeek() {
+	if !m.config.peek.active {
+		return
+	}
+	m.config.peek.active = false
+	topLineOffset := min(m.display.topItemLineOffset, m.numLinesForItem(m.display.topItemIdx)-1)
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, topLineOffset)
+}
+
+// stopPeekIfMoved stops peeking if the selection moved off the peeked item
+func (m *Model[T]) stopPeekIfMoved() {
+	if m.config.peek.active && m.peekedIdx() < 0 {
+		m.stopPeek()
+	}
+}
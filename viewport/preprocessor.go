@@ -0,0 +1,55 @@
+package viewport
+
+import (
+	"charm.land/lipgloss/v2"
+)
+
+// Preprocessor transforms the objects passed to SetObjects into the objects displayed, e.g. rendering markdown into
+// styled lines. It's given the content width and whether text wraps, and runs again whenever either changes so that
+// width-dependent rendering stays in sync with the viewport.
+type Preprocessor[T Object] func(objects []T, width int, wrapText bool) []T
+
+// ItemStyleFunc returns a style to layer under an unselected item's own styling. The style only applies to
+// portions of the item without existing ANSI styling, highlights or search matches.
+type ItemStyleFunc[T Object] func(object T) lipgloss.Style
+
+// preprocessing tracks the objects set on the viewport before preprocessing
+type preprocessing[T Object] struct {
+	fn Preprocessor[T]
+
+	// unprocessed is every object set on the viewport, before preprocessing
+	unprocessed []T
+
+	// width and wrapText are the arguments fn last ran with
+	width    int
+	wrapText bool
+}
+
+// preprocess runs the preprocessor, if any, on objects
+func (m *Model[T]) preprocess(objects []T) []T {
+	p := m.content.preprocessing
+	if p == nil {
+		return objects
+	}
+	p.unprocessed = objects
+	p.width = m.contentWidth()
+	p.wrapText = m.config.wrapText
+	return p.fn(objects, p.width, p.wrapText)
+}
+
+// reprocess sets the unprocessed objects again if the content width or wrapping changed since they were preprocessed
+func (m *Model[T]) reprocess() {
+	p := m.content.preprocessing
+	if p == nil || (p.width == m.contentWidth() && p.wrapText == m.config.wrapText) {
+		return
+	}
+	m.setObjects(p.unprocessed)
+}
+
+// layerItemStyle applies the item style, if any, to the unstyled portions of a rendered line of the item at itemIdx
+func (m *Model[T]) layerItemStyle(itemIdx int, line string) string {
+	if m.content.itemStyleFunc == nil {
+		return line
+	}
+	return styleUnstyled(line, m.content.itemStyleFunc(m.content.objectAt(itemIdx)))
+}
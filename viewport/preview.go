@@ -0,0 +1,138 @@
+package viewport
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// previewMargin is the number of columns left between the preview popup and each side of the viewport
+const previewMargin = 1
+
+// previewState tracks the popup previewing text over the content
+type previewState struct {
+	// active is true while the popup shows
+	active bool
+
+	// text is the previewed text, wrapped to fit the popup
+	text string
+
+	// yOffset is the number of wrapped rows of text scrolled above the top of the popup
+	yOffset int
+}
+
+// PreviewSelected shows the full content of the selected item, wrapped, in a popup over the content, e.g. to read a
+// long line without wrapping or panning, see Preview. Returns false if nothing is selected.
+func (m *Model[T]) PreviewSelected() bool {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() {
+		return false
+	}
+	m.Preview(m.content.itemAt(m.content.getSelectedIdx()).Content())
+	return true
+}
+
+// Preview shows text, wrapped, in a popup centered over the content and styled with PreviewStyle, until esc or
+// ClosePreview dismisses it. The popup is sized to fit text within the content area, and when text has more rows than
+// fit, the Up, Down, PageUp and PageDown keys scroll it. Other keys are ignored while it shows.
+func (m *Model[T]) Preview(text string) {
+	m.config.preview = previewState{active: true, text: text}
+}
+
+// ClosePreview dismisses the preview popup
+func (m *Model[T]) ClosePreview() {
+	m.config.preview = previewState{}
+}
+
+// IsPreviewing returns whether the preview popup shows
+func (m *Model[T]) IsPreviewing() bool {
+	return m.config.preview.active
+}
+
+// updatePreview handles msg while the preview popup shows, returning false if it isn't a key meant for the popup
+func (m *Model[T]) updatePreview(msg tea.Msg) bool {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return false
+	}
+	p := &m.config.preview
+	_, innerHeight := m.previewInnerSize()
+	switch {
+	case keyMsg.Code == tea.KeyEscape:
+		m.ClosePreview()
+	case key.Matches(keyMsg, m.navigation.keyMap.Up):
+		p.yOffset--
+	case key.Matches(keyMsg, m.navigation.keyMap.Down):
+		p.yOffset++
+	case key.Matches(keyMsg, m.navigation.keyMap.PageUp):
+		p.yOffset -= innerHeight
+	case key.Matches(keyMsg, m.navigation.keyMap.PageDown):
+		p.yOffset += innerHeight
+	}
+	return true
+}
+
+// previewInnerSize returns the largest width and height of the text in the preview popup that fit in the content area
+func (m *Model[T]) previewInnerSize() (width, height int) {
+	style := m.display.styles.PreviewStyle
+	width = m.display.bounds.width - style.GetHorizontalFrameSize() - 2*previewMargin
+	height = m.getNumContentLines() - style.GetVerticalFrameSize()
+	return max(0, width), max(0, height)
+}
+
+// previewRows returns the rows of the previewed text wrapped to at most maxWidth, each padded to the widest row
+func (m *Model[T]) previewRows(maxWidth int) []string {
+	lines := strings.Split(m.config.preview.text, "\n")
+	width := 1
+	for _, line := range lines {
+		width = max(width, lipgloss.Width(line))
+	}
+	width = min(width, maxWidth)
+
+	var rows []string
+	for _, line := range lines {
+		lineItem := item.NewItem(line)
+		starts := item.WrapRowStartsIndented(lineItem, width, 0, m.config.wrapMode, m.config.wordChars)
+		for i, start := range starts {
+			end := lineItem.Width()
+			if i+1 < len(starts) {
+				end = starts[i+1]
+			}
+			row, rowWidth := lineItem.Take(start, min(end-start, width), "", nil)
+			rows = append(rows, row+strings.Repeat(" ", width-rowWidth))
+		}
+	}
+	return rows
+}
+
+// previewLines draws the preview popup centered over the content lines, clipping its text to fit
+func (m *Model[T]) previewLines(lines []string) []string {
+	innerWidth, innerHeight := m.previewInnerSize()
+	if innerWidth == 0 || innerHeight == 0 {
+		return lines
+	}
+	p := &m.config.preview
+	rows := m.previewRows(innerWidth)
+	p.yOffset = clampValZeroToMax(p.yOffset, max(0, len(rows)-innerHeight))
+	rows = rows[p.yOffset:min(len(rows), p.yOffset+innerHeight)]
+
+	box := strings.Split(m.display.styles.PreviewStyle.Render(strings.Join(rows, "\n")), "\n")
+	boxWidth := lipgloss.Width(box[0])
+	w := m.display.bounds.width
+	top := max(0, (len(lines)-len(box))/2)
+	left := max(0, (w-boxWidth)/2)
+	res := make([]string, len(lines))
+	copy(res, lines)
+	for i, boxLine := range box {
+		if top+i >= len(res) {
+			break
+		}
+		lineItem := item.NewItem(res[top+i])
+		before, beforeWidth := lineItem.Take(0, left, "", nil)
+		after, _ := lineItem.Take(left+boxWidth, max(0, w-left-boxWidth), "", nil)
+		res[top+i] = before + strings.Repeat(" ", left-beforeWidth) + boxLine + after
+	}
+	return res
+}
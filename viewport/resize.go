@@ -0,0 +1,66 @@
+package viewport
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// ResizeSettledMsg applies the last size passed to Resize once resizing settles. Return it to the viewport's Update.
+type ResizeSettledMsg struct {
+	state *resizeState
+	seq   int
+}
+
+// resizeState throttles relayout while the viewport is resized rapidly, e.g. by dragging the terminal edge
+type resizeState struct {
+	// interval is the least time between relayouts from Resize, 0 to relayout on every call
+	interval time.Duration
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
+
+	// lastApplied is when Resize last laid out the viewport
+	lastApplied time.Time
+
+	// pending is the latest size not yet laid out, nil if none
+	pending *rectangle
+
+	// seq identifies the latest scheduled settle, so that earlier ones are ignored
+	seq int
+}
+
+func newResizeState() *resizeState {
+	return &resizeState{now: time.Now}
+}
+
+// resize applies the size now if the interval has passed since the last relayout, otherwise holds it and returns a
+// command that applies the latest held size once the interval passes
+func (m *Model[T]) resize(width, height int) tea.Cmd {
+	r := m.display.resize
+	now := r.now()
+	if r.interval <= 0 || now.Sub(r.lastApplied) >= r.interval {
+		r.pending = nil
+		r.lastApplied = now
+		m.setWidthHeight(width, height)
+		return nil
+	}
+	r.pending = &rectangle{width: width, height: height}
+	r.seq++
+	msg := ResizeSettledMsg{state: r, seq: r.seq}
+	return tea.Tick(r.interval-now.Sub(r.lastApplied), func(time.Time) tea.Msg {
+		return msg
+	})
+}
+
+// settleResize applies the held size if msg is the latest scheduled settle for this viewport
+func (m *Model[T]) settleResize(msg ResizeSettledMsg) {
+	r := m.display.resize
+	if msg.state != r || msg.seq != r.seq || r.pending == nil {
+		return
+	}
+	size := *r.pending
+	r.pending = nil
+	r.lastApplied = r.now()
+	m.setWidthHeight(size.width, size.height)
+}
@@ -0,0 +1,220 @@
+package viewport
+
+import "time"
+
+// retention bounds the objects kept, dropping the oldest beyond a maximum count, total size or age, see WithMaxItems,
+// WithMaxBytes and WithMaxAge
+type retention struct {
+	// maxItems is the most objects kept, 0 for no limit
+	maxItems int
+
+	// maxBytes is the most bytes of content kept, 0 for no limit
+	maxBytes int
+
+	// maxAge is the oldest an object's timestamp may be for it to be kept, 0 for no limit
+	maxAge time.Duration
+
+	// totalBytes is the size of the content of the objects kept, tracked while maxBytes is set
+	totalBytes int
+}
+
+// active returns whether any limit is set
+func (r retention) active() bool {
+	return r.maxItems > 0 || r.maxBytes > 0 || r.maxAge > 0
+}
+
+// WithMaxItems keeps at most n objects, dropping the oldest as objects are set or appended, e.g. to bound the memory
+// of a long-running log tail. See SetMaxItems.
+func WithMaxItems[T Object](n int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMaxItems(n)
+	}
+}
+
+// WithMaxBytes keeps at most b bytes of object content, dropping the oldest objects as objects are set or appended.
+// See SetMaxBytes.
+func WithMaxBytes[T Object](b int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMaxBytes(b)
+	}
+}
+
+// SetMaxItems sets the most objects kept, dropping the oldest, the first ones, beyond it now and as objects are set
+// or appended. The selected object stays selected while it's kept and sticky bottom keeps following new objects.
+// Objects from an item source aren't dropped. 0, the default, keeps every object.
+func (m *Model[T]) SetMaxItems(n int) {
+	m.config.retention.maxItems = max(0, n)
+	m.applyRetention()
+}
+
+// GetMaxItems returns the most objects kept, 0 if there's no limit
+func (m *Model[T]) GetMaxItems() int {
+	return m.config.retention.maxItems
+}
+
+// SetMaxBytes sets the most bytes of object content kept, dropping the oldest objects beyond it now and as objects
+// are set or appended, as SetMaxItems does. The newest object is always kept. 0, the default, keeps every object.
+func (m *Model[T]) SetMaxBytes(b int) {
+	m.config.retention.maxBytes = max(0, b)
+	m.applyRetention()
+}
+
+// GetMaxBytes returns the most bytes of object content kept, 0 if there's no limit
+func (m *Model[T]) GetMaxBytes() int {
+	return m.config.retention.maxBytes
+}
+
+// RetainNewest returns the last of objects within maxItems objects and maxBytes bytes of content, a limit of 0 being
+// no limit, always keeping the last object. It's how the viewport applies WithMaxItems and WithMaxBytes, e.g. for a
+// wrapper keeping its own copy of the objects. Measuring bytes reads the content of the objects kept.
+func RetainNewest[T Object](objects []T, maxItems, maxBytes int) []T {
+	start, _ := retainedStart(objects, retention{maxItems: maxItems, maxBytes: maxBytes})
+	return objects[start:]
+}
+
+// retainedStart returns the index of the first of objects kept within the limits of r and the size of the content
+// kept, 0 if maxBytes isn't set
+func retainedStart[T Object](objects []T, r retention) (int, int) {
+	start := 0
+	if r.maxItems > 0 {
+		start = max(0, len(objects)-r.maxItems)
+	}
+	if r.maxBytes <= 0 {
+		return start, 0
+	}
+	total := 0
+	for i := len(objects) - 1; i >= start; i-- {
+		size := len(objects[i].GetItem().Content())
+		if total+size > r.maxBytes && i < len(objects)-1 {
+			return i + 1, total
+		}
+		total += size
+	}
+	return start, total
+}
+
+// keptStart returns the index of the first of objects kept within the limits, including the max age, and the size of
+// the content kept, 0 if maxBytes isn't set
+func (m *Model[T]) keptStart(objects []T) (int, int) {
+	r := m.config.retention
+	start, totalBytes := retainedStart(objects, r)
+	for start < len(objects)-1 && m.isExpired(objects[start]) {
+		if r.maxBytes > 0 {
+			totalBytes -= len(objects[start].GetItem().Content())
+		}
+		start++
+	}
+	return start, totalBytes
+}
+
+// isExpired returns whether obj is older than the max age, false without a max age or timestamp function
+func (m *Model[T]) isExpired(obj T) bool {
+	if m.config.retention.maxAge <= 0 || m.content.timestampFunc == nil {
+		return false
+	}
+	return m.content.timestampFunc(obj).Before(m.config.timestamps.now().Add(-m.config.retention.maxAge))
+}
+
+// retain returns the objects kept of those being set, measuring their size
+func (m *Model[T]) retain(objects []T) []T {
+	r := &m.config.retention
+	if !r.active() {
+		return objects
+	}
+	start, totalBytes := m.keptStart(objects)
+	r.totalBytes = totalBytes
+	return objects[start:]
+}
+
+// retainAppended drops the oldest objects beyond the limits after objects were appended to the plain objects, not
+// joined, in a tree, preprocessed, sorted or filtered to changes
+func (m *Model[T]) retainAppended(objects []T) {
+	r := &m.config.retention
+	if !r.active() {
+		return
+	}
+	if r.maxBytes > 0 {
+		for i := range objects {
+			r.totalBytes += len(objects[i].GetItem().Content())
+		}
+	}
+	numDropped := 0
+	if r.maxItems > 0 {
+		numDropped = max(0, len(m.content.objects)-r.maxItems)
+	}
+	if r.maxBytes > 0 {
+		for i := range numDropped {
+			r.totalBytes -= len(m.content.objects[i].GetItem().Content())
+		}
+		for numDropped < len(m.content.objects)-1 && r.totalBytes > r.maxBytes {
+			r.totalBytes -= len(m.content.objects[numDropped].GetItem().Content())
+			numDropped++
+		}
+	}
+	for numDropped < len(m.content.objects)-1 && m.isExpired(m.content.objects[numDropped]) {
+		if r.maxBytes > 0 {
+			r.totalBytes -= len(m.content.objects[numDropped].GetItem().Content())
+		}
+		numDropped++
+	}
+	if numDropped > 0 {
+		m.dropOldest(numDropped)
+	}
+}
+
+// applyRetention drops the oldest objects beyond the limits after they change
+func (m *Model[T]) applyRetention() {
+	if !m.config.retention.active() || m.content.source != nil || m.content.isEmpty() {
+		return
+	}
+	objects := m.content.unprocessedObjects()
+	if start, _ := m.keptStart(objects); start > 0 {
+		m.setObjects(objects)
+	} else {
+		m.retain(objects)
+	}
+}
+
+// dropOldest removes the first n of the plain objects, keeping the selection, the content in view and the state of
+// the objects kept
+func (m *Model[T]) dropOldest(n int) {
+	m.removeFromSoftLimits(m.content.objects[:n], m.content.objects[n:])
+	clear(m.content.objects[:n])
+	m.content.objects = m.content.objects[n:]
+	m.content.resetStickyMemo()
+
+	if highlights := m.content.getHighlights(); len(highlights) > 0 {
+		kept := make([]Highlight, 0, len(highlights))
+		for _, h := range highlights {
+			if h.ItemIndex >= n {
+				h.ItemIndex -= n
+				kept = append(kept, h)
+			}
+		}
+		m.content.setHighlights(kept)
+	}
+	m.dropSearchMatches(n)
+	m.shiftMarks(-n)
+	m.shiftBookmarks(-n)
+	m.config.undo.shiftMoves(-n)
+	m.shiftExpandedDetails(-n)
+	m.shiftSeparators(-n)
+	m.shiftLinks(-n)
+	if m.config.goTo.flashItemIdx >= 0 {
+		m.config.goTo.flashItemIdx = max(-1, m.config.goTo.flashItemIdx-n)
+	}
+	m.config.blockSelection.anchorItemIdx = max(0, m.config.blockSelection.anchorItemIdx-n)
+
+	if m.display.topItemIdx < n {
+		m.display.setTopItemIdxAndOffset(0, 0)
+	} else {
+		m.display.setTopItemIdxAndOffset(m.display.topItemIdx-n, m.display.topItemLineOffset)
+	}
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.content.setSelectedIdx(m.content.getSelectedIdx() - n)
+		if m.navigation.prevSelectedIdx >= 0 {
+			m.navigation.prevSelectedIdx = max(-1, m.navigation.prevSelectedIdx-n)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package viewport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// SaveFormat is how the save key writes content to a file, see WithFileSaving
+type SaveFormat int
+
+const (
+	// SaveText writes the content without its ANSI styling to a .txt file
+	SaveText SaveFormat = iota
+
+	// SaveRaw writes the content as it is, ANSI escape sequences included, to a .ansi file, e.g. for less -R
+	SaveRaw
+
+	// SaveHTML writes the content to a standalone .html document that keeps its colors and styling
+	SaveHTML
+
+	numSaveFormats = iota
+)
+
+// String returns the name of the format shown in the filename prompt
+func (f SaveFormat) String() string {
+	switch f {
+	case SaveRaw:
+		return "raw"
+	case SaveHTML:
+		return "html"
+	default:
+		return "text"
+	}
+}
+
+// extension returns the file extension added to saved filenames without it
+func (f SaveFormat) extension() string {
+	switch f {
+	case SaveRaw:
+		return ".ansi"
+	case SaveHTML:
+		return ".html"
+	default:
+		return ".txt"
+	}
+}
+
+// FileSavedMsg is sent when saving content to a file with the save key completes, e.g. for the host to show a
+// confirmation or open the file. The viewport shows the result in its footer when the message reaches its Update.
+type FileSavedMsg struct {
+	// Path is the full path of the saved file
+	Path string
+
+	// Bytes is how many bytes were written
+	Bytes int
+
+	// Format is the format the content was saved in
+	Format SaveFormat
+
+	// Err is why saving failed, nil on success
+	Err error
+}
+
+// WithSaveFormat sets the format the save key writes content in, SaveText by default. It can also be changed with
+// tab while entering the filename.
+func WithSaveFormat[T Object](format SaveFormat) Option[T] {
+	return func(m *Model[T]) {
+		m.SetSaveFormat(format)
+	}
+}
+
+// SetSaveFormat sets the format the save key writes content in, see WithSaveFormat
+func (m *Model[T]) SetSaveFormat(format SaveFormat) {
+	if format < 0 || format >= numSaveFormats {
+		format = SaveText
+	}
+	m.config.saveFormat = format
+	m.config.saveState.filenameInput.Placeholder = m.defaultSaveFilename()
+}
+
+// GetSaveFormat returns the format the save key writes content in
+func (m *Model[T]) GetSaveFormat() SaveFormat {
+	return m.config.saveFormat
+}
+
+// defaultSaveFilename returns the timestamped filename content is saved to when none is entered
+func (m *Model[T]) defaultSaveFilename() string {
+	return time.Now().Format("20060102-150405") + m.config.saveFormat.extension()
+}
+
+// saveFilename returns the filename content is saved to given what was entered, adding the format's extension if
+// it's missing
+func (m *Model[T]) saveFilename(entered string) string {
+	if entered == "" {
+		return m.defaultSaveFilename()
+	}
+	if ext := m.config.saveFormat.extension(); !strings.HasSuffix(entered, ext) {
+		return entered + ext
+	}
+	return entered
+}
+
+// saveToFile saves all viewport objects to a file with the given filename in the save format.
+func (m *Model[T]) saveToFile(filename string) tea.Cmd {
+	format := m.config.saveFormat
+	return func() tea.Msg {
+		// create directory if needed
+		if err := os.MkdirAll(m.config.saveDir, 0750); err != nil {
+			return FileSavedMsg{Format: format, Err: fmt.Errorf("failed to create directory %s: %w", m.config.saveDir, err)}
+		}
+
+		fullPath := filepath.Join(m.config.saveDir, filename)
+		data := []byte(m.savedContent(format))
+		if err := os.WriteFile(fullPath, data, 0600); err != nil {
+			return FileSavedMsg{Format: format, Err: fmt.Errorf("failed to write file: %w", err)}
+		}
+
+		return FileSavedMsg{Path: fullPath, Bytes: len(data), Format: format}
+	}
+}
+
+// savedContent returns the content of all viewport objects, one per line, in format
+func (m *Model[T]) savedContent(format SaveFormat) string {
+	objects := m.content.allObjects()
+	if format == SaveHTML {
+		lines := make([]string, len(objects))
+		for i, obj := range objects {
+			lines[i] = obj.GetItem().Content()
+		}
+		return htmlDocument(lines)
+	}
+
+	var content strings.Builder
+	for _, obj := range objects {
+		if format == SaveRaw {
+			content.WriteString(obj.GetItem().Content())
+		} else {
+			content.WriteString(obj.GetItem().ContentNoAnsi())
+		}
+		content.WriteString("\n")
+	}
+	return content.String()
+}
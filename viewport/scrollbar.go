@@ -0,0 +1,62 @@
+package viewport
+
+const (
+	// scrollbarTrack and scrollbarThumb are the characters of the scrollbar's track and thumb
+	scrollbarTrack = "│"
+	scrollbarThumb = "┃"
+)
+
+// scrollbarThumbBounds returns the first row and the number of rows of the thumb of a scrollbar of height rows, given
+// the amount of content above the view, in view, and in total
+func scrollbarThumbBounds(height, above, inView, total int) (int, int) {
+	if height <= 0 {
+		return 0, 0
+	}
+	if total <= inView {
+		return 0, height
+	}
+	size := min(max((height*inView+total/2)/total, 1), height)
+	maxAbove := total - inView
+	// round so the thumb only reaches the top or bottom of the track when the view does
+	top := (height - size) * above / maxAbove
+	if above > 0 && top == 0 && height > size {
+		top = 1
+	}
+	if above < maxAbove && top == height-size && top > 0 {
+		top--
+	}
+	return top, size
+}
+
+// scrollbarColumn returns the rows of the scrollbar for the view showing itemIndexes over height rows. Like the
+// footer, it measures by items unless the footer metric is FooterMetricLines, as counting lines reads every item.
+func (m *Model[T]) scrollbarColumn(height int, itemIndexes []int) []string {
+	above, inView, total := m.display.topItemIdx, numDistinct(itemIndexes), m.content.numItems()
+	if m.config.footerMetric == FooterMetricLines {
+		above, total = m.linesAboveView()
+		inView = min(height, total-above)
+	}
+	thumbTop, thumbSize := scrollbarThumbBounds(height, above, inView, total)
+
+	track := m.display.styles.ScrollbarStyle.Render(scrollbarTrack)
+	thumb := m.display.styles.ScrollbarThumbStyle.Render(scrollbarThumb)
+	res := make([]string, height)
+	for i := range res {
+		res[i] = track
+		if i >= thumbTop && i < thumbTop+thumbSize {
+			res[i] = thumb
+		}
+	}
+	return res
+}
+
+// numDistinct returns the number of distinct values in the sorted idxs
+func numDistinct(idxs []int) int {
+	n := 0
+	for i, idx := range idxs {
+		if i == 0 || idx != idxs[i-1] {
+			n++
+		}
+	}
+	return n
+}
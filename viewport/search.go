@@ -0,0 +1,200 @@
+package viewport
+
+import (
+	"slices"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// searchMatch is a single occurrence of the search query
+type searchMatch struct {
+	itemIdx int
+	match   item.Match
+}
+
+// searchState tracks the in-viewport search, which highlights every occurrence of a query without hiding items
+type searchState struct {
+	// query is the exact text searched for, "" when search is off
+	query string
+
+	// matches is every occurrence of query, ordered by item index then position
+	matches []searchMatch
+
+	// firstMatchIdxByItem maps an item index to the index in matches of its first match
+	firstMatchIdxByItem map[int]int
+
+	// focusedIdx is the index in matches of the focused match, -1 if none
+	focusedIdx int
+}
+
+func newSearchState() searchState {
+	return searchState{
+		firstMatchIdxByItem: make(map[int]int),
+		focusedIdx:          -1,
+	}
+}
+
+// findSearchMatches scans items [fromIdx, numItems) for the query, appending to the existing matches
+func (m *Model[T]) findSearchMatches(fromIdx int) {
+	s := &m.content.search
+	for itemIdx := fromIdx; itemIdx < m.content.numItems(); itemIdx++ {
+		matches := m.content.itemAt(itemIdx).ExtractExactMatches(s.query)
+		if len(matches) == 0 {
+			continue
+		}
+		s.firstMatchIdxByItem[itemIdx] = len(s.matches)
+		for _, match := range matches {
+			s.matches = append(s.matches, searchMatch{itemIdx: itemIdx, match: match})
+		}
+	}
+}
+
+// refreshSearch recomputes search matches after the content changes, keeping the focused match index if possible
+func (m *Model[T]) refreshSearch() {
+	s := &m.content.search
+	if s.query == "" {
+		return
+	}
+	s.matches = s.matches[:0]
+	s.firstMatchIdxByItem = make(map[int]int)
+	m.findSearchMatches(0)
+	s.focusedIdx = min(s.focusedIdx, len(s.matches)-1)
+	if s.focusedIdx < 0 && len(s.matches) > 0 {
+		s.focusedIdx = 0
+	}
+}
+
+// appendSearchMatches finds search matches in items appended from fromIdx onwards
+func (m *Model[T]) appendSearchMatches(fromIdx int) {
+	s := &m.content.search
+	if s.query == "" {
+		return
+	}
+	hadMatches := len(s.matches) > 0
+	m.findSearchMatches(fromIdx)
+	if !hadMatches && len(s.matches) > 0 {
+		s.focusedIdx = 0
+	}
+}
+
+// prependSearchMatches finds search matches in the n items prepended before the existing ones, shifting the existing
+// matches down
+func (m *Model[T]) prependSearchMatches(n int) {
+	s := &m.content.search
+	if s.query == "" {
+		return
+	}
+	existing := s.matches
+	s.matches = nil
+	s.firstMatchIdxByItem = make(map[int]int, len(s.firstMatchIdxByItem))
+	for itemIdx := range n {
+		for _, match := range m.content.itemAt(itemIdx).ExtractExactMatches(s.query) {
+			s.matches = append(s.matches, searchMatch{itemIdx: itemIdx, match: match})
+		}
+	}
+	numNew := len(s.matches)
+	for _, match := range existing {
+		match.itemIdx += n
+		s.matches = append(s.matches, match)
+	}
+	for i := len(s.matches) - 1; i >= 0; i-- {
+		s.firstMatchIdxByItem[s.matches[i].itemIdx] = i
+	}
+	if s.focusedIdx >= 0 {
+		s.focusedIdx += numNew
+	} else if len(s.matches) > 0 {
+		s.focusedIdx = 0
+	}
+}
+
+// dropSearchMatches removes the matches in the first n items after they're dropped, moving the rest up by n items
+func (m *Model[T]) dropSearchMatches(n int) {
+	s := &m.content.search
+	if s.query == "" {
+		return
+	}
+	numDropped := 0
+	for numDropped < len(s.matches) && s.matches[numDropped].itemIdx < n {
+		numDropped++
+	}
+	s.matches = slices.Delete(s.matches, 0, numDropped)
+	s.firstMatchIdxByItem = make(map[int]int, len(s.firstMatchIdxByItem))
+	for i := len(s.matches) - 1; i >= 0; i-- {
+		s.matches[i].itemIdx -= n
+		s.firstMatchIdxByItem[s.matches[i].itemIdx] = i
+	}
+	switch {
+	case len(s.matches) == 0:
+		s.focusedIdx = -1
+	case s.focusedIdx >= numDropped:
+		s.focusedIdx -= numDropped
+	case s.focusedIdx >= 0:
+		s.focusedIdx = 0
+	}
+}
+
+// searchHighlightsForItem returns highlights for the search matches in the item at itemIdx
+func (m *Model[T]) searchHighlightsForItem(itemIdx int) []item.Highlight {
+	s := &m.content.search
+	first, ok := s.firstMatchIdxByItem[itemIdx]
+	if !ok {
+		return nil
+	}
+	var highlights []item.Highlight
+	for i := first; i < len(s.matches) && s.matches[i].itemIdx == itemIdx; i++ {
+		style := m.display.styles.SearchMatchStyle
+		if i == s.focusedIdx {
+			style = m.display.styles.FocusedSearchMatchStyle
+		}
+		highlights = append(highlights, item.Highlight{
+			Style:                    style,
+			ByteRangeUnstyledContent: s.matches[i].match.ByteRange,
+		})
+	}
+	return highlights
+}
+
+// focusSearchMatch focuses the match at matchIdx, scrolling it into view and selecting its item
+func (m *Model[T]) focusSearchMatch(matchIdx int) {
+	s := &m.content.search
+	if matchIdx < 0 || matchIdx >= len(s.matches) {
+		return
+	}
+	s.focusedIdx = matchIdx
+	match := s.matches[matchIdx]
+	m.ensureItemInView(match.itemIdx, match.match.WidthRange.Start, match.match.WidthRange.End, 0, 0)
+	if m.navigation.selectionEnabled && m.content.getSelectedIdx() != match.itemIdx {
+		m.content.setSelectedIdx(match.itemIdx)
+	}
+}
+
+// firstSearchMatchFrom returns the index of the first match at or after itemIdx, wrapping to the first match
+func (m *Model[T]) firstSearchMatchFrom(itemIdx int) int {
+	s := &m.content.search
+	for i := range s.matches {
+		if s.matches[i].itemIdx >= itemIdx {
+			return i
+		}
+	}
+	return 0
+}
+
+// headerHighlightsForLine returns the highlights set with SetHeaderHighlights for the header line at lineIdx, followed
+// by search matches in it if the search includes the header
+func (m *Model[T]) headerHighlightsForLine(lineIdx int, headerItem item.Item) []item.Highlight {
+	var highlights []item.Highlight
+	for _, h := range m.content.headerHighlights {
+		if h.ItemIndex == lineIdx {
+			highlights = append(highlights, h.ItemHighlight)
+		}
+	}
+	if query := m.content.search.query; query != "" && m.config.searchIncludesHeader {
+		for _, match := range headerItem.ExtractExactMatches(query) {
+			highlights = append(highlights, item.Highlight{
+				Style:                    m.display.styles.SearchMatchStyle,
+				ByteRangeUnstyledContent: match.ByteRange,
+			})
+		}
+	}
+	return highlights
+}
@@ -0,0 +1,32 @@
+package viewport
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// SelectionChangedMsg is sent when Update changes the selected item, e.g. by navigating or clicking, so that another
+// component like a preview pane can follow the selection. ItemIdx is the newly selected item's index. Changes made by
+// calling methods like SetSelectedItemIdx or SetObjects directly aren't reported, as the caller already knows of them.
+type SelectionChangedMsg struct {
+	ItemIdx int
+}
+
+// selectedIdxIfEnabled returns the selected item index, or -1 if nothing is selected
+func (m *Model[T]) selectedIdxIfEnabled() int {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() {
+		return -1
+	}
+	return m.content.getSelectedIdx()
+}
+
+// selectionChangedCmd returns a command sending a SelectionChangedMsg if a different item is selected than when
+// Update started
+func (m *Model[T]) selectionChangedCmd() tea.Cmd {
+	idx := m.selectedIdxIfEnabled()
+	if idx < 0 || idx == m.navigation.prevSelectedIdx {
+		return nil
+	}
+	return func() tea.Msg {
+		return SelectionChangedMsg{ItemIdx: idx}
+	}
+}
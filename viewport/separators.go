@@ -0,0 +1,73 @@
+package viewport
+
+import (
+	"slices"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// separatorLine is the separator shown beneath items, like grep's between non-contiguous groups of lines
+const separatorLine = "--"
+
+// withSeparator returns itm followed by a separator line
+func withSeparator(itm item.Item) item.Item {
+	var lines []item.SingleItem
+	for _, seg := range itm.LineBrokenItems() {
+		lines = append(lines, item.NewItem(seg.Content()))
+	}
+	return item.NewMultiLineItem(append(lines, item.NewItem(separatorLine))...)
+}
+
+// hasSeparator returns whether the item at idx has a separator line beneath it
+func (cm *contentManager[T]) hasSeparator(idx int) bool {
+	_, ok := cm.separators[idx]
+	return ok
+}
+
+// SetSeparators shows a separator line, "--" styled with SeparatorStyle, beneath each of the items at itemIdxs,
+// replacing any shown before, e.g. to mark where items that aren't contiguous in some larger list meet. The line
+// scrolls with the item above it but isn't part of its selection. Separators stay at their indexes when objects are
+// set or appended, move down with the items when objects are prepended, and are removed by SetItemSource. Pass nil
+// to remove them all.
+func (m *Model[T]) SetSeparators(itemIdxs []int) {
+	if len(itemIdxs) == 0 && len(m.content.separators) == 0 {
+		return
+	}
+	_, stayAtBottom := m.stickyPosition()
+	m.content.separators = make(map[int]struct{}, len(itemIdxs))
+	for _, idx := range itemIdxs {
+		m.content.separators[idx] = struct{}{}
+	}
+	m.refreshSearch()
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	} else if stayAtBottom {
+		m.safelySetTopItemIdxAndOffset(m.maxItemIdxAndMaxTopLineOffset())
+	}
+}
+
+// GetSeparators returns the indexes of the items with a separator line beneath them, in order
+func (m *Model[T]) GetSeparators() []int {
+	idxs := make([]int, 0, len(m.content.separators))
+	for idx := range m.content.separators {
+		idxs = append(idxs, idx)
+	}
+	slices.Sort(idxs)
+	return idxs
+}
+
+// shiftSeparators moves separators down by n items after objects are prepended, or up by -n after the oldest are dropped,
+// dropping those moved before the first item
+func (m *Model[T]) shiftSeparators(n int) {
+	if len(m.content.separators) == 0 {
+		return
+	}
+	shifted := make(map[int]struct{}, len(m.content.separators))
+	for idx := range m.content.separators {
+		if idx+n >= 0 {
+			shifted[idx+n] = struct{}{}
+		}
+	}
+	m.content.separators = shifted
+}
@@ -0,0 +1,163 @@
+package viewport
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	// smoothScrollFrames is how many frames a smooth scroll is animated over
+	smoothScrollFrames = 8
+
+	// smoothScrollMaxPages caps the distance animated, in pages. Longer jumps cut to this far from where they land
+	// and glide the rest of the way.
+	smoothScrollMaxPages = 3
+)
+
+// smoothScrollMsg shows the next frame of the smooth scroll with the same seq
+type smoothScrollMsg struct {
+	id  int
+	seq int
+}
+
+// smoothScrollState animates jumps of more than a page, see SetSmoothScroll
+type smoothScrollState struct {
+	// duration is how long a jump is animated over, 0 to jump instantly
+	duration time.Duration
+
+	// active is true while animating toward the target
+	active bool
+
+	// targetItemIdx and targetLineOffset are the top of the view the animation ends at
+	targetItemIdx    int
+	targetLineOffset int
+
+	// distance is how many lines above the target the animation started, negative when scrolling up
+	distance int
+
+	// frame is the number of frames shown so far
+	frame int
+
+	// shownItemIdx and shownLineOffset are the top of the view set by the last frame, so that scrolling some other
+	// way during the animation ends it
+	shownItemIdx    int
+	shownLineOffset int
+
+	// seq identifies the latest animation, so that frames of an earlier one are ignored
+	seq int
+}
+
+// SetSmoothScroll sets how long jumps of more than a page, like going to the top or bottom or bringing a far away
+// item into view, are animated over, keeping orientation in long content. 0, the default, jumps instantly.
+func (m *Model[T]) SetSmoothScroll(duration time.Duration) {
+	m.navigation.smoothScroll.duration = max(0, duration)
+	if duration <= 0 {
+		m.finishSmoothScroll()
+	}
+}
+
+// GetSmoothScroll returns how long jumps are animated over, 0 if smooth scrolling is disabled
+func (m *Model[T]) GetSmoothScroll() time.Duration {
+	return m.navigation.smoothScroll.duration
+}
+
+// IsSmoothScrolling returns whether a jump is being animated
+func (m *Model[T]) IsSmoothScrolling() bool {
+	return m.navigation.smoothScroll.active
+}
+
+// startSmoothScroll animates the jump from the top of the view at fromItemIdx and fromLineOffset to the current one
+// if it's more than a page, returning the command showing the next frame. Returns nil if the jump isn't animated.
+func (m *Model[T]) startSmoothScroll(fromItemIdx, fromLineOffset int) tea.Cmd {
+	s := &m.navigation.smoothScroll
+	if s.duration <= 0 || s.active {
+		return nil
+	}
+	height := m.getNumContentLines()
+	toItemIdx, toLineOffset := m.display.topItemIdx, m.display.topItemLineOffset
+	distance := m.linesBetween(fromItemIdx, fromLineOffset, toItemIdx, toLineOffset, smoothScrollMaxPages*height)
+	if height <= 0 || (distance <= height && distance >= -height) {
+		return nil
+	}
+	s.active = true
+	s.targetItemIdx, s.targetLineOffset = toItemIdx, toLineOffset
+	s.distance = distance
+	s.frame = 0
+	s.seq++
+	return m.showSmoothScrollFrame()
+}
+
+// advanceSmoothScroll shows the next frame of the animation, returning the command showing the one after, nil once
+// it's done or if the view was scrolled some other way since the last frame
+func (m *Model[T]) advanceSmoothScroll(msg smoothScrollMsg) tea.Cmd {
+	s := &m.navigation.smoothScroll
+	if !s.active || msg.seq != s.seq {
+		return nil
+	}
+	if m.display.topItemIdx != s.shownItemIdx || m.display.topItemLineOffset != s.shownLineOffset {
+		s.active = false
+		return nil
+	}
+	s.frame++
+	if s.frame >= smoothScrollFrames {
+		m.finishSmoothScroll()
+		return nil
+	}
+	return m.showSmoothScrollFrame()
+}
+
+// showSmoothScrollFrame sets the top of the view for the current frame, easing out toward the target, and returns
+// the command showing the next
+func (m *Model[T]) showSmoothScrollFrame() tea.Cmd {
+	s := &m.navigation.smoothScroll
+	remaining := smoothScrollFrames - s.frame
+	linesLeft := s.distance * remaining * remaining / (smoothScrollFrames * smoothScrollFrames)
+	m.safelySetTopItemIdxAndOffset(m.shiftLines(s.targetItemIdx, s.targetLineOffset, -linesLeft))
+	s.shownItemIdx, s.shownLineOffset = m.display.topItemIdx, m.display.topItemLineOffset
+	id, seq := m.id, s.seq
+	return tea.Tick(s.duration/smoothScrollFrames, func(time.Time) tea.Msg {
+		return smoothScrollMsg{id: id, seq: seq}
+	})
+}
+
+// finishSmoothScroll jumps to the end of the animation, if any
+func (m *Model[T]) finishSmoothScroll() {
+	s := &m.navigation.smoothScroll
+	if !s.active {
+		return
+	}
+	s.active = false
+	m.safelySetTopItemIdxAndOffset(s.targetItemIdx, s.targetLineOffset)
+}
+
+// linesBetween returns how many lines the top of the view moves going from one position to another, negative when
+// moving up, with its magnitude capped at limit
+func (m *Model[T]) linesBetween(fromItemIdx, fromLineOffset, toItemIdx, toLineOffset, limit int) int {
+	if fromItemIdx > toItemIdx || (fromItemIdx == toItemIdx && fromLineOffset > toLineOffset) {
+		return -m.linesBetween(toItemIdx, toLineOffset, fromItemIdx, fromLineOffset, limit)
+	}
+	if fromItemIdx == toItemIdx {
+		return min(limit, toLineOffset-fromLineOffset)
+	}
+	n := m.numLinesForItem(fromItemIdx) - fromLineOffset
+	for idx := fromItemIdx + 1; idx < toItemIdx && n < limit; idx++ {
+		n += m.numLinesForItem(idx)
+	}
+	return min(limit, n+toLineOffset)
+}
+
+// shiftLines returns the position numLines lines below the given one, above it if negative, stopping at the first
+// and last items
+func (m *Model[T]) shiftLines(itemIdx, lineOffset, numLines int) (int, int) {
+	lineOffset += numLines
+	for lineOffset < 0 && itemIdx > 0 {
+		itemIdx--
+		lineOffset += m.numLinesForItem(itemIdx)
+	}
+	for itemIdx < m.content.numItems()-1 && lineOffset >= m.numLinesForItem(itemIdx) {
+		lineOffset -= m.numLinesForItem(itemIdx)
+		itemIdx++
+	}
+	return itemIdx, max(0, lineOffset)
+}
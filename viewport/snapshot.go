@@ -0,0 +1,101 @@
+package viewport
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// SnapshotFormat is the document format of a snapshot of the view, see Snapshot
+type SnapshotFormat int
+
+const (
+	// SnapshotHTML is a standalone HTML document with the view in a preformatted block
+	SnapshotHTML SnapshotFormat = iota
+
+	// SnapshotSVG is a standalone SVG image of the view laid out on a grid of terminal cells
+	SnapshotSVG
+)
+
+const (
+	// svgFontSize, svgCellWidth and svgLineHeight are the size in pixels of the text and of each terminal cell in
+	// SVG snapshots
+	svgFontSize   = 14
+	svgCellWidth  = 8.4
+	svgLineHeight = 18
+
+	// svgPadding is the margin in pixels around the view in SVG snapshots
+	svgPadding = 10
+)
+
+// Snapshot returns the view as View renders it, colors and styling included, as a standalone document in format,
+// e.g. to share a styled screenshot of a tool built on the viewport. Escape sequences other than styling, like
+// hyperlinks and inline images, are left out.
+func (m *Model[T]) Snapshot(format SnapshotFormat) string {
+	lines := strings.Split(m.View(), "\n")
+	if format == SnapshotSVG {
+		return svgDocument(lines)
+	}
+	return htmlDocument(lines)
+}
+
+// svgDocument renders lines, which may hold ANSI styling, as a standalone SVG image
+func svgDocument(lines []string) string {
+	numCells := 0
+	for _, line := range lines {
+		numCells = max(numCells, lipgloss.Width(line))
+	}
+	width := float64(numCells)*svgCellWidth + 2*svgPadding
+	height := len(lines)*svgLineHeight + 2*svgPadding
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%d" viewBox="0 0 %g %d" `,
+		width, height, width, height)
+	fmt.Fprintf(&b, `font-family="monospace" font-size="%d" xml:space="preserve">`+"\n", svgFontSize)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", htmlBackground)
+	for row, line := range lines {
+		top := svgPadding + row*svgLineHeight
+		cell := 0
+		for _, run := range styledRuns(line) {
+			cells := lipgloss.Width(run.text)
+			x := svgPadding + float64(cell)*svgCellWidth
+			cell += cells
+			fg, bg := run.style.colors()
+			if bg != "" {
+				fmt.Fprintf(&b, `<rect x="%g" y="%d" width="%g" height="%d" fill="%s"/>`+"\n",
+					x, top, float64(cells)*svgCellWidth, svgLineHeight, bg)
+			}
+			// trailing spaces, e.g. padding, would otherwise be stretched over with the text
+			text := strings.TrimRight(run.text, " ")
+			if text == "" {
+				continue
+			}
+			fmt.Fprintf(&b, `<text x="%g" y="%d" textLength="%g" lengthAdjust="spacingAndGlyphs" fill="%s"%s>%s</text>`+"\n",
+				x, top+svgLineHeight*3/4, float64(lipgloss.Width(text))*svgCellWidth, cmp.Or(fg, htmlForeground),
+				svgTextAttrs(run.style), html.EscapeString(text))
+		}
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// svgTextAttrs returns the attributes of SVG text in style other than its position and color
+func svgTextAttrs(style sgrStyle) string {
+	var attrs strings.Builder
+	if style.bold {
+		attrs.WriteString(` font-weight="bold"`)
+	}
+	if style.faint {
+		attrs.WriteString(` opacity="0.5"`)
+	}
+	if style.italic {
+		attrs.WriteString(` font-style="italic"`)
+	}
+	if decoration := style.textDecoration(); decoration != "" {
+		fmt.Fprintf(&attrs, ` text-decoration="%s"`, decoration)
+	}
+	return attrs.String()
+}
@@ -0,0 +1,172 @@
+package viewport
+
+import (
+	"fmt"
+)
+
+// SoftLimits are content size thresholds beyond which the viewport disables expensive features, such as text
+// wrapping, live filtering and HighlightAll, rather than risk freezing on unexpectedly large content. A zero field
+// means no limit. Checking MaxTotalBytes or MaxLineWidth scans every object each time objects are set.
+type SoftLimits struct {
+	// MaxItems is the number of objects above which features are disabled
+	MaxItems int
+
+	// MaxTotalBytes is the total size of all objects' content in bytes above which features are disabled
+	MaxTotalBytes int
+
+	// MaxLineWidth is the width in terminal cells of the widest line above which features are disabled
+	MaxLineWidth int
+}
+
+// softLimitUsage accumulates the content sizes that SoftLimits constrain
+type softLimitUsage struct {
+	numItems     int
+	totalBytes   int
+	maxLineWidth int
+}
+
+// addSoftLimitUsage adds the sizes of objects to usage, only measuring content when limits require it
+func addSoftLimitUsage[T Object](usage *softLimitUsage, limits SoftLimits, objects []T) {
+	usage.numItems += len(objects)
+	if limits.MaxTotalBytes <= 0 && limits.MaxLineWidth <= 0 {
+		return
+	}
+	for i := range objects {
+		itm := objects[i].GetItem()
+		if limits.MaxTotalBytes > 0 {
+			usage.totalBytes += len(itm.Content())
+		}
+		if limits.MaxLineWidth > 0 {
+			usage.maxLineWidth = max(usage.maxLineWidth, maxSegmentWidth(itm))
+		}
+	}
+}
+
+// removeSoftLimitUsage subtracts the sizes of removed objects from usage, where remaining are the objects still
+// measured. The widest line is only measured again among remaining when a removed line was as wide.
+func removeSoftLimitUsage[T Object](usage *softLimitUsage, limits SoftLimits, removed, remaining []T) {
+	usage.numItems -= len(removed)
+	if limits.MaxTotalBytes <= 0 && limits.MaxLineWidth <= 0 {
+		return
+	}
+	removedMaxLineWidth := 0
+	for i := range removed {
+		itm := removed[i].GetItem()
+		if limits.MaxTotalBytes > 0 {
+			usage.totalBytes -= len(itm.Content())
+		}
+		if limits.MaxLineWidth > 0 {
+			removedMaxLineWidth = max(removedMaxLineWidth, maxSegmentWidth(itm))
+		}
+	}
+	if limits.MaxLineWidth > 0 && removedMaxLineWidth >= usage.maxLineWidth {
+		usage.maxLineWidth = 0
+		for i := range remaining {
+			usage.maxLineWidth = max(usage.maxLineWidth, maxSegmentWidth(remaining[i].GetItem()))
+		}
+	}
+}
+
+// exceeded returns a short description of the first limit the usage exceeds, or "" if within all limits
+func (u softLimitUsage) exceeded(limits SoftLimits) string {
+	if limits.MaxItems > 0 && u.numItems > limits.MaxItems {
+		return fmt.Sprintf("over %d items", limits.MaxItems)
+	}
+	if limits.MaxTotalBytes > 0 && u.totalBytes > limits.MaxTotalBytes {
+		return fmt.Sprintf("over %d bytes", limits.MaxTotalBytes)
+	}
+	if limits.MaxLineWidth > 0 && u.maxLineWidth > limits.MaxLineWidth {
+		return fmt.Sprintf("line over %d wide", limits.MaxLineWidth)
+	}
+	return ""
+}
+
+// CheckSoftLimits returns a short description of the first soft limit the objects exceed, or "" if they are
+// within all limits. See SoftLimitUsage to check objects as they're added without scanning them all each time.
+func CheckSoftLimits[T Object](limits SoftLimits, objects []T) string {
+	var usage softLimitUsage
+	addSoftLimitUsage(&usage, limits, objects)
+	return usage.exceeded(limits)
+}
+
+// SoftLimitUsage measures objects against soft limits as they're added and removed, so that content growing over
+// time is checked without scanning every object each time like CheckSoftLimits
+type SoftLimitUsage[T Object] struct {
+	limits SoftLimits
+	usage  softLimitUsage
+}
+
+// NewSoftLimitUsage returns a SoftLimitUsage measuring no objects against limits
+func NewSoftLimitUsage[T Object](limits SoftLimits) *SoftLimitUsage[T] {
+	return &SoftLimitUsage[T]{limits: limits}
+}
+
+// Limits returns the soft limits objects are measured against
+func (u *SoftLimitUsage[T]) Limits() SoftLimits {
+	return u.limits
+}
+
+// Add measures objects in addition to those already measured
+func (u *SoftLimitUsage[T]) Add(objects []T) {
+	addSoftLimitUsage(&u.usage, u.limits, objects)
+}
+
+// Remove stops measuring removed, objects previously added, where remaining are the objects still measured.
+// remaining is only scanned when MaxLineWidth is set and a removed line was the widest.
+func (u *SoftLimitUsage[T]) Remove(removed, remaining []T) {
+	removeSoftLimitUsage(&u.usage, u.limits, removed, remaining)
+}
+
+// Exceeded returns a short description of the first soft limit the measured objects exceed, or "" if they are
+// within all limits
+func (u *SoftLimitUsage[T]) Exceeded() string {
+	return u.usage.exceeded(u.limits)
+}
+
+// applySoftLimits measures all current objects against the soft limits. See addToSoftLimits.
+func (m *Model[T]) applySoftLimits() {
+	m.config.softLimitUsage = softLimitUsage{}
+	m.addToSoftLimits(m.content.allObjects())
+}
+
+// addToSoftLimits adds objects to the measured content and updates whether soft limits are exceeded. See
+// updateSoftLimitReason.
+func (m *Model[T]) addToSoftLimits(objects []T) {
+	addSoftLimitUsage(&m.config.softLimitUsage, m.config.softLimits, objects)
+	m.updateSoftLimitReason()
+}
+
+// removeFromSoftLimits removes objects from the measured content, where remaining are the objects kept, and updates
+// whether soft limits are exceeded. See updateSoftLimitReason.
+func (m *Model[T]) removeFromSoftLimits(removed, remaining []T) {
+	removeSoftLimitUsage(&m.config.softLimitUsage, m.config.softLimits, removed, remaining)
+	m.updateSoftLimitReason()
+}
+
+// updateSoftLimitReason updates whether the measured content exceeds soft limits, disabling wrapping while it does
+// and restoring it once it does not
+func (m *Model[T]) updateSoftLimitReason() {
+	m.config.softLimitReason = m.config.softLimitUsage.exceeded(m.config.softLimits)
+	if m.config.softLimitReason != "" {
+		if m.config.wrapText {
+			m.SetWrapText(false)
+			m.config.wrapSuspended = true
+		}
+		return
+	}
+	if m.config.wrapSuspended {
+		m.config.wrapSuspended = false
+		m.SetWrapText(true)
+	}
+}
+
+// softLimitNotice returns the notice shown while soft limits are exceeded, or "" if they are not
+func (m *Model[T]) softLimitNotice() string {
+	if m.config.softLimitReason == "" {
+		return ""
+	}
+	if m.config.wrapSuspended {
+		return fmt.Sprintf("large content (%s), wrap off", m.config.softLimitReason)
+	}
+	return fmt.Sprintf("large content (%s)", m.config.softLimitReason)
+}
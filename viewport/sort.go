@@ -0,0 +1,63 @@
+package viewport
+
+import "slices"
+
+// SortDirection is the order items are shown in when a sort function is set with SetSortFunc
+type SortDirection int
+
+const (
+	// SortNone shows items in the order they were set
+	SortNone SortDirection = iota
+
+	// SortAscending shows items ordered by the sort function
+	SortAscending
+
+	// SortDescending shows items in the reverse order of the sort function
+	SortDescending
+)
+
+// next returns the direction after d when cycling with the ToggleSort key
+func (d SortDirection) next() SortDirection {
+	return (d + 1) % 3
+}
+
+// sorting orders objects with a sort function
+type sorting[T Object] struct {
+	// less reports whether a sorts before b
+	less func(a, b T) bool
+
+	// direction is the current order
+	direction SortDirection
+
+	// unsorted is every object in the order it was set, when direction isn't SortNone
+	unsorted []T
+}
+
+// sortObjects orders objects in the current sort direction, remembering their original order so that it can be
+// restored
+func (m *Model[T]) sortObjects(objects []T) []T {
+	s := m.content.sorting
+	if s == nil || s.direction == SortNone {
+		return objects
+	}
+	s.unsorted = objects
+	sorted := slices.Clone(objects)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		if s.direction == SortDescending {
+			a, b = b, a
+		}
+		switch {
+		case s.less(a, b):
+			return -1
+		case s.less(b, a):
+			return 1
+		}
+		return 0
+	})
+	return sorted
+}
+
+// isSorted returns whether items are shown in an order other than the one they were set in
+func (m *Model[T]) isSorted() bool {
+	return m.content.sorting != nil && m.content.sorting.direction != SortNone
+}
@@ -0,0 +1,62 @@
+package viewport
+
+import (
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// stickyMemo remembers the sticky item index last found for a top item index, so that redrawing or scrolling the
+// view only checks the items it hasn't already checked rather than every item above the top
+type stickyMemo struct {
+	valid      bool
+	topItemIdx int
+	stickyIdx  int
+}
+
+// resetStickyMemo forgets the sticky item index last found, e.g. when objects are replaced or their indexes shift
+func (cm *contentManager[T]) resetStickyMemo() {
+	cm.sticky = stickyMemo{}
+}
+
+// stickyItemIdx returns the index of the most recent sticky item at or above topItemIdx, -1 if there's none
+func (m *Model[T]) stickyItemIdx(topItemIdx int) int {
+	memo := m.content.sticky
+	// check the items from topItemIdx down to stop, exclusive, falling back to stickyIdx
+	stop, stickyIdx := -1, -1
+	if memo.valid {
+		switch {
+		case topItemIdx >= memo.topItemIdx:
+			stop, stickyIdx = memo.topItemIdx, memo.stickyIdx
+		case memo.stickyIdx <= topItemIdx:
+			// nothing between the remembered sticky item and the remembered top is sticky
+			stop, stickyIdx = topItemIdx, memo.stickyIdx
+		}
+	}
+	for idx := topItemIdx; idx > stop; idx-- {
+		if m.content.stickyItemFunc(m.content.objectAt(idx)) {
+			stickyIdx = idx
+			break
+		}
+	}
+	m.content.sticky = stickyMemo{valid: true, topItemIdx: topItemIdx, stickyIdx: stickyIdx}
+	return stickyIdx
+}
+
+// stickyItemLine returns the first line of the sticky item to pin over the first content line of the view showing
+// itemIndexes, false if none should be pinned: without sticky items, while the sticky item's first line is still in
+// view, or while the selection is on the first content line
+func (m *Model[T]) stickyItemLine(itemIndexes []int) (string, bool) {
+	if m.content.stickyItemFunc == nil || len(itemIndexes) == 0 {
+		return "", false
+	}
+	topItemIdx := itemIndexes[0]
+	if m.navigation.selectionEnabled && topItemIdx == m.content.getSelectedIdx() {
+		return "", false
+	}
+	stickyIdx := m.stickyItemIdx(topItemIdx)
+	if stickyIdx < 0 || (stickyIdx == topItemIdx && m.display.topItemLineOffset == 0) {
+		return "", false
+	}
+	segments := m.content.itemAt(stickyIdx).LineBrokenItems()
+	line, _ := segments[0].Take(0, m.contentWidth(), m.config.continuationIndicator, []item.Highlight{})
+	return styleUnstyled(line, m.display.styles.StickyItemStyle), true
+}
@@ -14,14 +14,170 @@ type Styles struct {
 
 	FooterStyle       lipgloss.Style
 	SelectedItemStyle lipgloss.Style
+
+	// MessageStyle styles messages shown over the footer, like the results of saving and copying, and
+	// ErrorMessageStyle those describing errors, see ShowMessage
+	MessageStyle      lipgloss.Style
+	ErrorMessageStyle lipgloss.Style
+
+	// CollapsedGroupStyle styles the "(+N)" indicator on collapsed groups when line joining is enabled
+	CollapsedGroupStyle lipgloss.Style
+
+	// MovingItemStyle replaces SelectedItemStyle in move mode
+	MovingItemStyle lipgloss.Style
+
+	// BlurredStyle replaces SelectedItemStyle while the viewport is blurred, e.g. to dim the selection, see Blur
+	BlurredStyle lipgloss.Style
+
+	// MarkedItemStyle is layered under the styling of marked items that aren't selected
+	MarkedItemStyle lipgloss.Style
+
+	// BookmarkedItemStyle is layered under the styling of bookmarked items that aren't selected or marked
+	BookmarkedItemStyle lipgloss.Style
+
+	// SearchMatchStyle styles occurrences of the search query, and FocusedSearchMatchStyle the focused one
+	SearchMatchStyle        lipgloss.Style
+	FocusedSearchMatchStyle lipgloss.Style
+
+	// AddedItemStyle and ChangedItemStyle are layered under the styling of unselected items added or changed since
+	// the checkpoint set with Checkpoint
+	AddedItemStyle   lipgloss.Style
+	ChangedItemStyle lipgloss.Style
+
+	// DiffAddedStyle, DiffRemovedStyle and DiffChangedStyle are layered under the styling of unselected DiffObjects
+	// of each kind in diff mode, and style their markers, see SetDiffMode
+	DiffAddedStyle   lipgloss.Style
+	DiffRemovedStyle lipgloss.Style
+	DiffChangedStyle lipgloss.Style
+
+	// ControlCharStyle styles control characters shown in caret notation, e.g. ^M, see SetShowControlChars
+	ControlCharStyle lipgloss.Style
+
+	// InvisibleStyle styles the glyphs shown for trailing whitespace and characters that take no space, see
+	// SetShowInvisibles
+	InvisibleStyle lipgloss.Style
+
+	// BlockSelectionStyle styles the rectangle selected in block selection mode
+	BlockSelectionStyle lipgloss.Style
+
+	// SelectionRegionStyle styles the text selected in visual selection mode
+	SelectionRegionStyle lipgloss.Style
+
+	// LinkStyle styles links set with SetLinks, and FocusedLinkStyle the focused one
+	LinkStyle        lipgloss.Style
+	FocusedLinkStyle lipgloss.Style
+
+	// DetectedLinkStyle styles URLs and file:line paths detected in item text, see SetLinkDetection
+	DetectedLinkStyle lipgloss.Style
+
+	// StickyItemStyle is layered under the styling of the sticky item pinned under the header, see SetStickyItemFunc
+	StickyItemStyle lipgloss.Style
+
+	// SeparatorStyle styles the separator lines shown beneath items, see SetSeparators
+	SeparatorStyle lipgloss.Style
+
+	// LineNumberStyle styles the numbers in the line number column, see SetLineNumbers
+	LineNumberStyle lipgloss.Style
+
+	// TimeGutterStyle styles the ages of items in the time gutter, see SetTimeGutter
+	TimeGutterStyle lipgloss.Style
+
+	// ScrollbarStyle styles the track of the scrollbar and ScrollbarThumbStyle its thumb, see SetScrollbar
+	ScrollbarStyle      lipgloss.Style
+	ScrollbarThumbStyle lipgloss.Style
+
+	// MinimapStyle styles the track of the minimap and MinimapMarkStyle its marks, see SetMinimap
+	MinimapStyle     lipgloss.Style
+	MinimapMarkStyle lipgloss.Style
+
+	// FlashStyle replaces the styling of the item gone to while it flashes, see SetGoToFlash
+	FlashStyle lipgloss.Style
+
+	// LoadingStyle styles the spinner and message shown while loading, see SetLoading
+	LoadingStyle lipgloss.Style
+
+	// PreviewStyle styles the popup previewing text over the content, including its border, see Preview
+	PreviewStyle lipgloss.Style
+
+	// OverscrollStyle replaces FooterStyle while the overscroll indicator shows, see SetOverscrollIndicator
+	OverscrollStyle lipgloss.Style
+
+	// DisabledStyle replaces the styling of content while the viewport is disabled, and DisabledNoteStyle styles the
+	// note shown over it, see SetEnabled
+	DisabledStyle     lipgloss.Style
+	DisabledNoteStyle lipgloss.Style
+
+	// HighlightPriorities layers the built-in highlights and the selection over highlights set with SetHighlights
+	HighlightPriorities HighlightPriorities
+}
+
+// HighlightPriorities orders the built-in highlights where they overlap each other or highlights set with
+// SetHighlights, which use their own item.Highlight Priority. Higher shows over lower. On the selected item,
+// highlights with a lower priority than Selection are hidden under the selection style. All are 0 by default, so every
+// highlight shows through the selection and the one starting first shows where they overlap.
+type HighlightPriorities struct {
+	Selection       int
+	Search          int
+	Link            int
+	BlockSelection  int
+	VisualSelection int
 }
 
 // DefaultStyles returns a set of default styles for the viewport.
-// Uses only reverse video — no 256-color or true-color values.
+// Uses only reverse video and safe ANSI colors — no 256-color or true-color values.
 func DefaultStyles() Styles {
 	return Styles{
-		SelectionPrefix:   "",
-		FooterStyle:       lipgloss.NewStyle(),
-		SelectedItemStyle: lipgloss.NewStyle().Reverse(true),
+		SelectionPrefix:     "",
+		FooterStyle:         lipgloss.NewStyle(),
+		SelectedItemStyle:   lipgloss.NewStyle().Reverse(true),
+		MessageStyle:        lipgloss.NewStyle(),
+		ErrorMessageStyle:   lipgloss.NewStyle().Foreground(lipgloss.Red),
+		CollapsedGroupStyle: lipgloss.NewStyle(),
+		MovingItemStyle:     lipgloss.NewStyle().Reverse(true).Bold(true),
+		BlurredStyle:        lipgloss.NewStyle().Reverse(true).Faint(true),
+		MarkedItemStyle:     lipgloss.NewStyle().Bold(true),
+		BookmarkedItemStyle: lipgloss.NewStyle().Underline(true),
+
+		SearchMatchStyle:        lipgloss.NewStyle().Reverse(true).Foreground(lipgloss.BrightRed),
+		FocusedSearchMatchStyle: lipgloss.NewStyle().Reverse(true).Foreground(lipgloss.Cyan),
+
+		AddedItemStyle:   lipgloss.NewStyle().Foreground(lipgloss.Green),
+		ChangedItemStyle: lipgloss.NewStyle().Foreground(lipgloss.Yellow),
+
+		DiffAddedStyle:   lipgloss.NewStyle().Foreground(lipgloss.Green),
+		DiffRemovedStyle: lipgloss.NewStyle().Foreground(lipgloss.Red),
+		DiffChangedStyle: lipgloss.NewStyle().Foreground(lipgloss.Yellow),
+
+		ControlCharStyle:     lipgloss.NewStyle().Foreground(lipgloss.Magenta),
+		InvisibleStyle:       lipgloss.NewStyle().Faint(true),
+		BlockSelectionStyle:  lipgloss.NewStyle().Reverse(true).Foreground(lipgloss.Magenta),
+		SelectionRegionStyle: lipgloss.NewStyle().Reverse(true).Foreground(lipgloss.Cyan),
+
+		LinkStyle:        lipgloss.NewStyle().Underline(true),
+		FocusedLinkStyle: lipgloss.NewStyle().Underline(true).Reverse(true),
+
+		DetectedLinkStyle: lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Blue),
+
+		StickyItemStyle: lipgloss.NewStyle().Bold(true),
+
+		SeparatorStyle: lipgloss.NewStyle().Faint(true),
+
+		LineNumberStyle: lipgloss.NewStyle().Faint(true),
+
+		TimeGutterStyle: lipgloss.NewStyle().Faint(true),
+
+		ScrollbarStyle:      lipgloss.NewStyle().Faint(true),
+		ScrollbarThumbStyle: lipgloss.NewStyle(),
+
+		MinimapStyle:     lipgloss.NewStyle(),
+		MinimapMarkStyle: lipgloss.NewStyle().Foreground(lipgloss.BrightRed),
+
+		FlashStyle:      lipgloss.NewStyle().Reverse(true).Foreground(lipgloss.Yellow),
+		OverscrollStyle: lipgloss.NewStyle().Reverse(true),
+		LoadingStyle:    lipgloss.NewStyle().Foreground(lipgloss.Cyan),
+		PreviewStyle:    lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1),
+
+		DisabledStyle:     lipgloss.NewStyle().Faint(true),
+		DisabledNoteStyle: lipgloss.NewStyle().Bold(true),
 	}
 }
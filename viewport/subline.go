@@ -0,0 +1,70 @@
+package viewport
+
+// subLineState tracks the line-level cursor within the selected item in sub-line selection mode
+type subLineState struct {
+	// enabled is true if up and down move through the lines of the selected item, see SetSubLineSelection
+	enabled bool
+
+	// itemIdx is the item the cursor was last placed in. The cursor is on the first line of any other selected item.
+	itemIdx int
+
+	// lineOffset is the cursor's line within itemIdx
+	lineOffset int
+}
+
+// SetSubLineSelection sets whether the selection is a single line of the selected item rather than the whole item.
+// The Up and Down keys then move through the lines of an item that wraps or spans several lines before moving to the
+// next item, and only the cursor's line is styled as selected, e.g. for items that wrap to dozens of rows. The
+// selected item is still the one reported by GetSelectedItemIdx. Has no effect unless selection is enabled.
+func (m *Model[T]) SetSubLineSelection(enabled bool) {
+	m.navigation.subLine.enabled = enabled
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// GetSubLineSelection returns whether the selection is a single line of the selected item
+func (m *Model[T]) GetSubLineSelection() bool {
+	return m.navigation.subLine.enabled
+}
+
+// GetSelectedLineOffset returns the line of the selected item the cursor is on in sub-line selection mode, 0 otherwise
+func (m *Model[T]) GetSelectedLineOffset() int {
+	if !m.subLineActive() {
+		return 0
+	}
+	return m.selectedLineOffset()
+}
+
+// subLineActive returns true if the selection is a single line of the selected item
+func (m *Model[T]) subLineActive() bool {
+	return m.navigation.subLine.enabled && m.navigation.selectionEnabled && !m.content.isEmpty()
+}
+
+// selectedLineOffset returns the cursor's line within the selected item, clamped to its lines
+func (m *Model[T]) selectedLineOffset() int {
+	s := m.navigation.subLine
+	selectedIdx := m.content.getSelectedIdx()
+	if s.itemIdx != selectedIdx {
+		return 0
+	}
+	return clampValZeroToMax(s.lineOffset, m.numLinesForItem(selectedIdx)-1)
+}
+
+// moveSubLineCursor moves the cursor delta lines, into the items above or below when passing the selected item's
+// first or last line, and scrolls it into view
+func (m *Model[T]) moveSubLineCursor(delta int) {
+	itemIdx, lineOffset := m.content.getSelectedIdx(), m.selectedLineOffset()+delta
+	for lineOffset < 0 && itemIdx > 0 {
+		itemIdx--
+		lineOffset += m.numLinesForItem(itemIdx)
+	}
+	for lineOffset >= m.numLinesForItem(itemIdx) && itemIdx < m.content.numItems()-1 {
+		lineOffset -= m.numLinesForItem(itemIdx)
+		itemIdx++
+	}
+	lineOffset = clampValZeroToMax(lineOffset, m.numLinesForItem(itemIdx)-1)
+
+	m.navigation.subLine.itemIdx, m.navigation.subLine.lineOffset = itemIdx, lineOffset
+	m.setSelectedItemIdx(itemIdx)
+}
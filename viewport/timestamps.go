@@ -0,0 +1,168 @@
+package viewport
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// timeGutterWidth is the width of the time gutter: a space, then an age of up to four characters like "15m"
+const timeGutterWidth = 5
+
+// goToTimeLayouts are the layouts of times of day accepted by the go-to input, taken on the day of the last item
+var goToTimeLayouts = []string{"15:04:05", "15:04"}
+
+// timestampState tracks the time gutter and the clock objects are aged by
+type timestampState struct {
+	// gutter is whether the age of each item is shown in a gutter on the right edge of the content
+	gutter bool
+
+	// now returns the current time, replaceable in tests
+	now func() time.Time
+}
+
+func newTimestampState() timestampState {
+	return timestampState{now: time.Now}
+}
+
+// WithTimestampFunc sets a function returning the time of an object. See SetTimestampFunc.
+func WithTimestampFunc[T Object](timestamp func(T) time.Time) Option[T] {
+	return func(m *Model[T]) {
+		m.SetTimestampFunc(timestamp)
+	}
+}
+
+// WithMaxAge keeps only objects at most d old by their timestamp. See SetMaxAge.
+func WithMaxAge[T Object](d time.Duration) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMaxAge(d)
+	}
+}
+
+// WithTimeGutter sets whether the age of each item is shown in a gutter on the right edge of the content. See
+// SetTimeGutter.
+func WithTimeGutter[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetTimeGutter(enabled)
+	}
+}
+
+// SetTimestampFunc sets a function returning the time of an object, e.g. when a log line was written, enabling the
+// max age, GoToTime, times in the go-to input and the time gutter. Objects are expected in time order, oldest first.
+// Pass nil to disable.
+func (m *Model[T]) SetTimestampFunc(timestamp func(T) time.Time) {
+	m.content.timestampFunc = timestamp
+	if m.config.timestamps.gutter {
+		m.afterGutterChanged()
+	}
+	m.applyRetention()
+}
+
+// SetMaxAge sets the oldest an object may be by its timestamp, e.g. 15 minutes to keep the last 15 minutes of a log
+// tail, dropping older objects now and as objects are set or appended, as SetMaxItems does. Objects age without new
+// ones arriving, so call DropExpired periodically to drop them regardless. It requires a timestamp function. The
+// newest object is always kept. 0, the default, keeps objects of any age.
+func (m *Model[T]) SetMaxAge(d time.Duration) {
+	m.config.retention.maxAge = max(0, d)
+	m.applyRetention()
+}
+
+// GetMaxAge returns the oldest an object may be, 0 if there's no limit
+func (m *Model[T]) GetMaxAge() time.Duration {
+	return m.config.retention.maxAge
+}
+
+// DropExpired drops the objects older than the max age now, e.g. on a tick while no new objects arrive
+func (m *Model[T]) DropExpired() {
+	m.applyRetention()
+}
+
+// GoToTime goes to the first item at or after t by its timestamp with GoToItem, or the last item if every item is
+// earlier. It returns nil without a timestamp function.
+func (m *Model[T]) GoToTime(t time.Time) tea.Cmd {
+	if m.content.timestampFunc == nil || m.content.isEmpty() {
+		return nil
+	}
+	idx := sort.Search(m.content.numItems(), func(i int) bool {
+		return !m.content.timestampFunc(m.content.objectAt(i)).Before(t)
+	})
+	return m.GoToItem(idx)
+}
+
+// SetTimeGutter sets whether the age of each item, like "42s", "15m", "3h" or "12d", is shown beside its first line in
+// a gutter on the right edge of the content, left of the minimap and scrollbar if shown, styled with TimeGutterStyle.
+// It requires a timestamp function. Ages are as of the last render. The gutter narrows the content, so wrapped items
+// rewrap.
+func (m *Model[T]) SetTimeGutter(enabled bool) {
+	if m.config.timestamps.gutter == enabled {
+		return
+	}
+	m.config.timestamps.gutter = enabled
+	m.afterGutterChanged()
+}
+
+// GetTimeGutter returns whether the time gutter is shown
+func (m *Model[T]) GetTimeGutter() bool {
+	return m.config.timestamps.gutter
+}
+
+// numTimeGutterCols returns the width of the time gutter, 0 if it isn't shown
+func (m *Model[T]) numTimeGutterCols() int {
+	if !m.config.timestamps.gutter || m.content.timestampFunc == nil {
+		return 0
+	}
+	return timeGutterWidth
+}
+
+// timeGutterColumn returns the rows of the time gutter for the view showing itemIndexes over height rows, with the
+// age of each item on its first row
+func (m *Model[T]) timeGutterColumn(height int, itemIndexes []int) []string {
+	now := m.config.timestamps.now()
+	blank := strings.Repeat(" ", timeGutterWidth)
+	res := make([]string, height)
+	for i := range res {
+		res[i] = blank
+		if i >= len(itemIndexes) || (i > 0 && itemIndexes[i-1] == itemIndexes[i]) {
+			continue
+		}
+		age := now.Sub(m.content.timestampFunc(m.content.objectAt(itemIndexes[i])))
+		res[i] = " " + m.display.styles.TimeGutterStyle.Render(fmt.Sprintf("%*s", timeGutterWidth-1, formatAge(age)))
+	}
+	return res
+}
+
+// formatAge returns d in its largest whole unit of seconds, minutes, hours or days, at most four characters
+func formatAge(d time.Duration) string {
+	d = max(0, d)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", min(999, int(d/(24*time.Hour))))
+	}
+}
+
+// parseGoToTime returns the time typed in the go-to input, a time of day on the day of the last item, false if value
+// isn't one or there's no timestamp function
+func (m *Model[T]) parseGoToTime(value string) (time.Time, bool) {
+	if m.content.timestampFunc == nil || m.content.isEmpty() {
+		return time.Time{}, false
+	}
+	last := m.content.timestampFunc(m.content.objectAt(m.content.numItems() - 1))
+	for _, layout := range goToTimeLayouts {
+		tod, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		return time.Date(last.Year(), last.Month(), last.Day(), tod.Hour(), tod.Minute(), tod.Second(), 0,
+			last.Location()), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,233 @@
+package viewport
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+const (
+	// treeIndent indents a tree node's item once per level of depth
+	treeIndent = "  "
+
+	// treeExpandedMarker, treeCollapsedMarker and treeLeafMarker precede a tree node's item
+	treeExpandedMarker  = "▾ "
+	treeCollapsedMarker = "▸ "
+	treeLeafMarker      = "  "
+)
+
+// TreeNode is an Object in a tree, reporting its depth. A node's parent is the closest preceding node with a smaller
+// depth, so objects are set in depth-first order, e.g. a file tree listing each directory before its contents.
+// Objects that aren't TreeNodes are at depth 0.
+type TreeNode interface {
+	Object
+	GetTreeDepth() int
+}
+
+// treeState hides the descendants of collapsed nodes when tree mode is on
+type treeState[T Object] struct {
+	// sourceObjects is every object set on the viewport, including those hidden in collapsed nodes
+	sourceObjects []T
+
+	// depths is the depth of each of sourceObjects
+	depths []int
+
+	// collapsed is the set of indexes of sourceObjects whose descendants are hidden
+	collapsed map[int]struct{}
+
+	// visible is the index in sourceObjects of each displayed item
+	visible []int
+}
+
+func newTreeState[T Object]() *treeState[T] {
+	return &treeState[T]{collapsed: make(map[int]struct{})}
+}
+
+// treeDepth returns the depth of obj in the tree, 0 if it isn't a TreeNode
+func treeDepth[T Object](obj T) int {
+	if node, ok := any(obj).(TreeNode); ok {
+		return max(0, node.GetTreeDepth())
+	}
+	return 0
+}
+
+// build sets the tree's objects, returning the visible ones. Nodes collapsed before are kept collapsed, matching them
+// with compareFn if non-nil or by position otherwise.
+func (t *treeState[T]) build(objects []T, compareFn CompareFn[T]) []T {
+	var prevCollapsed []T
+	for idx := range t.collapsed {
+		prevCollapsed = append(prevCollapsed, t.sourceObjects[idx])
+	}
+	prevCollapsedIdxs := t.collapsed
+
+	t.sourceObjects = objects
+	t.depths = make([]int, len(objects))
+	for i, obj := range objects {
+		t.depths[i] = treeDepth(obj)
+	}
+
+	t.collapsed = make(map[int]struct{})
+	for i, obj := range objects {
+		if !t.hasChildren(i) {
+			continue
+		}
+		if compareFn == nil {
+			if _, ok := prevCollapsedIdxs[i]; ok {
+				t.collapsed[i] = struct{}{}
+			}
+			continue
+		}
+		for _, p := range prevCollapsed {
+			if compareFn(obj, p) {
+				t.collapsed[i] = struct{}{}
+				break
+			}
+		}
+	}
+	return t.refresh()
+}
+
+// refresh recomputes which nodes are visible, returning their objects
+func (t *treeState[T]) refresh() []T {
+	t.visible = t.visible[:0]
+	objects := make([]T, 0, len(t.sourceObjects))
+	hiddenBelow := -1
+	for i, obj := range t.sourceObjects {
+		if hiddenBelow >= 0 {
+			if t.depths[i] > hiddenBelow {
+				continue
+			}
+			hiddenBelow = -1
+		}
+		t.visible = append(t.visible, i)
+		objects = append(objects, obj)
+		if _, ok := t.collapsed[i]; ok {
+			hiddenBelow = t.depths[i]
+		}
+	}
+	return objects
+}
+
+// hasChildren returns true if the node at sourceIdx has descendants
+func (t *treeState[T]) hasChildren(sourceIdx int) bool {
+	return sourceIdx+1 < len(t.depths) && t.depths[sourceIdx+1] > t.depths[sourceIdx]
+}
+
+// sourceIdx returns the index in sourceObjects of the displayed item at idx, -1 if out of range
+func (t *treeState[T]) sourceIdx(idx int) int {
+	if idx < 0 || idx >= len(t.visible) {
+		return -1
+	}
+	return t.visible[idx]
+}
+
+// visibleIdx returns the displayed item index of the node at sourceIdx, or of its closest visible ancestor if it's
+// hidden in a collapsed node
+func (t *treeState[T]) visibleIdx(sourceIdx int) int {
+	return max(0, sort.SearchInts(t.visible, sourceIdx+1)-1)
+}
+
+// itemAt returns itm, the item of the displayed node at idx, indented to its depth behind a marker showing whether
+// it's collapsed
+func (t *treeState[T]) itemAt(idx int, itm item.Item) item.Item {
+	sourceIdx := t.sourceIdx(idx)
+	if sourceIdx < 0 {
+		return itm
+	}
+	indent := strings.Repeat(treeIndent, t.depths[sourceIdx])
+	marker := treeLeafMarker
+	if t.hasChildren(sourceIdx) {
+		marker = treeExpandedMarker
+		if _, ok := t.collapsed[sourceIdx]; ok {
+			marker = treeCollapsedMarker
+		}
+	}
+
+	segments := itm.LineBrokenItems()
+	if len(segments) == 1 {
+		return item.NewItem(indent + marker + segments[0].Content())
+	}
+	lines := make([]item.SingleItem, len(segments))
+	for i, seg := range segments {
+		prefix := indent + marker
+		if i > 0 {
+			prefix = indent + treeLeafMarker
+		}
+		lines[i] = item.NewItem(prefix + seg.Content())
+	}
+	return item.NewMultiLineItem(lines...)
+}
+
+// setTreeNodeCollapsed collapses or expands the displayed node at itemIdx, keeping the selected node selected, or
+// selecting its closest visible ancestor if it's hidden. Marks and expanded details stay with their nodes.
+func (m *Model[T]) setTreeNodeCollapsed(itemIdx int, collapsed bool) {
+	t := m.content.tree
+	sourceIdx := t.sourceIdx(itemIdx)
+	if sourceIdx < 0 || !t.hasChildren(sourceIdx) {
+		return
+	}
+	if _, ok := t.collapsed[sourceIdx]; ok == collapsed {
+		return
+	}
+	if collapsed {
+		t.collapsed[sourceIdx] = struct{}{}
+	} else {
+		delete(t.collapsed, sourceIdx)
+	}
+	m.refreshTree()
+}
+
+// refreshTree redisplays the tree after nodes are collapsed or expanded
+func (m *Model[T]) refreshTree() {
+	t := m.content.tree
+	selectedSourceIdx := t.sourceIdx(m.content.getSelectedIdx())
+	markedSourceIdxs := m.treeSourceIdxs(m.content.marked)
+	bookmarkedSourceIdxs := m.treeSourceIdxs(m.content.bookmarked)
+	detailsSourceIdxs := m.treeSourceIdxs(m.content.expandedDetails)
+
+	m.content.objects = t.refresh()
+	m.content.resetStickyMemo()
+	m.content.marked = m.treeVisibleIdxs(markedSourceIdxs)
+	m.content.bookmarked = m.treeVisibleIdxs(bookmarkedSourceIdxs)
+	m.content.expandedDetails = m.treeVisibleIdxs(detailsSourceIdxs)
+	if selectedSourceIdx >= 0 {
+		m.content.setSelectedIdx(t.visibleIdx(selectedSourceIdx))
+	}
+	m.refreshSearch()
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// treeSourceIdxs returns the indexes in the tree's source objects of the displayed items in idxs
+func (m *Model[T]) treeSourceIdxs(idxs map[int]struct{}) []int {
+	res := make([]int, 0, len(idxs))
+	for idx := range idxs {
+		if sourceIdx := m.content.tree.sourceIdx(idx); sourceIdx >= 0 {
+			res = append(res, sourceIdx)
+		}
+	}
+	return res
+}
+
+// treeVisibleIdxs returns the displayed item indexes of the visible nodes at sourceIdxs
+func (m *Model[T]) treeVisibleIdxs(sourceIdxs []int) map[int]struct{} {
+	res := make(map[int]struct{}, len(sourceIdxs))
+	for _, sourceIdx := range sourceIdxs {
+		idx := m.content.tree.visibleIdx(sourceIdx)
+		if m.content.tree.sourceIdx(idx) == sourceIdx {
+			res[idx] = struct{}{}
+		}
+	}
+	return res
+}
+
+// treeTotal returns the number of tree nodes including hidden ones when tree mode is on, 0 otherwise
+func (m *Model[T]) treeTotal() int {
+	if m.content.tree == nil || m.content.source != nil {
+		return 0
+	}
+	return len(m.content.tree.sourceObjects)
+}
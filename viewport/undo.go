@@ -0,0 +1,147 @@
+package viewport
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// Change is a reversible change to the content, recorded with RecordChange so that it can be undone and redone
+type Change struct {
+	// Description is shown in the footer when the change is undone or redone, e.g. "delete item"
+	Description string
+
+	// Revert undoes the change, returning an optional command, e.g. to notify the host
+	Revert func() tea.Cmd
+
+	// Apply redoes the change after it's been reverted, returning an optional command
+	Apply func() tea.Cmd
+
+	// move is the move of an item recorded by the viewport, nil for changes the host recorded
+	move *recordedMove
+}
+
+// recordedMove is a move of the item at from to to, its indexes kept up to date as objects are prepended or dropped
+type recordedMove struct {
+	from, to int
+}
+
+// undoState holds the undo and redo stacks
+type undoState struct {
+	// limit is the maximum number of changes kept for undo, 0 when undo is disabled
+	limit int
+
+	// undo is the stack of changes that can be undone, most recent last
+	undo []Change
+
+	// redo is the stack of undone changes that can be redone, most recently undone last
+	redo []Change
+}
+
+// record pushes a change onto the undo stack, dropping the oldest beyond the limit and clearing the redo stack
+func (u *undoState) record(change Change) {
+	if u.limit <= 0 {
+		return
+	}
+	u.undo = append(u.undo, change)
+	if len(u.undo) > u.limit {
+		u.undo = u.undo[len(u.undo)-u.limit:]
+	}
+	u.redo = nil
+}
+
+// shiftMoves moves the indexes of recorded moves down by n items after objects are prepended, or up by -n after the
+// oldest are dropped, forgetting moves of items dropped
+func (u *undoState) shiftMoves(n int) {
+	shift := func(changes []Change) []Change {
+		kept := changes[:0]
+		for _, c := range changes {
+			if c.move != nil {
+				c.move.from += n
+				c.move.to += n
+				if c.move.from < 0 || c.move.to < 0 {
+					continue
+				}
+			}
+			kept = append(kept, c)
+		}
+		return kept
+	}
+	u.undo = shift(u.undo)
+	u.redo = shift(u.redo)
+}
+
+// dropMoves forgets recorded moves once the objects are replaced, as their indexes no longer refer to the same items.
+// Changes the host recorded are kept, as reverting them usually sets the objects again.
+func (u *undoState) dropMoves() {
+	drop := func(changes []Change) []Change {
+		kept := changes[:0]
+		for _, c := range changes {
+			if c.move == nil {
+				kept = append(kept, c)
+			}
+		}
+		return kept
+	}
+	u.undo = drop(u.undo)
+	u.redo = drop(u.redo)
+}
+
+// recordMove records moving the item at from to to
+func (m *Model[T]) recordMove(from, to int) {
+	move := &recordedMove{from: from, to: to}
+	m.config.undo.record(Change{
+		Description: "move item",
+		Revert:      func() tea.Cmd { return m.replayMove(move.to, move.from) },
+		Apply:       func() tea.Cmd { return m.replayMove(move.from, move.to) },
+		move:        move,
+	})
+}
+
+// replayMove moves the item at from to to for undo or redo, selecting it and returning a command sending an
+// ItemMovedMsg. Returns nil if the content changed so that the move is no longer possible.
+func (m *Model[T]) replayMove(from, to int) tea.Cmd {
+	n := m.content.numItems()
+	if !m.canMoveItems() || from < 0 || from >= n || to < 0 || to >= n {
+		return nil
+	}
+	m.moveItem(from, to)
+	if m.navigation.selectionEnabled {
+		m.content.setSelectedIdx(to)
+		m.scrollSoSelectionInView()
+	}
+	return func() tea.Msg {
+		return ItemMovedMsg{From: from, To: to}
+	}
+}
+
+// undoCmd reverts the most recent change, returning its command batched with one showing what was undone
+func (m *Model[T]) undoCmd() tea.Cmd {
+	u := &m.config.undo
+	if len(u.undo) == 0 {
+		return m.showResult("Nothing to undo", false)
+	}
+	change := u.undo[len(u.undo)-1]
+	u.undo = u.undo[:len(u.undo)-1]
+	u.redo = append(u.redo, change)
+	var cmd tea.Cmd
+	if change.Revert != nil {
+		cmd = change.Revert()
+	}
+	return tea.Batch(cmd, m.showResult("Undid "+change.Description, false))
+}
+
+// redoCmd applies the most recently undone change again, returning its command batched with one showing what was
+// redone
+func (m *Model[T]) redoCmd() tea.Cmd {
+	u := &m.config.undo
+	if len(u.redo) == 0 {
+		return m.showResult("Nothing to redo", false)
+	}
+	change := u.redo[len(u.redo)-1]
+	u.redo = u.redo[:len(u.redo)-1]
+	u.undo = append(u.undo, change)
+	var cmd tea.Cmd
+	if change.Apply != nil {
+		cmd = change.Apply()
+	}
+	return tea.Batch(cmd, m.showResult("Redid "+change.Description, false))
+}
@@ -2,13 +2,14 @@ package viewport
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
@@ -55,10 +56,25 @@ func WithKeyMap[T Object](keyMap KeyMap) Option[T] {
 	}
 }
 
+// WithKeyGroupEnabled enables or disables a group of the viewport's key bindings, applied after any WithKeyMap
+// that precedes it
+func WithKeyGroupEnabled[T Object](group KeyGroup, enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetKeyGroupEnabled(group, enabled)
+	}
+}
+
+// WithDisabledNote sets the note centered over the content while the viewport is disabled
+func WithDisabledNote[T Object](note string) Option[T] {
+	return func(m *Model[T]) {
+		m.SetDisabledNote(note)
+	}
+}
+
 // WithStyles sets the styling for the viewport
 func WithStyles[T Object](styles Styles) Option[T] {
 	return func(m *Model[T]) {
-		m.display.styles = styles
+		m.SetStyles(styles)
 	}
 }
 
@@ -69,6 +85,46 @@ func WithWrapText[T Object](wrap bool) Option[T] {
 	}
 }
 
+// WithWrapMode sets where wrapped lines break when wrapping is enabled. See item.WrapMode.
+func WithWrapMode[T Object](mode item.WrapMode) Option[T] {
+	return func(m *Model[T]) {
+		m.SetWrapMode(mode)
+	}
+}
+
+// DefaultWrapIndicator is a marker for wrapped rows, see SetWrapIndicator
+const DefaultWrapIndicator = "↪ "
+
+// WithWrapIndicator sets a marker for wrapped rows after an item's first. See SetWrapIndicator.
+func WithWrapIndicator[T Object](indicator string, style lipgloss.Style) Option[T] {
+	return func(m *Model[T]) {
+		m.SetWrapIndicator(indicator, style)
+	}
+}
+
+// WithWrapIndent sets how many cells wrapped rows after an item's first are indented. See SetWrapIndent.
+func WithWrapIndent[T Object](indent int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetWrapIndent(indent)
+	}
+}
+
+// WithSoftLimits sets content size thresholds beyond which expensive features are disabled. See SoftLimits.
+func WithSoftLimits[T Object](limits SoftLimits) Option[T] {
+	return func(m *Model[T]) {
+		m.SetSoftLimits(limits)
+	}
+}
+
+// WithMouseSupport sets whether the viewport handles mouse messages: the wheel scrolls (horizontally with shift),
+// clicking an item selects it and dragging scrolls. The program must also enable mouse reporting, e.g. by setting
+// tea.View.MouseMode. See SetMouseOrigin for viewports not at the top left of the screen.
+func WithMouseSupport[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMouseSupport(enabled)
+	}
+}
+
 // WithSelectionEnabled sets whether the viewport allows selection
 func WithSelectionEnabled[T Object](enabled bool) Option[T] {
 	return func(m *Model[T]) {
@@ -90,6 +146,105 @@ func WithProgressBarEnabled[T Object](enabled bool) Option[T] {
 	}
 }
 
+// WithResizeThrottle sets the least time between relayouts from Resize, coalescing rapid resizes
+func WithResizeThrottle[T Object](interval time.Duration) Option[T] {
+	return func(m *Model[T]) {
+		m.SetResizeThrottle(interval)
+	}
+}
+
+// WithWrapPrecompute sets how many items past the view are wrapped in the background, see SetWrapPrecompute
+func WithWrapPrecompute[T Object](numItems int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetWrapPrecompute(numItems)
+	}
+}
+
+// WithContentOnly sets whether the viewport renders just its items, see SetContentOnly
+func WithContentOnly[T Object](contentOnly bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetContentOnly(contentOnly)
+	}
+}
+
+// WithFooterFormatter sets the function that renders the footer text
+func WithFooterFormatter[T Object](formatter FooterFormatter) Option[T] {
+	return func(m *Model[T]) {
+		m.SetFooterFormatter(formatter)
+	}
+}
+
+// WithFooterMetric sets what the footer's scroll position measures. See SetFooterMetric.
+func WithFooterMetric[T Object](metric FooterMetric) Option[T] {
+	return func(m *Model[T]) {
+		m.SetFooterMetric(metric)
+	}
+}
+
+// WithScrollbar sets whether a scrollbar is shown on the right edge of the content. See SetScrollbar.
+func WithScrollbar[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetScrollbar(enabled)
+	}
+}
+
+// WithMinimap sets whether a minimap of highlights is shown on the right edge of the content. See SetMinimap.
+func WithMinimap[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMinimap(enabled)
+	}
+}
+
+// WithMatchingItemPadding sets the lines of context left around items moved to with NextItemMatching and
+// PrevItemMatching
+func WithMatchingItemPadding[T Object](lines int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMatchingItemPadding(lines)
+	}
+}
+
+// WithDiffMode sets whether the content is rendered as a diff, see SetDiffMode
+func WithDiffMode[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetDiffMode(enabled)
+	}
+}
+
+// WithLinkHandler sets the function activating OSC 8 hyperlinks, see SetLinkHandler
+func WithLinkHandler[T Object](handler func(url string) tea.Cmd) Option[T] {
+	return func(m *Model[T]) {
+		m.SetLinkHandler(handler)
+	}
+}
+
+// WithLinkDetection sets whether URLs and file:line paths in item text are detected as links, see SetLinkDetection
+func WithLinkDetection[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetLinkDetection(enabled)
+	}
+}
+
+// WithSubLineSelection sets whether the selection is a single line of the selected item, see SetSubLineSelection
+func WithSubLineSelection[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetSubLineSelection(enabled)
+	}
+}
+
+// WithGoToFlash sets how long the item gone to flashes, see SetGoToFlash
+func WithGoToFlash[T Object](duration time.Duration) Option[T] {
+	return func(m *Model[T]) {
+		m.SetGoToFlash(duration)
+	}
+}
+
+// WithSmoothScroll sets how long jumps of more than a page are animated over, see SetSmoothScroll
+func WithSmoothScroll[T Object](duration time.Duration) Option[T] {
+	return func(m *Model[T]) {
+		m.SetSmoothScroll(duration)
+	}
+}
+
 // WithStickyTop sets whether to automatically scroll to the top when content changes
 func WithStickyTop[T Object](stickyTop bool) Option[T] {
 	return func(m *Model[T]) {
@@ -104,6 +259,83 @@ func WithStickyBottom[T Object](stickyBottom bool) Option[T] {
 	}
 }
 
+// WithFollowMode sets whether to follow appended content. See SetFollowMode.
+func WithFollowMode[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetFollowMode(enabled)
+	}
+}
+
+// WithSortFunc orders items with less. See SetSortFunc.
+func WithSortFunc[T Object](less func(a, b T) bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetSortFunc(less)
+	}
+}
+
+// WithSearchIncludesHeader sets whether the search highlights the header. See SetSearchIncludesHeader.
+func WithSearchIncludesHeader[T Object](include bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetSearchIncludesHeader(include)
+	}
+}
+
+// WithShowControlChars sets whether control characters are shown in caret notation. See SetShowControlChars.
+func WithShowControlChars[T Object](show bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetShowControlChars(show)
+	}
+}
+
+// WithShowInvisibles sets whether invisible characters are shown as visible glyphs. See SetShowInvisibles.
+func WithShowInvisibles[T Object](show bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetShowInvisibles(show)
+	}
+}
+
+// WithTabWidth sets the number of cells between the tab stops tabs expand to, see SetTabWidth
+func WithTabWidth[T Object](width int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetTabWidth(width)
+	}
+}
+
+// WithColumns lays out Row objects in columns. See SetColumns.
+func WithColumns[T Object](layout ColumnLayout) Option[T] {
+	return func(m *Model[T]) {
+		m.SetColumns(layout)
+	}
+}
+
+// WithPanStep sets how many columns the Left and Right keys pan. See SetPanStep.
+func WithPanStep[T Object](step int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetPanStep(step)
+	}
+}
+
+// WithPanAcceleration sets how much repeated pans speed up. See SetPanAcceleration.
+func WithPanAcceleration[T Object](maxMultiplier int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetPanAcceleration(maxMultiplier)
+	}
+}
+
+// WithPanSelectedOnly sets whether panning moves only the selected item. See SetPanSelectedOnly.
+func WithPanSelectedOnly[T Object](selectedOnly bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetPanSelectedOnly(selectedOnly)
+	}
+}
+
+// WithMoveMode sets whether the selected item can be reordered with the keyboard. See SetMoveMode.
+func WithMoveMode[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetMoveMode(enabled)
+	}
+}
+
 // WithSelectionStyleOverridesItemStyle controls whether the selection style replaces the item's
 // existing ANSI styling. When true (default), the selected item is stripped of its original
 // styling and the selection style is applied to all non-highlighted regions. When false,
@@ -114,8 +346,9 @@ func WithSelectionStyleOverridesItemStyle[T Object](overrides bool) Option[T] {
 	}
 }
 
-// WithFileSaving configures automatic file saving when a hotkey is pressed.
-// Files are saved to the specified directory with timestamp-based names.
+// WithFileSaving configures saving all content to a file when saveKey is pressed. A filename is entered in the
+// footer, defaulting to a timestamp-based name, and the file is saved to saveDir in the format set with
+// WithSaveFormat, sending a FileSavedMsg.
 func WithFileSaving[T Object](saveDir string, saveKey key.Binding) Option[T] {
 	return func(m *Model[T]) {
 		m.config.saveDir = saveDir
@@ -123,6 +356,74 @@ func WithFileSaving[T Object](saveDir string, saveKey key.Binding) Option[T] {
 	}
 }
 
+// WithClipboard configures copying to the clipboard when copyKey is pressed. The key copies the marked items if any,
+// otherwise the selected item when selection is enabled, otherwise the visible content. See Copy.
+func WithClipboard[T Object](copyKey key.Binding, target ClipboardTarget) Option[T] {
+	return func(m *Model[T]) {
+		m.config.copyKey = copyKey
+		m.config.clipboardTarget = target
+	}
+}
+
+// WithInlineEditing enables editing the selected item inline when editKey is pressed. See StartEditing.
+func WithInlineEditing[T Object](editKey key.Binding) Option[T] {
+	return func(m *Model[T]) {
+		m.config.editKey = editKey
+	}
+}
+
+// WithUndo enables undoing and redoing up to limit content changes with the Undo and Redo keys. See RecordChange.
+func WithUndo[T Object](limit int) Option[T] {
+	return func(m *Model[T]) {
+		m.SetUndoLimit(limit)
+	}
+}
+
+// WithLineJoining groups objects for which isContinuation returns true (e.g. indented stack trace lines)
+// under the preceding object, displaying each group as a single selectable item. See SetLineJoining.
+func WithLineJoining[T Object](isContinuation func(T) bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetLineJoining(isContinuation)
+	}
+}
+
+// WithStickyItemFunc sets a function returning whether an object is sticky, pinned under the header once scrolled
+// past. See SetStickyItemFunc.
+func WithStickyItemFunc[T Object](isSticky func(T) bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetStickyItemFunc(isSticky)
+	}
+}
+
+// WithTreeMode shows objects as a tree of collapsible TreeNodes. See SetTreeMode.
+func WithTreeMode[T Object](enabled bool) Option[T] {
+	return func(m *Model[T]) {
+		m.SetTreeMode(enabled)
+	}
+}
+
+// WithPreprocessor sets a function that transforms objects before they're displayed. See SetPreprocessor.
+func WithPreprocessor[T Object](preprocessor Preprocessor[T]) Option[T] {
+	return func(m *Model[T]) {
+		m.SetPreprocessor(preprocessor)
+	}
+}
+
+// WithItemStyleFunc sets a function returning a style to layer under each unselected item. See SetItemStyleFunc.
+func WithItemStyleFunc[T Object](styleFunc ItemStyleFunc[T]) Option[T] {
+	return func(m *Model[T]) {
+		m.SetItemStyleFunc(styleFunc)
+	}
+}
+
+// WithWordChars sets which runes are considered part of a word for word-wise operations
+// like wrapping at word boundaries with item.WrapWords. See item.WordChars.
+func WithWordChars[T Object](wordChars item.WordChars) Option[T] {
+	return func(m *Model[T]) {
+		m.SetWordChars(wordChars)
+	}
+}
+
 // Model represents a viewport component
 type Model[T Object] struct {
 	// content manages the content and selection state
@@ -136,6 +437,44 @@ type Model[T Object] struct {
 
 	// config manages configuration options
 	config *configuration
+
+	// id identifies the viewport in its internal messages, so that those of other viewports are ignored
+	id int
+}
+
+// lastID is the id of the most recently created viewport
+var lastID atomic.Int64
+
+// nextID returns the id of a new viewport
+func nextID() int {
+	return int(lastID.Add(1))
+}
+
+// IsInternalMsg returns whether msg is one of the viewport's own messages, e.g. timing out a key sequence or showing
+// the next frame of a smooth scroll. Components wrapping the viewport should pass these to its Update whatever input
+// they're capturing.
+func IsInternalMsg(msg tea.Msg) bool {
+	_, ok := internalMsgID(msg)
+	return ok
+}
+
+// internalMsgID returns the id of the viewport an internal message is for, and false for other messages
+func internalMsgID(msg tea.Msg) (int, bool) {
+	switch msg := msg.(type) {
+	case chordTimeoutMsg:
+		return msg.id, true
+	case smoothScrollMsg:
+		return msg.id, true
+	case wrapPrecomputedMsg:
+		return msg.id, true
+	case clearFlashMsg:
+		return msg.id, true
+	case clearMessageMsg:
+		return msg.id, true
+	case clearOverscrollMsg:
+		return msg.id, true
+	}
+	return 0, false
 }
 
 // New creates a new viewport model with reasonable defaults
@@ -147,11 +486,12 @@ func New[T Object](width, height int, opts ...Option[T]) (m *Model[T]) {
 		height = 0
 	}
 
-	m = &Model[T]{}
+	m = &Model[T]{id: nextID()}
 	m.content = newContentManager[T]()
 	m.display = newDisplayManager(width, height, DefaultStyles())
 	m.navigation = newNavigationManager(DefaultKeyMap())
 	m.config = newConfiguration()
+	m.syncControlCharStyle()
 
 	for _, opt := range opts {
 		if opt != nil {
@@ -164,25 +504,80 @@ func New[T Object](width, height int, opts ...Option[T]) (m *Model[T]) {
 
 // Update processes messages and updates the model
 func (m *Model[T]) Update(msg tea.Msg) (*Model[T], tea.Cmd) {
+	// internal messages of other viewports, e.g. the other pane of a split view, are ignored
+	if id, ok := internalMsgID(msg); ok && id != m.id {
+		return m, nil
+	}
+	m.navigation.prevSelectedIdx = m.selectedIdxIfEnabled()
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseClickMsg, tea.MouseWheelMsg:
+		// input acts on where a smooth scroll is heading
+		m.finishSmoothScroll()
+	}
+	prevTopItemIdx, prevTopItemLineOffset := m.display.topItemIdx, m.display.topItemLineOffset
+	m, cmd := m.update(msg)
+	m.stopPeekIfMoved()
+	return m, tea.Batch(cmd, m.startSmoothScroll(prevTopItemIdx, prevTopItemLineOffset), m.syncFollowing(),
+		m.selectionChangedCmd(), m.startWrapPrecompute(prevTopItemIdx, prevTopItemLineOffset))
+}
+
+func (m *Model[T]) update(msg tea.Msg) (*Model[T], tea.Cmd) {
 	var (
 		cmd  tea.Cmd
 		cmds []tea.Cmd
 	)
 
+	// resizes settle regardless of what's capturing input
+	if msg, ok := msg.(ResizeSettledMsg); ok {
+		m.settleResize(msg)
+		return m, nil
+	}
+	if msg, ok := msg.(smoothScrollMsg); ok {
+		return m, m.advanceSmoothScroll(msg)
+	}
+	if msg, ok := msg.(spinner.TickMsg); ok {
+		return m, m.updateLoading(msg)
+	}
+	if msg, ok := msg.(wrapPrecomputedMsg); ok {
+		m.finishWrapPrecompute(msg)
+		return m, nil
+	}
+	if m.handleControlMsg(msg) {
+		return m, nil
+	}
+	if timeout, ok := msg.(chordTimeoutMsg); ok {
+		// the keys of a sequence pressed before it timed out may be a binding themselves
+		if msg = m.timeOutChord(timeout); msg == nil {
+			return m, nil
+		}
+	}
+
+	// a disabled viewport ignores input
+	if !m.config.enabled {
+		switch msg.(type) {
+		case tea.KeyMsg, tea.MouseMsg:
+			return m, nil
+		}
+	}
+
+	// a blurred viewport ignores keys, which are meant for another component
+	if _, ok := msg.(tea.KeyMsg); ok && !m.config.focused {
+		return m, nil
+	}
+
 	// route all messages to filename textinput when actively entering filename
 	if m.config.saveState.enteringFilename {
 		if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
 			switch keyMsg.Code {
 			case tea.KeyEnter:
-				filename := m.config.saveState.filenameInput.Value()
-				if filename == "" {
-					filename = time.Now().Format("20060102-150405") + ".txt"
-				} else if !strings.HasSuffix(filename, ".txt") {
-					filename += ".txt"
-				}
+				filename := m.saveFilename(m.config.saveState.filenameInput.Value())
 				m.config.saveState.enteringFilename = false
 				m.config.saveState.saving = true
 				return m, m.saveToFile(filename)
+			case tea.KeyTab:
+				// cycle through the save formats
+				m.SetSaveFormat((m.config.saveFormat + 1) % numSaveFormats)
+				return m, nil
 			case tea.KeyEscape:
 				m.config.saveState.enteringFilename = false
 				return m, nil
@@ -193,18 +588,221 @@ func (m *Model[T]) Update(msg tea.Msg) (*Model[T], tea.Cmd) {
 		return m, cmd
 	}
 
+	// route all messages to the edit textinput when editing the selected item
+	if m.config.editState.editing {
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+			switch keyMsg.Code {
+			case tea.KeyEnter:
+				return m, m.confirmEditing()
+			case tea.KeyEscape:
+				m.CancelEditing()
+				return m, nil
+			}
+		}
+		m.config.editState.input, cmd = m.config.editState.input.Update(msg)
+		return m, cmd
+	}
+
+	// route all messages to the go-to input while it's shown
+	if m.config.goTo.active {
+		return m, m.updateGoTo(msg)
+	}
+
+	// keys scroll or dismiss the preview popup while it's shown
+	if m.config.preview.active && m.updatePreview(msg) {
+		return m, nil
+	}
+
+	// in block selection, left and right move the block's edge and enter and esc end it
+	if m.config.blockSelection.active {
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+			switch {
+			case keyMsg.Code == tea.KeyEnter:
+				return m, m.confirmBlockSelection()
+			case keyMsg.Code == tea.KeyEscape:
+				m.CancelBlockSelection()
+				return m, nil
+			case key.Matches(keyMsg, m.navigation.keyMap.Left):
+				m.moveBlockCursor(-1)
+				return m, nil
+			case key.Matches(keyMsg, m.navigation.keyMap.Right):
+				m.moveBlockCursor(1)
+				return m, nil
+			}
+		}
+	}
+
+	// in visual selection, left and right move the cursor, the copy key copies the selection and enter and esc end it
+	if m.config.visualSelection.active {
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+			switch {
+			case keyMsg.Code == tea.KeyEnter:
+				return m, m.confirmVisualSelection()
+			case keyMsg.Code == tea.KeyEscape:
+				m.CancelVisualSelection()
+				return m, nil
+			case key.Matches(keyMsg, m.navigation.keyMap.Left):
+				m.moveVisualCursor(-1)
+				return m, nil
+			case key.Matches(keyMsg, m.navigation.keyMap.Right):
+				m.moveVisualCursor(1)
+				return m, nil
+			case key.Matches(keyMsg, m.config.copyKey):
+				cmd = m.Copy()
+				m.CancelVisualSelection()
+				return m, cmd
+			}
+		}
+	}
+
+	// keys may continue or complete a sequence bound like "g g"
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		if msg, cmd = m.chordKey(keyMsg); msg == nil {
+			return m, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.navigation.selectionEnabled && !m.content.isEmpty() {
+			switch {
+			case key.Matches(msg, m.navigation.keyMap.CenterSelection):
+				m.ScrollItemToCenter(m.content.getSelectedIdx())
+				return m, nil
+			case key.Matches(msg, m.navigation.keyMap.SelectionToTop):
+				m.ScrollItemToTop(m.content.getSelectedIdx())
+				return m, nil
+			case key.Matches(msg, m.navigation.keyMap.SelectionToBottom):
+				m.ScrollItemToBottom(m.content.getSelectedIdx())
+				return m, nil
+			}
+		}
+		if key.Matches(msg, m.navigation.keyMap.BlockSelect) && m.StartBlockSelection() {
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.VisualSelect) && m.StartVisualSelection() {
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.GoTo) && !m.content.isEmpty() {
+			return m, m.startGoTo()
+		}
+		if key.Matches(msg, m.config.editKey) {
+			if cmd = m.StartEditing(); cmd != nil {
+				return m, cmd
+			}
+		}
+		if key.Matches(msg, m.navigation.keyMap.ToggleExpand) && m.content.joining != nil && m.navigation.selectionEnabled {
+			selectedIdx := m.content.getSelectedIdx()
+			m.SetGroupExpanded(selectedIdx, !m.content.joining.isExpanded(selectedIdx))
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.ToggleExpand) && m.navigation.selectionEnabled && !m.content.isEmpty() {
+			selectedIdx := m.content.getSelectedIdx()
+			if t := m.content.tree; t != nil && t.hasChildren(t.sourceIdx(selectedIdx)) {
+				m.SetNodeCollapsed(selectedIdx, !m.IsNodeCollapsed(selectedIdx))
+				return m, nil
+			}
+			if len(detailItems(m.content.objectAt(selectedIdx))) > 0 {
+				m.SetDetailsExpanded(selectedIdx, !m.IsDetailsExpanded(selectedIdx))
+				return m, nil
+			}
+		}
+		if key.Matches(msg, m.navigation.keyMap.ToggleSort) && m.content.sorting != nil {
+			m.SetSortDirection(m.content.sorting.direction.next())
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.ToggleHexView) {
+			m.SetHexView(m.content.hexView.next())
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.ToggleMarked) && m.navigation.selectionEnabled && !m.content.isEmpty() {
+			selectedIdx := m.content.getSelectedIdx()
+			m.SetMarked(selectedIdx, !m.IsMarked(selectedIdx))
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.Peek) && m.navigation.selectionEnabled && !m.config.wrapText {
+			m.SetPeek(!m.GetPeek())
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.navigation.keyMap.ToggleBookmark):
+			m.ToggleBookmark()
+			return m, nil
+		case key.Matches(msg, m.navigation.keyMap.NextBookmark):
+			m.NextBookmark()
+			return m, nil
+		case key.Matches(msg, m.navigation.keyMap.PrevBookmark):
+			m.PrevBookmark()
+			return m, nil
+		case m.config.diffMode && key.Matches(msg, m.navigation.keyMap.NextHunk):
+			m.NextHunk()
+			return m, nil
+		case m.config.diffMode && key.Matches(msg, m.navigation.keyMap.PrevHunk):
+			m.PrevHunk()
+			return m, nil
+		}
+		if m.config.undo.limit > 0 {
+			switch {
+			case key.Matches(msg, m.navigation.keyMap.Undo):
+				return m, m.undoCmd()
+			case key.Matches(msg, m.navigation.keyMap.Redo):
+				return m, m.redoCmd()
+			}
+		}
+		if m.navigation.moveMode {
+			switch {
+			case key.Matches(msg, m.navigation.keyMap.MoveItemUp):
+				return m, m.moveSelectedCmd(-1)
+			case key.Matches(msg, m.navigation.keyMap.MoveItemDown):
+				return m, m.moveSelectedCmd(1)
+			}
+		}
+		if key.Matches(msg, m.navigation.keyMap.NextSearchMatch) && len(m.content.search.matches) > 0 {
+			m.NextMatch()
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.PrevSearchMatch) && len(m.content.search.matches) > 0 {
+			m.PrevMatch()
+			return m, nil
+		}
+		if key.Matches(msg, m.navigation.keyMap.OpenLink) {
+			if cmd = m.openLinkCmd(); cmd != nil {
+				return m, cmd
+			}
+		}
+		if len(m.content.links.byItem) > 0 || len(m.visibleLinks()) > 0 {
+			switch {
+			case key.Matches(msg, m.navigation.keyMap.NextLink):
+				m.NextLink()
+				return m, nil
+			case key.Matches(msg, m.navigation.keyMap.PrevLink):
+				m.PrevLink()
+				return m, nil
+			case key.Matches(msg, m.navigation.keyMap.ActivateLink):
+				if cmd = m.activateLinkCmd(); cmd != nil {
+					return m, cmd
+				}
+			}
+		}
+		if key.Matches(msg, m.config.copyKey) && !m.config.saveState.saving {
+			return m, m.Copy()
+		}
+		if key.Matches(msg, m.navigation.keyMap.CopySelected) && m.navigation.selectionEnabled {
+			return m, m.CopySelected()
+		}
+		if key.Matches(msg, m.navigation.keyMap.CopySelectedStyled) && m.navigation.selectionEnabled {
+			return m, m.CopySelectedStyled()
+		}
 		if key.Matches(msg, m.config.saveKey) {
 			saveDirDefined := m.config.saveDir != ""
 			saving := m.config.saveState.saving
-			showingResult := m.config.saveState.showingResult
+			showingMessage := m.config.message.text != ""
 			enteringFilename := m.config.saveState.enteringFilename
-			if !saveDirDefined || saving || showingResult || enteringFilename {
+			if !saveDirDefined || saving || showingMessage || enteringFilename {
 				return m, nil
 			}
 			ti := textinput.New()
-			ti.Placeholder = time.Now().Format("20060102-150405") + ".txt"
+			ti.Placeholder = m.defaultSaveFilename()
 			ti.Focus()
 			ti.CharLimit = 256
 			ti.SetWidth(m.display.bounds.width - 20)
@@ -213,35 +811,50 @@ func (m *Model[T]) Update(msg tea.Msg) (*Model[T], tea.Cmd) {
 			return m, textinput.Blink
 		}
 
-	case fileSavedMsg:
-		// update save state with result
-		m.config.saveState.saving = false
-		m.config.saveState.showingResult = true
-		if msg.err != nil {
-			m.config.saveState.isError = true
-			m.config.saveState.resultMsg = fmt.Sprintf("Save failed: %v", msg.err)
-		} else {
-			m.config.saveState.isError = false
-			m.config.saveState.resultMsg = fmt.Sprintf("Saved to %s", msg.filename)
-		}
-		// start 4 second timer to clear result
-		cmd = func() tea.Msg {
-			time.Sleep(4 * time.Second)
-			return clearSaveResultMsg{}
-		}
-		cmds = append(cmds, cmd)
-		return m, tea.Batch(cmds...)
-
-	case clearSaveResultMsg:
-		// clear the save result display
-		m.config.saveState.showingResult = false
-		m.config.saveState.resultMsg = ""
-		m.config.saveState.isError = false
+	case tea.MouseMsg:
+		if cmd = m.mouseLinkCmd(msg); cmd != nil {
+			return m, cmd
+		}
+		prev := m.scrollPosition()
+		if m.handleMouse(msg) {
+			return m, m.showOverscroll(wheelAction(msg.Mouse()), prev)
+		}
+
+	case clearOverscrollMsg:
+		if msg.seq == m.config.overscroll.seq {
+			m.config.overscroll.showing = false
+		}
+		return m, nil
+
+	case clearFlashMsg:
+		if msg.seq == m.config.goTo.flashSeq {
+			m.config.goTo.flashItemIdx = -1
+		}
+		return m, nil
+
+	case FileSavedMsg:
+		m.config.saveState.saving = false
+		if msg.Err != nil {
+			return m, m.showResult(fmt.Sprintf("Save failed: %v", msg.Err), true)
+		}
+		return m, m.showResult(fmt.Sprintf("Saved to %s (%d bytes)", msg.Path, msg.Bytes), false)
+
+	case CopiedMsg:
+		if msg.Err != nil {
+			return m, m.showResult(fmt.Sprintf("Copy failed: %v", msg.Err), true)
+		}
+		return m, m.showResult(fmt.Sprintf("Copied %s", msg.Description), false)
+
+	case clearMessageMsg:
+		if msg.seq == m.config.message.seq {
+			m.ClearMessage()
+		}
 		return m, nil
 	}
 
 	// handle navigation for KeyMsg
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		prev := m.scrollPosition()
 		navResult := m.navigation.processKeyMsg(keyMsg, m.navCtx())
 
 		switch navResult.action {
@@ -253,21 +866,32 @@ func (m *Model[T]) Update(msg tea.Msg) (*Model[T], tea.Cmd) {
 			m.scrollVertical(navResult)
 
 		case actionLeft, actionRight:
-			m.scrollHorizontal(navResult)
+			m.scrollHorizontal(m.navigation.pan.accelerate(navResult))
+		case actionPanToStart:
+			m.SetXOffset(0)
+		case actionPanToEnd:
+			m.SetXOffset(m.maxItemWidth())
 
 		default:
 			// no-op on keypress that doesn't produce a selection action
 		}
+		cmds = append(cmds, m.showOverscroll(navResult.action, prev))
 	}
 
 	cmds = append(cmds, cmd)
 	return m, tea.Batch(cmds...)
 }
 
-// View renders the viewport
+// View renders the viewport. Zero or negative dimensions never panic: negative widths and heights are treated as 0,
+// and a viewport of height 0 renders as the empty string.
 func (m *Model[T]) View() string {
+	if m.display.bounds.height == 0 {
+		m.display.wrapPrefixedRows = nil
+		return ""
+	}
+	m.refreshColumnWidths()
+
 	var builder strings.Builder
-	wrap := m.config.wrapText
 
 	visibleHeaderLines := m.getVisibleHeaderLines()
 	itemIndexes := m.getVisibleContentItemIndexes()
@@ -285,14 +909,67 @@ func (m *Model[T]) View() string {
 	}
 
 	// render post-header line if set
-	if m.config.postHeaderLine != "" {
+	if m.showsPostHeaderLine() {
 		postHeaderItem := item.NewItem(m.config.postHeaderLine)
 		truncated, _ := postHeaderItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
 		builder.WriteString(truncated)
 		builder.WriteByte('\n')
 	}
 
-	// content lines — render each visible line using segment-aware logic.
+	// content lines, padded to the content height
+	for _, line := range m.renderContentLines(itemIndexes) {
+		builder.WriteString(line)
+		builder.WriteByte('\n')
+	}
+
+	// render pre-footer line if set
+	if m.showsPreFooterLine() {
+		preFooterItem := item.NewItem(m.config.preFooterLine)
+		truncated, _ := preFooterItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
+		builder.WriteString(truncated)
+		builder.WriteByte('\n')
+	}
+
+	if m.config.contentOnly {
+		// no footer
+	} else if m.config.goTo.active {
+		// show the go-to input in the footer
+		footerItem := item.NewItem(goToPrompt + m.config.goTo.input.View())
+		truncated, _ := footerItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
+		builder.WriteString(m.display.styles.FooterStyle.Render(truncated))
+	} else if m.config.saveState.enteringFilename {
+		// show filename input in footer
+		prompt := "Save as: "
+		inputView := m.config.saveState.filenameInput.View()
+		footerContent := prompt + inputView + " [" + m.config.saveFormat.String() + "]"
+		footerItem := item.NewItem(footerContent)
+		truncated, _ := footerItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
+		builder.WriteString(m.display.styles.FooterStyle.Render(truncated))
+	} else if m.config.saveState.saving {
+		statusItem := item.NewItem("Saving...")
+		truncated, _ := statusItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
+		builder.WriteString(m.display.styles.FooterStyle.Render(truncated))
+	} else if m.config.message.text != "" {
+		// show the message over the footer
+		messageItem := item.NewItem(m.config.message.text)
+		truncated, _ := messageItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
+		style := m.display.styles.MessageStyle
+		if m.config.message.isError {
+			style = m.display.styles.ErrorMessageStyle
+		}
+		builder.WriteString(style.Render(truncated))
+	} else if m.config.footerEnabled {
+		// pad so footer shows up at bottom
+		builder.WriteString(m.getTruncatedFooterLine(itemIndexes))
+	}
+
+	return m.display.render(strings.TrimSuffix(builder.String(), "\n"))
+}
+
+// renderContentLines renders the content lines of the items at itemIndexes, one per line, padded with blank lines to
+// the content height. Each line is rendered using segment-aware logic: an item may have multiple line-broken segments
+// (via LineBrokenItems()), each rendered on a separate terminal line and wrapping independently.
+func (m *Model[T]) renderContentLines(itemIndexes []int) []string {
 	// An item may have multiple line-broken segments (via LineBrokenItems()), each rendered
 	// on a separate terminal line and wrapping independently.
 	truncatedVisibleContentLines := make([]string, len(itemIndexes))
@@ -302,6 +979,8 @@ func (m *Model[T]) View() string {
 	cw := m.contentWidth()
 	hasPrefix := m.navigation.selectionEnabled && m.display.styles.SelectionPrefix != ""
 	prefixPad := m.selectionPrefixPadding()
+	subLineActive := m.subLineActive()
+	selectedLineOffset := m.GetSelectedLineOffset()
 
 	// segment tracking state for multi-line items
 	var currentSegments []item.Item
@@ -309,31 +988,79 @@ func (m *Model[T]) View() string {
 	currentCellsToLeft := 0
 	prevItemIdx := -1
 
+	// graphics state for the item being rendered
+	var currentGraphics item.GraphicsItem
+	var isGraphics, graphicsInView bool
+
+	// whether the item being rendered wraps, which only the peeked item does while unwrapped
+	var wrap bool
+
+	// row tracking state for wrap modes other than item.WrapChars or with a wrap indent, where rows can be narrower
+	// than cw
+	layout := m.wrapLayout()
+	var wrapByRowStarts bool
+	var currentRowStarts []int
+	currentRowIdx := 0
+
 	// initialize segment state for the first visible item
-	if wrap && len(itemIndexes) > 0 {
-		topItem := m.content.objects[itemIndexes[0]].GetItem()
+	if len(itemIndexes) > 0 {
+		topItem := m.content.itemAt(itemIndexes[0])
 		currentSegments = topItem.LineBrokenItems()
-		var wrapOffset int
-		currentSegIdx, wrapOffset = decomposeLineOffset(currentSegments, m.display.topItemLineOffset, cw)
-		currentCellsToLeft = wrapOffset * cw
+		wrap = m.wrapsItem(itemIndexes[0])
+		wrapByRowStarts = wrap && layout.byRowStarts()
+		if wrap {
+			var wrapOffset int
+			currentSegIdx, wrapOffset = decomposeLineOffset(currentSegments, m.display.topItemLineOffset, cw, layout)
+			currentCellsToLeft = wrapOffset * cw
+			if wrapByRowStarts {
+				currentRowStarts = layout.rowStarts(currentSegments[currentSegIdx], cw)
+				currentRowIdx = min(wrapOffset, len(currentRowStarts)-1)
+				currentCellsToLeft = currentRowStarts[currentRowIdx]
+			}
+		} else {
+			// unwrapped, each segment is one line
+			currentSegIdx = clampValZeroToMax(m.display.topItemLineOffset, len(currentSegments)-1)
+		}
 		prevItemIdx = itemIndexes[0]
 	}
 
+	m.display.wrapPrefixedRows = make([]bool, len(itemIndexes))
 	for idx, itemIdx := range itemIndexes {
 		// when we encounter a new item, refresh segment tracking
 		if itemIdx != prevItemIdx {
-			fullItem := m.content.objects[itemIdx].GetItem()
+			fullItem := m.content.itemAt(itemIdx)
 			currentSegments = fullItem.LineBrokenItems()
 			currentSegIdx = 0
 			currentCellsToLeft = 0
 			prevItemIdx = itemIdx
+			wrap = m.wrapsItem(itemIdx)
+			wrapByRowStarts = wrap && layout.byRowStarts()
+			if wrapByRowStarts {
+				currentRowStarts = layout.rowStarts(currentSegments[0], cw)
+				currentRowIdx = 0
+			}
 		}
 
 		var truncated string
-		isSelection := m.navigation.selectionEnabled && itemIdx == m.content.getSelectedIdx()
+		isSeparatorRow := m.content.hasSeparator(itemIdx) && currentSegIdx == len(currentSegments)-1
+		isSelection := m.navigation.selectionEnabled && itemIdx == m.content.getSelectedIdx() && !isSeparatorRow
+		if isSelection && subLineActive {
+			// only the cursor's line of the selected item is selected
+			lineOffset := 0
+			for i := idx - 1; i >= 0 && itemIndexes[i] == itemIdx; i-- {
+				lineOffset++
+			}
+			if itemIdx == m.display.topItemIdx {
+				lineOffset += m.display.topItemLineOffset
+			}
+			isSelection = lineOffset == selectedLineOffset
+		}
 
 		// get highlights for this item and remap to current segment
 		highlights := m.getHighlightsForItem(itemIdx)
+		if isSelection {
+			highlights = m.highlightsAboveSelection(highlights)
+		}
 		if isSelection && m.config.selectionStyleOverridesItemStyle {
 			highlights = m.selectionHighlights(itemIdx, highlights)
 		}
@@ -348,7 +1075,35 @@ func (m *Model[T]) View() string {
 			segment = item.NewItem(segment.ContentNoAnsi())
 		}
 
-		if wrap {
+		isIndentedRow := false
+		if wrapByRowStarts {
+			rowEnd := segment.Width()
+			if currentRowIdx+1 < len(currentRowStarts) {
+				rowEnd = currentRowStarts[currentRowIdx+1]
+			}
+			rowWidth := cw
+			if currentRowIdx > 0 {
+				isIndentedRow = layout.indent > 0
+				rowWidth -= layout.indent
+			}
+			// a row can run past rowWidth with whitespace at a word break, which isn't shown
+			truncated, _ = segment.Take(
+				currentCellsToLeft,
+				min(rowEnd-currentCellsToLeft, rowWidth),
+				"",
+				highlights,
+			)
+			// advance row and segment tracking for next iteration
+			if idx+1 < len(itemIndexes) && itemIndexes[idx+1] == itemIdx {
+				currentRowIdx++
+				if currentRowIdx >= len(currentRowStarts) && currentSegIdx+1 < len(currentSegments) {
+					currentSegIdx++
+					currentRowStarts = layout.rowStarts(currentSegments[currentSegIdx], cw)
+					currentRowIdx = 0
+				}
+				currentCellsToLeft = currentRowStarts[min(currentRowIdx, len(currentRowStarts)-1)]
+			}
+		} else if wrap {
 			var widthTaken int
 			truncated, widthTaken = segment.Take(
 				currentCellsToLeft,
@@ -367,18 +1122,35 @@ func (m *Model[T]) View() string {
 		} else {
 			// non-wrapped: render segment with horizontal panning
 			truncated, _ = segment.Take(
-				m.display.xOffset,
+				m.xOffsetOf(itemIdx),
 				cw,
 				m.config.continuationIndicator,
 				highlights,
 			)
+			// advance to the next segment for next iteration
+			if idx+1 < len(itemIndexes) && itemIndexes[idx+1] == itemIdx && currentSegIdx+1 < len(currentSegments) {
+				currentSegIdx++
+			}
 		}
 
-		if isSelection && !m.config.selectionStyleOverridesItemStyle {
-			truncated = m.styleSelection(truncated)
+		if isSeparatorRow {
+			truncated = m.display.styles.SeparatorStyle.Render(item.StripAnsi(truncated))
+		} else if isSelection && !m.config.selectionStyleOverridesItemStyle {
+			truncated = styleUnstyled(truncated, m.selectedItemStyle())
+		} else if !isSelection && m.isFlashing(itemIdx) {
+			truncated = styleUnstyled(truncated, m.display.styles.FlashStyle)
+		} else if !isSelection && m.IsMarked(itemIdx) {
+			truncated = styleUnstyled(truncated, m.display.styles.MarkedItemStyle)
+		} else if !isSelection && m.IsBookmarked(itemIdx) {
+			truncated = styleUnstyled(truncated, m.display.styles.BookmarkedItemStyle)
+		} else if !isSelection {
+			var changed bool
+			if truncated, changed = m.diffStyledLine(itemIdx, truncated); !changed {
+				truncated = m.layerItemStyle(itemIdx, truncated)
+			}
 		}
 
-		pannedRight := m.display.xOffset > 0
+		pannedRight := m.xOffsetOf(itemIdx) > 0
 		segmentHasWidth := segment.Width() > 0
 		pannedPastAllWidth := lipgloss.Width(truncated) == 0
 		if !wrap && pannedRight && segmentHasWidth && pannedPastAllWidth {
@@ -386,13 +1158,43 @@ func (m *Model[T]) View() string {
 			continuation := item.NewItem(m.config.continuationIndicator)
 			truncated, _ = continuation.Take(0, cw, "", []item.Highlight{})
 			if isSelection {
-				truncated = m.display.styles.SelectedItemStyle.Render(item.StripAnsi(truncated))
+				truncated = m.selectedItemStyle().Render(item.StripAnsi(truncated))
 			}
 		}
 
 		if isSelection && lipgloss.Width(truncated) == 0 {
 			// ensure selection is visible even if line empty
-			truncated = m.display.styles.SelectedItemStyle.Render(" ")
+			truncated = m.selectedItemStyle().Render(" ")
+		}
+
+		if isIndentedRow {
+			truncated = layout.prefix + truncated
+			m.display.wrapPrefixedRows[idx] = true
+		}
+
+		// pass graphics sequences through when the whole image is visible, otherwise show the placeholder
+		if idx == 0 || itemIndexes[idx-1] != itemIdx {
+			currentGraphics, isGraphics = m.content.itemAt(itemIdx).(item.GraphicsItem)
+			graphicsInView = isGraphics && m.graphicsInView(currentGraphics, itemIndexes, idx)
+			if isGraphics && !graphicsInView && idx == 0 && m.display.topItemLineOffset > 0 {
+				// the first row, with the placeholder, is scrolled out of view
+				truncated, _ = item.NewItem(currentGraphics.Placeholder()).Take(0, cw, m.config.continuationIndicator, nil)
+				if isSelection {
+					truncated = m.selectedItemStyle().Render(truncated)
+				}
+			} else if graphicsInView {
+				truncated = currentGraphics.Sequence()
+			}
+		} else if graphicsInView {
+			truncated = ""
+		}
+
+		// the selected item is replaced by the text input while editing, on its first visible line
+		if isSelection && m.config.editState.editing {
+			truncated = ""
+			if idx == 0 || itemIndexes[idx-1] != itemIdx {
+				truncated = m.config.editState.input.View()
+			}
 		}
 
 		// prepend selection prefix or padding
@@ -404,83 +1206,123 @@ func (m *Model[T]) View() string {
 			}
 		}
 
+		if m.config.diffMode {
+			truncated = m.diffMarker(itemIdx) + truncated
+		}
+
+		if m.config.lineNumbers.mode != LineNumbersOff {
+			// numbered on the item's first line only
+			if (idx == 0 && (itemIdx != m.display.topItemIdx || m.display.topItemLineOffset == 0)) ||
+				(idx > 0 && itemIndexes[idx-1] != itemIdx) {
+				truncated = m.lineNumber(itemIdx) + truncated
+			} else {
+				truncated = m.lineNumberPadding() + truncated
+			}
+		}
+
 		truncatedVisibleContentLines[idx] = truncated
 	}
 
-	for i := range truncatedVisibleContentLines {
-		builder.WriteString(truncatedVisibleContentLines[i])
-		builder.WriteByte('\n')
+	if line, ok := m.stickyItemLine(itemIndexes); ok {
+		if hasPrefix {
+			line = prefixPad + line
+		}
+		if m.config.diffMode {
+			line = diffMarkerPadding() + line
+		}
+		line = m.lineNumberPadding() + line
+		truncatedVisibleContentLines[0] = line
 	}
 
-	nVisibleLines := len(itemIndexes)
-	padCount := max(0, m.getNumContentLines()-nVisibleLines)
-	for range padCount {
-		builder.WriteByte('\n')
+	if m.content.isEmpty() {
+		if m.config.loading.active {
+			truncatedVisibleContentLines = m.loadingContentLines(truncatedVisibleContentLines)
+		} else if m.config.emptyState != nil {
+			truncatedVisibleContentLines = m.emptyStateLines(truncatedVisibleContentLines)
+		}
+	}
+	if !m.config.enabled {
+		truncatedVisibleContentLines = m.disabledContentLines(truncatedVisibleContentLines)
 	}
 
-	// render pre-footer line if set
-	if m.config.preFooterLine != "" {
-		preFooterItem := item.NewItem(m.config.preFooterLine)
-		truncated, _ := preFooterItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
-		builder.WriteString(truncated)
-		builder.WriteByte('\n')
+	nVisibleLines := len(truncatedVisibleContentLines)
+	padCount := max(0, m.getNumContentLines()-nVisibleLines)
+	if m.numGutterCols() > 0 {
+		// the gutter runs the full height of the content, including the blank lines below it
+		truncatedVisibleContentLines = m.withGutter(truncatedVisibleContentLines, nVisibleLines+padCount, itemIndexes)
+		padCount = 0
 	}
 
-	if m.config.saveState.enteringFilename {
-		// show filename input in footer
-		prompt := "Save as: "
-		inputView := m.config.saveState.filenameInput.View()
-		footerContent := prompt + inputView
-		footerItem := item.NewItem(footerContent)
-		truncated, _ := footerItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
-		builder.WriteString(m.display.styles.FooterStyle.Render(truncated))
-	} else if m.config.saveState.saving || m.config.saveState.showingResult {
-		// show save status footer
-		var statusMsg string
-		if m.config.saveState.saving {
-			statusMsg = "Saving..."
-		} else if m.config.saveState.showingResult {
-			statusMsg = m.config.saveState.resultMsg
-		}
-		statusItem := item.NewItem(statusMsg)
-		truncated, _ := statusItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
-		styledMsg := m.display.styles.FooterStyle.Render(truncated)
-		builder.WriteString(styledMsg)
-	} else if m.config.footerEnabled {
-		// pad so footer shows up at bottom
-		builder.WriteString(m.getTruncatedFooterLine(itemIndexes))
+	for range padCount {
+		truncatedVisibleContentLines = append(truncatedVisibleContentLines, "")
+	}
+	if m.config.preview.active {
+		truncatedVisibleContentLines = m.previewLines(truncatedVisibleContentLines)
 	}
+	return truncatedVisibleContentLines
+}
 
-	return m.display.render(strings.TrimSuffix(builder.String(), "\n"))
+// graphicsInView returns true if every row of the graphics item starting at line idx of the visible content is in
+// view and unclipped, so its sequence can be drawn
+func (m *Model[T]) graphicsInView(g item.GraphicsItem, itemIndexes []int, idx int) bool {
+	if g.Cols() > m.contentWidth() || (!m.config.wrapText && m.xOffsetOf(itemIndexes[idx]) > 0) {
+		return false
+	}
+	if idx == 0 && m.display.topItemLineOffset > 0 {
+		return false
+	}
+	numLines := 0
+	for i := idx; i < len(itemIndexes) && itemIndexes[i] == itemIndexes[idx]; i++ {
+		numLines++
+	}
+	return numLines == g.Rows()
 }
 
 // SetObjects sets the objects
 func (m *Model[T]) SetObjects(objects []T) {
+	if b := m.content.batch; b != nil {
+		b.objects, b.objectsSet, b.appended, b.prepended = objects, true, nil, nil
+		return
+	}
+	m.setObjects(objects)
+}
+
+func (m *Model[T]) setObjects(objects []T) {
+	m.cancelWrapPrecompute()
 	var initialNumLinesAboveSelection int
-	var stayAtTop, stayAtBottom bool
 	var prevSelection T
+	stayAtTop, stayAtBottom := m.stickyPosition()
 	if m.navigation.selectionEnabled {
 		if inView := m.selectionInViewInfo(); inView.numLinesSelectionInView > 0 {
 			initialNumLinesAboveSelection = inView.numLinesAboveSelection
 		}
-		currentItems := m.content.objects
+		numCurrentItems := m.content.numItems()
 		selectedIdx := m.content.getSelectedIdx()
-		if m.navigation.topSticky && len(currentItems) > 0 && selectedIdx == 0 {
-			stayAtTop = true
-		} else if m.navigation.bottomSticky && (len(currentItems) == 0 || (selectedIdx == len(currentItems)-1)) {
-			stayAtBottom = true
-		} else if m.content.compareFn != nil && 0 <= selectedIdx && selectedIdx < len(currentItems) {
-			prevSelection = currentItems[selectedIdx]
-		}
-	} else {
-		if m.navigation.topSticky && m.isScrolledToTop() {
-			stayAtTop = true
-		} else if m.navigation.bottomSticky && m.isScrolledToBottom() {
-			stayAtBottom = true
+		if !stayAtTop && !stayAtBottom && m.content.compareFn != nil && 0 <= selectedIdx &&
+			selectedIdx < numCurrentItems {
+			prevSelection = m.content.objectAt(selectedIdx)
 		}
 	}
 
+	prevMarked := m.markedObjects()
+	prevBookmarked := m.bookmarkedObjects()
+	prevExpanded := m.expandedDetailObjects()
+	objects = m.retain(objects)
+	objects = m.sortObjects(m.filterChanges(m.preprocess(objects)))
+	if m.content.joining != nil {
+		objects = m.content.joining.join(objects, m.content.compareFn)
+	} else if m.content.tree != nil {
+		objects = m.content.tree.build(objects, m.content.compareFn)
+	}
 	m.content.objects = objects
+	m.content.source, m.content.sourceLen = nil, 0
+	m.content.resetStickyMemo()
+	m.config.undo.dropMoves()
+	m.applySoftLimits()
+	m.refreshSearch()
+	m.remarkObjects(prevMarked)
+	m.rebookmarkObjects(prevBookmarked)
+	m.reexpandDetails(prevExpanded)
 	// ensure scroll position is valid given new Item
 	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
 
@@ -511,7 +1353,7 @@ func (m *Model[T]) SetObjects(objects []T) {
 
 		// when staying at bottom, just want to scroll so selection in view, which is done above
 		if !stayAtBottom {
-			m.content.selectedIdx = clampValZeroToMax(m.content.selectedIdx, len(m.content.objects)-1)
+			m.content.selectedIdx = clampValZeroToMax(m.content.selectedIdx, m.content.numItems()-1)
 			m.scrollSoSelectionInView()
 			if inView := m.selectionInViewInfo(); inView.numLinesSelectionInView > 0 {
 				deltaLinesAbove := initialNumLinesAboveSelection - inView.numLinesAboveSelection
@@ -528,20 +1370,452 @@ func (m *Model[T]) SetObjects(objects []T) {
 	}
 }
 
-// SetTopSticky sets whether selection should stay at top when new Item added and selection is at the top
-func (m *Model[T]) SetTopSticky(topSticky bool) {
-	m.navigation.topSticky = topSticky
+// stickyPosition returns whether setting objects keeps the view at the top or at the bottom, as sticky top and bottom
+// do when the first or last item is selected, or without selection, when scrolled to the top or bottom
+func (m *Model[T]) stickyPosition() (stayAtTop, stayAtBottom bool) {
+	if m.navigation.selectionEnabled {
+		numCurrentItems := m.content.numItems()
+		selectedIdx := m.content.getSelectedIdx()
+		if m.navigation.topSticky && numCurrentItems > 0 && selectedIdx == 0 {
+			return true, false
+		}
+		return false, m.stickyBottom() && (numCurrentItems == 0 || selectedIdx == numCurrentItems-1)
+	}
+	if m.navigation.topSticky && m.isScrolledToTop() {
+		return true, false
+	}
+	return false, m.stickyBottom() && m.isScrolledToBottom()
+}
+
+// SetItemSource sets a source that provides objects by index in place of SetObjects, for content too large to hold
+// in a slice such as a multi-million-line file. Only the objects in or near view are read from it while rendering
+// and navigating. The source's length is read when it's set and on RefreshItemSource, so call that after it grows or
+// shrinks. Searching and saving read every object. The preprocessor, line joining and tree mode are disabled, and soft
+// limits aren't measured. SetObjects replaces the source, as do AppendObjects and PrependObjects after reading every
+// object from it. Pass nil to clear the content.
+func (m *Model[T]) SetItemSource(source ItemSource[T]) {
+	if source == nil {
+		m.setObjects(nil)
+		return
+	}
+	m.content.preprocessing = nil
+	m.content.joining = nil
+	m.content.tree = nil
+	m.content.objects = nil
+	m.content.source = source
+	m.content.sourceLen = 0
+	m.content.resetStickyMemo()
+	m.content.marked = make(map[int]struct{})
+	m.content.bookmarked = make(map[int]struct{})
+	m.content.expandedDetails = make(map[int]struct{})
+	m.content.separators = nil
+	m.config.softLimitUsage = softLimitUsage{}
+	m.config.softLimitReason = ""
+	if m.config.wrapSuspended {
+		m.config.wrapSuspended = false
+		m.SetWrapText(true)
+	}
+	m.content.search.matches = m.content.search.matches[:0]
+	m.content.search.firstMatchIdxByItem = make(map[int]int)
+	m.content.search.focusedIdx = -1
+	m.display.setTopItemIdxAndOffset(0, 0)
+	m.content.setSelectedIdx(0)
+	m.refreshSource()
+}
+
+// RefreshItemSource reads the length of the item source set with SetItemSource again, e.g. after lines are appended
+// to a file it reads from. The selection and content in view are kept, following the new bottom when sticky bottom or
+// follow mode applies, and search matches are found in new objects.
+func (m *Model[T]) RefreshItemSource() {
+	if m.content.source == nil {
+		return
+	}
+	m.refreshSource()
+}
+
+// AppendObjects adds objects after the existing ones. Unlike SetObjects, existing objects aren't revisited:
+// the selection and scroll position are kept, following the new bottom when sticky bottom applies, and
+// highlights are unchanged.
+func (m *Model[T]) AppendObjects(objects []T) {
+	if b := m.content.batch; b != nil {
+		b.appended = append(b.appended, objects...)
+		return
+	}
+	m.appendObjects(objects)
+}
+
+func (m *Model[T]) appendObjects(objects []T) {
+	if len(objects) == 0 {
+		return
+	}
+	if m.content.isEmpty() {
+		m.setObjects(objects)
+		return
+	}
+	if m.content.preprocessing != nil || m.content.source != nil || m.content.tree != nil || m.showingChangesOnly() ||
+		m.isSorted() || (m.content.joining != nil && m.config.retention.active()) {
+		m.setObjects(append(append([]T{}, m.content.unprocessedObjects()...), objects...))
+		return
+	}
+	stayAtBottom := m.stickyBottom() && m.isAtBottom()
+
+	prevNumItems := m.content.numItems()
+	if m.content.joining != nil {
+		m.content.objects = append(m.content.objects, m.content.joining.appendObjects(objects)...)
+		// leading continuations may have changed the last existing group
+		m.content.resetStickyMemo()
+	} else {
+		m.content.objects = append(m.content.objects, objects...)
+	}
+	m.addToSoftLimits(objects)
+	if m.content.joining != nil {
+		// leading continuations may have changed the last existing group
+		m.refreshSearch()
+	} else {
+		m.appendSearchMatches(prevNumItems)
+		m.retainAppended(objects)
+	}
+
+	if m.navigation.selectionEnabled {
+		if stayAtBottom {
+			m.content.setSelectedIdx(m.content.numItems() - 1)
+			m.scrollSoSelectionInView()
+		}
+	} else if stayAtBottom {
+		maxItemIdx, maxTopLineOffset := m.maxItemIdxAndMaxTopLineOffset()
+		m.display.setTopItemIdxAndOffset(maxItemIdx, maxTopLineOffset)
+	}
+}
+
+// PrependObjects adds objects before the existing ones. The selection and the content in view are kept, staying at
+// the new top when sticky top applies, and highlight item indexes are shifted to match. With line joining enabled,
+// all objects are rejoined as in SetObjects.
+func (m *Model[T]) PrependObjects(objects []T) {
+	if b := m.content.batch; b != nil {
+		b.prepended = append(append([]T{}, objects...), b.prepended...)
+		return
+	}
+	m.prependObjects(objects)
+}
+
+func (m *Model[T]) prependObjects(objects []T) {
+	if len(objects) == 0 {
+		return
+	}
+	if m.content.isEmpty() || m.content.joining != nil || m.content.tree != nil || m.content.preprocessing != nil ||
+		m.content.source != nil || m.showingChangesOnly() || m.isSorted() || m.config.retention.active() {
+		m.setObjects(append(append([]T{}, objects...), m.content.unprocessedObjects()...))
+		return
+	}
+
+	var stayAtTop bool
+	if m.navigation.selectionEnabled {
+		stayAtTop = m.navigation.topSticky && m.content.getSelectedIdx() == 0
+	} else {
+		stayAtTop = m.navigation.topSticky && m.isScrolledToTop()
+	}
+
+	n := len(objects)
+	m.content.objects = append(append(make([]T, 0, n+m.content.numItems()), objects...), m.content.objects...)
+	m.content.resetStickyMemo()
+	m.addToSoftLimits(objects)
+	if highlights := m.content.getHighlights(); len(highlights) > 0 {
+		shifted := make([]Highlight, len(highlights))
+		for i, h := range highlights {
+			h.ItemIndex += n
+			shifted[i] = h
+		}
+		m.content.setHighlights(shifted)
+	}
+	m.prependSearchMatches(n)
+	m.shiftMarks(n)
+	m.shiftBookmarks(n)
+	m.config.undo.shiftMoves(n)
+	m.shiftExpandedDetails(n)
+	m.shiftSeparators(n)
+	m.shiftLinks(n)
+
+	if stayAtTop {
+		m.display.setTopItemIdxAndOffset(0, 0)
+		if m.navigation.selectionEnabled {
+			m.content.setSelectedIdx(0)
+		}
+		return
+	}
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx+n, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.content.setSelectedIdx(m.content.getSelectedIdx() + n)
+	}
+}
+
+// SetTopSticky sets whether selection should stay at top when new Item added and selection is at the top
+func (m *Model[T]) SetTopSticky(topSticky bool) {
+	m.navigation.topSticky = topSticky
+}
+
+// SetBottomSticky sets whether selection should stay at bottom when new Item added and selection is at the bottom
+func (m *Model[T]) SetBottomSticky(bottomSticky bool) {
+	m.navigation.bottomSticky = bottomSticky
+}
+
+// SetFollowMode sets whether to follow appended content, like a live tail. Enabling it scrolls to the bottom. While
+// at the bottom, the viewport follows new content as with SetBottomSticky and shows a FOLLOWING indicator in the
+// footer. Scrolling away from the bottom disengages following and scrolling back re-engages it, each sending a
+// FollowStateChangedMsg.
+func (m *Model[T]) SetFollowMode(enabled bool) {
+	m.navigation.follow = followState{enabled: enabled}
+	if enabled {
+		m.GoToBottom()
+		m.navigation.follow.following = m.IsFollowing()
+	}
+}
+
+// GetFollowMode returns whether follow mode is enabled
+func (m *Model[T]) GetFollowMode() bool {
+	return m.navigation.follow.enabled
+}
+
+// IsFollowing returns whether follow mode is enabled and engaged, i.e. the viewport is at the bottom
+func (m *Model[T]) IsFollowing() bool {
+	return m.navigation.follow.enabled && m.isAtBottom()
+}
+
+// SetSortFunc orders items ascending by less, which reports whether a sorts before b, e.g. for process viewers or
+// resource lists. Objects set later are sorted too, and items that compare equal keep the order they were set in.
+// The ToggleSort key cycles between ascending, descending and the order objects were set in. Set a selection
+// comparator with SetSelectionComparator to keep the selected object selected as items are reordered. Pass nil to
+// show items in the order they were set.
+func (m *Model[T]) SetSortFunc(less func(a, b T) bool) {
+	objects := m.content.unprocessedObjects()
+	if less == nil {
+		m.content.sorting = nil
+	} else {
+		m.content.sorting = &sorting[T]{less: less, direction: SortAscending}
+	}
+	m.setObjects(objects)
+}
+
+// SetSortDirection sets the order items are shown in. Does nothing without a sort function.
+func (m *Model[T]) SetSortDirection(direction SortDirection) {
+	s := m.content.sorting
+	if s == nil || s.direction == direction {
+		return
+	}
+	objects := m.content.unprocessedObjects()
+	s.direction = direction
+	s.unsorted = nil
+	m.setObjects(objects)
+}
+
+// GetSortDirection returns the order items are shown in, SortNone without a sort function
+func (m *Model[T]) GetSortDirection() SortDirection {
+	if m.content.sorting == nil {
+		return SortNone
+	}
+	return m.content.sorting.direction
+}
+
+// SetTabWidth sets the number of cells between the tab stops that tabs in items expand to as spaces, e.g. 4 or 8, so
+// that they render the same in every terminal and count toward the width of items when wrapping and panning.
+// Highlights, including those of HighlightAll, remain byte ranges of content with its tabs as they are. 0, the
+// default, passes tabs through to the terminal as they are.
+func (m *Model[T]) SetTabWidth(width int) {
+	m.content.tabWidth = max(0, width)
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+}
+
+// GetTabWidth returns the number of cells between the tab stops that tabs expand to, 0 if they aren't expanded
+func (m *Model[T]) GetTabWidth() int {
+	return m.content.tabWidth
+}
+
+// SetShowControlChars sets whether control characters in items, e.g. carriage returns, NUL bytes or stray escapes,
+// are shown in caret notation (^M, ^@, ^[) styled with ControlCharStyle, as control characters passed through to the
+// terminal can break the layout. Tabs are passed through unless expanded, see SetTabWidth. Disabled by default.
+func (m *Model[T]) SetShowControlChars(show bool) {
+	m.config.showControlChars = show
+	m.syncControlCharStyle()
+}
+
+// GetShowControlChars returns whether control characters are shown in caret notation
+func (m *Model[T]) GetShowControlChars() bool {
+	return m.config.showControlChars
+}
+
+// SetShowInvisibles sets whether invisible characters in items are shown as visible glyphs, like less -U or an editor
+// rendering whitespace: trailing spaces and tabs as · and →, and characters that take no space, like zero-width spaces,
+// joiners and byte order marks, as their code point, e.g. <U+200B>, all styled with InvisibleStyle. Control characters
+// are shown in caret notation meanwhile, even if SetShowControlChars disabled it. Disabled by default.
+func (m *Model[T]) SetShowInvisibles(show bool) {
+	m.config.showInvisibles = show
+	m.syncControlCharStyle()
+}
+
+// GetShowInvisibles returns whether invisible characters are shown as visible glyphs
+func (m *Model[T]) GetShowInvisibles() bool {
+	return m.config.showInvisibles
+}
+
+// syncControlCharStyle sets the styles items' control and invisible characters are rendered with, if shown
+func (m *Model[T]) syncControlCharStyle() {
+	m.content.controlCharStyle, m.content.invisibleStyle = nil, nil
+	m.content.visualized.clear()
+	if m.config.showControlChars || m.config.showInvisibles {
+		style := m.display.styles.ControlCharStyle
+		m.content.controlCharStyle = &style
+	}
+	if m.config.showInvisibles {
+		style := m.display.styles.InvisibleStyle
+		m.content.invisibleStyle = &style
+	}
+}
+
+// SetColumns turns on column mode for tabular data: objects implementing Row are rendered with their cells in
+// columns as wide as the widest cell among the rows in view, truncated and aligned per the layout's Columns. The
+// layout's leading PinnedColumns stay in place when panning horizontally. Column mode doesn't apply with line joining.
+func (m *Model[T]) SetColumns(layout ColumnLayout) {
+	m.content.columns = &columnState{layout: layout, truncation: m.config.continuationIndicator}
+	m.refreshColumnWidths()
+}
+
+// ClearColumns turns off column mode, rendering objects from their items
+func (m *Model[T]) ClearColumns() {
+	m.content.columns = nil
+}
+
+// GetColumnWidths returns the width of each column in column mode as last fit to the rows in view, e.g. to render a
+// matching header. Returns nil when column mode is off.
+func (m *Model[T]) GetColumnWidths() []int {
+	if m.content.columns == nil {
+		return nil
+	}
+	return m.content.columns.widths
+}
+
+// SetPanStep sets how many columns the Left and Right keys pan when wrapping is disabled. A step of 0, the default,
+// pans a quarter of the viewport width.
+func (m *Model[T]) SetPanStep(step int) {
+	m.navigation.pan.step = max(0, step)
+}
+
+// GetPanStep returns how many columns the Left and Right keys pan, 0 for a quarter of the viewport width
+func (m *Model[T]) GetPanStep() int {
+	return m.navigation.pan.step
+}
+
+// SetPanAcceleration speeds up panning while the Left or Right key is held: each pan in the same direction soon after
+// the last pans one more step than it, up to maxMultiplier steps. A maxMultiplier of 1 or less, the default, disables
+// acceleration.
+func (m *Model[T]) SetPanAcceleration(maxMultiplier int) {
+	m.navigation.pan.maxMultiplier = maxMultiplier
+	m.navigation.pan.multiplier = 1
+}
+
+// GetPanAcceleration returns the most the pan step is multiplied by while panning is held
+func (m *Model[T]) GetPanAcceleration() int {
+	return m.navigation.pan.maxMultiplier
+}
+
+// SetPanSelectedOnly sets whether panning moves only the selected item, e.g. to read one long line while the others
+// stay aligned at their start, rather than every item, the default. Panning is limited by the width of the selected
+// item, and carries over to the next one selected. It applies only when selection is enabled, and can be toggled at
+// any time.
+func (m *Model[T]) SetPanSelectedOnly(selectedOnly bool) {
+	m.navigation.pan.selectedOnly = selectedOnly
+	m.SetXOffset(m.display.xOffset)
+}
+
+// GetPanSelectedOnly returns whether panning moves only the selected item
+func (m *Model[T]) GetPanSelectedOnly() bool {
+	return m.navigation.pan.selectedOnly
+}
+
+// SetMoveMode sets whether the MoveItemUp and MoveItemDown keys reorder the selected item, e.g. for priority lists
+// or playlists. In move mode, the selected item is styled with MovingItemStyle and each move sends an ItemMovedMsg.
+// The viewport reorders its own objects, so callers keeping their own copy should apply the same move. Items can only
+// be moved when selection is enabled, and never with a preprocessor or line joining.
+func (m *Model[T]) SetMoveMode(enabled bool) {
+	m.navigation.moveMode = enabled
+}
+
+// GetMoveMode returns whether move mode is enabled
+func (m *Model[T]) GetMoveMode() bool {
+	return m.navigation.moveMode
+}
+
+// MoveItem moves the item at from to index to, shifting the items between, and keeps the selection, marks, links
+// and highlights with their items. Unlike the move keys, it works outside move mode and without selection. Returns
+// false if the item can't be moved.
+func (m *Model[T]) MoveItem(from, to int) bool {
+	n := m.content.numItems()
+	if !m.canMoveItems() || from < 0 || from >= n || to < 0 || to >= n {
+		return false
+	}
+	if from != to {
+		m.moveItem(from, to)
+		m.recordMove(from, to)
+	}
+	return true
+}
+
+// SetUndoLimit sets the maximum number of content changes kept for undo, dropping the oldest beyond it. The Undo and
+// Redo keys revert and reapply changes, showing what was undone or redone in the footer. Moves in move mode and with
+// MoveItem are recorded automatically, and hosts record their own changes, e.g. edits or deletions, with
+// RecordChange. 0 disables undo and clears the history.
+func (m *Model[T]) SetUndoLimit(limit int) {
+	u := &m.config.undo
+	u.limit = max(0, limit)
+	if len(u.undo) > u.limit {
+		u.undo = u.undo[len(u.undo)-u.limit:]
+	}
+	if u.limit == 0 {
+		u.redo = nil
+	}
+}
+
+// RecordChange records a change the host made to the content so that it can be undone, clearing the changes that
+// could be redone. Does nothing when undo is disabled.
+func (m *Model[T]) RecordChange(change Change) {
+	m.config.undo.record(change)
 }
 
-// SetBottomSticky sets whether selection should stay at bottom when new Item added and selection is at the bottom
-func (m *Model[T]) SetBottomSticky(bottomSticky bool) {
-	m.navigation.bottomSticky = bottomSticky
+// Undo reverts the most recent change, returning a command from the change's Revert along with one showing what was
+// undone in the footer
+func (m *Model[T]) Undo() tea.Cmd {
+	return m.undoCmd()
+}
+
+// Redo reapplies the most recently undone change, returning a command from the change's Apply along with one
+// showing what was redone in the footer
+func (m *Model[T]) Redo() tea.Cmd {
+	return m.redoCmd()
+}
+
+// CanUndo returns whether there's a change to undo
+func (m *Model[T]) CanUndo() bool {
+	return len(m.config.undo.undo) > 0
+}
+
+// CanRedo returns whether there's an undone change to redo
+func (m *Model[T]) CanRedo() bool {
+	return len(m.config.undo.redo) > 0
+}
+
+// ClearUndoHistory forgets all changes that could be undone or redone
+func (m *Model[T]) ClearUndoHistory() {
+	m.config.undo.undo = nil
+	m.config.undo.redo = nil
 }
 
 // SetSelectionEnabled sets whether the viewport allows line selection
 func (m *Model[T]) SetSelectionEnabled(selectionEnabled bool) {
 	wasEnabled := m.navigation.selectionEnabled
 	m.navigation.selectionEnabled = selectionEnabled
+	if !selectionEnabled {
+		m.CancelEditing()
+		m.CancelBlockSelection()
+		m.CancelVisualSelection()
+	}
 
 	// when enabling selection, set the selected item to the top visible item and ensure the top line is in view
 	if selectionEnabled && !wasEnabled && !m.content.isEmpty() {
@@ -551,6 +1825,17 @@ func (m *Model[T]) SetSelectionEnabled(selectionEnabled bool) {
 	}
 }
 
+// SetKeyGroupEnabled enables or disables a group of the viewport's key bindings, e.g. to keep navigation while turning
+// off selection and feature keys
+func (m *Model[T]) SetKeyGroupEnabled(group KeyGroup, enabled bool) {
+	m.navigation.keyMap.SetGroupEnabled(group, enabled)
+}
+
+// GetKeyGroupEnabled returns true if any binding in the group is enabled
+func (m *Model[T]) GetKeyGroupEnabled(group KeyGroup) bool {
+	return m.navigation.keyMap.GroupEnabled(group)
+}
+
 // SetFooterEnabled sets whether the viewport shows the footer when it overflows
 func (m *Model[T]) SetFooterEnabled(footerEnabled bool) {
 	m.config.footerEnabled = footerEnabled
@@ -561,6 +1846,79 @@ func (m *Model[T]) SetProgressBarEnabled(enabled bool) {
 	m.config.progressBarEnabled = enabled
 }
 
+// SetFooterFormatter sets the function that renders the footer text. nil restores DefaultFooterFormatter.
+func (m *Model[T]) SetFooterFormatter(formatter FooterFormatter) {
+	if formatter == nil {
+		formatter = DefaultFooterFormatter
+	}
+	m.config.footerFormatter = formatter
+}
+
+// SetFooterMetric sets what the footer's scroll position measures, items by default
+func (m *Model[T]) SetFooterMetric(metric FooterMetric) {
+	m.config.footerMetric = metric
+}
+
+// GetFooterMetric returns what the footer's scroll position measures
+func (m *Model[T]) GetFooterMetric() FooterMetric {
+	return m.config.footerMetric
+}
+
+// SetScrollbar sets whether a scrollbar is shown in a one-column gutter on the right edge of the content. Its thumb
+// shows the position and proportion of the content in view, styled with ScrollbarThumbStyle over a track styled with
+// ScrollbarStyle. Like the footer, it measures items unless the footer metric is FooterMetricLines. The gutter
+// narrows the content, so wrapped items rewrap.
+func (m *Model[T]) SetScrollbar(enabled bool) {
+	if m.config.scrollbar == enabled {
+		return
+	}
+	m.config.scrollbar = enabled
+	m.afterGutterChanged()
+}
+
+// GetScrollbar returns whether a scrollbar is shown
+func (m *Model[T]) GetScrollbar() bool {
+	return m.config.scrollbar
+}
+
+// SetMinimap sets whether a minimap is shown in a one-column gutter on the right edge of the content, left of the
+// scrollbar if shown. Like an editor's scroll annotations, it marks where highlights and search matches sit across
+// all the content with MinimapMarkStyle over a track styled with MinimapStyle, each of its rows standing for an equal
+// share of the items. With mouse support, clicking a row jumps to its first marked item. The gutter narrows the
+// content, so wrapped items rewrap.
+func (m *Model[T]) SetMinimap(enabled bool) {
+	if m.config.minimap == enabled {
+		return
+	}
+	m.config.minimap = enabled
+	m.afterGutterChanged()
+}
+
+// GetMinimap returns whether a minimap is shown
+func (m *Model[T]) GetMinimap() bool {
+	return m.config.minimap
+}
+
+// afterGutterChanged relays out the content after the gutter is widened or narrowed
+func (m *Model[T]) afterGutterChanged() {
+	m.reprocess()
+	m.setEditInputWidth()
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// SetFooterFilterInfo sets the filter state passed to the footer formatter. Pass nil when no filter is applied.
+func (m *Model[T]) SetFooterFilterInfo(info *FooterFilterInfo) {
+	m.config.footerFilterInfo = info
+}
+
+// GetFooterFilterInfo returns the filter state passed to the footer formatter, nil if none is set
+func (m *Model[T]) GetFooterFilterInfo() *FooterFilterInfo {
+	return m.config.footerFilterInfo
+}
+
 // SetPostHeaderLine sets a line to render just below the header.
 // Pass empty string to disable. The line will be truncated to viewport width.
 func (m *Model[T]) SetPostHeaderLine(line string) {
@@ -578,6 +1936,59 @@ func (m *Model[T]) GetPreFooterLine() string {
 	return m.config.preFooterLine
 }
 
+// SetWordChars sets which runes are considered part of a word for word-wise operations. See WithWordChars.
+func (m *Model[T]) SetWordChars(wordChars item.WordChars) {
+	if m.config.wordChars == wordChars {
+		return
+	}
+	m.config.wordChars = wordChars
+	if m.config.wrapText {
+		// re-wrap, keeping the selection in place
+		m.SetWrapText(true)
+	}
+}
+
+// GetWordChars returns the word definition used for word-wise operations
+func (m *Model[T]) GetWordChars() item.WordChars {
+	return m.config.wordChars
+}
+
+// SetMouseSupport sets whether the viewport handles mouse messages. See WithMouseSupport.
+func (m *Model[T]) SetMouseSupport(enabled bool) {
+	m.config.mouse.enabled = enabled
+	m.config.mouse.dragging = false
+}
+
+// GetMouseSupport returns whether the viewport handles mouse messages
+func (m *Model[T]) GetMouseSupport() bool {
+	return m.config.mouse.enabled
+}
+
+// SetMouseOrigin sets the screen column and row of the viewport's top left cell, used to translate mouse
+// coordinates when the viewport isn't rendered at the top left of the screen
+func (m *Model[T]) SetMouseOrigin(x, y int) {
+	m.config.mouse.originX = x
+	m.config.mouse.originY = y
+}
+
+// SetSoftLimits sets content size thresholds beyond which expensive features are disabled and a notice is shown
+// in the footer. See SoftLimits.
+func (m *Model[T]) SetSoftLimits(limits SoftLimits) {
+	m.config.softLimits = limits
+	m.applySoftLimits()
+}
+
+// GetSoftLimits returns the content size thresholds beyond which expensive features are disabled
+func (m *Model[T]) GetSoftLimits() SoftLimits {
+	return m.config.softLimits
+}
+
+// SoftLimitsExceeded returns true if the current content exceeds the soft limits, in which case expensive features
+// are disabled
+func (m *Model[T]) SoftLimitsExceeded() bool {
+	return m.config.softLimitReason != ""
+}
+
 // SetSelectionComparator sets the comparator function for maintaining the current selection when Item changes.
 // If compareFn is non-nil, the viewport will try to maintain the current selection when Item changes.
 func (m *Model[T]) SetSelectionComparator(compareFn CompareFn[T]) {
@@ -589,15 +2000,64 @@ func (m *Model[T]) GetSelectionEnabled() bool {
 	return m.navigation.selectionEnabled
 }
 
+// SetEnabled sets whether the viewport is enabled. A disabled viewport renders its content dimmed with
+// DisabledStyle, shows the note set with SetDisabledNote over it, and ignores key and mouse input, e.g. for a pane
+// whose source is disconnected. Disabling cancels inline editing, block selection and visual selection.
+func (m *Model[T]) SetEnabled(enabled bool) {
+	m.config.enabled = enabled
+	if !enabled {
+		m.CancelEditing()
+		m.CancelBlockSelection()
+		m.CancelVisualSelection()
+	}
+}
+
+// GetEnabled returns whether the viewport is enabled
+func (m *Model[T]) GetEnabled() bool {
+	return m.config.enabled
+}
+
+// SetDisabledNote sets the note centered over the content while the viewport is disabled. Empty for no note.
+func (m *Model[T]) SetDisabledNote(note string) {
+	m.config.disabledNote = note
+}
+
+// GetDisabledNote returns the note shown while the viewport is disabled
+func (m *Model[T]) GetDisabledNote() string {
+	return m.config.disabledNote
+}
+
+// Focus gives the viewport focus, so it handles key input. Viewports are focused by default.
+func (m *Model[T]) Focus() {
+	m.config.focused = true
+}
+
+// Blur takes focus from the viewport, e.g. when another pane of a multi-pane app gets it. While blurred, the viewport
+// ignores key input, still handling mouse input and other messages, and styles its selection with BlurredStyle.
+func (m *Model[T]) Blur() {
+	m.config.focused = false
+}
+
+// Focused returns whether the viewport has focus
+func (m *Model[T]) Focused() bool {
+	return m.config.focused
+}
+
 // IsCapturingInput returns true when the viewport is in a mode that should capture all input
 // (e.g., filename entry for saving). Callers should forward all messages to the viewport
 // without processing them when this returns true.
 func (m *Model[T]) IsCapturingInput() bool {
-	return m.config.saveState.enteringFilename
+	return m.config.saveState.enteringFilename || m.config.editState.editing || m.config.blockSelection.active ||
+		m.config.visualSelection.active || m.config.goTo.active || m.config.preview.active
 }
 
 // SetWrapText sets whether the viewport wraps text
 func (m *Model[T]) SetWrapText(wrapText bool) {
+	if m.config.softLimitReason != "" {
+		// remember the request, restoring wrapping once content is within soft limits again
+		m.config.wrapSuspended = wrapText
+		wrapText = false
+	}
 	var initialNumLinesAboveSelection int
 	if m.navigation.selectionEnabled {
 		if inView := m.selectionInViewInfo(); inView.numLinesSelectionInView > 0 {
@@ -605,8 +2065,10 @@ func (m *Model[T]) SetWrapText(wrapText bool) {
 		}
 	}
 	m.config.wrapText = wrapText
+	m.config.peek.active = false
 	m.display.topItemLineOffset = 0
 	m.display.xOffset = 0
+	m.reprocess()
 	if m.navigation.selectionEnabled {
 		m.scrollSoSelectionInView()
 		if inView := m.selectionInViewInfo(); inView.numLinesSelectionInView > 0 {
@@ -623,11 +2085,85 @@ func (m *Model[T]) GetWrapText() bool {
 	return m.config.wrapText
 }
 
+// SetWrapMode sets where wrapped lines break when wrapping is enabled
+func (m *Model[T]) SetWrapMode(mode item.WrapMode) {
+	if m.config.wrapMode == mode {
+		return
+	}
+	m.config.wrapMode = mode
+	if m.config.wrapText {
+		// re-wrap, keeping the selection in place
+		m.SetWrapText(true)
+	}
+}
+
+// GetWrapMode returns where wrapped lines break when wrapping is enabled
+func (m *Model[T]) GetWrapMode() item.WrapMode {
+	return m.config.wrapMode
+}
+
+// SetWrapIndent sets how many cells wrapped rows after an item's first are indented when wrapping is enabled, e.g.
+// for a hanging indent under a log line's timestamp. It's capped to leave at least one cell for content.
+func (m *Model[T]) SetWrapIndent(indent int) {
+	indent = max(0, indent)
+	if m.config.wrapIndent == indent {
+		return
+	}
+	m.config.wrapIndent = indent
+	if m.config.wrapText {
+		// re-wrap, keeping the selection in place
+		m.SetWrapText(true)
+	}
+}
+
+// GetWrapIndent returns how many cells wrapped rows after an item's first are indented
+func (m *Model[T]) GetWrapIndent() int {
+	return m.config.wrapIndent
+}
+
+// SetWrapIndicator sets a marker, e.g. DefaultWrapIndicator, shown in the given style at the start of wrapped rows
+// after an item's first when wrapping is enabled, so they're distinguishable from new items. It follows any wrap
+// indent, and its width is taken from the row's content. It's left out of copied visible lines and saved content. An
+// empty indicator removes it.
+func (m *Model[T]) SetWrapIndicator(indicator string, style lipgloss.Style) {
+	m.config.wrapIndicatorStyle = style
+	if m.config.wrapIndicator == indicator {
+		return
+	}
+	m.config.wrapIndicator = indicator
+	if m.config.wrapText {
+		// re-wrap, keeping the selection in place
+		m.SetWrapText(true)
+	}
+}
+
+// GetWrapIndicator returns the marker for wrapped rows after an item's first
+func (m *Model[T]) GetWrapIndicator() string {
+	return m.config.wrapIndicator
+}
+
 // SetWidth sets the viewport's width
 func (m *Model[T]) SetWidth(width int) {
 	m.setWidthHeight(width, m.display.bounds.height)
 }
 
+// Resize sets the viewport's width and height, including header and footer. With a resize throttle set, relayout
+// happens at most once per interval while sizes arrive rapidly, e.g. from WindowSizeMsgs while dragging the terminal
+// edge; the returned command delivers a ResizeSettledMsg that applies the final size once they stop.
+func (m *Model[T]) Resize(width, height int) tea.Cmd {
+	return m.resize(width, height)
+}
+
+// SetResizeThrottle sets the least time between relayouts from Resize. 0, the default, relays out on every call.
+func (m *Model[T]) SetResizeThrottle(interval time.Duration) {
+	m.display.resize.interval = max(0, interval)
+}
+
+// GetResizeThrottle returns the least time between relayouts from Resize
+func (m *Model[T]) GetResizeThrottle() time.Duration {
+	return m.display.resize.interval
+}
+
 // GetWidth returns the viewport width
 func (m *Model[T]) GetWidth() int {
 	return m.display.bounds.width
@@ -646,7 +2182,7 @@ func (m *Model[T]) GetHeight() int {
 // GoToTop sets the viewport to the top position.
 func (m *Model[T]) GoToTop() {
 	if m.navigation.selectionEnabled {
-		m.SetSelectedItemIdx(0)
+		m.setSelectedItemIdx(0)
 	} else {
 		m.display.topItemIdx = 0
 		m.display.topItemLineOffset = 0
@@ -656,7 +2192,7 @@ func (m *Model[T]) GoToTop() {
 // GoToBottom sets the viewport to the bottom position.
 func (m *Model[T]) GoToBottom() {
 	if m.navigation.selectionEnabled {
-		m.SetSelectedItemIdx(m.content.getSelectedIdx() + m.content.numItems())
+		m.setSelectedItemIdx(m.content.getSelectedIdx() + m.content.numItems())
 	} else {
 		maxItemIdx, maxTopLineOffset := m.maxItemIdxAndMaxTopLineOffset()
 		m.display.setTopItemIdxAndOffset(maxItemIdx, maxTopLineOffset)
@@ -706,6 +2242,196 @@ func (m *Model[T]) HalfPageDown() {
 // SetStyles sets the styling for the viewport
 func (m *Model[T]) SetStyles(styles Styles) {
 	m.display.styles = styles
+	m.syncControlCharStyle()
+	if m.content.joining != nil {
+		m.content.joining.setIndicatorStyle(styles.CollapsedGroupStyle)
+	}
+}
+
+// SetPreprocessor sets a function that transforms the objects passed to SetObjects into the objects displayed. It runs
+// again with the objects last set whenever the content width or wrapping changes. With a preprocessor set,
+// AppendObjects and PrependObjects preprocess all objects again. Pass nil to disable.
+func (m *Model[T]) SetPreprocessor(preprocessor Preprocessor[T]) {
+	objects := m.content.unprocessedObjects()
+	if preprocessor == nil {
+		m.content.preprocessing = nil
+	} else {
+		m.content.preprocessing = &preprocessing[T]{fn: preprocessor}
+	}
+	m.setObjects(objects)
+}
+
+// SetItemStyleFunc sets a function returning a style to layer under each unselected item's own styling, e.g. to dim
+// some items. The style only applies to portions of the item without existing ANSI styling, highlights or search
+// matches. With line joining enabled, it's called with the parent object of each group. Pass nil to disable.
+func (m *Model[T]) SetItemStyleFunc(styleFunc ItemStyleFunc[T]) {
+	m.content.itemStyleFunc = styleFunc
+}
+
+// SetLineJoining groups objects for which isContinuation returns true under the preceding object. Each group is
+// displayed and selected as a single item: collapsed, it shows the parent with a "(+N)" indicator; expanded, it
+// shows the parent followed by its continuation objects. The ToggleExpand key toggles the selected group.
+// Item indexes, including those of highlights and the selection, refer to groups rather than individual objects.
+// Pass nil to disable.
+func (m *Model[T]) SetLineJoining(isContinuation func(T) bool) {
+	objects := m.content.unprocessedObjects()
+	if isContinuation == nil {
+		m.content.joining = nil
+	} else {
+		m.content.joining = newLineJoining(isContinuation, m.display.styles.CollapsedGroupStyle)
+	}
+	m.setObjects(objects)
+}
+
+// SetGroupExpanded sets whether the group at itemIdx shows its continuation objects when line joining is enabled
+func (m *Model[T]) SetGroupExpanded(itemIdx int, expanded bool) {
+	if m.content.joining == nil {
+		return
+	}
+	m.content.joining.setExpanded(itemIdx, expanded)
+	m.refreshSearch()
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// SetAllGroupsExpanded expands or collapses every group when line joining is enabled
+func (m *Model[T]) SetAllGroupsExpanded(expanded bool) {
+	if m.content.joining == nil {
+		return
+	}
+	for i := range m.content.joining.groups {
+		m.content.joining.setExpanded(i, expanded)
+	}
+	m.refreshSearch()
+	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
+	if m.navigation.selectionEnabled {
+		m.scrollSoSelectionInView()
+	}
+}
+
+// IsGroupExpanded returns whether the group at itemIdx shows its continuation objects
+func (m *Model[T]) IsGroupExpanded(itemIdx int) bool {
+	if m.content.joining == nil {
+		return false
+	}
+	return m.content.joining.isExpanded(itemIdx)
+}
+
+// GetGroupObjects returns the parent and continuation objects of the group at itemIdx when line joining is
+// enabled, or just the object at itemIdx otherwise
+func (m *Model[T]) GetGroupObjects(itemIdx int) []T {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return nil
+	}
+	if m.content.joining == nil {
+		return []T{m.content.objectAt(itemIdx)}
+	}
+	return m.content.joining.groupObjects(itemIdx)
+}
+
+// SetStickyItemFunc sets a function returning whether an object is sticky, e.g. a date separator or section title.
+// Once scrolled past, the most recent sticky item above the top of the view stays pinned over the first content line,
+// styled with StickyItemStyle, unless the selection is on that line. Pass nil to disable.
+func (m *Model[T]) SetStickyItemFunc(isSticky func(T) bool) {
+	m.content.stickyItemFunc = isSticky
+	m.content.resetStickyMemo()
+}
+
+// SetTreeMode sets whether objects are shown as a tree of TreeNodes. Each node is indented to its depth behind a
+// marker showing whether it's collapsed, and the ToggleExpand key collapses or expands the selected node, hiding or
+// showing its descendants. Item indexes, including those of highlights and the selection, refer to visible nodes. The
+// footer shows how many nodes are visible out of the total. Tree mode doesn't apply with line joining, and sorting
+// reorders nodes without regard to the tree.
+func (m *Model[T]) SetTreeMode(enabled bool) {
+	if enabled == (m.content.tree != nil) {
+		return
+	}
+	objects := m.content.unprocessedObjects()
+	if enabled {
+		m.content.tree = newTreeState[T]()
+	} else {
+		m.content.tree = nil
+	}
+	m.setObjects(objects)
+}
+
+// GetTreeMode returns whether objects are shown as a tree
+func (m *Model[T]) GetTreeMode() bool {
+	return m.content.tree != nil
+}
+
+// SetNodeCollapsed sets whether the tree node at itemIdx hides its descendants when tree mode is on. The selected node
+// stays selected, or its closest visible ancestor is selected if it's hidden. Collapsed nodes are kept collapsed when
+// objects change if they match a previously collapsed object with the selection comparator (see
+// SetSelectionComparator), and by position otherwise.
+func (m *Model[T]) SetNodeCollapsed(itemIdx int, collapsed bool) {
+	if m.content.tree == nil || m.content.source != nil {
+		return
+	}
+	m.setTreeNodeCollapsed(itemIdx, collapsed)
+}
+
+// SetAllNodesCollapsed collapses or expands every tree node with descendants when tree mode is on
+func (m *Model[T]) SetAllNodesCollapsed(collapsed bool) {
+	t := m.content.tree
+	if t == nil || m.content.source != nil {
+		return
+	}
+	t.collapsed = make(map[int]struct{})
+	if collapsed {
+		for i := range t.sourceObjects {
+			if t.hasChildren(i) {
+				t.collapsed[i] = struct{}{}
+			}
+		}
+	}
+	m.refreshTree()
+}
+
+// IsNodeCollapsed returns whether the tree node at itemIdx hides its descendants
+func (m *Model[T]) IsNodeCollapsed(itemIdx int) bool {
+	if m.content.tree == nil {
+		return false
+	}
+	_, ok := m.content.tree.collapsed[m.content.tree.sourceIdx(itemIdx)]
+	return ok
+}
+
+// SetDetailsExpanded sets whether the item at itemIdx shows its detail items indented beneath it, if its object is a
+// DetailedObject. Expanded details are kept when objects change if they match a previously expanded object with the
+// selection comparator (see SetSelectionComparator), and collapsed otherwise. Details aren't shown with line joining
+// enabled.
+func (m *Model[T]) SetDetailsExpanded(itemIdx int, expanded bool) {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return
+	}
+	if expanded {
+		m.content.expandedDetails[itemIdx] = struct{}{}
+	} else {
+		delete(m.content.expandedDetails, itemIdx)
+	}
+	m.afterDetailsChanged()
+}
+
+// SetAllDetailsExpanded expands or collapses the details of every item
+func (m *Model[T]) SetAllDetailsExpanded(expanded bool) {
+	m.content.expandedDetails = make(map[int]struct{})
+	if expanded {
+		for i := range m.content.numItems() {
+			if len(detailItems(m.content.objectAt(i))) > 0 {
+				m.content.expandedDetails[i] = struct{}{}
+			}
+		}
+	}
+	m.afterDetailsChanged()
+}
+
+// IsDetailsExpanded returns whether the item at itemIdx shows its detail items
+func (m *Model[T]) IsDetailsExpanded(itemIdx int) bool {
+	_, ok := m.content.expandedDetails[itemIdx]
+	return ok
 }
 
 // GetTopItemIdxAndLineOffset returns the current top item index and line offset within that item
@@ -713,12 +2439,28 @@ func (m *Model[T]) GetTopItemIdxAndLineOffset() (int, int) {
 	return m.display.topItemIdx, m.display.topItemLineOffset
 }
 
+// SetTopItemIdxAndLineOffset scrolls so that the item at topItemIdx is at the top, with topItemLineOffset of its
+// lines scrolled past, clamped to the content. The selection doesn't change, so it can end up out of view.
+func (m *Model[T]) SetTopItemIdxAndLineOffset(topItemIdx, topItemLineOffset int) {
+	m.finishSmoothScroll()
+	m.safelySetTopItemIdxAndOffset(topItemIdx, topItemLineOffset)
+}
+
 // SetSelectedItemIdx sets the selected context index. Automatically puts selection in view as necessary
 func (m *Model[T]) SetSelectedItemIdx(selectedItemIdx int) {
+	if b := m.content.batch; b != nil {
+		b.selectedIdx = &selectedItemIdx
+		return
+	}
+	m.setSelectedItemIdx(selectedItemIdx)
+}
+
+func (m *Model[T]) setSelectedItemIdx(selectedItemIdx int) {
 	if !m.navigation.selectionEnabled {
 		return
 	}
 	m.content.setSelectedIdx(selectedItemIdx)
+	m.stopPeekIfMoved()
 	m.scrollSoSelectionInView()
 }
 
@@ -735,7 +2477,199 @@ func (m *Model[T]) GetSelectedItem() *T {
 	if !m.navigation.selectionEnabled {
 		return nil
 	}
-	return m.content.getSelectedItem()
+	return m.content.getSelectedItem()
+}
+
+// GetItemAtScreenPosition returns the index of the item rendered at the given row and column of View(), both
+// 0-indexed from the top left, along with the byte offset in the item's ContentNoAnsi() of the character at that
+// position. Columns past the end of a line map to the end of that line, and columns within the selection prefix
+// map to the start of the line. ok is false if no item is rendered on the row, e.g. for header and footer rows.
+func (m *Model[T]) GetItemAtScreenPosition(row, col int) (itemIdx, byteOffset int, ok bool) {
+	contentRow := row - len(m.getVisibleHeaderLines())
+	if m.showsPostHeaderLine() {
+		contentRow--
+	}
+	itemIndexes := m.getVisibleContentItemIndexes()
+	if contentRow < 0 || contentRow >= len(itemIndexes) {
+		return 0, 0, false
+	}
+	itemIdx = itemIndexes[contentRow]
+
+	// line offset of the row within its item
+	lineOffset := 0
+	for i := contentRow - 1; i >= 0 && itemIndexes[i] == itemIdx; i-- {
+		lineOffset++
+	}
+	if itemIdx == m.display.topItemIdx {
+		lineOffset += m.display.topItemLineOffset
+	}
+
+	if m.navigation.selectionEnabled && m.display.styles.SelectionPrefix != "" {
+		col -= lipgloss.Width(m.display.styles.SelectionPrefix)
+	}
+	col = max(0, col-m.numDiffMarkerCols()-m.numLineNumberCols())
+
+	segments := m.content.itemAt(itemIdx).LineBrokenItems()
+	segIdx, cellsToLeft := 0, 0
+	if !m.wrapsItem(itemIdx) {
+		segIdx = clampValZeroToMax(lineOffset, len(segments)-1)
+		cellsToLeft = m.xOffsetOf(itemIdx)
+	} else {
+		cw := m.contentWidth()
+		var wrapOffset int
+		layout := m.wrapLayout()
+		segIdx, wrapOffset = decomposeLineOffset(segments, lineOffset, cw, layout)
+		if layout.byRowStarts() {
+			rowStarts := layout.rowStarts(segments[segIdx], cw)
+			rowIdx := min(wrapOffset, len(rowStarts)-1)
+			cellsToLeft = rowStarts[rowIdx]
+			if rowIdx > 0 {
+				col = max(0, col-layout.indent)
+			}
+			if rowIdx+1 < len(rowStarts) {
+				// past the end of a row that continues on the next maps to the row's last cell
+				col = min(col, rowStarts[rowIdx+1]-cellsToLeft-1)
+			}
+		} else {
+			// rows can be narrower than cw when a wide rune doesn't fit, so replicate the rendered rows
+			var widthTaken int
+			for range wrapOffset {
+				_, widthTaken = segments[segIdx].Take(cellsToLeft, cw, "", nil)
+				cellsToLeft += widthTaken
+			}
+			_, widthTaken = segments[segIdx].Take(cellsToLeft, cw, "", nil)
+			if cellsToLeft+widthTaken < segments[segIdx].Width() {
+				col = min(col, widthTaken-1)
+			}
+		}
+	}
+
+	for i := range segIdx {
+		byteOffset += len(segments[i].ContentNoAnsi()) + 1 // newline between segments
+	}
+	byteOffset += item.ByteOffsetAtCell(segments[segIdx].ContentNoAnsi(), cellsToLeft+col)
+	return itemIdx, byteOffset, true
+}
+
+// ScreenPositionOf returns the row and column of View(), both 0-indexed from the top left, at which the character at
+// byteOffset in the ContentNoAnsi() of the item at itemIdx is rendered, accounting for wrapping, panning, the header
+// and the selection prefix. It's the inverse of GetItemAtScreenPosition, for positioning overlays like tooltips over
+// content. A byteOffset at the end of a line is the cell just past it. visible is false, and row and col are 0, if the
+// position is scrolled or panned out of view.
+func (m *Model[T]) ScreenPositionOf(itemIdx, byteOffset int) (row, col int, visible bool) {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return 0, 0, false
+	}
+	segments := m.content.itemAt(itemIdx).LineBrokenItems()
+	segIdx := 0
+	byteOffset = max(0, byteOffset)
+	for segIdx < len(segments)-1 && byteOffset > len(segments[segIdx].ContentNoAnsi()) {
+		byteOffset -= len(segments[segIdx].ContentNoAnsi()) + 1 // newline between segments
+		segIdx++
+	}
+	segment := segments[segIdx]
+	cell := item.CellAtByteOffset(segment.ContentNoAnsi(), byteOffset)
+
+	// line offset of the position within its item and column within the content area
+	cw := m.contentWidth()
+	lineOffset := segIdx
+	if !m.wrapsItem(itemIdx) {
+		col = cell - m.xOffsetOf(itemIdx)
+	} else {
+		layout := m.wrapLayout()
+		lineOffset = 0
+		for i := range segIdx {
+			lineOffset += layout.numRows(segments[i], cw)
+		}
+		if layout.byRowStarts() {
+			rowStarts := layout.rowStarts(segment, cw)
+			rowIdx := rowIdxForCell(rowStarts, cell)
+			lineOffset += rowIdx
+			col = cell - rowStarts[rowIdx]
+			if rowIdx > 0 {
+				col += layout.indent
+			}
+		} else {
+			// rows can be narrower than cw when a wide rune doesn't fit, so replicate the rendered rows
+			cellsToLeft := 0
+			for {
+				_, widthTaken := segment.Take(cellsToLeft, cw, "", nil)
+				if widthTaken <= 0 || cell < cellsToLeft+widthTaken || cellsToLeft+widthTaken >= segment.Width() {
+					break
+				}
+				cellsToLeft += widthTaken
+				lineOffset++
+			}
+			col = cell - cellsToLeft
+		}
+	}
+	if col < 0 || col >= cw {
+		return 0, 0, false
+	}
+
+	itemIndexes := m.getVisibleContentItemIndexes()
+	firstRow := slices.Index(itemIndexes, itemIdx)
+	if firstRow < 0 {
+		return 0, 0, false
+	}
+	if itemIdx == m.display.topItemIdx {
+		lineOffset -= m.display.topItemLineOffset
+	}
+	contentRow := firstRow + lineOffset
+	if lineOffset < 0 || contentRow >= len(itemIndexes) || itemIndexes[contentRow] != itemIdx {
+		return 0, 0, false
+	}
+
+	row = contentRow + len(m.getVisibleHeaderLines())
+	if m.showsPostHeaderLine() {
+		row++
+	}
+	if m.navigation.selectionEnabled && m.display.styles.SelectionPrefix != "" {
+		col += lipgloss.Width(m.display.styles.SelectionPrefix)
+	}
+	return row, col + m.numDiffMarkerCols() + m.numLineNumberCols(), true
+}
+
+// DumpState returns a plain-text, unstyled description of the viewport's current state: dimensions, item counts,
+// the visible range, selection and wrapping. It is intended for debugging, logging and assistive tooling, and its
+// exact format may change.
+func (m *Model[T]) DumpState() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "size: %dx%d\n", m.display.bounds.width, m.display.bounds.height)
+	fmt.Fprintf(&b, "items: %d\n", m.content.numItems())
+	fmt.Fprintf(&b, "header lines: %d\n", len(m.content.header))
+
+	itemIndexes := m.getVisibleContentItemIndexes()
+	if len(itemIndexes) == 0 {
+		b.WriteString("visible: none\n")
+	} else {
+		fmt.Fprintf(&b, "visible: items %d-%d of %d, %d lines\n",
+			itemIndexes[0]+1, itemIndexes[len(itemIndexes)-1]+1, m.content.numItems(), len(itemIndexes))
+	}
+
+	if !m.navigation.selectionEnabled {
+		b.WriteString("selection: disabled\n")
+	} else if m.content.isEmpty() {
+		b.WriteString("selection: none\n")
+	} else {
+		selectedIdx := m.content.getSelectedIdx()
+		fmt.Fprintf(&b, "selection: item %d of %d: %q\n",
+			selectedIdx+1, m.content.numItems(), m.content.itemAt(selectedIdx).ContentNoAnsi())
+	}
+
+	if m.config.wrapText {
+		b.WriteString("wrap: on\n")
+	} else {
+		fmt.Fprintf(&b, "wrap: off, x offset %d\n", m.display.xOffset)
+	}
+	fmt.Fprintf(&b, "sticky: top %t, bottom %t", m.navigation.topSticky, m.navigation.bottomSticky)
+	if m.config.softLimitReason != "" {
+		fmt.Fprintf(&b, "\nsoft limits exceeded: %s", m.config.softLimitReason)
+	}
+	if s := m.content.search; s.query != "" {
+		fmt.Fprintf(&b, "\nsearch: %q, match %d of %d", s.query, s.focusedIdx+1, len(s.matches))
+	}
+	return b.String()
 }
 
 // SetHeader sets the header, an unselectable set of lines at the top of the viewport
@@ -743,13 +2677,62 @@ func (m *Model[T]) SetHeader(header []string) {
 	m.content.header = header
 }
 
+// SetHeaderHighlights sets what to highlight in the header, where each highlight's ItemIndex is the index of the
+// header line it applies to, e.g. to highlight filter matches in a column header line
+func (m *Model[T]) SetHeaderHighlights(highlights []Highlight) {
+	m.content.headerHighlights = highlights
+}
+
+// GetHeaderHighlights returns the highlights set on the header with SetHeaderHighlights
+func (m *Model[T]) GetHeaderHighlights() []Highlight {
+	return m.content.headerHighlights
+}
+
+// SetSearchIncludesHeader sets whether the search set with SetSearch also highlights occurrences of the query in
+// the header. Header matches are highlighted with SearchMatchStyle but aren't counted or navigated to. Off by default.
+func (m *Model[T]) SetSearchIncludesHeader(include bool) {
+	m.config.searchIncludesHeader = include
+}
+
+// GetSearchIncludesHeader returns whether the search highlights occurrences of the query in the header
+func (m *Model[T]) GetSearchIncludesHeader() bool {
+	return m.config.searchIncludesHeader
+}
+
 // EnsureItemInView scrolls or pans the viewport so that the specified portion of an item is visible.
 // If the desired item portion is above or below the current view, it scrolls vertically to bring it into view, leaving
 // verticalPad number of lines of context if possible.
 // If the desired item portion is to the left or right of the current view, it pans horizontally to bring it into view,
 // leaving horizontalPad number of columns of context if possible.
 // Afterwards, it's possible that the selection is out of view of the viewport.
+// It's stable: calling it again with the same arguments, without changing the content, size or scroll position in
+// between, never changes the view.
 func (m *Model[T]) EnsureItemInView(itemIdx, startWidth, endWidth, verticalPad, horizontalPad int) {
+	if b := m.content.batch; b != nil {
+		b.inView = &itemInViewRequest{itemIdx, startWidth, endWidth, verticalPad, horizontalPad}
+		return
+	}
+	m.ensureItemInView(itemIdx, startWidth, endWidth, verticalPad, horizontalPad)
+}
+
+// EnsureItemInViewCmd is EnsureItemInView, animating the scroll when smooth scrolling is enabled, see
+// SetSmoothScroll. The returned command drives the animation, nil if there's none.
+func (m *Model[T]) EnsureItemInViewCmd(itemIdx, startWidth, endWidth, verticalPad, horizontalPad int) tea.Cmd {
+	prevTopItemIdx, prevTopItemLineOffset := m.display.topItemIdx, m.display.topItemLineOffset
+	m.EnsureItemInView(itemIdx, startWidth, endWidth, verticalPad, horizontalPad)
+	return m.startSmoothScroll(prevTopItemIdx, prevTopItemLineOffset)
+}
+
+// EnsureItemInViewScrolled is EnsureItemInView, returning true if it scrolled or panned the viewport. It returns false
+// during a batch update, where bringing the item into view is deferred.
+func (m *Model[T]) EnsureItemInViewScrolled(itemIdx, startWidth, endWidth, verticalPad, horizontalPad int) bool {
+	prevTopItemIdx, prevTopItemLineOffset, prevXOffset := m.display.topItemIdx, m.display.topItemLineOffset, m.display.xOffset
+	m.EnsureItemInView(itemIdx, startWidth, endWidth, verticalPad, horizontalPad)
+	return m.display.topItemIdx != prevTopItemIdx || m.display.topItemLineOffset != prevTopItemLineOffset ||
+		m.display.xOffset != prevXOffset
+}
+
+func (m *Model[T]) ensureItemInView(itemIdx, startWidth, endWidth, verticalPad, horizontalPad int) {
 	if m.display.bounds.width == 0 {
 		return
 	}
@@ -760,18 +2743,66 @@ func (m *Model[T]) EnsureItemInView(itemIdx, startWidth, endWidth, verticalPad,
 
 	itemIdx, startWidth, endWidth = m.clampItemAndWidthParams(itemIdx, startWidth, endWidth)
 
+	// centering an item that can't get its padding on both sides of an even number of lines depends on which half
+	// of the view it's in, so repeating the call would alternate between the two. Skip the call if nothing changed.
+	inView := m.itemInViewState(itemInViewRequest{itemIdx, startWidth, endWidth, verticalPad, horizontalPad})
+	if last := m.display.lastInView; last != nil && *last == inView {
+		return
+	}
+	defer func() {
+		inView := m.itemInViewState(inView.request)
+		m.display.lastInView = &inView
+	}()
+
 	if m.config.wrapText {
 		m.ensureWrappedPortionInView(itemIdx, startWidth, endWidth, verticalPad)
 	} else {
-		m.ensureUnwrappedItemVerticallyInView(itemIdx, verticalPad)
-		m.ensureUnwrappedPortionHorizontallyInView(startWidth, endWidth, horizontalPad)
+		segments := m.content.itemAt(itemIdx).LineBrokenItems()
+		startSegIdx, startCol := segmentForCellPosition(segments, startWidth)
+		endSegIdx, endCol := segmentForCellPosition(segments, endWidth)
+		if endSegIdx != startSegIdx {
+			endCol = startCol
+		}
+		numContentLines := m.getNumContentLines()
+		if m.itemsAreSingleLine(itemIdx-numContentLines, itemIdx+numContentLines) {
+			m.ensureUnwrappedItemVerticallyInView(itemIdx, verticalPad)
+		} else {
+			m.ensureLinesInView(itemIdx, startSegIdx, endSegIdx, verticalPad)
+		}
+		m.ensureUnwrappedPortionHorizontallyInView(startCol, endCol, horizontalPad)
+	}
+}
+
+// itemInViewState returns what determines the result of bringing the item portion of r into view
+func (m *Model[T]) itemInViewState(r itemInViewRequest) itemInView {
+	return itemInView{
+		request:           r,
+		bounds:            m.display.bounds,
+		numContentLines:   m.getNumContentLines(),
+		wrapText:          m.config.wrapText,
+		numItems:          m.content.numItems(),
+		itemContent:       m.content.itemAt(r.itemIdx).Content(),
+		topItemIdx:        m.display.topItemIdx,
+		topItemLineOffset: m.display.topItemLineOffset,
+		xOffset:           m.display.xOffset,
+	}
+}
+
+// itemsAreSingleLine returns true if no item in the inclusive range [fromIdx, toIdx] spans multiple lines
+// when unwrapped. Out of range indexes are ignored.
+func (m *Model[T]) itemsAreSingleLine(fromIdx, toIdx int) bool {
+	for idx := max(0, fromIdx); idx <= min(toIdx, m.content.numItems()-1); idx++ {
+		if m.numLinesForItem(idx) > 1 {
+			return false
+		}
 	}
+	return true
 }
 
 // clampItemAndWidthParams clamps itemIdx, startWidth, and endWidth to valid ranges
 func (m *Model[T]) clampItemAndWidthParams(itemIdx, startWidth, endWidth int) (int, int, int) {
 	itemIdx = max(0, min(itemIdx, m.content.numItems()-1))
-	itemWidth := m.content.objects[itemIdx].GetItem().Width()
+	itemWidth := m.content.itemAt(itemIdx).Width()
 	startWidth = max(0, min(startWidth, itemWidth))
 	endWidth = max(startWidth, min(endWidth, itemWidth))
 	return itemIdx, startWidth, endWidth
@@ -796,10 +2827,21 @@ func (m *Model[T]) scrollVertical(navResult navigationResult) {
 	// viewport scroll implicitly to keep it in view. A page/half-page jump
 	// scrolls the content by a fixed amount and carries the selection along.
 	movesSelectionOnly := navResult.action == actionUp || navResult.action == actionDown
+	if movesSelectionOnly && m.subLineActive() {
+		m.moveSubLineCursor(navResult.selectionAmount)
+		return
+	}
 	if !movesSelectionOnly {
 		m.scrollDownLines(navResult.scrollAmount)
+		switch m.navigation.pageScroll {
+		case PageScrollKeepsSelectionInView:
+			m.keepSelectionVisible(navResult.scrollAmount > 0)
+			return
+		case PageScrollLeavesSelection:
+			return
+		}
 	}
-	m.SetSelectedItemIdx(m.content.getSelectedIdx() + navResult.selectionAmount)
+	m.setSelectedItemIdx(m.content.getSelectedIdx() + navResult.selectionAmount)
 }
 
 func (m *Model[T]) scrollHorizontal(navResult navigationResult) {
@@ -814,13 +2856,19 @@ func (m *Model[T]) ensureWrappedPortionInView(itemIdx, startWidth, endWidth, ver
 		panic("ensureWrappedPortionInView called when wrapText is false")
 	}
 	viewportWidth := m.contentWidth()
-	segments := m.content.objects[itemIdx].GetItem().LineBrokenItems()
-	startLineOffset := lineOffsetForCellPosition(segments, startWidth, viewportWidth)
-	endLineOffset := lineOffsetForCellPosition(segments, max(0, endWidth-1), viewportWidth)
+	segments := m.content.itemAt(itemIdx).LineBrokenItems()
+	layout := m.wrapLayout()
+	startLineOffset := lineOffsetForCellPosition(segments, startWidth, viewportWidth, layout)
+	endLineOffset := lineOffsetForCellPosition(segments, max(0, endWidth-1), viewportWidth, layout)
 	if endWidth == 0 {
 		endLineOffset = 0
 	}
 
+	m.ensureLinesInView(itemIdx, startLineOffset, endLineOffset, verticalPad)
+}
+
+// ensureLinesInView ensures the lines from startLineOffset to endLineOffset of the item are visible
+func (m *Model[T]) ensureLinesInView(itemIdx, startLineOffset, endLineOffset, verticalPad int) {
 	numLinesInPortion := endLineOffset - startLineOffset + 1
 	numContentLines := m.getNumContentLines()
 
@@ -839,7 +2887,7 @@ func (m *Model[T]) ensureWrappedPortionInView(itemIdx, startWidth, endWidth, ver
 
 	// check if already in view before any scroll-direction-based positioning
 	// this prevents oscillation when scrollingDown changes between calls
-	portionStartInView, portionEndInView, linesAbovePortion, linesBelowPortion := m.getWrappedPortionViewInfo(itemIdx, startLineOffset, endLineOffset)
+	portionStartInView, portionEndInView, linesAbovePortion, linesBelowPortion := m.getPortionViewInfo(itemIdx, startLineOffset, endLineOffset)
 
 	// if fully visible, check if position is already acceptable
 	if portionStartInView && portionEndInView {
@@ -930,11 +2978,8 @@ func (m *Model[T]) ensureWrappedPortionInView(itemIdx, startWidth, endWidth, ver
 	}
 }
 
-// getWrappedPortionViewInfo returns whether the portion is in view and padding information
-func (m *Model[T]) getWrappedPortionViewInfo(itemIdx, startLineOffset, endLineOffset int) (portionStartInView, portionEndInView bool, linesAbove, linesBelow int) {
-	if !m.config.wrapText {
-		panic("getWrappedPortionViewInfo called when wrapText is false")
-	}
+// getPortionViewInfo returns whether the portion is in view and padding information
+func (m *Model[T]) getPortionViewInfo(itemIdx, startLineOffset, endLineOffset int) (portionStartInView, portionEndInView bool, linesAbove, linesBelow int) {
 	itemIndexes := m.getVisibleContentItemIndexes()
 	itemFirstSeenAt := -1
 	portionStartPos := -1
@@ -1018,148 +3063,585 @@ func (m *Model[T]) ensureUnwrappedItemVerticallyInView(itemIdx, verticalPad int)
 		}
 	}
 
-	itemInBottomHalfOfViewport := m.display.topItemIdx+numContentLines/2 <= itemIdx
+	itemInBottomHalfOfViewport := m.display.topItemIdx+numContentLines/2 <= itemIdx
+
+	// when padding can't be satisfied on both sides, center the item
+	if verticalPad*2+1 > numContentLines {
+		desiredPadding := numContentLines / 2
+		if itemInBottomHalfOfViewport {
+			// leave desiredPadding lines below
+			targetTopItemIdx := max(0, itemIdx-numContentLines+1+desiredPadding)
+			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+		} else {
+			// leave desiredPadding lines above
+			targetTopItemIdx := max(0, itemIdx-desiredPadding)
+			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+		}
+		return
+	}
+
+	desiredPad := min(verticalPad, numContentLines-1)
+
+	if visiblePosition >= 0 {
+		// item is visible, check if padding is respected
+		linesAbove := visiblePosition
+		linesBelow := len(itemIndexes) - visiblePosition - 1
+
+		if linesAbove >= desiredPad && linesBelow >= desiredPad {
+			return
+		}
+
+		if itemInBottomHalfOfViewport {
+			targetTopItemIdx := max(0, itemIdx-numContentLines+1+desiredPad)
+			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+		} else {
+			targetTopItemIdx := max(0, itemIdx-desiredPad)
+			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+		}
+		return
+	}
+
+	// not visible, position based on item position
+	if itemInBottomHalfOfViewport {
+		// leave desiredPad lines below
+		targetTopItemIdx := max(0, itemIdx-numContentLines+1+desiredPad)
+		m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+	} else {
+		// leave desiredPad lines above
+		targetTopItemIdx := max(0, itemIdx-desiredPad)
+		m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+	}
+}
+
+// ensureUnwrappedPortionHorizontallyInView pans horizontally to bring portion into view
+func (m *Model[T]) ensureUnwrappedPortionHorizontallyInView(startWidth, endWidth, horizontalPad int) {
+	if m.config.wrapText {
+		panic("ensureUnwrappedPortionHorizontallyInView called when wrapText is true")
+	}
+	viewportWidth := m.contentWidth()
+	currentXOffset := m.display.xOffset
+
+	visibleStartWidth := currentXOffset + 1
+	visibleEndWidth := currentXOffset + viewportWidth
+
+	portionStartInView := startWidth >= visibleStartWidth && startWidth <= visibleEndWidth
+	portionEndInView := endWidth >= visibleStartWidth && endWidth <= visibleEndWidth
+
+	portionWidth := endWidth - startWidth
+	panningRight := startWidth > visibleStartWidth
+
+	// portion wider than viewport: align left edge with padding
+	if portionWidth > viewportWidth {
+		desiredColumnsLeft := min(horizontalPad, viewportWidth-1)
+		targetXOffset := max(0, startWidth-desiredColumnsLeft)
+		m.SetXOffset(targetXOffset)
+		return
+	}
+
+	// when padding can't be satisfied on both sides, center the portion
+	if horizontalPad*2+portionWidth > viewportWidth {
+		desiredColumnsLeft := (viewportWidth - portionWidth) / 2
+		targetXOffset := max(0, startWidth-desiredColumnsLeft)
+		m.SetXOffset(targetXOffset)
+		return
+	}
+
+	desiredPad := min(horizontalPad, viewportWidth-portionWidth)
+
+	if portionStartInView && portionEndInView {
+		// already fully visible, check if padding is respected
+		columnsLeft := startWidth - currentXOffset
+		columnsRight := currentXOffset + viewportWidth - endWidth
+
+		if columnsLeft >= desiredPad && columnsRight >= desiredPad {
+			return
+		}
+
+		// adjust position based on panning direction
+		if panningRight {
+			targetXOffset := max(0, endWidth+desiredPad-viewportWidth)
+			m.SetXOffset(targetXOffset)
+		} else {
+			targetXOffset := max(0, startWidth-desiredPad)
+			m.SetXOffset(targetXOffset)
+		}
+		return
+	}
+
+	// not visible, position based on panning direction
+	if panningRight {
+		// panning right: leave desiredPad columns to the right
+		targetXOffset := max(0, endWidth+desiredPad-viewportWidth)
+		m.SetXOffset(targetXOffset)
+	} else {
+		// panning left: leave desiredPad columns to the left
+		targetXOffset := max(0, startWidth-desiredPad)
+		m.SetXOffset(targetXOffset)
+	}
+}
+
+// SetXOffset sets the horizontal offset, in terminal cell width, for panning when text wrapping is disabled
+func (m *Model[T]) SetXOffset(widthOffset int) {
+	if m.config.wrapText {
+		return
+	}
+	maxXOffset := m.maxItemWidth() - m.contentWidth()
+	m.display.xOffset = max(0, min(maxXOffset, widthOffset))
+}
+
+// GetXOffsetWidth returns the horizontal offset, in terminal cell width, for panning when text wrapping is disabled
+func (m *Model[T]) GetXOffsetWidth() int {
+	if m.config.wrapText {
+		return 0
+	}
+	return m.display.xOffset
+}
+
+// SetHighlights sets specific positions to highlight with custom styles in the viewport.
+func (m *Model[T]) SetHighlights(highlights []Highlight) {
+	m.content.setHighlights(highlights)
+}
+
+// AppendHighlights adds highlights, keeping the existing ones, without going through all highlights again like
+// SetHighlights, e.g. as the matches of a long-running search arrive.
+func (m *Model[T]) AppendHighlights(highlights []Highlight) {
+	m.content.appendHighlights(highlights)
+}
+
+// GetHighlights returns all highlights.
+func (m *Model[T]) GetHighlights() []Highlight {
+	return m.content.getHighlights()
+}
+
+// HighlightAll adds a highlight in style for every non-overlapping occurrence of substr in the unstyled content of the
+// item at itemIdx, keeping existing highlights. Where highlights overlap, the one starting first shows, and the rest
+// of a later one shows after it ends. Does nothing while the content exceeds the soft limits, see SoftLimits.
+func (m *Model[T]) HighlightAll(itemIdx int, substr string, style lipgloss.Style) {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() || substr == "" || m.config.softLimitReason != "" {
+		return
+	}
+	matches := m.content.rawItemAt(itemIdx).ExtractExactMatches(substr)
+	if len(matches) == 0 {
+		return
+	}
+	highlights := slices.Clone(m.content.getHighlights())
+	for _, match := range matches {
+		highlights = append(highlights, Highlight{
+			ItemIndex: itemIdx,
+			ItemHighlight: item.Highlight{
+				Style:                    style,
+				ByteRangeUnstyledContent: match.ByteRange,
+			},
+		})
+	}
+	m.content.setHighlights(highlights)
+}
+
+// SetMarked sets whether the item at itemIdx is marked. Marked items are styled with MarkedItemStyle, and the
+// ToggleMarked key, disabled by default, toggles the mark of the selected item. On SetObjects, marks are kept for
+// objects matching a previously marked object with the selection comparator (see SetSelectionComparator), and cleared
+// otherwise.
+func (m *Model[T]) SetMarked(itemIdx int, marked bool) {
+	if itemIdx < 0 || itemIdx >= m.content.numItems() {
+		return
+	}
+	if marked {
+		m.content.marked[itemIdx] = struct{}{}
+	} else {
+		delete(m.content.marked, itemIdx)
+	}
+}
+
+// IsMarked returns whether the item at itemIdx is marked
+func (m *Model[T]) IsMarked(itemIdx int) bool {
+	_, ok := m.content.marked[itemIdx]
+	return ok
+}
+
+// GetMarkedItems returns the marked objects in item order
+func (m *Model[T]) GetMarkedItems() []T {
+	return m.markedObjects()
+}
+
+// GetMarkedItemIdxs returns the indexes of marked items in ascending order
+func (m *Model[T]) GetMarkedItemIdxs() []int {
+	return m.markedItemIdxs()
+}
+
+// ClearMarks unmarks all items
+func (m *Model[T]) ClearMarks() {
+	m.content.marked = make(map[int]struct{})
+}
+
+// Copy returns a command copying the visual selection while selecting text, otherwise the unstyled content of the
+// marked items if any, otherwise of the selected item when selection is enabled, otherwise of the visible content. Content goes to the clipboard target set with
+// WithClipboard, OSC 52 by default.
+func (m *Model[T]) Copy() tea.Cmd {
+	if text := m.GetVisualSelectionText(); text != "" {
+		return m.copyCmd(text, "selection")
+	}
+	if len(m.content.marked) > 0 {
+		return m.CopyMarked()
+	}
+	if m.navigation.selectionEnabled {
+		return m.CopySelected()
+	}
+	return m.CopyVisible()
+}
+
+// CopySelected returns a command copying the unstyled content of the selected item, nil if nothing is selected
+func (m *Model[T]) CopySelected() tea.Cmd {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() {
+		return nil
+	}
+	return m.copyCmd(m.itemsText([]int{m.content.getSelectedIdx()}), "1 item")
+}
+
+// CopySelectedStyled returns a command copying the content of the selected item with its ANSI escape sequences, e.g.
+// to paste colored output into another terminal, nil if nothing is selected
+func (m *Model[T]) CopySelectedStyled() tea.Cmd {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() {
+		return nil
+	}
+	return m.copyTextCmd(m.itemsStyledText([]int{m.content.getSelectedIdx()}), "1 styled item", true)
+}
+
+// CopyMarked returns a command copying the unstyled content of the marked items, one per line, nil if none are marked
+func (m *Model[T]) CopyMarked() tea.Cmd {
+	idxs := m.markedItemIdxs()
+	if len(idxs) == 0 {
+		return nil
+	}
+	return m.copyCmd(m.itemsText(idxs), pluralize(len(idxs), "item"))
+}
+
+// CopyVisible returns a command copying the unstyled text of the content lines currently on screen, nil if there are
+// none
+func (m *Model[T]) CopyVisible() tea.Cmd {
+	text, numLines := m.visibleText()
+	if numLines == 0 {
+		return nil
+	}
+	return m.copyCmd(text, pluralize(numLines, "line"))
+}
+
+// StartEditing replaces the selected item with a single-line text input pre-filled with its unstyled content,
+// returning the command that focuses it. While editing, the viewport captures all input (see IsCapturingInput):
+// enter confirms, sending an ItemEditedMsg, and escape cancels. Returns nil if selection is disabled or there's no
+// content.
+func (m *Model[T]) StartEditing() tea.Cmd {
+	return m.startEditing()
+}
+
+// CancelEditing stops editing the selected item without sending an ItemEditedMsg
+func (m *Model[T]) CancelEditing() {
+	m.config.editState = editState{}
+}
+
+// IsEditing returns whether the selected item is being edited inline
+func (m *Model[T]) IsEditing() bool {
+	return m.config.editState.editing
+}
+
+// StartBlockSelection starts selecting a rectangle of text, anchored at the selected item and the left edge of the
+// view, e.g. to grab a column out of aligned output. Moving the selection extends the block over items, and the Left
+// and Right keys move its edge by one display column. Enter confirms it, sending a BlockSelectedMsg, and esc cancels
+// it. Returns false if selection is disabled or there's no content.
+func (m *Model[T]) StartBlockSelection() bool {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() || m.config.editState.editing ||
+		m.config.visualSelection.active {
+		return false
+	}
+	col := m.GetXOffsetWidth()
+	m.config.blockSelection = blockSelection{
+		active:        true,
+		anchorItemIdx: m.content.getSelectedIdx(),
+		anchorCol:     col,
+		cursorCol:     col,
+	}
+	return true
+}
+
+// SetBlockSelection selects the rectangle spanning display columns startCol to endCol, inclusive, of the items from
+// anchorItemIdx to the selected item. Returns false if block selection can't be started.
+func (m *Model[T]) SetBlockSelection(anchorItemIdx, startCol, endCol int) bool {
+	if !m.StartBlockSelection() {
+		return false
+	}
+	m.config.blockSelection.anchorItemIdx = clampValZeroToMax(anchorItemIdx, m.content.numItems()-1)
+	m.config.blockSelection.anchorCol = max(0, startCol)
+	m.config.blockSelection.cursorCol = max(0, endCol)
+	return true
+}
+
+// CancelBlockSelection stops block selection without sending a BlockSelectedMsg
+func (m *Model[T]) CancelBlockSelection() {
+	m.config.blockSelection = blockSelection{}
+}
+
+// IsBlockSelecting returns whether a block is being selected
+func (m *Model[T]) IsBlockSelecting() bool {
+	return m.config.blockSelection.active
+}
+
+// GetBlockSelection returns the unstyled text in the block's display columns of each line it spans, nil if no block
+// is being selected
+func (m *Model[T]) GetBlockSelection() []string {
+	if !m.config.blockSelection.active || m.content.isEmpty() {
+		return nil
+	}
+	return m.extractBlock(m.blockBounds())
+}
+
+// StartVisualSelection starts selecting text character by character, like vim's visual mode, anchored at the selected
+// item and the left edge of the view. Moving the selection extends it over items, and the Left and Right keys move
+// its end by one display column. Enter confirms it, sending a VisualSelectedMsg, the copy key copies it and esc
+// cancels it. Returns false if selection is disabled or there's no content.
+func (m *Model[T]) StartVisualSelection() bool {
+	if !m.navigation.selectionEnabled || m.content.isEmpty() || m.config.editState.editing ||
+		m.config.blockSelection.active {
+		return false
+	}
+	col := m.GetXOffsetWidth()
+	m.config.visualSelection = visualSelection{
+		active:        true,
+		anchorItemIdx: m.content.getSelectedIdx(),
+		anchorCol:     col,
+		cursorCol:     col,
+	}
+	return true
+}
+
+// SetVisualSelection selects the text from display column anchorCol of the item at anchorItemIdx to display column
+// cursorCol, inclusive, of the selected item. Returns false if visual selection can't be started.
+func (m *Model[T]) SetVisualSelection(anchorItemIdx, anchorCol, cursorCol int) bool {
+	if !m.StartVisualSelection() {
+		return false
+	}
+	m.config.visualSelection.anchorItemIdx = clampValZeroToMax(anchorItemIdx, m.content.numItems()-1)
+	m.config.visualSelection.anchorCol = max(0, anchorCol)
+	m.config.visualSelection.cursorCol = max(0, cursorCol)
+	return true
+}
+
+// CancelVisualSelection stops visual selection without sending a VisualSelectedMsg
+func (m *Model[T]) CancelVisualSelection() {
+	m.config.visualSelection = visualSelection{}
+}
 
-	// when padding can't be satisfied on both sides, center the item
-	if verticalPad*2+1 > numContentLines {
-		desiredPadding := numContentLines / 2
-		if itemInBottomHalfOfViewport {
-			// leave desiredPadding lines below
-			targetTopItemIdx := max(0, itemIdx-numContentLines+1+desiredPadding)
-			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
-		} else {
-			// leave desiredPadding lines above
-			targetTopItemIdx := max(0, itemIdx-desiredPadding)
-			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
-		}
-		return
+// IsVisualSelecting returns whether text is being selected in visual selection mode
+func (m *Model[T]) IsVisualSelecting() bool {
+	return m.config.visualSelection.active
+}
+
+// GetVisualSelectionText returns the unstyled text of the visual selection, with a newline between items, "" if
+// nothing is being selected
+func (m *Model[T]) GetVisualSelectionText() string {
+	if !m.config.visualSelection.active || m.content.isEmpty() {
+		return ""
 	}
+	return m.extractVisualSelection()
+}
 
-	desiredPad := min(verticalPad, numContentLines-1)
+// Checkpoint snapshots the current objects so that later content can be compared against them, like watch -d.
+// Objects are matched across refreshes by identity, a key from identity or their unstyled content if identity is
+// nil. Items added since the checkpoint are styled with AddedItemStyle and those with the identity of a checkpoint
+// object but different content with ChangedItemStyle. See GetDiffStatus, GetRemovedSinceCheckpoint and
+// SetShowChangesOnly.
+func (m *Model[T]) Checkpoint(identity IdentityFunc[T]) {
+	objects := m.content.unfilteredObjects()
+	c := &checkpoint[T]{
+		identity:     identity,
+		objects:      objects,
+		contentByKey: make(map[string]string, len(objects)),
+	}
+	for _, obj := range objects {
+		c.contentByKey[c.key(obj)] = obj.GetItem().ContentNoAnsi()
+	}
+	changesOnly := m.showingChangesOnly()
+	unprocessed := m.content.unprocessedObjects()
+	m.content.checkpoint = c
+	if changesOnly {
+		// everything is unchanged relative to the new checkpoint
+		c.changesOnly = true
+		m.setObjects(unprocessed)
+	}
+}
 
-	if visiblePosition >= 0 {
-		// item is visible, check if padding is respected
-		linesAbove := visiblePosition
-		linesBelow := len(itemIndexes) - visiblePosition - 1
+// ClearCheckpoint removes the checkpoint, showing all items without change styles
+func (m *Model[T]) ClearCheckpoint() {
+	m.SetShowChangesOnly(false)
+	m.content.checkpoint = nil
+}
 
-		if linesAbove >= desiredPad && linesBelow >= desiredPad {
-			return
-		}
+// GetDiffStatus returns how the item at itemIdx differs from the checkpoint, DiffUnchanged if there's none
+func (m *Model[T]) GetDiffStatus(itemIdx int) DiffStatus {
+	return m.diffStatus(itemIdx)
+}
 
-		if itemInBottomHalfOfViewport {
-			targetTopItemIdx := max(0, itemIdx-numContentLines+1+desiredPad)
-			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
-		} else {
-			targetTopItemIdx := max(0, itemIdx-desiredPad)
-			m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+// GetRemovedSinceCheckpoint returns the checkpoint objects whose identity no current object has, in checkpoint order
+func (m *Model[T]) GetRemovedSinceCheckpoint() []T {
+	c := m.content.checkpoint
+	if c == nil {
+		return nil
+	}
+	current := make(map[string]struct{})
+	for _, obj := range m.content.unfilteredObjects() {
+		current[c.key(obj)] = struct{}{}
+	}
+	var removed []T
+	for _, obj := range c.objects {
+		if _, ok := current[c.key(obj)]; !ok {
+			removed = append(removed, obj)
 		}
-		return
 	}
+	return removed
+}
 
-	// not visible, position based on item position
-	if itemInBottomHalfOfViewport {
-		// leave desiredPad lines below
-		targetTopItemIdx := max(0, itemIdx-numContentLines+1+desiredPad)
-		m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
-	} else {
-		// leave desiredPad lines above
-		targetTopItemIdx := max(0, itemIdx-desiredPad)
-		m.safelySetTopItemIdxAndOffset(targetTopItemIdx, 0)
+// SetShowChangesOnly sets whether to hide items unchanged since the checkpoint. Does nothing without a checkpoint.
+func (m *Model[T]) SetShowChangesOnly(changesOnly bool) {
+	c := m.content.checkpoint
+	if c == nil || c.changesOnly == changesOnly {
+		return
 	}
+	objects := m.content.unprocessedObjects()
+	c.changesOnly = changesOnly
+	c.unfiltered = nil
+	m.setObjects(objects)
 }
 
-// ensureUnwrappedPortionHorizontallyInView pans horizontally to bring portion into view
-func (m *Model[T]) ensureUnwrappedPortionHorizontallyInView(startWidth, endWidth, horizontalPad int) {
-	if m.config.wrapText {
-		panic("ensureUnwrappedPortionHorizontallyInView called when wrapText is true")
-	}
-	viewportWidth := m.contentWidth()
-	currentXOffset := m.display.xOffset
+// GetShowChangesOnly returns whether items unchanged since the checkpoint are hidden
+func (m *Model[T]) GetShowChangesOnly() bool {
+	return m.showingChangesOnly()
+}
 
-	visibleStartWidth := currentXOffset + 1
-	visibleEndWidth := currentXOffset + viewportWidth
+// showingChangesOnly returns whether items unchanged since the checkpoint are hidden
+func (m *Model[T]) showingChangesOnly() bool {
+	return m.content.checkpoint != nil && m.content.checkpoint.changesOnly
+}
 
-	portionStartInView := startWidth >= visibleStartWidth && startWidth <= visibleEndWidth
-	portionEndInView := endWidth >= visibleStartWidth && endWidth <= visibleEndWidth
+// SetSearch highlights every occurrence of query in the viewport content, focusing the first match at or after the
+// selection (or the top of the viewport when selection is disabled) and scrolling it into view. Unlike
+// filterableviewport, no items are hidden. The NextSearchMatch and PrevSearchMatch keys move between matches.
+// Matches are kept up to date as content changes. Pass "" to clear the search.
+func (m *Model[T]) SetSearch(query string) {
+	m.content.search = newSearchState()
+	if query == "" {
+		return
+	}
+	m.content.search.query = query
+	m.findSearchMatches(0)
+	if len(m.content.search.matches) == 0 {
+		return
+	}
+	fromIdx := m.display.topItemIdx
+	if m.navigation.selectionEnabled {
+		fromIdx = m.content.getSelectedIdx()
+	}
+	m.focusSearchMatch(m.firstSearchMatchFrom(fromIdx))
+}
 
-	portionWidth := endWidth - startWidth
-	panningRight := startWidth > visibleStartWidth
+// GetSearch returns the current search query, "" if none
+func (m *Model[T]) GetSearch() string {
+	return m.content.search.query
+}
 
-	// portion wider than viewport: align left edge with padding
-	if portionWidth > viewportWidth {
-		desiredColumnsLeft := min(horizontalPad, viewportWidth-1)
-		targetXOffset := max(0, startWidth-desiredColumnsLeft)
-		m.SetXOffset(targetXOffset)
+// NextMatch focuses the next search match, wrapping around to the first
+func (m *Model[T]) NextMatch() {
+	s := &m.content.search
+	if len(s.matches) == 0 {
 		return
 	}
+	m.focusSearchMatch((s.focusedIdx + 1) % len(s.matches))
+}
 
-	// when padding can't be satisfied on both sides, center the portion
-	if horizontalPad*2+portionWidth > viewportWidth {
-		desiredColumnsLeft := (viewportWidth - portionWidth) / 2
-		targetXOffset := max(0, startWidth-desiredColumnsLeft)
-		m.SetXOffset(targetXOffset)
+// PrevMatch focuses the previous search match, wrapping around to the last
+func (m *Model[T]) PrevMatch() {
+	s := &m.content.search
+	if len(s.matches) == 0 {
 		return
 	}
+	m.focusSearchMatch((s.focusedIdx - 1 + len(s.matches)) % len(s.matches))
+}
 
-	desiredPad := min(horizontalPad, viewportWidth-portionWidth)
-
-	if portionStartInView && portionEndInView {
-		// already fully visible, check if padding is respected
-		columnsLeft := startWidth - currentXOffset
-		columnsRight := currentXOffset + viewportWidth - endWidth
+// GetSearchMatchCount returns the number of search matches
+func (m *Model[T]) GetSearchMatchCount() int {
+	return len(m.content.search.matches)
+}
 
-		if columnsLeft >= desiredPad && columnsRight >= desiredPad {
-			return
-		}
+// GetFocusedSearchMatchIdx returns the index of the focused search match, -1 if none
+func (m *Model[T]) GetFocusedSearchMatchIdx() int {
+	return m.content.search.focusedIdx
+}
 
-		// adjust position based on panning direction
-		if panningRight {
-			targetXOffset := max(0, endWidth+desiredPad-viewportWidth)
-			m.SetXOffset(targetXOffset)
-		} else {
-			targetXOffset := max(0, startWidth-desiredPad)
-			m.SetXOffset(targetXOffset)
-		}
+// SetLinks registers links, actionable regions such as URLs or inline buttons, in the item at itemIdx, replacing any
+// it had. Links are styled with LinkStyle. The NextLink and PrevLink keys cycle focus among the links in view, and
+// the ActivateLink key sends a LinkActivatedMsg with the ID of the focused link. Like highlights, links are kept by
+// item index until changed, and shifted when objects are prepended. Pass nil to remove the item's links. OSC 8
+// hyperlinks in items are links too, without being set, see SetLinkHandler.
+func (m *Model[T]) SetLinks(itemIdx int, links []Link) {
+	if itemIdx < 0 {
 		return
 	}
-
-	// not visible, position based on panning direction
-	if panningRight {
-		// panning right: leave desiredPad columns to the right
-		targetXOffset := max(0, endWidth+desiredPad-viewportWidth)
-		m.SetXOffset(targetXOffset)
+	l := &m.content.links
+	if len(links) == 0 {
+		delete(l.byItem, itemIdx)
 	} else {
-		// panning left: leave desiredPad columns to the left
-		targetXOffset := max(0, startWidth-desiredPad)
-		m.SetXOffset(targetXOffset)
+		l.byItem[itemIdx] = sortedLinks(links)
+	}
+	if l.focused != nil && l.focused.itemIdx == itemIdx {
+		l.focused = nil
 	}
 }
 
-// SetXOffset sets the horizontal offset, in terminal cell width, for panning when text wrapping is disabled
-func (m *Model[T]) SetXOffset(widthOffset int) {
-	if m.config.wrapText {
-		return
-	}
-	maxXOffset := m.maxItemWidth() - m.contentWidth()
-	m.display.xOffset = max(0, min(maxXOffset, widthOffset))
+// GetLinks returns the links registered in the item at itemIdx
+func (m *Model[T]) GetLinks(itemIdx int) []Link {
+	return m.content.links.byItem[itemIdx]
 }
 
-// GetXOffsetWidth returns the horizontal offset, in terminal cell width, for panning when text wrapping is disabled
-func (m *Model[T]) GetXOffsetWidth() int {
-	if m.config.wrapText {
-		return 0
+// GetHyperlinks returns the OSC 8 hyperlinks in the item at itemIdx as links, their IDs and URLs being their targets
+func (m *Model[T]) GetHyperlinks(itemIdx int) []Link {
+	var links []Link
+	for _, link := range m.itemLinks(itemIdx) {
+		if link.URL != "" {
+			links = append(links, link)
+		}
 	}
-	return m.display.xOffset
+	return links
 }
 
-// SetHighlights sets specific positions to highlight with custom styles in the viewport.
-func (m *Model[T]) SetHighlights(highlights []Highlight) {
-	m.content.setHighlights(highlights)
+// SetLinkHandler sets the function activating OSC 8 hyperlinks in items, e.g. returning a command that opens the URL
+// in a browser. Hyperlinks are focused and activated like links set with SetLinks, and also with the OpenLink key,
+// which activates the focused link or else the first in the selected item, and with mouse support, by clicking them.
+// Without a handler, activating a hyperlink sends a LinkActivatedMsg with its URL.
+func (m *Model[T]) SetLinkHandler(handler func(url string) tea.Cmd) {
+	m.config.linkHandler = handler
 }
 
-// GetHighlights returns all highlights.
-func (m *Model[T]) GetHighlights() []Highlight {
-	return m.content.getHighlights()
+// ClearLinks removes all links
+func (m *Model[T]) ClearLinks() {
+	m.content.links = newLinkState()
+}
+
+// NextLink focuses the next link in view, wrapping around to the first
+func (m *Model[T]) NextLink() {
+	m.cycleLink(1)
+}
+
+// PrevLink focuses the previous link in view, wrapping around to the last
+func (m *Model[T]) PrevLink() {
+	m.cycleLink(-1)
+}
+
+// GetFocusedLink returns the focused link and its item index, ok false if none is focused
+func (m *Model[T]) GetFocusedLink() (itemIdx int, link Link, ok bool) {
+	ref, link, ok := m.focusedLink()
+	return ref.itemIdx, link, ok
+}
+
+// ActivateLink returns a command sending a LinkActivatedMsg for the focused link, nil if none is focused
+func (m *Model[T]) ActivateLink() tea.Cmd {
+	return m.activateLinkCmd()
 }
 
 func (m *Model[T]) maxItemWidth() int {
@@ -1167,6 +3649,13 @@ func (m *Model[T]) maxItemWidth() int {
 		panic("maxItemWidth should not be called when wrapping is enabled")
 	}
 
+	if m.panSelectedOnly() {
+		if m.content.isEmpty() {
+			return 0
+		}
+		return maxSegmentWidth(m.content.itemAt(m.content.getSelectedIdx()))
+	}
+
 	maxLineWidth := 0
 
 	headerLines := m.getVisibleHeaderLines()
@@ -1178,7 +3667,6 @@ func (m *Model[T]) maxItemWidth() int {
 
 	// check content line widths without fully rendering all of them
 	if !m.content.isEmpty() {
-		items := m.content.objects
 		startIdx := clampValZeroToMax(m.display.topItemIdx, m.content.numItems()-1)
 		numItemsToCheck := min(m.content.numItems()-startIdx, m.display.bounds.height)
 
@@ -1187,8 +3675,7 @@ func (m *Model[T]) maxItemWidth() int {
 			if itemIdx >= m.content.numItems() {
 				break
 			}
-			currItem := items[itemIdx].GetItem()
-			if w := currItem.Width(); w > maxLineWidth {
+			if w := maxSegmentWidth(m.content.itemAt(itemIdx)); w > maxLineWidth {
 				maxLineWidth = w
 			}
 		}
@@ -1198,8 +3685,12 @@ func (m *Model[T]) maxItemWidth() int {
 }
 
 func (m *Model[T]) numLinesForItem(itemIdx int) int {
-	if !m.config.wrapText {
-		return 1
+	if !m.wrapsItem(itemIdx) {
+		if itemIdx < 0 || itemIdx >= m.content.numItems() {
+			return 1
+		}
+		// unwrapped, each line-broken segment is one line
+		return item.NumLineBrokenItems(m.content.itemAt(itemIdx))
 	}
 	cw := m.contentWidth()
 	if cw == 0 {
@@ -1208,20 +3699,20 @@ func (m *Model[T]) numLinesForItem(itemIdx int) int {
 	if m.content.isEmpty() || itemIdx < 0 || itemIdx >= m.content.numItems() {
 		return 0
 	}
-	items := m.content.objects
-	return items[itemIdx].GetItem().NumWrappedLines(cw)
+	return m.content.wrapCache.numRows(itemIdx, m.content.itemAt(itemIdx), cw, m.wrapLayout())
 }
 
 // contentWidth returns the width available for rendering content items.
 // When selection is enabled and a SelectionPrefix is configured, the prefix
-// reduces the available content width. Headers, footers, and other chrome
-// use the full bounds.width instead.
+// reduces the available content width, as do the gutter of the minimap and scrollbar, the diff markers and the line
+// numbers.
+// Headers, footers, and other chrome use the full bounds.width instead.
 func (m *Model[T]) contentWidth() int {
+	width := m.display.bounds.width - m.numGutterCols() - m.numDiffMarkerCols() - m.numLineNumberCols()
 	if m.navigation.selectionEnabled && m.display.styles.SelectionPrefix != "" {
-		pw := lipgloss.Width(m.display.styles.SelectionPrefix)
-		return max(0, m.display.bounds.width-pw)
+		width -= lipgloss.Width(m.display.styles.SelectionPrefix)
 	}
-	return m.display.bounds.width
+	return max(0, width)
 }
 
 // selectionPrefixPadding returns whitespace the same width as SelectionPrefix.
@@ -1237,6 +3728,8 @@ func (m *Model[T]) setWidthHeight(width, height int) {
 		return
 	}
 	m.display.setBounds(rectangle{width: width, height: height})
+	m.reprocess()
+	m.setEditInputWidth()
 	m.safelySetTopItemIdxAndOffset(m.display.topItemIdx, m.display.topItemLineOffset)
 	if m.navigation.selectionEnabled {
 		m.scrollSoSelectionInView()
@@ -1261,32 +3754,45 @@ func (m *Model[T]) safelySetTopItemIdxAndOffset(topItemIdx, topItemLineOffset in
 
 // getNumContentLines returns the number of lines of between the header and footer/pre-footer
 func (m *Model[T]) getNumContentLines() int {
-	return m.display.getNumContentLines(len(m.getVisibleHeaderLines()), m.config.postHeaderLine != "", m.config.preFooterLine != "", true)
+	return m.display.getNumContentLines(len(m.getVisibleHeaderLines()), m.showsPostHeaderLine(), m.showsPreFooterLine(), !m.config.contentOnly)
 }
 
 func (m *Model[T]) scrollSoSelectionInView() {
 	if !m.navigation.selectionEnabled {
 		panic("scrollSoSelectionInView called when selection is not enabled")
 	}
-	selectedItem := m.content.getSelectedItem()
-	if selectedItem == nil {
+	if m.content.getSelectedItem() == nil {
+		return
+	}
+	if m.subLineActive() {
+		// only the cursor's line needs to be in view
+		lineOffset := m.selectedLineOffset()
+		m.ensureLinesInView(m.content.selectedIdx, lineOffset, lineOffset, 0)
+		return
+	}
+	selectedItem := m.content.itemAt(m.content.selectedIdx)
+	if numLines := m.numLinesForItem(m.content.selectedIdx); !m.config.wrapText && numLines > 1 {
+		// bring all lines of a multi-line item into view, maintaining xOffset
+		prevXOffset := m.display.xOffset
+		m.ensureLinesInView(m.content.selectedIdx, 0, numLines-1, 0)
+		m.SetXOffset(prevXOffset)
 		return
 	}
-	selectedItemWidth := (*selectedItem).GetItem().Width()
+	selectedItemWidth := selectedItem.Width()
 	startWidth := 0
 	endWidth := selectedItemWidth
 	if !m.config.wrapText && m.display.xOffset > 0 {
 		if selectedItemWidth < m.display.xOffset {
 			// ensure the selection is visible by scrolling, but maintain xOffset if possible
 			prevXOffset := m.display.xOffset
-			m.EnsureItemInView(m.content.selectedIdx, 0, 0, 0, 0)
+			m.ensureItemInView(m.content.selectedIdx, 0, 0, 0, 0)
 			m.SetXOffset(prevXOffset)
 			return
 		}
 		startWidth = m.display.xOffset
 		endWidth = m.display.xOffset + m.contentWidth() - 1
 	}
-	m.EnsureItemInView(m.content.selectedIdx, startWidth, endWidth, 0, 0)
+	m.ensureItemInView(m.content.selectedIdx, startWidth, endWidth, 0, 0)
 }
 
 // getItemIdxAbove consumes n lines by moving up through items, returning the final item index and line offset
@@ -1345,29 +3851,24 @@ func (m *Model[T]) scrollDownLines(numLinesDown int) {
 	}
 
 	newTopItemIdx, newTopItemLineOffset := m.display.topItemIdx, m.display.topItemLineOffset
-	if !m.config.wrapText {
-		newTopItemIdx = m.display.topItemIdx + numLinesDown
-	} else {
-		// wrapped
-		if numLinesDown < 0 { // scrolling up
-			if newTopItemLineOffset >= -numLinesDown {
-				// same item, just change offset
-				newTopItemLineOffset += numLinesDown
-			} else {
-				// need to scroll up through multiple items
-				linesToConsume := -numLinesDown - newTopItemLineOffset
-				newTopItemIdx, newTopItemLineOffset = m.getItemIdxAbove(newTopItemIdx, newTopItemLineOffset, linesToConsume)
-			}
-		} else { // scrolling down
-			numLinesInTopItem := m.numLinesForItem(newTopItemIdx)
-			if newTopItemLineOffset+numLinesDown < numLinesInTopItem {
-				// same item, just change offset
-				newTopItemLineOffset += numLinesDown
-			} else {
-				// need to scroll down through multiple items
-				linesToConsume := numLinesDown - (numLinesInTopItem - (newTopItemLineOffset + 1))
-				newTopItemIdx, newTopItemLineOffset = m.getItemIdxBelow(newTopItemIdx, linesToConsume)
-			}
+	if numLinesDown < 0 { // scrolling up
+		if newTopItemLineOffset >= -numLinesDown {
+			// same item, just change offset
+			newTopItemLineOffset += numLinesDown
+		} else {
+			// need to scroll up through multiple items
+			linesToConsume := -numLinesDown - newTopItemLineOffset
+			newTopItemIdx, newTopItemLineOffset = m.getItemIdxAbove(newTopItemIdx, newTopItemLineOffset, linesToConsume)
+		}
+	} else { // scrolling down
+		numLinesInTopItem := m.numLinesForItem(newTopItemIdx)
+		if newTopItemLineOffset+numLinesDown < numLinesInTopItem {
+			// same item, just change offset
+			newTopItemLineOffset += numLinesDown
+		} else {
+			// need to scroll down through multiple items
+			linesToConsume := numLinesDown - (numLinesInTopItem - (newTopItemLineOffset + 1))
+			newTopItemIdx, newTopItemLineOffset = m.getItemIdxBelow(newTopItemIdx, linesToConsume)
 		}
 	}
 	m.safelySetTopItemIdxAndOffset(newTopItemIdx, newTopItemLineOffset)
@@ -1377,7 +3878,7 @@ func (m *Model[T]) scrollDownLines(numLinesDown int) {
 // getVisibleHeaderLines returns the lines of header that are visible in the viewport as strings.
 // header lines will take precedence over content and footer if there is not enough vertical height
 func (m *Model[T]) getVisibleHeaderLines() []string {
-	if m.display.bounds.height == 0 {
+	if m.display.bounds.height == 0 || m.config.contentOnly {
 		return nil
 	}
 
@@ -1391,8 +3892,13 @@ func (m *Model[T]) getVisibleHeaderLines() []string {
 		0,
 		m.display.bounds.height,
 		len(headerItems),
-		func(idx int) item.Item { return headerItems[idx] },
-		m.display.bounds.width, // headers use full viewport width
+		func(idx int) int {
+			if !m.config.wrapText {
+				return 1
+			}
+			// headers use full viewport width and always wrap at exact width
+			return headerItems[idx].NumWrappedLines(m.display.bounds.width)
+		},
 	)
 
 	headerLines := make([]string, len(itemIndexes))
@@ -1406,7 +3912,7 @@ func (m *Model[T]) getVisibleHeaderLines() []string {
 				currentItemIdxWidthToLeft,
 				m.display.bounds.width,
 				"",
-				[]item.Highlight{}, // no highlights for header
+				m.headerHighlightsForLine(itemIdx, headerItems[itemIdx]),
 			)
 			if idx+1 < len(itemIndexes) {
 				nextItemIdx := itemIndexes[idx+1]
@@ -1422,7 +3928,7 @@ func (m *Model[T]) getVisibleHeaderLines() []string {
 				0, // header doesn't pan horizontally
 				m.display.bounds.width,
 				m.config.continuationIndicator,
-				[]item.Highlight{}, // no highlights for header
+				m.headerHighlightsForLine(itemIdx, headerItems[itemIdx]),
 			)
 		}
 		headerLines[idx] = truncated
@@ -1438,7 +3944,7 @@ func (m *Model[T]) getVisibleContentItemIndexes() []int {
 	}
 
 	linesUsedByHeader := len(m.getVisibleHeaderLines())
-	if m.config.postHeaderLine != "" {
+	if m.showsPostHeaderLine() {
 		linesUsedByHeader++ // post-header
 	}
 	numLinesAfterHeader := max(0, m.display.bounds.height-linesUsedByHeader)
@@ -1448,20 +3954,17 @@ func (m *Model[T]) getVisibleContentItemIndexes() []int {
 		m.display.topItemLineOffset,
 		numLinesAfterHeader,
 		m.content.numItems(),
-		func(idx int) item.Item {
-			return m.content.objects[idx].GetItem()
-		},
-		m.contentWidth(), // content uses narrower width when selection prefix is configured
+		m.numLinesForItem, // content uses narrower width when selection prefix is configured
 	)
 	if len(itemIndexes) == 0 {
 		return nil
 	}
 
 	reservedLines := 0
-	if m.config.footerEnabled {
+	if m.config.footerEnabled && !m.config.contentOnly {
 		reservedLines++ // footer
 	}
-	if m.config.preFooterLine != "" {
+	if m.showsPreFooterLine() {
 		reservedLines++ // pre-footer
 	}
 	if reservedLines > 0 {
@@ -1471,14 +3974,13 @@ func (m *Model[T]) getVisibleContentItemIndexes() []int {
 }
 
 // getItemIndexesSpanningLines returns the item indexes for each line given a top item index, offset and num lines.
-// wrapWidth is the width used for wrapping calculations (content width for content, bounds width for headers).
+// numWrappedLines returns the number of lines an item spans when wrapped, and is only called when wrapping.
 func (m *Model[T]) getItemIndexesSpanningLines(
 	topItemIdx int,
 	topItemLineOffset int,
 	totalNumLines int,
 	numItems int,
-	getItem func(int) item.Item,
-	wrapWidth int,
+	numWrappedLines func(int) int,
 ) []int {
 	if numItems == 0 || totalNumLines == 0 {
 		return nil
@@ -1493,47 +3995,33 @@ func (m *Model[T]) getItemIndexesSpanningLines(
 
 	currItemIdx := clampValZeroToMax(topItemIdx, numItems-1)
 
-	currItem := getItem(currItemIdx)
 	done := totalNumLines == 0
 	if done {
 		return itemIndexes
 	}
 
-	if m.config.wrapText {
-		// first item has potentially fewer lines depending on the line offset
-		numLines := max(0, currItem.NumWrappedLines(wrapWidth)-topItemLineOffset)
-		for range numLines {
-			// adding untruncated, unstyled items
-			done = addLine(currItemIdx)
-			if done {
-				break
-			}
+	// first item has potentially fewer lines depending on the line offset
+	numLines := max(0, numWrappedLines(currItemIdx)-topItemLineOffset)
+	for range numLines {
+		// adding untruncated, unstyled items
+		done = addLine(currItemIdx)
+		if done {
+			break
 		}
+	}
 
-		for !done {
-			currItemIdx++
-			if currItemIdx >= numItems {
-				done = true
-			} else {
-				currItem = getItem(currItemIdx)
-				numLines = currItem.NumWrappedLines(wrapWidth)
-				for range numLines {
-					// adding untruncated, unstyled items
-					done = addLine(currItemIdx)
-					if done {
-						break
-					}
-				}
-			}
-		}
-	} else {
-		done = addLine(currItemIdx)
-		for !done {
-			currItemIdx++
-			if currItemIdx >= numItems {
-				done = true
-			} else {
+	for !done {
+		currItemIdx++
+		if currItemIdx >= numItems {
+			done = true
+		} else {
+			numLines = numWrappedLines(currItemIdx)
+			for range numLines {
+				// adding untruncated, unstyled items
 				done = addLine(currItemIdx)
+				if done {
+					break
+				}
 			}
 		}
 	}
@@ -1553,24 +4041,38 @@ func (m *Model[T]) getTruncatedFooterLine(visibleContentItemIndexes []int) strin
 		return ""
 	}
 
-	var footerString string
 	var percentScrolled int
 
 	// if selection is disabled, numerator should be item index of bottom visible line
 	if !m.navigation.selectionEnabled {
 		numerator = visibleContentItemIndexes[len(visibleContentItemIndexes)-1] + 1
-		if m.config.wrapText && numerator == denominator && !m.isScrolledToBottom() {
-			// if wrapped && bottom visible line is max item index, but actually not fully scrolled to bottom, show 99%
-			percentScrolled = 99
-			footerString = fmt.Sprintf("99%% (%d/%d)", numerator, denominator)
-		}
 	}
-
-	if footerString == "" {
+	if !m.navigation.selectionEnabled && numerator == denominator && !m.isScrolledToBottom() {
+		// if bottom visible line is max item index, but actually not fully scrolled to bottom, show 99%
+		percentScrolled = 99
+	} else {
 		percentScrolled = percent(numerator, denominator)
-		footerString = fmt.Sprintf("%d%% (%d/%d)", percentScrolled, numerator, denominator)
 	}
 
+	var line, totalLines int
+	if m.config.footerMetric == FooterMetricLines {
+		line, totalLines = m.footerLinePosition(len(visibleContentItemIndexes))
+		percentScrolled = percent(line, totalLines)
+	}
+
+	footerString := m.config.footerFormatter(FooterInfo{
+		Percent:         percentScrolled,
+		Current:         numerator,
+		Total:           denominator,
+		Line:            line,
+		TotalLines:      totalLines,
+		TreeTotal:       m.treeTotal(),
+		Following:       m.IsFollowing(),
+		Overscroll:      m.overscrollIndicator(),
+		SoftLimitNotice: m.softLimitNotice(),
+		Filter:          m.config.footerFilterInfo,
+	})
+
 	if m.config.progressBarEnabled {
 		barSpace := m.display.bounds.width - len(footerString) - 1
 		if barSpace >= 3 {
@@ -1579,8 +4081,15 @@ func (m *Model[T]) getTruncatedFooterLine(visibleContentItemIndexes []int) strin
 		}
 	}
 
-	footerItem := item.NewItem(footerString)
+	loading := ""
+	if m.config.loading.active {
+		loading = m.loadingText() + "  "
+	}
+	footerItem := item.NewItem(loading + footerString)
 	f, _ := footerItem.Take(0, m.display.bounds.width, m.config.continuationIndicator, []item.Highlight{})
+	if m.config.overscroll.showing {
+		return m.display.styles.OverscrollStyle.Render(f)
+	}
 	return m.display.styles.FooterStyle.Render(f)
 }
 
@@ -1635,20 +4144,18 @@ func (m *Model[T]) maxItemIdxAndMaxTopLineOffset() (int, int) {
 	}
 
 	headerLines := len(m.getVisibleHeaderLines())
-	if m.config.postHeaderLine != "" {
+	if m.showsPostHeaderLine() {
 		headerLines++ // post-header
 	}
 	reservedLines := 1 // footer
-	if m.config.preFooterLine != "" {
+	if m.config.contentOnly {
+		reservedLines = 0
+	}
+	if m.showsPreFooterLine() {
 		reservedLines++ // pre-footer
 	}
 	numContentLines := max(0, m.display.bounds.height-headerLines-reservedLines)
 
-	if !m.config.wrapText {
-		return max(0, numItems-numContentLines), 0
-	}
-
-	// wrapped
 	maxTopItemIdx, maxTopItemLineOffset := numItems-1, 0
 	numLinesLastItem := m.numLinesForItem(numItems - 1)
 	if numContentLines <= numLinesLastItem {
@@ -1662,21 +4169,58 @@ func (m *Model[T]) maxItemIdxAndMaxTopLineOffset() (int, int) {
 	return max(0, maxTopItemIdx), max(0, maxTopItemLineOffset)
 }
 
-// getHighlightsForItem returns highlights for the specific item index
+// getHighlightsForItem returns highlights for the specific item index, including search matches and links
 func (m *Model[T]) getHighlightsForItem(itemIndex int) []item.Highlight {
-	return m.content.getItemHighlightsForItem(itemIndex)
+	highlights := m.content.getItemHighlightsForItem(itemIndex)
+	searchHighlights := m.searchHighlightsForItem(itemIndex)
+	linkHighlights := m.linkHighlightsForItem(itemIndex)
+	blockHighlights := m.blockHighlightsForItem(itemIndex)
+	visualHighlights := m.visualHighlightsForItem(itemIndex)
+	if len(searchHighlights) == 0 && len(linkHighlights) == 0 && len(blockHighlights) == 0 &&
+		len(visualHighlights) == 0 {
+		return highlights
+	}
+	priorities := m.display.styles.HighlightPriorities
+	setPriority(linkHighlights, priorities.Link)
+	setPriority(searchHighlights, priorities.Search)
+	setPriority(blockHighlights, priorities.BlockSelection)
+	setPriority(visualHighlights, priorities.VisualSelection)
+	merged := make([]item.Highlight, 0,
+		len(highlights)+len(searchHighlights)+len(linkHighlights)+len(blockHighlights)+len(visualHighlights))
+	merged = append(append(append(merged, highlights...), linkHighlights...), searchHighlights...)
+	return append(append(merged, blockHighlights...), visualHighlights...)
 }
 
-func (m *Model[T]) getNumVisibleItems() int {
-	if !m.config.wrapText {
-		return m.getNumContentLines()
+// setPriority sets the priority of each of highlights
+func setPriority(highlights []item.Highlight, priority int) {
+	for i := range highlights {
+		highlights[i].Priority = priority
+	}
+}
+
+// highlightsAboveSelection returns the highlights that show over the selection style on the selected item
+func (m *Model[T]) highlightsAboveSelection(highlights []item.Highlight) []item.Highlight {
+	selectionPriority := m.display.styles.HighlightPriorities.Selection
+	var above []item.Highlight
+	for _, h := range highlights {
+		if h.Priority >= selectionPriority {
+			above = append(above, h)
+		}
 	}
+	return above
+}
+
+func (m *Model[T]) getNumVisibleItems() int {
 	itemIndexes := m.getVisibleContentItemIndexes()
 	// return distinct number of items
 	itemIndexSet := make(map[int]struct{})
 	for _, i := range itemIndexes {
 		itemIndexSet[i] = struct{}{}
 	}
+	if !m.config.wrapText {
+		// unwrapped, empty lines below the content count as one item each
+		return len(itemIndexSet) + max(0, m.getNumContentLines()-len(itemIndexes))
+	}
 	return len(itemIndexSet)
 }
 
@@ -1684,7 +4228,7 @@ func (m *Model[T]) getNumVisibleItems() int {
 // highlights with the selection style, so that the selection background covers
 // the entire item while match highlights remain visible on top.
 func (m *Model[T]) selectionHighlights(itemIdx int, matchHighlights []item.Highlight) []item.Highlight {
-	itemLen := len(m.content.objects[itemIdx].GetItem().ContentNoAnsi())
+	itemLen := len(m.content.itemAt(itemIdx).ContentNoAnsi())
 	if itemLen == 0 {
 		return matchHighlights
 	}
@@ -1706,33 +4250,33 @@ func (m *Model[T]) selectionHighlights(itemIdx int, matchHighlights []item.Highl
 	for _, h := range sorted {
 		if h.ByteRangeUnstyledContent.Start > pos {
 			result = append(result, item.Highlight{
-				Style:                    m.display.styles.SelectedItemStyle,
+				Style:                    m.selectedItemStyle(),
 				ByteRangeUnstyledContent: item.ByteRange{Start: pos, End: h.ByteRangeUnstyledContent.Start},
 			})
 		}
 		result = append(result, h)
-		pos = h.ByteRangeUnstyledContent.End
+		pos = max(pos, h.ByteRangeUnstyledContent.End)
 	}
 	if pos < itemLen {
 		result = append(result, item.Highlight{
-			Style:                    m.display.styles.SelectedItemStyle,
+			Style:                    m.selectedItemStyle(),
 			ByteRangeUnstyledContent: item.ByteRange{Start: pos, End: itemLen},
 		})
 	}
 	return result
 }
 
-// styleSelection applies the selection style to unstyled portions of the string,
-// preserving any existing ANSI styling. Used when selectionStyleOverridesItemStyle is false.
-func (m *Model[T]) styleSelection(selection string) string {
-	split := surroundingAnsiRegex.Split(selection, -1)
-	matches := surroundingAnsiRegex.FindAllString(selection, -1)
+// styleUnstyled applies style to unstyled portions of the string, preserving any existing ANSI styling. Used for the
+// selection when selectionStyleOverridesItemStyle is false, and for item styles.
+func styleUnstyled(s string, style lipgloss.Style) string {
+	split := surroundingAnsiRegex.Split(s, -1)
+	matches := surroundingAnsiRegex.FindAllString(s, -1)
 	var builder strings.Builder
-	builder.Grow(len(selection))
+	builder.Grow(len(s))
 
 	for i, section := range split {
 		if section != "" {
-			builder.WriteString(m.display.styles.SelectedItemStyle.Render(section))
+			builder.WriteString(style.Render(section))
 		}
 		if i < len(split)-1 && i < len(matches) {
 			builder.WriteString(matches[i])
@@ -1741,48 +4285,61 @@ func (m *Model[T]) styleSelection(selection string) string {
 	return builder.String()
 }
 
-// fileSavedMsg is returned when file saving completes.
-type fileSavedMsg struct {
-	filename string // full path to saved file
-	err      error  // error if save failed, nil on success
-}
+// wrapLayout is how wrapped lines break: where, and how far rows after the first are indented
+type wrapLayout struct {
+	mode item.WrapMode
 
-// clearSaveResultMsg is sent after some seconds to clear the save result display
-type clearSaveResultMsg struct{}
+	// indent is the width of prefix
+	indent int
 
-// saveToFile saves all viewport objects to a file with the given filename.
-func (m *Model[T]) saveToFile(filename string) tea.Cmd {
-	return func() tea.Msg {
-		// create directory if needed
-		if err := os.MkdirAll(m.config.saveDir, 0750); err != nil {
-			return fileSavedMsg{err: fmt.Errorf("failed to create directory %s: %w", m.config.saveDir, err)}
-		}
+	// prefix is rendered before rows after the first: the wrap indent followed by the wrap indicator
+	prefix string
 
-		fullPath := filepath.Join(m.config.saveDir, filename)
+	// wordChars defines the words that WrapWords avoids splitting
+	wordChars item.WordChars
+}
 
-		// collect content without ANSI codes
-		var content strings.Builder
-		for _, obj := range m.content.objects {
-			content.WriteString(obj.GetItem().ContentNoAnsi())
-			content.WriteString("\n")
-		}
+// wrapLayout returns the wrap layout at the current content width. The indent and indicator are capped to leave at
+// least one cell for content.
+func (m *Model[T]) wrapLayout() wrapLayout {
+	maxIndent := max(0, m.contentWidth()-1)
+	spaces := min(m.config.wrapIndent, maxIndent)
+	prefix := strings.Repeat(" ", spaces)
+	if m.config.wrapIndicator != "" && spaces < maxIndent {
+		indicator, _ := item.NewItem(m.config.wrapIndicator).Take(0, maxIndent-spaces, "", nil)
+		prefix += m.config.wrapIndicatorStyle.Render(indicator)
+	}
+	return wrapLayout{
+		mode:      m.config.wrapMode,
+		indent:    lipgloss.Width(prefix),
+		prefix:    prefix,
+		wordChars: m.config.wordChars,
+	}
+}
 
-		if err := os.WriteFile(fullPath, []byte(content.String()), 0600); err != nil {
-			return fileSavedMsg{err: fmt.Errorf("failed to write file: %w", err)}
-		}
+// byRowStarts returns whether rows can be narrower than the wrap width, so must be computed with rowStarts
+func (l wrapLayout) byRowStarts() bool {
+	return l.mode != item.WrapChars || l.indent > 0
+}
 
-		return fileSavedMsg{filename: fullPath, err: nil}
-	}
+// rowStarts returns the cell offsets at which each wrapped row of a single-line item begins
+func (l wrapLayout) rowStarts(itm item.Item, wrapWidth int) []int {
+	return item.WrapRowStartsIndented(itm, wrapWidth, l.indent, l.mode, l.wordChars)
+}
+
+// numRows returns the number of rows itm wraps to
+func (l wrapLayout) numRows(itm item.Item, wrapWidth int) int {
+	return item.NumWrappedRowsIndented(itm, wrapWidth, l.indent, l.mode, l.wordChars)
 }
 
 // decomposeLineOffset converts a line offset within an item into
 // (segmentIdx, wrapOffset) given the item's line-broken items.
 // segmentIdx is which line-broken item, wrapOffset is how many wrapped lines
 // into that segment. For single-line items: returns (0, lineOffset).
-func decomposeLineOffset(segments []item.Item, lineOffset, wrapWidth int) (segmentIdx, wrapOffset int) {
+func decomposeLineOffset(segments []item.Item, lineOffset, wrapWidth int, layout wrapLayout) (segmentIdx, wrapOffset int) {
 	remaining := lineOffset
 	for i, seg := range segments {
-		n := seg.NumWrappedLines(wrapWidth)
+		n := layout.numRows(seg, wrapWidth)
 		if remaining < n {
 			return i, remaining
 		}
@@ -1826,12 +4383,15 @@ func remapHighlightsForSegment(highlights []item.Highlight, segments []item.Item
 }
 
 // lineOffsetForCellPosition converts a cumulative cell position across
-// line-broken items into a line offset. For single-line items: cellPos / wrapWidth.
-func lineOffsetForCellPosition(segments []item.Item, cellPos, wrapWidth int) int {
-	if len(segments) <= 1 || wrapWidth <= 0 {
-		if wrapWidth <= 0 {
-			return 0
-		}
+// line-broken items into a line offset. For single-line items wrapped with item.WrapChars: cellPos / wrapWidth.
+func lineOffsetForCellPosition(segments []item.Item, cellPos, wrapWidth int, layout wrapLayout) int {
+	if wrapWidth <= 0 {
+		return 0
+	}
+	if layout.byRowStarts() {
+		return lineOffsetForCellPositionFromRowStarts(segments, cellPos, wrapWidth, layout)
+	}
+	if len(segments) <= 1 {
 		return cellPos / wrapWidth
 	}
 	cumCells := 0
@@ -1839,9 +4399,7 @@ func lineOffsetForCellPosition(segments []item.Item, cellPos, wrapWidth int) int
 	for _, seg := range segments {
 		segWidth := seg.Width()
 		if cumCells+segWidth > cellPos {
-			if wrapWidth > 0 {
-				lineOffset += (cellPos - cumCells) / wrapWidth
-			}
+			lineOffset += (cellPos - cumCells) / wrapWidth
 			return lineOffset
 		}
 		cumCells += segWidth
@@ -1850,6 +4408,60 @@ func lineOffsetForCellPosition(segments []item.Item, cellPos, wrapWidth int) int
 	return max(0, lineOffset-1)
 }
 
+// lineOffsetForCellPositionFromRowStarts is lineOffsetForCellPosition for wrap layouts where
+// rows may be narrower than the wrap width.
+func lineOffsetForCellPositionFromRowStarts(segments []item.Item, cellPos, wrapWidth int, layout wrapLayout) int {
+	cumCells := 0
+	lineOffset := 0
+	for _, seg := range segments {
+		rowStarts := layout.rowStarts(seg, wrapWidth)
+		segWidth := seg.Width()
+		if cumCells+segWidth > cellPos {
+			return lineOffset + rowIdxForCell(rowStarts, cellPos-cumCells)
+		}
+		cumCells += segWidth
+		lineOffset += len(rowStarts)
+	}
+	return max(0, lineOffset-1)
+}
+
+// segmentForCellPosition returns the index of the segment containing cellPos, a cell offset across all
+// segments, and the cell offset of cellPos within that segment
+func segmentForCellPosition(segments []item.Item, cellPos int) (segIdx, col int) {
+	for i, seg := range segments {
+		segWidth := seg.Width()
+		if cellPos < segWidth || i == len(segments)-1 {
+			return i, max(0, cellPos)
+		}
+		cellPos -= segWidth
+	}
+	return 0, 0
+}
+
+// maxSegmentWidth returns the width of the widest line-broken segment of itm
+func maxSegmentWidth(itm item.Item) int {
+	if item.NumLineBrokenItems(itm) == 1 {
+		return itm.Width()
+	}
+	maxWidth := 0
+	for _, seg := range itm.LineBrokenItems() {
+		maxWidth = max(maxWidth, seg.Width())
+	}
+	return maxWidth
+}
+
+// rowIdxForCell returns the index of the wrapped row containing cell, given the row start cell offsets
+func rowIdxForCell(rowStarts []int, cell int) int {
+	rowIdx := 0
+	for i, start := range rowStarts {
+		if start > cell {
+			break
+		}
+		rowIdx = i
+	}
+	return rowIdx
+}
+
 func percent(a, b int) int {
 	if b == 0 {
 		return 100
@@ -0,0 +1,136 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func toObjects(content []string) []object {
+	objects := make([]object, len(content))
+	for i := range content {
+		objects[i] = object{item: item.NewItem(content[i])}
+	}
+	return objects
+}
+
+func TestAppendObjects_KeepsSelection(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	vp.AppendObjects(toObjects([]string{"c", "d"}))
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"c",
+		"25% (1/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestAppendObjects_StickyBottomSelection(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithStickyBottom[object](true))
+	setContent(vp, []string{"a", "b"})
+	vp.SetSelectedItemIdx(1)
+
+	vp.AppendObjects(toObjects([]string{"c", "d"}))
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		"c",
+		selectionStyle.Render("d"),
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestAppendObjects_StickyBottomNoSelection(t *testing.T) {
+	w, h := 15, 3
+	vp := newViewport(w, h, WithStickyBottom[object](true), WithWrapText[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	vp.AppendObjects(toObjects([]string{"c", "a long line that wraps"}))
+	expectedView := internal.Pad(w, h, []string{
+		"a long line tha",
+		"t wraps",
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestAppendObjects_Empty(t *testing.T) {
+	w, h := 15, 3
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+
+	vp.AppendObjects(toObjects([]string{"a"}))
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestAppendObjects_LineJoiningExtendsLastGroup(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h, WithLineJoining[object](isIndented))
+	setContent(vp, []string{"parent", "  child"})
+
+	vp.AppendObjects(toObjects([]string{"  child 2", "next"}))
+	expectedView := internal.Pad(w, h, []string{
+		"parent (+2)",
+		"next",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if n := len(vp.GetGroupObjects(0)); n != 3 {
+		t.Errorf("expected 3 objects in first group, got %d", n)
+	}
+}
+
+func TestPrependObjects_KeepsViewAndShiftsHighlights(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"c", "d"})
+	vp.SetSelectedItemIdx(1)
+	vp.SetHighlights([]Highlight{{
+		ItemIndex: 1,
+		ItemHighlight: item.Highlight{
+			Style:                    internal.RedFg,
+			ByteRangeUnstyledContent: item.ByteRange{Start: 0, End: 1},
+		},
+	}})
+
+	vp.PrependObjects(toObjects([]string{"a", "b"}))
+	if vp.GetSelectedItemIdx() != 3 {
+		t.Errorf("expected selected item 3, got %d", vp.GetSelectedItemIdx())
+	}
+	if idx := vp.GetHighlights()[0].ItemIndex; idx != 3 {
+		t.Errorf("expected highlight on item 3, got %d", idx)
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		"c",
+		internal.RedFg.Render("d"),
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestPrependObjects_StickyTop(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithStickyTop[object](true))
+	setContent(vp, []string{"c", "d"})
+
+	vp.PrependObjects(toObjects([]string{"a", "b"}))
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"c",
+		"25% (1/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,120 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestBatchUpdate_DefersUntilEnd(t *testing.T) {
+	w, h := 20, 5
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	vp.BeginUpdate()
+	if !vp.IsUpdating() {
+		t.Fatal("expected a batch update to be open")
+	}
+	vp.SetHeader([]string{"header"})
+	vp.SetObjects(toObjects([]string{"1", "2", "3", "4", "5", "6"}))
+	vp.AppendObjects(toObjects([]string{"7"}))
+	vp.SetSelectedItemIdx(5)
+	vp.EnsureItemInView(6, 0, 0, 0, 0)
+	if vp.GetSelectedItemIdx() != 0 || vp.content.numItems() != 2 {
+		t.Errorf("expected the content from before the batch while it's open, got %d items with %d selected",
+			vp.content.numItems(), vp.GetSelectedItemIdx())
+	}
+
+	vp.EndUpdate()
+	if vp.IsUpdating() {
+		t.Fatal("expected the batch update to be closed")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"header",
+		"5",
+		selectionStyle.Render("6"),
+		"7",
+		"85% (6/7)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestBatchUpdate_Prepend(t *testing.T) {
+	w, h := 20, 6
+	vp := newViewport(w, h)
+	setContent(vp, []string{"a", "b"})
+
+	vp.BeginUpdate()
+	vp.PrependObjects(toObjects([]string{"x"}))
+	vp.PrependObjects(toObjects([]string{"y"}))
+	if n := vp.content.numItems(); n != 2 {
+		t.Errorf("expected the content from before the batch while it's open, got %d items", n)
+	}
+	vp.EndUpdate()
+	expectedView := internal.Pad(w, h, []string{
+		"y",
+		"x",
+		"a",
+		"b",
+		"",
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// objects set in the batch go between the prepended and appended ones
+	vp.BeginUpdate()
+	vp.SetObjects(toObjects([]string{"1", "2"}))
+	vp.PrependObjects(toObjects([]string{"0"}))
+	vp.AppendObjects(toObjects([]string{"3"}))
+	vp.EndUpdate()
+	expectedView = internal.Pad(w, h, []string{
+		"0",
+		"1",
+		"2",
+		"3",
+		"",
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestBatchUpdate_SingleRecomputation(t *testing.T) {
+	numRuns := 0
+	vp := newViewport(20, 5, WithPreprocessor[object](func(objects []object, _ int, _ bool) []object {
+		numRuns++
+		return objects
+	}))
+	numRuns = 0
+
+	vp.BeginUpdate()
+	vp.SetObjects(toObjects([]string{"a"}))
+	vp.SetObjects(toObjects([]string{"a", "b"}))
+	vp.AppendObjects(toObjects([]string{"c"}))
+	vp.EndUpdate()
+	if numRuns != 1 {
+		t.Errorf("expected the objects processed once, got %d", numRuns)
+	}
+	if n := vp.content.numItems(); n != 3 {
+		t.Errorf("expected 3 items, got %d", n)
+	}
+}
+
+func TestBatchUpdate_Nested(t *testing.T) {
+	vp := newViewport(20, 5)
+	setContent(vp, []string{"a"})
+
+	vp.BeginUpdate()
+	vp.BeginUpdate()
+	vp.AppendObjects(toObjects([]string{"b"}))
+	vp.EndUpdate()
+	if !vp.IsUpdating() || vp.content.numItems() != 1 {
+		t.Fatal("expected the inner EndUpdate to leave the batch open")
+	}
+	vp.EndUpdate()
+	if vp.IsUpdating() || vp.content.numItems() != 2 {
+		t.Errorf("expected the outer EndUpdate to apply the append, got %d items", vp.content.numItems())
+	}
+
+	// unmatched EndUpdate is a no-op
+	vp.EndUpdate()
+}
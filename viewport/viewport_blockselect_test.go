@@ -0,0 +1,103 @@
+package viewport
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var blockSelectKeyMsg = tea.KeyPressMsg{Code: 'v', Mod: tea.ModCtrl}
+
+func newBlockViewport(width, height int) *Model[object] {
+	keyMap := DefaultKeyMap()
+	keyMap.BlockSelect.SetEnabled(true)
+	vp := newViewport(width, height, WithSelectionEnabled[object](true), WithKeyMap[object](keyMap))
+	vp.SetStyles(Styles{
+		FooterStyle:         lipgloss.NewStyle(),
+		SelectedItemStyle:   selectionStyle,
+		BlockSelectionStyle: internal.RedFg,
+	})
+	return vp
+}
+
+func TestBlockSelect_KeyDisabledByDefault(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	vp, _ = vp.Update(blockSelectKeyMsg)
+	if vp.IsBlockSelecting() {
+		t.Error("expected the block select key disabled by default")
+	}
+}
+
+func TestBlockSelect_KeysSelectColumn(t *testing.T) {
+	w, h := 20, 4
+	vp := newBlockViewport(w, h)
+	setContent(vp, []string{
+		"a 10 x",
+		"b 200 y",
+		"c 3",
+	})
+
+	vp, _ = vp.Update(blockSelectKeyMsg)
+	if !vp.IsBlockSelecting() || !vp.IsCapturingInput() {
+		t.Fatal("expected block selection to capture input")
+	}
+	for range 2 {
+		vp, _ = vp.Update(rightKeyMsg)
+	}
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	if got, want := vp.GetBlockSelection(), []string{"a 1", "b 2", "c 3"}; !slices.Equal(got, want) {
+		t.Errorf("expected block %q, got %q", want, got)
+	}
+
+	vp.SetBlockSelection(0, 2, 4)
+	expectedView := internal.Pad(w, h, []string{
+		"a " + internal.RedFg.Render("10 ") + "x",
+		"b " + internal.RedFg.Render("200") + " y",
+		selectionStyle.Render("c ") + internal.RedFg.Render("3"),
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, cmd := vp.Update(enterKeyMsg)
+	if vp.IsBlockSelecting() {
+		t.Error("expected block selection to end on enter")
+	}
+	msg, ok := cmd().(BlockSelectedMsg)
+	if !ok {
+		t.Fatalf("expected BlockSelectedMsg, got %T", cmd())
+	}
+	if want := []string{"10 ", "200", "3"}; !slices.Equal(msg.Lines, want) {
+		t.Errorf("expected lines %q, got %q", want, msg.Lines)
+	}
+}
+
+func TestBlockSelect_DisplayColumns(t *testing.T) {
+	vp := newBlockViewport(20, 4)
+	setContent(vp, []string{"世界 ok", "ab cd"})
+	vp.SetSelectedItemIdx(1)
+
+	// columns 2 and 3 hold 界 in the first line
+	vp.SetBlockSelection(0, 2, 3)
+	if got, want := vp.GetBlockSelection(), []string{"界", " c"}; !slices.Equal(got, want) {
+		t.Errorf("expected block %q, got %q", want, got)
+	}
+
+	vp, cmd := vp.Update(escapeKeyMsg)
+	if vp.IsBlockSelecting() || cmd != nil || vp.GetBlockSelection() != nil {
+		t.Error("expected esc to cancel block selection without a command")
+	}
+}
+
+func TestBlockSelect_RequiresSelection(t *testing.T) {
+	vp := newViewport(20, 4)
+	setContent(vp, []string{"a"})
+	if vp.StartBlockSelection() {
+		t.Error("expected block selection to require selection")
+	}
+}
@@ -0,0 +1,108 @@
+package viewport
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+var (
+	toggleBookmarkKeyMsg = internal.MakeKeyMsg('m')
+	nextBookmarkKeyMsg   = internal.MakeKeyMsg('\'')
+	prevBookmarkKeyMsg   = internal.MakeKeyMsg('"')
+)
+
+func TestBookmarks_KeysDisabledByDefault(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(10))
+
+	vp, _ = vp.Update(toggleBookmarkKeyMsg)
+	if idxs := vp.GetBookmarkedItemIdxs(); len(idxs) != 0 {
+		t.Errorf("expected the bookmark keys disabled by default, got bookmarks %v", idxs)
+	}
+}
+
+func TestBookmarks_KeysToggleAndCycle(t *testing.T) {
+	keyMap := DefaultKeyMap()
+	keyMap.ToggleBookmark.SetEnabled(true)
+	keyMap.NextBookmark.SetEnabled(true)
+	keyMap.PrevBookmark.SetEnabled(true)
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true), WithKeyMap[object](keyMap))
+	setContent(vp, numberedContent(10))
+
+	vp, _ = vp.Update(toggleBookmarkKeyMsg)
+	vp.SetSelectedItemIdx(5)
+	vp, _ = vp.Update(toggleBookmarkKeyMsg)
+	vp.SetBookmarked(8, true)
+	if idxs := vp.GetBookmarkedItemIdxs(); !slices.Equal(idxs, []int{0, 5, 8}) {
+		t.Fatalf("expected bookmarks at 0, 5 and 8, got %v", idxs)
+	}
+
+	for _, expected := range []int{8, 0, 5} {
+		vp, _ = vp.Update(nextBookmarkKeyMsg)
+		if vp.GetSelectedItemIdx() != expected {
+			t.Errorf("expected item %d selected, got %d", expected, vp.GetSelectedItemIdx())
+		}
+	}
+	for _, expected := range []int{0, 8} {
+		vp, _ = vp.Update(prevBookmarkKeyMsg)
+		if vp.GetSelectedItemIdx() != expected {
+			t.Errorf("expected item %d selected, got %d", expected, vp.GetSelectedItemIdx())
+		}
+	}
+
+	// toggling again removes the bookmark
+	vp, _ = vp.Update(toggleBookmarkKeyMsg)
+	if vp.IsBookmarked(8) {
+		t.Error("expected the bookmark at 8 removed")
+	}
+}
+
+func TestBookmarks_WithoutSelectionRestoresScrollPosition(t *testing.T) {
+	vp := newViewport(10, 4)
+	setContent(vp, numberedContent(20))
+
+	vp.ScrollDown(7)
+	vp.ToggleBookmark()
+	vp.GoToTop()
+	if !vp.NextBookmark() {
+		t.Fatal("expected a bookmark")
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 7 {
+		t.Errorf("expected item 7 at the top, got %d", top)
+	}
+
+	vp.ClearBookmarks()
+	if vp.NextBookmark() || vp.PrevBookmark() {
+		t.Error("expected no bookmarks")
+	}
+}
+
+func TestBookmarks_KeptAcrossSetObjectsWithComparator(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c"})
+	vp.SetBookmarked(1, true)
+
+	// without a comparator, bookmarks are cleared
+	setContent(vp, []string{"x", "a", "b", "c"})
+	if len(vp.GetBookmarkedItemIdxs()) != 0 {
+		t.Errorf("expected no bookmarks, got %v", vp.GetBookmarkedItemIdxs())
+	}
+
+	vp.SetSelectionComparator(objectsEqual)
+	vp.SetBookmarked(2, true)
+	setContent(vp, []string{"y", "x", "a", "b", "c"})
+	if idxs := vp.GetBookmarkedItemIdxs(); !slices.Equal(idxs, []int{3}) {
+		t.Errorf("expected the bookmark to follow b to 3, got %v", idxs)
+	}
+	if items := vp.GetBookmarkedItems(); len(items) != 1 || items[0].item.Content() != "b" {
+		t.Errorf("expected b bookmarked, got %v", items)
+	}
+
+	vp.PrependObjects([]object{{item: item.NewItem("z")}})
+	if idxs := vp.GetBookmarkedItemIdxs(); !slices.Equal(idxs, []int{4}) {
+		t.Errorf("expected the bookmark shifted to 4, got %v", idxs)
+	}
+}
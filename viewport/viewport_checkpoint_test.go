@@ -0,0 +1,97 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func newCheckpointViewport(width, height int) *Model[object] {
+	vp := newViewport(width, height)
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		AddedItemStyle:    internal.GreenFg,
+		ChangedItemStyle:  internal.RedFg,
+	})
+	return vp
+}
+
+// processName identifies a line like "name cpu" by its name
+func processName(o object) string {
+	name, _, _ := strings.Cut(o.GetItem().ContentNoAnsi(), " ")
+	return name
+}
+
+func TestCheckpoint_StylesAddedAndChanged(t *testing.T) {
+	w, h := 20, 5
+	vp := newCheckpointViewport(w, h)
+	setContent(vp, []string{"a 1", "b 1", "c 1"})
+	vp.Checkpoint(processName)
+	setContent(vp, []string{"a 1", "b 2", "d 1"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"a 1",
+		internal.RedFg.Render("b 2"),
+		internal.GreenFg.Render("d 1"),
+		"",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	for idx, want := range []DiffStatus{DiffUnchanged, DiffChanged, DiffAdded} {
+		if got := vp.GetDiffStatus(idx); got != want {
+			t.Errorf("expected item %d status %d, got %d", idx, want, got)
+		}
+	}
+	removed := vp.GetRemovedSinceCheckpoint()
+	if len(removed) != 1 || removed[0].GetItem().ContentNoAnsi() != "c 1" {
+		t.Errorf("expected c removed, got %v", removed)
+	}
+
+	vp.ClearCheckpoint()
+	if vp.GetDiffStatus(1) != DiffUnchanged || vp.GetRemovedSinceCheckpoint() != nil {
+		t.Error("expected no diffs after ClearCheckpoint")
+	}
+}
+
+func TestCheckpoint_ContentIdentity(t *testing.T) {
+	vp := newCheckpointViewport(20, 4)
+	setContent(vp, []string{"a", "b"})
+	vp.Checkpoint(nil)
+	setContent(vp, []string{"b", "c"})
+
+	if vp.GetDiffStatus(0) != DiffUnchanged || vp.GetDiffStatus(1) != DiffAdded {
+		t.Errorf("expected b unchanged and c added, got %d and %d", vp.GetDiffStatus(0), vp.GetDiffStatus(1))
+	}
+}
+
+func TestCheckpoint_ShowChangesOnly(t *testing.T) {
+	w, h := 20, 4
+	vp := newCheckpointViewport(w, h)
+	vp.SetShowChangesOnly(true)
+	if vp.GetShowChangesOnly() {
+		t.Fatal("expected changes only to require a checkpoint")
+	}
+
+	setContent(vp, []string{"a 1", "b 1", "c 1"})
+	vp.Checkpoint(processName)
+	vp.SetShowChangesOnly(true)
+	setContent(vp, []string{"a 1", "b 2", "c 1"})
+	vp.AppendObjects(toObjects([]string{"d 1"}))
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("b 2"),
+		internal.GreenFg.Render("d 1"),
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetShowChangesOnly(false)
+	if n := vp.content.numItems(); n != 4 {
+		t.Errorf("expected all 4 items shown again, got %d", n)
+	}
+}
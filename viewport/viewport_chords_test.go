@@ -0,0 +1,115 @@
+package viewport
+
+import (
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestChords_CenterSelection(t *testing.T) {
+	w, h := 12, 6
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(20))
+	vp.SetSelectedItemIdx(10)
+
+	vp, _ = vp.Update(internal.MakeKeyMsg('z'))
+	if vp.GetPendingKeys() != "z" {
+		t.Errorf("expected z pending, got %q", vp.GetPendingKeys())
+	}
+	vp, _ = vp.Update(internal.MakeKeyMsg('z'))
+	expectedView := internal.Pad(w, h, []string{
+		"8",
+		"9",
+		selectionStyle.Render("10"),
+		"11",
+		"12",
+		"55% (11/20)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(internal.MakeKeyMsg('z'))
+	vp, _ = vp.Update(internal.MakeKeyMsg('t'))
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("10"),
+		"11",
+		"12",
+		"13",
+		"14",
+		"55% (11/20)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(internal.MakeKeyMsg('z'))
+	vp, _ = vp.Update(internal.MakeKeyMsg('b'))
+	expectedView = internal.Pad(w, h, []string{
+		"6",
+		"7",
+		"8",
+		"9",
+		selectionStyle.Render("10"),
+		"55% (11/20)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if vp.GetPendingKeys() != "" {
+		t.Errorf("expected no pending keys, got %q", vp.GetPendingKeys())
+	}
+}
+
+func TestChords_SelectionBindingsIgnoredWithoutSelection(t *testing.T) {
+	vp := newViewport(10, 6)
+	setContent(vp, numberedContent(20))
+
+	vp, cmd := vp.Update(internal.MakeKeyMsg('z'))
+	if vp.GetPendingKeys() != "" || cmd != nil {
+		t.Errorf("expected z not to wait for a selection binding, got %q pending", vp.GetPendingKeys())
+	}
+}
+
+func TestChords_AbandonedSequence(t *testing.T) {
+	vp := newViewport(10, 6, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(20))
+
+	// z continues no sequence ending in j, so j acts on its own
+	vp, _ = vp.Update(internal.MakeKeyMsg('z'))
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 1 || vp.GetPendingKeys() != "" {
+		t.Errorf("expected the selection moved down with no pending keys, got %d and %q", vp.GetSelectedItemIdx(),
+			vp.GetPendingKeys())
+	}
+}
+
+func TestChords_SharedPrefixActsAfterTimeout(t *testing.T) {
+	keyMap := DefaultKeyMap()
+	keyMap.Top = key.NewBinding(key.WithKeys("g"))
+	keyMap.Bottom = key.NewBinding(key.WithKeys("g e"))
+	vp := newViewport(10, 6, WithSelectionEnabled[object](true), WithKeyMap[object](keyMap),
+		WithKeyChordTimeout[object](time.Millisecond))
+	setContent(vp, numberedContent(20))
+	vp.SetSelectedItemIdx(10)
+
+	// g waits for a possible e
+	vp, cmd := vp.Update(goToTopKeyMsg)
+	if vp.GetSelectedItemIdx() != 10 {
+		t.Fatalf("expected g to wait, got selection %d", vp.GetSelectedItemIdx())
+	}
+	timeout := cmd()
+	vp, _ = vp.Update(timeout)
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected g to go to the top after the timeout, got selection %d", vp.GetSelectedItemIdx())
+	}
+
+	vp, cmd = vp.Update(goToTopKeyMsg)
+	staleTimeout := cmd()
+	vp, _ = vp.Update(internal.MakeKeyMsg('e'))
+	if vp.GetSelectedItemIdx() != 19 {
+		t.Errorf("expected g e to go to the bottom, got selection %d", vp.GetSelectedItemIdx())
+	}
+
+	// the sequence's timeout no longer acts once it's complete
+	vp, _ = vp.Update(staleTimeout)
+	if vp.GetSelectedItemIdx() != 19 {
+		t.Errorf("expected the stale timeout ignored, got selection %d", vp.GetSelectedItemIdx())
+	}
+}
@@ -0,0 +1,192 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var (
+	copyKey    = key.NewBinding(key.WithKeys("y"))
+	copyKeyMsg = internal.MakeKeyMsg('y')
+)
+
+// copiedMsgFromCmd runs a copy command, returning the CopiedMsg it produces
+func copiedMsgFromCmd(t *testing.T, cmd tea.Cmd) CopiedMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a copy command")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("expected a batch of commands")
+	}
+	for _, c := range batch {
+		if msg, ok := c().(CopiedMsg); ok {
+			return msg
+		}
+	}
+	t.Fatal("expected a CopiedMsg")
+	return CopiedMsg{}
+}
+
+func TestClipboard_CopiesSelectedItem(t *testing.T) {
+	vp := newViewport(30, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"first", internal.RedFg.Render("second"), "third"})
+	vp.SetSelectedItemIdx(1)
+
+	if got := vp.itemsText([]int{vp.GetSelectedItemIdx()}); got != "second" {
+		t.Errorf("expected %q, got %q", "second", got)
+	}
+	if msg := copiedMsgFromCmd(t, vp.Copy()); msg.Description != "1 item" {
+		t.Errorf("expected 1 item copied, got %q", msg.Description)
+	}
+}
+
+func TestClipboard_CopiesMarkedItemsWhenAnyMarked(t *testing.T) {
+	vp := newViewport(30, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"first", "second", "third"})
+	vp.SetMarked(2, true)
+	vp.SetMarked(0, true)
+
+	if got := vp.itemsText(vp.GetMarkedItemIdxs()); got != "first\nthird" {
+		t.Errorf("expected %q, got %q", "first\nthird", got)
+	}
+	if msg := copiedMsgFromCmd(t, vp.Copy()); msg.Description != "2 items" {
+		t.Errorf("expected 2 items copied, got %q", msg.Description)
+	}
+}
+
+func TestClipboard_CopiesGroupObjectsWithLineJoining(t *testing.T) {
+	vp := newViewport(30, 4, WithSelectionEnabled[object](true), WithLineJoining[object](isIndented))
+	setContent(vp, []string{"error", "  at foo", "next"})
+
+	if got := vp.itemsText([]int{0}); got != "error\n  at foo" {
+		t.Errorf("expected %q, got %q", "error\n  at foo", got)
+	}
+}
+
+func TestClipboard_CopiesVisibleLinesWithoutSelection(t *testing.T) {
+	vp := newViewport(10, 4)
+	vp.SetHeader([]string{"header"})
+	setContent(vp, []string{"a", "bb", "c", "d"})
+
+	text, numLines := vp.visibleText()
+	if text != "a\nbb" || numLines != 2 {
+		t.Errorf("expected %q over 2 lines, got %q over %d", "a\nbb", text, numLines)
+	}
+	if msg := copiedMsgFromCmd(t, vp.Copy()); msg.Description != "2 lines" {
+		t.Errorf("expected 2 lines copied, got %q", msg.Description)
+	}
+}
+
+func TestClipboard_VisibleLinesExcludeSelectionPrefix(t *testing.T) {
+	vp := newViewport(10, 3, WithSelectionEnabled[object](true))
+	vp.SetStyles(Styles{
+		SelectionPrefix:   "> ",
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+	})
+	setContent(vp, []string{"a", "b"})
+
+	if text, _ := vp.visibleText(); text != "a\nb" {
+		t.Errorf("expected %q, got %q", "a\nb", text)
+	}
+}
+
+func TestClipboard_VisibleLinesExcludeWrapIndicator(t *testing.T) {
+	vp := newViewport(6, 4,
+		WithWrapText[object](true),
+		WithWrapIndent[object](1),
+		WithWrapIndicator[object](DefaultWrapIndicator, lipgloss.NewStyle()),
+	)
+	setContent(vp, []string{"abcdefghi", "  x"})
+
+	if text, _ := vp.visibleText(); text != "abcdef\nghi\n  x" {
+		t.Errorf("expected %q, got %q", "abcdef\nghi\n  x", text)
+	}
+}
+
+func TestClipboard_KeyShowsResultInFooter(t *testing.T) {
+	w, h := 20, 3
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithClipboard[object](copyKey, ClipboardOSC52))
+	setContent(vp, []string{"a", "b"})
+
+	vp, cmd := vp.Update(copyKeyMsg)
+	vp, _ = vp.Update(copiedMsgFromCmd(t, cmd))
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"Copied 1 item",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(clearMessageMsg{id: vp.id, seq: vp.config.message.seq})
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestClipboard_NoKeyByDefault(t *testing.T) {
+	vp := newViewport(20, 3, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	if _, cmd := vp.Update(copyKeyMsg); cmd != nil {
+		if _, ok := cmd().(tea.BatchMsg); ok {
+			t.Error("expected no copy without a copy key")
+		}
+	}
+}
+
+func TestClipboard_CopySelectedKeys(t *testing.T) {
+	km := DefaultKeyMap()
+	km.CopySelected.SetEnabled(true)
+	km.CopySelectedStyled.SetEnabled(true)
+	vp := newViewport(20, 3, WithSelectionEnabled[object](true), WithKeyMap[object](km))
+	styled := internal.RedFg.Render("first")
+	setContent(vp, []string{styled, "second"})
+
+	if got := vp.itemsStyledText([]int{0}); got != styled {
+		t.Errorf("expected %q, got %q", styled, got)
+	}
+
+	_, cmd := vp.Update(copyKeyMsg)
+	if msg := copiedMsgFromCmd(t, cmd); msg.Description != "1 item" || msg.Styled {
+		t.Errorf("expected 1 unstyled item copied, got %q (styled %t)", msg.Description, msg.Styled)
+	}
+	vp, cmd = vp.Update(internal.MakeKeyMsg('Y'))
+	msg := copiedMsgFromCmd(t, cmd)
+	if msg.Description != "1 styled item" || !msg.Styled {
+		t.Errorf("expected 1 styled item copied, got %q (styled %t)", msg.Description, msg.Styled)
+	}
+
+	vp, _ = vp.Update(msg)
+	expectedView := internal.Pad(20, 3, []string{
+		selectionStyle.Render("first"),
+		"second",
+		"Copied 1 styled item",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestClipboard_CopySelectedKeysNeedSelection(t *testing.T) {
+	km := DefaultKeyMap()
+	km.CopySelected.SetEnabled(true)
+	vp := newViewport(20, 3, WithKeyMap[object](km))
+	setContent(vp, []string{"a", "b"})
+
+	if vp.CopySelectedStyled() != nil {
+		t.Error("expected nothing to copy without selection")
+	}
+	if _, cmd := vp.Update(copyKeyMsg); cmd != nil {
+		if _, ok := cmd().(tea.BatchMsg); ok {
+			t.Error("expected no copy without selection")
+		}
+	}
+}
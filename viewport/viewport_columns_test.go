@@ -0,0 +1,121 @@
+package viewport
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// tableRow is a row of cells, rendered joined by "|" outside column mode
+type tableRow struct {
+	cells []string
+}
+
+func (r tableRow) GetItem() item.Item {
+	return item.NewItem(strings.Join(r.cells, "|"))
+}
+
+func (r tableRow) GetCells() []item.SingleItem {
+	cells := make([]item.SingleItem, len(r.cells))
+	for i, c := range r.cells {
+		cells[i] = item.NewItem(c)
+	}
+	return cells
+}
+
+func newTableViewport(width, height int, layout ColumnLayout) *Model[tableRow] {
+	return New[tableRow](width, height,
+		WithStyles[tableRow](Styles{FooterStyle: lipgloss.NewStyle(), SelectedItemStyle: selectionStyle}),
+		WithColumns[tableRow](layout),
+	)
+}
+
+func tableRows(rows ...[]string) []tableRow {
+	objects := make([]tableRow, len(rows))
+	for i, cells := range rows {
+		objects[i] = tableRow{cells: cells}
+	}
+	return objects
+}
+
+func TestColumns_WidthsAndAlignment(t *testing.T) {
+	w, h := 25, 4
+	vp := newTableViewport(w, h, ColumnLayout{
+		Columns:   []Column{{}, {Align: AlignRight}, {MaxWidth: 5}},
+		Separator: " | ",
+	})
+	vp.SetObjects(tableRows(
+		[]string{"web", "3", "running"},
+		[]string{"database", "12", "ok"},
+	))
+
+	expectedView := internal.Pad(w, h, []string{
+		"web      |  3 | ru...",
+		"database | 12 | ok",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if got, want := vp.GetColumnWidths(), []int{8, 2, 5}; !slices.Equal(got, want) {
+		t.Errorf("expected column widths %v, got %v", want, got)
+	}
+
+	vp.ClearColumns()
+	expectedView = internal.Pad(w, h, []string{
+		"web|3|running",
+		"database|12|ok",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if vp.GetColumnWidths() != nil {
+		t.Error("expected no column widths with column mode off")
+	}
+}
+
+func TestColumns_FitToRowsInView(t *testing.T) {
+	w, h := 20, 3
+	vp := newTableViewport(w, h, ColumnLayout{})
+	vp.SetObjects(tableRows(
+		[]string{"a", "1"},
+		[]string{"b", "2"},
+		[]string{"long name", "3"},
+	))
+
+	expectedView := internal.Pad(w, h, []string{
+		"a 1",
+		"b 2",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollDown(1)
+	expectedView = internal.Pad(w, h, []string{
+		"b         2",
+		"long name 3",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestColumns_PinnedColumnsStayWhenPanning(t *testing.T) {
+	w, h := 12, 3
+	vp := newTableViewport(w, h, ColumnLayout{PinnedColumns: 1})
+	vp.SetObjects(tableRows(
+		[]string{"id1", "a long value here"},
+		[]string{"id2", "short"},
+	))
+	vp.View()
+
+	vp.SetXOffset(7)
+	expectedView := internal.Pad(w, h, []string{
+		"id1 ...ue...",
+		"id2",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
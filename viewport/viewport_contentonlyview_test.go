@@ -0,0 +1,79 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestContentOnlyView(t *testing.T) {
+	w, h := 20, 6
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	vp.SetHeader([]string{"header"})
+	vp.SetPostHeaderLine("post-header")
+	vp.SetPreFooterLine("pre-footer")
+	setContent(vp, numberedContent(10))
+
+	// just the lines between the chrome
+	expectedView := internal.Pad(w, 2, []string{
+		internal.BlueFg.Render("0"),
+		"1",
+	})
+	internal.CmpStr(t, expectedView, vp.ContentOnlyView())
+
+	// blank lines pad out short content
+	setContent(vp, numberedContent(1))
+	expectedView = internal.Pad(w, 2, []string{
+		internal.BlueFg.Render("0"),
+		"",
+	})
+	internal.CmpStr(t, expectedView, vp.ContentOnlyView())
+}
+
+func TestContentOnlyMode(t *testing.T) {
+	w, h := 20, 5
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithContentOnly[object](true))
+	vp.SetHeader([]string{"header"})
+	vp.SetPostHeaderLine("post-header")
+	vp.SetPreFooterLine("pre-footer")
+	setContent(vp, numberedContent(10))
+
+	// the items fill the viewport
+	expectedView := internal.Pad(w, h, []string{
+		internal.BlueFg.Render("0"),
+		"1",
+		"2",
+		"3",
+		"4",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	internal.CmpStr(t, expectedView, vp.ContentOnlyView())
+
+	// scrolling uses the full height
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"5",
+		"6",
+		"7",
+		"8",
+		internal.BlueFg.Render("9"),
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if itemIdx, _, ok := vp.GetItemAtScreenPosition(0, 0); !ok || itemIdx != 5 {
+		t.Errorf("expected item 5 on the first row, got %d (ok %t)", itemIdx, ok)
+	}
+
+	// turning it off brings back the chrome
+	vp.SetContentOnly(false)
+	if vp.GetContentOnly() {
+		t.Error("expected content-only mode to be off")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"header",
+		"post-header",
+		internal.BlueFg.Render("9"),
+		"pre-footer",
+		"100% (10/10)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
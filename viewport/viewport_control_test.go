@@ -0,0 +1,51 @@
+package viewport
+
+import (
+	"testing"
+)
+
+func TestControl_ScrollToMsg(t *testing.T) {
+	vp := newViewport(10, 6, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(100))
+
+	vp, cmd := vp.Update(ScrollToMsg{ItemIdx: 50})
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 46 {
+		t.Errorf("expected item 46 at the top, got %d", top)
+	}
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected the selection to stay on item 0, got %d", vp.GetSelectedItemIdx())
+	}
+	if changes := selectionChanges(cmd); len(changes) != 0 {
+		t.Errorf("expected no selection change, got %+v", changes)
+	}
+}
+
+func TestControl_SelectItemMsg(t *testing.T) {
+	vp := newViewport(10, 6, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(100))
+
+	vp, cmd := vp.Update(SelectItemMsg{ItemIdx: 50})
+	if vp.GetSelectedItemIdx() != 50 {
+		t.Errorf("expected item 50 to be selected, got %d", vp.GetSelectedItemIdx())
+	}
+	if changes := selectionChanges(cmd); len(changes) != 1 || changes[0].ItemIdx != 50 {
+		t.Errorf("expected a change to item 50, got %+v", changes)
+	}
+
+	// applies while capturing input
+	vp.StartBlockSelection()
+	vp, _ = vp.Update(SelectItemMsg{ItemIdx: 52})
+	if vp.GetSelectedItemIdx() != 52 || !vp.IsBlockSelecting() {
+		t.Errorf("expected item 52 to be selected while block selecting, got %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestControl_SelectItemMsgWithoutSelection(t *testing.T) {
+	vp := newViewport(10, 6)
+	setContent(vp, numberedContent(100))
+
+	vp, _ = vp.Update(SelectItemMsg{ItemIdx: 50})
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 0 {
+		t.Errorf("expected no scrolling without selection, got item %d at the top", top)
+	}
+}
@@ -0,0 +1,100 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestControlChars_ShownInCaretNotation(t *testing.T) {
+	w, h := 15, 3
+	vp := newViewport(w, h, WithShowControlChars[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		ControlCharStyle:  internal.RedFg,
+	})
+	setContent(vp, []string{"done\r", "a\x00b"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"done" + internal.RedFg.Render("^M"),
+		"a" + internal.RedFg.Render("^@") + "b",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetShowControlChars(false)
+	if vp.GetShowControlChars() {
+		t.Error("expected control characters hidden")
+	}
+	if got := vp.content.itemAt(0).Content(); got != "done\r" {
+		t.Errorf("expected control characters passed through, got %q", got)
+	}
+}
+
+func TestControlChars_DisabledByDefault(t *testing.T) {
+	vp := newViewport(15, 3)
+	setContent(vp, []string{"done\r"})
+	if vp.GetShowControlChars() {
+		t.Error("expected control characters passed through by default")
+	}
+	if got := vp.content.itemAt(0).Content(); got != "done\r" {
+		t.Errorf("expected control characters passed through, got %q", got)
+	}
+}
+
+func TestControlChars_HighlightsCaretNotation(t *testing.T) {
+	w, h := 15, 3
+	vp := newViewport(w, h, WithShowControlChars[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		ControlCharStyle:  internal.RedFg,
+	})
+	setContent(vp, []string{"a\x00bc def", "x\rbc"})
+	vp.SetHighlights([]Highlight{
+		{ItemIndex: 0, ItemHighlight: item.Highlight{
+			Style: internal.BlueFg, ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 4},
+		}},
+		{ItemIndex: 1, ItemHighlight: item.Highlight{
+			Style: internal.BlueFg, ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 4},
+		}},
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"a" + internal.RedFg.Render("^@") + internal.BlueFg.Render("bc") + " def",
+		"x" + internal.RedFg.Render("^M") + internal.BlueFg.Render("bc"),
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestControlChars_ShowInvisibles(t *testing.T) {
+	w, h := 20, 3
+	vp := newViewport(w, h, WithShowControlChars[object](false))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		ControlCharStyle:  internal.RedFg,
+		InvisibleStyle:    internal.BlueFg,
+	})
+	setContent(vp, []string{"a b \t", "x\u200by\a"})
+
+	vp.SetShowInvisibles(true)
+	if !vp.GetShowInvisibles() {
+		t.Error("expected invisibles shown")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"a b" + internal.BlueFg.Render("·") + internal.BlueFg.Render("→"),
+		"x" + internal.BlueFg.Render("<U+200B>") + "y" + internal.RedFg.Render("^G"),
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetShowInvisibles(false)
+	if got := vp.content.itemAt(1).Content(); got != "x\u200by\a" {
+		t.Errorf("expected invisibles passed through, got %q", got)
+	}
+}
@@ -0,0 +1,138 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+type detailedObject struct {
+	item    item.Item
+	details []item.Item
+}
+
+func (o detailedObject) GetItem() item.Item {
+	return o.item
+}
+
+func (o detailedObject) GetDetailItems() []item.Item {
+	return o.details
+}
+
+func newDetailedObject(content string, details ...string) detailedObject {
+	o := detailedObject{item: item.NewItem(content)}
+	for _, d := range details {
+		o.details = append(o.details, item.NewItem(d))
+	}
+	return o
+}
+
+func newDetailsViewport(w, h int) *Model[detailedObject] {
+	return New[detailedObject](w, h, WithSelectionEnabled[detailedObject](true), WithStyles[detailedObject](Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+	}))
+}
+
+func TestDetailsToggleExpand(t *testing.T) {
+	w, h := 30, 6
+	vp := newDetailsViewport(w, h)
+	vp.SetObjects([]detailedObject{
+		newDetailedObject("error: boom", "at foo", "at bar"),
+		newDetailedObject("info: ok"),
+	})
+
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if !vp.IsDetailsExpanded(0) {
+		t.Fatal("expected item 0 details to be expanded")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("error: boom"),
+		selectionStyle.Render("  at foo"),
+		selectionStyle.Render("  at bar"),
+		"info: ok",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if vp.IsDetailsExpanded(0) {
+		t.Fatal("expected item 0 details to be collapsed")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("error: boom"),
+		"info: ok",
+		"",
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestDetailsNoDetailsIgnoresToggle(t *testing.T) {
+	vp := newDetailsViewport(30, 6)
+	vp.SetObjects([]detailedObject{newDetailedObject("info: ok")})
+
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if vp.IsDetailsExpanded(0) {
+		t.Error("expected an item without details not to expand")
+	}
+}
+
+func TestDetailsKeptAcrossSetObjectsWithComparator(t *testing.T) {
+	w, h := 30, 6
+	vp := newDetailsViewport(w, h)
+	vp.SetSelectionComparator(func(a, b detailedObject) bool {
+		return a.item != nil && b.item != nil && a.item.ContentNoAnsi() == b.item.ContentNoAnsi()
+	})
+	vp.SetObjects([]detailedObject{
+		newDetailedObject("a", "detail a"),
+		newDetailedObject("b", "detail b"),
+	})
+	vp.SetDetailsExpanded(1, true)
+
+	vp.SetObjects([]detailedObject{
+		newDetailedObject("new"),
+		newDetailedObject("a", "detail a"),
+		newDetailedObject("b", "detail b"),
+	})
+	if vp.IsDetailsExpanded(1) || !vp.IsDetailsExpanded(2) {
+		t.Errorf("expected the details of b to stay expanded at its new index, got %v", vp.content.expandedDetails)
+	}
+
+	vp.PrependObjects([]detailedObject{newDetailedObject("newer")})
+	if !vp.IsDetailsExpanded(3) {
+		t.Errorf("expected the expanded details to shift with prepended objects, got %v", vp.content.expandedDetails)
+	}
+}
+
+func TestDetailsAllExpanded(t *testing.T) {
+	w, h := 30, 6
+	vp := newDetailsViewport(w, h)
+	vp.SetSelectionEnabled(false)
+	vp.SetObjects([]detailedObject{
+		newDetailedObject("a", "detail a"),
+		newDetailedObject("b"),
+		newDetailedObject("c", "detail c", "more c"),
+	})
+
+	vp.SetAllDetailsExpanded(true)
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"  detail a",
+		"b",
+		"c",
+		"  detail c",
+		"99% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetAllDetailsExpanded(false)
+	if vp.IsDetailsExpanded(0) || vp.IsDetailsExpanded(2) {
+		t.Error("expected all details collapsed")
+	}
+}
@@ -0,0 +1,129 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+type diffLine struct {
+	item item.Item
+	kind DiffKind
+}
+
+func (d diffLine) GetItem() item.Item {
+	return d.item
+}
+
+func (d diffLine) GetDiffKind() DiffKind {
+	return d.kind
+}
+
+var _ DiffObject = diffLine{}
+
+var (
+	nextHunkKeyMsg = internal.MakeKeyMsg('}')
+	prevHunkKeyMsg = internal.MakeKeyMsg('{')
+)
+
+func newDiffViewport(width, height int, lines []diffLine, opts ...Option[diffLine]) *Model[diffLine] {
+	vp := New[diffLine](width, height, append([]Option[diffLine]{
+		WithStyles[diffLine](Styles{
+			SelectedItemStyle: selectionStyle,
+			DiffAddedStyle:    internal.GreenFg,
+			DiffRemovedStyle:  internal.RedFg,
+		}),
+		WithDiffMode[diffLine](true),
+	}, opts...)...)
+	vp.SetObjects(lines)
+	return vp
+}
+
+func diffLines(kinds string) []diffLine {
+	var lines []diffLine
+	for i, r := range kinds {
+		kind := map[rune]DiffKind{' ': DiffKindContext, '+': DiffKindAdded, '-': DiffKindRemoved, '~': DiffKindChanged}[r]
+		lines = append(lines, diffLine{item: item.NewItem(string(rune('a' + i))), kind: kind})
+	}
+	return lines
+}
+
+func TestDiffMode_MarkersAndStyles(t *testing.T) {
+	w, h := 6, 5
+	vp := newDiffViewport(w, h, diffLines(" -+~"))
+
+	expectedView := internal.Pad(w, h, []string{
+		"  a",
+		internal.RedFg.Render("- ") + internal.RedFg.Render("b"),
+		internal.GreenFg.Render("+ ") + internal.GreenFg.Render("c"),
+		"~ d",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetDiffMode(false)
+	if vp.GetDiffMode() {
+		t.Fatal("expected diff mode off")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"c",
+		"d",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestDiffMode_MarkersNarrowWrappedContent(t *testing.T) {
+	w, h := 6, 4
+	vp := newDiffViewport(w, h, []diffLine{{item: item.NewItem("abcdefg")}}, WithWrapText[diffLine](true))
+
+	expectedView := internal.Pad(w, h, []string{
+		"  abcd",
+		"  efg",
+		"",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestDiffMode_HunkKeys(t *testing.T) {
+	vp := newDiffViewport(10, 5, diffLines("  -+  ~  +"), WithSelectionEnabled[diffLine](true))
+
+	for _, expected := range []int{2, 6, 9} {
+		vp, _ = vp.Update(nextHunkKeyMsg)
+		if vp.GetSelectedItemIdx() != expected {
+			t.Errorf("expected item %d selected, got %d", expected, vp.GetSelectedItemIdx())
+		}
+	}
+	if vp.NextHunk() {
+		t.Error("expected no later hunk")
+	}
+
+	// within a hunk, the previous hunk is its own start
+	vp.SetSelectedItemIdx(3)
+	vp, _ = vp.Update(prevHunkKeyMsg)
+	if vp.GetSelectedItemIdx() != 2 {
+		t.Errorf("expected item 2 selected, got %d", vp.GetSelectedItemIdx())
+	}
+	if vp.PrevHunk() {
+		t.Error("expected no earlier hunk")
+	}
+}
+
+func TestDiffMode_HunksWithoutSelection(t *testing.T) {
+	vp := newDiffViewport(10, 4, diffLines("  -   ~   "))
+
+	if !vp.NextHunk() {
+		t.Fatal("expected a hunk")
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 2 {
+		t.Errorf("expected item 2 at the top, got %d", top)
+	}
+	vp.SetDiffMode(false)
+	if vp.NextHunk() {
+		t.Error("expected no hunks outside diff mode")
+	}
+}
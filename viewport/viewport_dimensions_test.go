@@ -0,0 +1,61 @@
+package viewport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestDimensions_ZeroHeightRendersEmpty(t *testing.T) {
+	vp := newViewport(10, 5)
+	setContent(vp, []string{"abc"})
+	vp.SetHeight(-1)
+	if vp.GetHeight() != 0 {
+		t.Errorf("expected a negative height treated as 0, got %d", vp.GetHeight())
+	}
+	if view := vp.View(); view != "" {
+		t.Errorf("expected an empty render, got %q", view)
+	}
+}
+
+var degenerateSizes = []int{-5, -1, 0, 1, 2}
+
+func TestDimensions_DegenerateSizesDontPanic(t *testing.T) {
+	for _, wrap := range []bool{false, true} {
+		for _, w := range degenerateSizes {
+			for _, h := range degenerateSizes {
+				t.Run(fmt.Sprintf("wrap=%t/%dx%d", wrap, w, h), func(t *testing.T) {
+					vp := newViewport(w, h, WithSelectionEnabled[object](true), WithWrapText[object](wrap))
+					vp.SetHeader([]string{"header"})
+					vp.SetPreFooterLine("pre-footer")
+					setContent(vp, []string{"first line", "a much longer second line that wraps", "third"})
+					vp.SetHighlights([]Highlight{{
+						ItemIndex:     1,
+						ItemHighlight: item.Highlight{ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 6}},
+					}})
+					vp.SetSearch("line")
+					_ = vp.View()
+
+					vp.EnsureItemInView(2, 0, 5, 1, 1)
+					vp.SetSelectedItemIdx(1)
+					vp.StartBlockSelection()
+					vp.CancelBlockSelection()
+					vp.SetWrapIndent(4)
+					vp.SetWrapIndicator(DefaultWrapIndicator, vp.display.styles.FooterStyle)
+					vp.SetXOffset(3)
+					for _, msg := range []any{downKeyMsg, upKeyMsg, goToBottomKeyMsg, rightKeyMsg, leftKeyMsg} {
+						vp, _ = vp.Update(msg)
+					}
+					vp.SetWidth(w)
+					vp.SetHeight(h)
+					_ = vp.View()
+					_ = vp.CopyVisible()
+					_ = vp.GetBlockSelection()
+					vp.SetWrapText(!wrap)
+					_ = vp.View()
+				})
+			}
+		}
+	}
+}
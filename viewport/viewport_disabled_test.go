@@ -0,0 +1,75 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func newDisabledViewport(width, height int, options ...Option[object]) *Model[object] {
+	vp := newViewport(width, height, options...)
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		DisabledStyle:     internal.BlueFg,
+		DisabledNoteStyle: internal.RedFg,
+	})
+	return vp
+}
+
+func TestDisabled_DimsContentWithNote(t *testing.T) {
+	w, h := 20, 5
+	vp := newDisabledViewport(w, h, WithSelectionEnabled[object](true), WithDisabledNote[object]("disconnected"))
+	setContent(vp, []string{"a", internal.GreenFg.Render("b")})
+	vp.SetEnabled(false)
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.BlueFg.Render("a"),
+		"    " + internal.RedFg.Render("disconnected"),
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetEnabled(true)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		internal.GreenFg.Render("b"),
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestDisabled_IgnoresInput(t *testing.T) {
+	vp := newDisabledViewport(20, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c"})
+	vp.SetEnabled(false)
+	if vp.GetEnabled() {
+		t.Fatal("expected the viewport disabled")
+	}
+
+	vp, cmd := vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 0 || cmd != nil {
+		t.Errorf("expected keys ignored while disabled, got selection %d", vp.GetSelectedItemIdx())
+	}
+
+	vp.SetEnabled(true)
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected keys handled once enabled, got selection %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestDisabled_CancelsBlockSelection(t *testing.T) {
+	vp := newDisabledViewport(20, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"abc"})
+	vp.StartBlockSelection()
+	vp.SetEnabled(false)
+	if vp.IsBlockSelecting() || vp.IsCapturingInput() {
+		t.Error("expected disabling to cancel block selection")
+	}
+}
@@ -0,0 +1,41 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestDumpState(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true))
+	vp.SetHeader([]string{"header"})
+	setContent(vp, []string{
+		"first",
+		"\x1b[31msecond\x1b[m",
+		"third",
+	})
+	vp, _ = vp.Update(downKeyMsg)
+
+	expected := `size: 20x4
+items: 3
+header lines: 1
+visible: items 1-2 of 3, 2 lines
+selection: item 2 of 3: "second"
+wrap: off, x offset 0
+sticky: top false, bottom false`
+	internal.CmpStr(t, expected, vp.DumpState())
+}
+
+func TestDumpState_EmptyNoSelection(t *testing.T) {
+	vp := newViewport(10, 3, WithWrapText[object](true))
+	vp.SetTopSticky(true)
+
+	expected := `size: 10x3
+items: 0
+header lines: 0
+visible: none
+selection: disabled
+wrap: on
+sticky: top true, bottom false`
+	internal.CmpStr(t, expected, vp.DumpState())
+}
@@ -0,0 +1,109 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+var (
+	editKey         = key.NewBinding(key.WithKeys("e"))
+	editKeyMsg      = internal.MakeKeyMsg('e')
+	backspaceKeyMsg = tea.KeyPressMsg{Code: tea.KeyBackspace}
+)
+
+func newEditViewport(width, height int) *Model[object] {
+	return newViewport(width, height, WithSelectionEnabled[object](true), WithInlineEditing[object](editKey))
+}
+
+// visibleLines returns the unstyled lines of the view
+func visibleLines(vp *Model[object]) []string {
+	return strings.Split(item.StripAnsi(vp.View()), "\n")
+}
+
+func TestEdit_ConfirmSendsItemEditedMsg(t *testing.T) {
+	vp := newEditViewport(20, 4)
+	setContent(vp, []string{"buy milk", "walk dog"})
+	vp, _ = vp.Update(downKeyMsg)
+
+	vp, _ = vp.Update(editKeyMsg)
+	if !vp.IsEditing() || !vp.IsCapturingInput() {
+		t.Fatal("expected editing to capture input")
+	}
+	for _, r := range "s!" {
+		vp, _ = vp.Update(internal.MakeKeyMsg(r))
+	}
+	vp, _ = vp.Update(backspaceKeyMsg)
+	if lines := visibleLines(vp); !strings.HasPrefix(lines[1], "walk dogs") {
+		t.Errorf("expected the text input in place of the selected item, got %q", lines[1])
+	}
+
+	vp, cmd := vp.Update(enterKeyMsg)
+	if vp.IsEditing() {
+		t.Error("expected editing to stop on enter")
+	}
+	if cmd == nil {
+		t.Fatal("expected a command on enter")
+	}
+	msg, ok := cmd().(ItemEditedMsg)
+	if !ok {
+		t.Fatalf("expected ItemEditedMsg, got %T", cmd())
+	}
+	if msg.ItemIndex != 1 || msg.Content != "walk dogs" {
+		t.Errorf("expected item 1 edited to %q, got %+v", "walk dogs", msg)
+	}
+}
+
+func TestEdit_CancelRestoresItem(t *testing.T) {
+	vp := newEditViewport(20, 4)
+	setContent(vp, []string{"buy milk"})
+
+	vp, _ = vp.Update(editKeyMsg)
+	vp, _ = vp.Update(internal.MakeKeyMsg('x'))
+	vp, cmd := vp.Update(escapeKeyMsg)
+	if vp.IsEditing() || cmd != nil {
+		t.Error("expected editing to stop without a command on escape")
+	}
+	expectedView := internal.Pad(20, 4, []string{
+		selectionStyle.Render("buy milk"),
+		"",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestEdit_LongContentScrollsWithinWidth(t *testing.T) {
+	w := 10
+	vp := newEditViewport(w, 3)
+	setContent(vp, []string{"a long todo item"})
+
+	vp, _ = vp.Update(editKeyMsg)
+	line := visibleLines(vp)[0]
+	if !strings.Contains(line, "item") || strings.Contains(line, "a long") {
+		t.Errorf("expected the input scrolled to the cursor at the end, got %q", line)
+	}
+	if width := len([]rune(strings.TrimRight(line, " "))); width > w {
+		t.Errorf("expected the input to fit in %d cells, got %q", w, line)
+	}
+}
+
+func TestEdit_RequiresSelection(t *testing.T) {
+	vp := newViewport(20, 4, WithInlineEditing[object](editKey))
+	setContent(vp, []string{"buy milk"})
+
+	if cmd := vp.StartEditing(); cmd != nil || vp.IsEditing() {
+		t.Error("expected editing to require selection")
+	}
+
+	vp.SetSelectionEnabled(true)
+	vp.StartEditing()
+	vp.SetSelectionEnabled(false)
+	if vp.IsEditing() {
+		t.Error("expected disabling selection to cancel editing")
+	}
+}
@@ -0,0 +1,65 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestEmptyState_CenteredWhileNoItems(t *testing.T) {
+	w, h := 20, 5
+	var gotWidth, gotHeight int
+	vp := newViewport(w, h, WithEmptyState[object](func(width, height int) string {
+		gotWidth, gotHeight = width, height
+		return "No results.\nPress / to filter."
+	}))
+	setContent(vp, []string{})
+
+	expectedView := internal.Pad(w, h, []string{
+		"",
+		"    No results.",
+		" Press / to filter.",
+		"",
+		"",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	// the last line is left for the footer
+	if gotWidth != w || gotHeight != h-1 {
+		t.Errorf("expected the empty state rendered at %dx%d, got %dx%d", w, h-1, gotWidth, gotHeight)
+	}
+
+	// content replaces the empty state
+	setContent(vp, []string{"a"})
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"",
+		"",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestEmptyState_TruncatedAndLoadingTakesPrecedence(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h, WithEmptyState[object](func(width, height int) string {
+		return "nothing to see here\nline 2\nline 3"
+	}), WithLoadingMessage[object]("Loading"))
+	setContent(vp, []string{})
+
+	expectedView := internal.Pad(w, h, []string{
+		"nothing...",
+		"  line 2",
+		"",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetLoading(true)
+	if view := vp.View(); view == expectedView {
+		t.Error("expected the loading indicator instead of the empty state while loading")
+	}
+
+	vp.SetLoading(false)
+	vp.SetEmptyState(nil)
+	internal.CmpStr(t, internal.Pad(w, h, []string{}), vp.View())
+}
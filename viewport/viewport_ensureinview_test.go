@@ -0,0 +1,75 @@
+package viewport
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// randomContent returns n lines of random width, some with wide characters and some multi-line
+func randomContent(r *rand.Rand, n int) []string {
+	words := []string{"a", "bb", "ccc", "世界", "dddd dddd", "eeeeeeeeeeeeeeeeeee", "ff-ff"}
+	lines := make([]string, n)
+	for i := range lines {
+		var b strings.Builder
+		for range r.Intn(8) {
+			b.WriteString(words[r.Intn(len(words))])
+			b.WriteByte(' ')
+		}
+		if r.Intn(10) == 0 {
+			b.WriteString("\nsecond line")
+		}
+		lines[i] = b.String()
+	}
+	return lines
+}
+
+func TestEnsureItemInView_Stable(t *testing.T) {
+	for seed := range int64(300) {
+		r := rand.New(rand.NewSource(seed))
+		w, h := 1+r.Intn(30), 1+r.Intn(12)
+		vp := newViewport(w, h, WithSelectionEnabled[object](r.Intn(2) == 0))
+		vp.SetWrapText(r.Intn(2) == 0)
+		content := randomContent(r, r.Intn(40))
+		setContent(vp, content)
+		vp.ScrollDown(r.Intn(20))
+
+		itemIdx := r.Intn(len(content) + 2)
+		startWidth := r.Intn(60)
+		endWidth := startWidth + r.Intn(20)
+		verticalPad, horizontalPad := r.Intn(4), r.Intn(4)
+		args := fmt.Sprintf("seed %d: %dx%d, wrap %t, item %d, widths %d-%d, pads %d/%d", seed, w, h,
+			vp.GetWrapText(), itemIdx, startWidth, endWidth, verticalPad, horizontalPad)
+
+		vp.EnsureItemInView(itemIdx, startWidth, endWidth, verticalPad, horizontalPad)
+		view := vp.View()
+		for i := range 3 {
+			if vp.EnsureItemInViewScrolled(itemIdx, startWidth, endWidth, verticalPad, horizontalPad) {
+				t.Fatalf("%s: expected repeated call %d not to scroll", args, i+1)
+			}
+			if repeated := vp.View(); repeated != view {
+				t.Fatalf("%s: expected repeated call %d not to change the view\nbefore:\n%s\nafter:\n%s", args, i+1, view,
+					repeated)
+			}
+		}
+	}
+}
+
+func TestEnsureItemInViewScrolled(t *testing.T) {
+	vp := newViewport(10, 4)
+	setContent(vp, []string{"a", "b", "c", "d", "e", "f"})
+
+	if vp.EnsureItemInViewScrolled(1, 0, 0, 0, 0) {
+		t.Error("expected no scroll for an item in view")
+	}
+	if !vp.EnsureItemInViewScrolled(5, 0, 0, 0, 0) {
+		t.Error("expected a scroll for an item below the view")
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 3 {
+		t.Errorf("expected item 3 at the top, got %d", top)
+	}
+	if vp.EnsureItemInViewScrolled(5, 0, 0, 0, 0) {
+		t.Error("expected no scroll when repeated")
+	}
+}
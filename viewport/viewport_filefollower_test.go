@@ -0,0 +1,137 @@
+package viewport
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// appendToFile appends content to the file at path
+func appendToFile(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectLines fails t unless msg carries the expected lines without error
+func expectLines(t *testing.T, msg FileLinesMsg, expected ...string) {
+	t.Helper()
+	if msg.Err != nil {
+		t.Fatalf("expected no error, got %v", msg.Err)
+	}
+	if !slices.Equal(msg.Lines, expected) {
+		t.Errorf("expected lines %q, got %q", expected, msg.Lines)
+	}
+}
+
+func TestFileFollower_FollowsGrowth(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	follower := NewFileFollower(path, 0)
+	defer func() { _ = follower.Close() }()
+
+	// waits for the file to exist
+	expectLines(t, follower.Poll())
+
+	appendToFile(t, path, "first\r\nsec")
+	expectLines(t, follower.Poll(), "first")
+	appendToFile(t, path, "ond\nthird\n")
+	expectLines(t, follower.Poll(), "second", "third")
+	expectLines(t, follower.Poll())
+}
+
+func TestFileFollower_Truncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendToFile(t, path, "old 1\nold 2\n")
+	follower := NewFileFollower(path, 0)
+	defer func() { _ = follower.Close() }()
+	expectLines(t, follower.Poll(), "old 1", "old 2")
+
+	if err := os.WriteFile(path, []byte("new\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	msg := follower.Poll()
+	expectLines(t, msg, "new")
+	if !msg.Truncated || msg.Reopened {
+		t.Errorf("expected truncation reported, got %+v", msg)
+	}
+}
+
+func TestFileFollower_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendToFile(t, path, "before\n")
+	follower := NewFileFollower(path, 0)
+	defer func() { _ = follower.Close() }()
+	expectLines(t, follower.Poll(), "before")
+
+	// written to the old file after it's renamed, then the new file is created
+	appendToFile(t, path, "last of old")
+	if err := os.Rename(path, filepath.Join(dir, "app.log.1")); err != nil {
+		t.Fatal(err)
+	}
+	expectLines(t, follower.Poll())
+	appendToFile(t, path, "first of new\n")
+
+	msg := follower.Poll()
+	expectLines(t, msg, "last of old", "first of new")
+	if !msg.Reopened || msg.Truncated {
+		t.Errorf("expected reopening reported, got %+v", msg)
+	}
+	appendToFile(t, path, "more\n")
+	expectLines(t, follower.Poll(), "more")
+}
+
+func TestFileFollower_NextWaitsForLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	follower := NewFileFollower(path, time.Millisecond)
+	defer func() { _ = follower.Close() }()
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = os.WriteFile(path, []byte("arrived\n"), 0o600)
+	}()
+	msg, ok := follower.Next()().(FileLinesMsg)
+	if !ok {
+		t.Fatal("expected a FileLinesMsg")
+	}
+	expectLines(t, msg, "arrived")
+	if msg.Path != path {
+		t.Errorf("expected the path %q, got %q", path, msg.Path)
+	}
+}
+
+func TestFileFollower_CloseStopsNext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	follower := NewFileFollower(path, time.Millisecond)
+
+	done := make(chan any)
+	go func() { done <- follower.Next()() }()
+	time.Sleep(10 * time.Millisecond)
+	if err := follower.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-done:
+		if msg != nil {
+			t.Errorf("expected no message once closed, got %v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Next to stop once closed")
+	}
+
+	// the file isn't reopened once it appears
+	appendToFile(t, path, "late\n")
+	if msg := follower.Poll(); !errors.Is(msg.Err, fs.ErrClosed) || len(msg.Lines) != 0 {
+		t.Errorf("expected polling a closed follower to fail without lines, got %+v", msg)
+	}
+}
@@ -0,0 +1,172 @@
+package viewport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// newLineObject returns the object of a line read by a FileSource
+func newLineObject(line string) object {
+	return object{item: item.NewItem(line)}
+}
+
+// writeTempFile writes content to a new file in a temporary directory, returning its path
+func writeTempFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "input.log")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileSource_ReadsLines(t *testing.T) {
+	var b strings.Builder
+	for i := range 1000 {
+		fmt.Fprintf(&b, "line %d\r\n", i)
+	}
+	b.WriteString("unterminated")
+	source, err := NewFileSource(writeTempFile(t, b.String()), newLineObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = source.Close() }()
+
+	if n := source.Len(); n != 1001 {
+		t.Fatalf("expected 1001 lines, got %d", n)
+	}
+	for _, i := range []int{0, 63, 64, 500, 999, 0} {
+		if got, expected := source.At(i).GetItem().Content(), fmt.Sprintf("line %d", i); got != expected {
+			t.Errorf("expected %q at %d, got %q", expected, i, got)
+		}
+	}
+	if got := source.At(1000).GetItem().Content(); got != "unterminated" {
+		t.Errorf("expected the unterminated last line, got %q", got)
+	}
+	if err := source.Err(); err != nil {
+		t.Errorf("expected no read error, got %v", err)
+	}
+}
+
+func TestFileSource_RefreshFollowsGrowthAndTruncation(t *testing.T) {
+	path := writeTempFile(t, "a\nb")
+	source, err := NewFileSource(path, newLineObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = source.Close() }()
+	if n, last := source.Len(), source.At(1).GetItem().Content(); n != 2 || last != "b" {
+		t.Fatalf("expected 2 lines ending in b, got %d ending in %q", n, last)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("c\nd\n")
+	_ = f.Close()
+	if err := source.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if n, grown := source.Len(), source.At(1).GetItem().Content(); n != 3 || grown != "bc" {
+		t.Errorf("expected the unterminated line to grow to bc, got %d lines with %q", n, grown)
+	}
+
+	if err := os.WriteFile(path, []byte("x\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if n, first := source.Len(), source.At(0).GetItem().Content(); n != 1 || first != "x" {
+		t.Errorf("expected the truncated file indexed again, got %d lines starting %q", n, first)
+	}
+}
+
+func TestFileSource_RefreshRewrittenFile(t *testing.T) {
+	path := writeTempFile(t, "a\nb\n")
+	source, err := NewFileSource(path, newLineObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = source.Close() }()
+
+	// truncated and written to again past where it was indexed
+	if err := os.WriteFile(path, []byte("xyz\n12\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if n, first := source.Len(), source.At(0).GetItem().Content(); n != 2 || first != "xyz" {
+		t.Errorf("expected the rewritten file indexed again, got %d lines starting %q", n, first)
+	}
+}
+
+func TestFileSource_RefreshKeepsCompleteBlocks(t *testing.T) {
+	path := writeTempFile(t, strings.Repeat("line\n", 100))
+	source, err := NewFileSource(path, newLineObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = source.Close() }()
+	source.At(0)
+	source.At(99)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("more\n")
+	_ = f.Close()
+	if err := source.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := source.cache[0]; !ok {
+		t.Error("expected the complete first block kept")
+	}
+	if _, ok := source.cache[1]; ok {
+		t.Error("expected the partial last block forgotten")
+	}
+	if last := source.At(100).GetItem().Content(); last != "more" {
+		t.Errorf("expected the appended line, got %q", last)
+	}
+}
+
+func TestFileSource_InViewport(t *testing.T) {
+	w, h := 15, 4
+	source, err := OpenFileSource(writeTempFile(t, "first\nsecond\nthird\nfourth\n"), newLineObject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = source.Close() }()
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	vp.SetItemSource(source)
+	if n := source.Len(); n != 0 {
+		t.Errorf("expected no lines before indexing, got %d", n)
+	}
+
+	if err := source.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	vp.RefreshItemSource()
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("first"),
+		"second",
+		"third",
+		"25% (1/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestFileSource_MissingFile(t *testing.T) {
+	if _, err := NewFileSource(filepath.Join(t.TempDir(), "missing"), newLineObject); err == nil {
+		t.Error("expected an error opening a missing file")
+	}
+}
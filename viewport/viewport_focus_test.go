@@ -0,0 +1,71 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestFocus_BlurredStyle(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		BlurredStyle:      internal.RedFg,
+	})
+	setContent(vp, []string{"a", "b"})
+
+	vp.Blur()
+	if vp.Focused() {
+		t.Fatal("expected the viewport blurred")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("a"),
+		"b",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.Focus()
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestFocus_BlurredIgnoresKeys(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true), WithMouseSupport[object](true))
+	setContent(vp, numberedContent(10))
+	if !vp.Focused() {
+		t.Fatal("expected the viewport focused by default")
+	}
+
+	vp.Blur()
+	vp, cmd := vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 0 || cmd != nil {
+		t.Errorf("expected keys ignored while blurred, got selection %d", vp.GetSelectedItemIdx())
+	}
+
+	// the mouse and control messages still work
+	vp, _ = vp.Update(tea.MouseClickMsg{X: 0, Y: 1, Button: tea.MouseLeft})
+	if vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected a click to select item 1 while blurred, got %d", vp.GetSelectedItemIdx())
+	}
+	vp, _ = vp.Update(SelectItemMsg{ItemIdx: 2})
+	if vp.GetSelectedItemIdx() != 2 {
+		t.Errorf("expected SelectItemMsg to select item 2 while blurred, got %d", vp.GetSelectedItemIdx())
+	}
+
+	vp.Focus()
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 3 {
+		t.Errorf("expected keys handled once focused, got selection %d", vp.GetSelectedItemIdx())
+	}
+}
@@ -0,0 +1,95 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+// followMsgFromCmd returns the FollowStateChangedMsg produced by cmd, if any
+func followMsgFromCmd(cmd tea.Cmd) (FollowStateChangedMsg, bool) {
+	if cmd == nil {
+		return FollowStateChangedMsg{}, false
+	}
+	switch msg := cmd().(type) {
+	case FollowStateChangedMsg:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if f, ok := followMsgFromCmd(c); ok {
+				return f, true
+			}
+		}
+	}
+	return FollowStateChangedMsg{}, false
+}
+
+func TestFollow_AppendScrollsWithIndicator(t *testing.T) {
+	w, h := 25, 4
+	vp := newViewport(w, h, WithFollowMode[object](true))
+	setContent(vp, []string{"1", "2", "3", "4"})
+	vp.AppendObjects(toObjects([]string{"5"}))
+
+	expectedView := internal.Pad(w, h, []string{
+		"3",
+		"4",
+		"5",
+		"FOLLOWING  100% (5/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if !vp.IsFollowing() {
+		t.Error("expected to be following")
+	}
+}
+
+func TestFollow_DisengagesAndReengages(t *testing.T) {
+	w, h := 25, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithFollowMode[object](true))
+	setContent(vp, []string{"1", "2", "3", "4"})
+	if vp.GetSelectedItemIdx() != 3 {
+		t.Fatalf("expected the last item selected, got %d", vp.GetSelectedItemIdx())
+	}
+
+	vp, cmd := vp.Update(upKeyMsg)
+	if msg, ok := followMsgFromCmd(cmd); !ok || msg.Following {
+		t.Errorf("expected following to disengage, got %+v (sent %t)", msg, ok)
+	}
+	vp.AppendObjects(toObjects([]string{"5"}))
+	expectedView := internal.Pad(w, h, []string{
+		"2",
+		selectionStyle.Render("3"),
+		"4",
+		"60% (3/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, cmd = vp.Update(upKeyMsg)
+	if _, ok := followMsgFromCmd(cmd); ok {
+		t.Error("expected no message when following doesn't change")
+	}
+
+	vp, cmd = vp.Update(goToBottomKeyMsg)
+	if msg, ok := followMsgFromCmd(cmd); !ok || !msg.Following {
+		t.Errorf("expected following to re-engage, got %+v (sent %t)", msg, ok)
+	}
+	if !vp.IsFollowing() {
+		t.Error("expected to be following")
+	}
+}
+
+func TestFollow_Disabled(t *testing.T) {
+	w, h := 25, 4
+	vp := newViewport(w, h, WithFollowMode[object](true))
+	setContent(vp, []string{"1", "2", "3", "4"})
+	vp.SetFollowMode(false)
+	vp.AppendObjects(toObjects([]string{"5"}))
+
+	if vp.GetFollowMode() || vp.IsFollowing() {
+		t.Error("expected follow mode off")
+	}
+	_, cmd := vp.Update(upKeyMsg)
+	if _, ok := followMsgFromCmd(cmd); ok {
+		t.Error("expected no message with follow mode off")
+	}
+}
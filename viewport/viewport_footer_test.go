@@ -0,0 +1,103 @@
+package viewport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestFooterFormatter_Custom(t *testing.T) {
+	w, h := 25, 3
+	vp := newViewport(w, h, WithFooterFormatter[object](func(info FooterInfo) string {
+		return fmt.Sprintf("line %d of %d", info.Current, info.Total)
+	}))
+	setContent(vp, []string{"a", "b", "c"})
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"line 2 of 3",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetFooterFormatter(nil)
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestFooterFormatter_FilterSwitchesAndReverts(t *testing.T) {
+	w, h := 40, 3
+	vp := newViewport(w, h, WithFooterFormatter[object](FilterFooterFormatter))
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp.SetFooterFilterInfo(&FooterFilterInfo{Mode: "[regex]", Query: "b", FocusedMatch: 1, MatchCount: 1, MatchingItemsOnly: true})
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		`[regex] "b" match 1/1  matching only  (2/3)`[:w-3] + "...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetFooterFilterInfo(&FooterFilterInfo{Mode: "[exact]", Query: "z"})
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		`[exact] "z" no matches  (2/3)`,
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetFooterFilterInfo(nil)
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestFooterMetric_LinesCountsWrappedRows(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h, WithFooterMetric[object](FooterMetricLines))
+	vp.SetWrapText(true)
+	setContent(vp, []string{"1234567890123456789012345", "b", "c"})
+	expectedView := internal.Pad(w, h, []string{
+		"1234567890",
+		"1234567890",
+		"12345",
+		"60% (li...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	info := FooterInfo{}
+	vp.SetFooterFormatter(func(i FooterInfo) string {
+		info = i
+		return ""
+	})
+	vp.View()
+	if info.Line != 3 || info.TotalLines != 5 || info.Percent != 60 {
+		t.Errorf("expected line 3 of 5 at 60%%, got line %d of %d at %d%%", info.Line, info.TotalLines, info.Percent)
+	}
+
+	vp.SetFooterMetric(FooterMetricItems)
+	vp.View()
+	if info.Line != 0 || info.TotalLines != 0 || info.Percent != 33 {
+		t.Errorf("expected the item metric at 33%%, got line %d of %d at %d%%", info.Line, info.TotalLines, info.Percent)
+	}
+}
+
+func TestFooterMetric_LinesReadout(t *testing.T) {
+	w, h := 30, 3
+	vp := newViewport(w, h, WithFooterMetric[object](FooterMetricLines))
+	setContent(vp, []string{"a", "b", "c", "d"})
+	vp.ScrollDown(1)
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		"c",
+		"75% (line 3 of 4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
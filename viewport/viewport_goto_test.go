@@ -0,0 +1,176 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var goToKeyMsg = internal.MakeKeyMsg(':')
+
+// goToKeyMap returns the default key map with the GoTo key enabled
+func goToKeyMap() KeyMap {
+	keyMap := DefaultKeyMap()
+	keyMap.GoTo.SetEnabled(true)
+	return keyMap
+}
+
+// typeGoTo opens the go-to input, types s and confirms it
+func typeGoTo(vp *Model[object], s string) (*Model[object], tea.Cmd) {
+	vp, _ = vp.Update(goToKeyMsg)
+	for _, r := range s {
+		vp, _ = vp.Update(internal.MakeKeyMsg(r))
+	}
+	return vp.Update(enterKeyMsg)
+}
+
+func TestGoTo_ItemCentersLikeEnsureItemInView(t *testing.T) {
+	w, h := 6, 4
+	vp := newViewport(w, h)
+	setContent(vp, numberedContent(20))
+
+	if cmd := vp.GoToItem(10); cmd != nil {
+		t.Error("expected no command without flashing")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"8",
+		"9",
+		"10",
+		"55%...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// already in view, so stable
+	vp.GoToItem(10)
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// clamped to the content
+	vp.GoToItem(100)
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 17 {
+		t.Errorf("expected item 17 at the top, got %d", top)
+	}
+}
+
+func TestGoTo_PercentSelects(t *testing.T) {
+	vp := newViewport(6, 4, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(21))
+
+	tests := []struct {
+		percent  float64
+		expected int
+	}{
+		{percent: 0, expected: 0},
+		{percent: 50, expected: 10},
+		{percent: 100, expected: 20},
+		{percent: -5, expected: 0},
+		{percent: 250, expected: 20},
+	}
+	for _, tt := range tests {
+		vp.GoToPercent(tt.percent)
+		if vp.GetSelectedItemIdx() != tt.expected {
+			t.Errorf("%v%%: expected selected item %d, got %d", tt.percent, tt.expected, vp.GetSelectedItemIdx())
+		}
+	}
+}
+
+func TestGoTo_KeyDisabledByDefault(t *testing.T) {
+	vp := newViewport(12, 4, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(21))
+
+	vp, _ = vp.Update(goToKeyMsg)
+	if vp.IsCapturingInput() {
+		t.Error("expected the go-to key disabled by default")
+	}
+}
+
+func TestGoTo_Input(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithKeyMap[object](goToKeyMap()))
+	setContent(vp, numberedContent(21))
+
+	vp, _ = vp.Update(goToKeyMsg)
+	if !vp.IsCapturingInput() {
+		t.Fatal("expected the go-to input to capture input")
+	}
+	vp, _ = vp.Update(internal.MakeKeyMsg('7'))
+	if lines := visibleLines(vp); strings.TrimRight(lines[h-1], " ") != "Go to: 7" {
+		t.Errorf("expected the go-to input in the footer, got %q", lines[h-1])
+	}
+	vp, _ = vp.Update(enterKeyMsg)
+	if vp.IsCapturingInput() {
+		t.Error("expected the go-to input to close on enter")
+	}
+	// numbers are 1-based
+	if vp.GetSelectedItemIdx() != 6 {
+		t.Errorf("expected selected item 6, got %d", vp.GetSelectedItemIdx())
+	}
+
+	vp, _ = typeGoTo(vp, "50%")
+	if vp.GetSelectedItemIdx() != 10 {
+		t.Errorf("expected selected item 10, got %d", vp.GetSelectedItemIdx())
+	}
+
+	// esc cancels
+	vp, _ = vp.Update(goToKeyMsg)
+	vp, _ = vp.Update(internal.MakeKeyMsg('1'))
+	vp, _ = vp.Update(escapeKeyMsg)
+	if vp.IsCapturingInput() || vp.GetSelectedItemIdx() != 10 {
+		t.Errorf("expected esc to cancel at item 10, got item %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestGoTo_InvalidInputShowsError(t *testing.T) {
+	w, h := 30, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithKeyMap[object](goToKeyMap()))
+	setContent(vp, numberedContent(5))
+
+	vp, _ = typeGoTo(vp, "abc")
+	if lines := visibleLines(vp); strings.TrimRight(lines[h-1], " ") != "Invalid line number: abc" {
+		t.Errorf("expected an error in the footer, got %q", lines[h-1])
+	}
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected the selection unchanged, got %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestGoTo_Flash(t *testing.T) {
+	w, h := 6, 4
+	vp := newViewport(w, h, WithGoToFlash[object](time.Millisecond))
+	vp.display.styles.FlashStyle = internal.RedFg
+	setContent(vp, numberedContent(3))
+
+	first := vp.GoToItem(1)
+	if first == nil {
+		t.Fatal("expected a command ending the flash")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"0",
+		internal.RedFg.Render("1"),
+		"2",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// a later flash outlasts the end of an earlier one
+	second := vp.GoToItem(2)
+	vp, _ = vp.Update(first())
+	expectedView = internal.Pad(w, h, []string{
+		"0",
+		"1",
+		internal.RedFg.Render("2"),
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(second())
+	expectedView = internal.Pad(w, h, []string{
+		"0",
+		"1",
+		"2",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
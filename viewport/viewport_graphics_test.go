@@ -0,0 +1,91 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+const sixel = "\x1bPq#0;2;0;0;0#0~~@@vv@@~~$-\x1b\\"
+
+func setGraphicsContent(vp *Model[object], cols, rows int) {
+	vp.SetObjects([]object{
+		{item: item.NewItem("above")},
+		{item: item.NewGraphicsItem(sixel, cols, rows, "[image]")},
+		{item: item.NewItem("below")},
+	})
+}
+
+func TestGraphics_PassedThroughWhenFullyVisible(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h)
+	setGraphicsContent(vp, 8, 2)
+
+	expectedView := internal.Pad(w, h, []string{
+		"above",
+		sixel,
+		"",
+		"below",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestGraphics_PlaceholderWhenClippedAtBottom(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h)
+	setGraphicsContent(vp, 8, 2)
+
+	expectedView := internal.Pad(w, h, []string{
+		"above",
+		"[image]",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestGraphics_PlaceholderWhenClippedAtTop(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h)
+	setGraphicsContent(vp, 8, 3)
+	vp.ScrollDown(2)
+
+	expectedView := internal.Pad(w, h, []string{
+		"[image]",
+		"",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestGraphics_PlaceholderWhenWiderThanViewport(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h)
+	setGraphicsContent(vp, 20, 2)
+
+	expectedView := internal.Pad(w, h, []string{
+		"above",
+		"[image]",
+		"",
+		"below",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestGraphics_SelectedPassedThrough(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	setGraphicsContent(vp, 8, 2)
+	vp.SetSelectedItemIdx(1)
+
+	expectedView := internal.Pad(w, h, []string{
+		"above",
+		sixel,
+		"",
+		"below",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
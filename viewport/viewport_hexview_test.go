@@ -0,0 +1,52 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestHexView_BinaryItemsAsHexDumps(t *testing.T) {
+	w, h := 80, 4
+	vp := newViewport(w, h, WithHexView[object](HexViewBinary))
+	setContent(vp, []string{"text", "PK\x03\x04\x00\x00"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"text",
+		"00000000  50 4b 03 04 00 00                                 |PK....|",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetHexView(HexViewAll)
+	expectedView = internal.Pad(w, h, []string{
+		"00000000  74 65 78 74                                       |text|",
+		"00000000  50 4b 03 04 00 00                                 |PK....|",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestHexView_ToggleKeyCycles(t *testing.T) {
+	keyMap := DefaultKeyMap()
+	keyMap.ToggleHexView.SetEnabled(true)
+	vp := newViewport(80, 4, WithKeyMap[object](keyMap))
+	setContent(vp, []string{"text"})
+
+	toggleKeyMsg := internal.MakeKeyMsg('H')
+	for _, expected := range []HexView{HexViewBinary, HexViewAll, HexViewOff} {
+		vp, _ = vp.Update(toggleKeyMsg)
+		if vp.GetHexView() != expected {
+			t.Errorf("expected hex view %d, got %d", expected, vp.GetHexView())
+		}
+	}
+
+	// disabled by default
+	vp = newViewport(80, 4)
+	vp, _ = vp.Update(toggleKeyMsg)
+	if vp.GetHexView() != HexViewOff {
+		t.Errorf("expected the hex view unchanged by the disabled key, got %d", vp.GetHexView())
+	}
+}
@@ -0,0 +1,59 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestHighlightAll(t *testing.T) {
+	vp := newViewport(20, 4)
+	setContent(vp, []string{"a-b-a-b", internal.BlueFg.Render("ab") + "ab"})
+
+	vp.HighlightAll(0, "a", internal.RedFg)
+	vp.HighlightAll(0, "-b", internal.GreenBg)
+	vp.HighlightAll(1, "ba", internal.RedFg)
+	vp.HighlightAll(5, "a", internal.RedFg)
+	vp.HighlightAll(1, "", internal.RedFg)
+	if got := len(vp.GetHighlights()); got != 5 {
+		t.Errorf("expected 5 highlights, got %d", got)
+	}
+
+	expectedView := internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		internal.RedFg.Render("a") + internal.GreenBg.Render("-b") + "-" + internal.RedFg.Render("a") +
+			internal.GreenBg.Render("-b"),
+		internal.BlueFg.Render("a") + internal.RedFg.Render("ba") + "b",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestAppendHighlights(t *testing.T) {
+	vp := newViewport(20, 4)
+	setContent(vp, []string{"abcd", "efgh"})
+
+	vp.SetHighlights([]Highlight{{ItemIndex: 0, ItemHighlight: item.Highlight{
+		Style: internal.RedFg, ByteRangeUnstyledContent: item.ByteRange{Start: 0, End: 1},
+	}}})
+	vp.AppendHighlights([]Highlight{
+		{ItemIndex: 0, ItemHighlight: item.Highlight{
+			Style: internal.GreenBg, ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 4},
+		}},
+		{ItemIndex: 1, ItemHighlight: item.Highlight{
+			Style: internal.RedFg, ByteRangeUnstyledContent: item.ByteRange{Start: 1, End: 2},
+		}},
+	})
+	if got := len(vp.GetHighlights()); got != 3 {
+		t.Errorf("expected 3 highlights, got %d", got)
+	}
+
+	expectedView := internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		internal.RedFg.Render("a") + "b" + internal.GreenBg.Render("cd"),
+		"e" + internal.RedFg.Render("f") + "gh",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,82 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestHighlightPriority_Highlights(t *testing.T) {
+	vp := newViewport(20, 3)
+	setContent(vp, []string{"abcdef"})
+	vp.SetHighlights([]Highlight{
+		{ItemIndex: 0, ItemHighlight: item.Highlight{
+			Style: internal.RedFg, ByteRangeUnstyledContent: item.ByteRange{Start: 0, End: 4},
+		}},
+		{ItemIndex: 0, ItemHighlight: item.Highlight{
+			Style: internal.BlueFg, ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 6}, Priority: 1,
+		}},
+	})
+	expectedView := internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		internal.RedFg.Render("ab") + internal.BlueFg.Render("cdef"),
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestHighlightPriority_Search(t *testing.T) {
+	styles := Styles{SearchMatchStyle: internal.BlueFg, FocusedSearchMatchStyle: internal.BlueFg}
+	vp := newViewport(20, 3, WithStyles[object](styles))
+	setContent(vp, []string{"abcdef"})
+	vp.SetHighlights([]Highlight{{ItemIndex: 0, ItemHighlight: item.Highlight{
+		Style: internal.RedFg, ByteRangeUnstyledContent: item.ByteRange{Start: 0, End: 4},
+	}}})
+	vp.SetSearch("cd")
+
+	// by default the highlight starting first shows
+	expectedView := internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		internal.RedFg.Render("abcd") + "ef",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	styles.HighlightPriorities.Search = 1
+	vp.SetStyles(styles)
+	expectedView = internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		internal.RedFg.Render("ab") + internal.BlueFg.Render("cd") + "ef",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestHighlightPriority_Selection(t *testing.T) {
+	styles := Styles{SelectedItemStyle: selectionStyle}
+	styles.HighlightPriorities.Selection = 1
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true), WithStyles[object](styles))
+	setContent(vp, []string{"abc", "abc"})
+	var highlights []Highlight
+	for itemIdx := range 2 {
+		highlights = append(highlights,
+			Highlight{ItemIndex: itemIdx, ItemHighlight: item.Highlight{
+				Style: internal.RedFg, ByteRangeUnstyledContent: item.ByteRange{Start: 0, End: 1},
+			}},
+			Highlight{ItemIndex: itemIdx, ItemHighlight: item.Highlight{
+				Style: internal.GreenBg, ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 3}, Priority: 1,
+			}},
+		)
+	}
+	vp.SetHighlights(highlights)
+
+	// the selection hides highlights of lower priority on the selected item only
+	expectedView := internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		selectionStyle.Render("ab") + internal.GreenBg.Render("c"),
+		internal.RedFg.Render("a") + "b" + internal.GreenBg.Render("c"),
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,112 @@
+package viewport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// countingSource is an ItemSource generating numbered lines, recording which indexes were read
+type countingSource struct {
+	n    int
+	read map[int]struct{}
+}
+
+func newCountingSource(n int) *countingSource {
+	return &countingSource{n: n, read: make(map[int]struct{})}
+}
+
+func (s *countingSource) Len() int {
+	return s.n
+}
+
+func (s *countingSource) At(i int) object {
+	s.read[i] = struct{}{}
+	return object{item: item.NewItem(fmt.Sprintf("line %d", i))}
+}
+
+func TestItemSource_OnlyReadsItemsNearView(t *testing.T) {
+	w, h := 25, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithWrapText[object](true))
+	source := newCountingSource(5_000_000)
+	vp.SetItemSource(source)
+
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("line 0"),
+		"line 1",
+		"line 2",
+		"0% (1/5000000)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"line 4999997",
+		"line 4999998",
+		selectionStyle.Render("line 4999999"),
+		"100% (5000000/5000000)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	if len(source.read) > 100 {
+		t.Errorf("expected only objects near view to be read, read %d", len(source.read))
+	}
+}
+
+func TestItemSource_RefreshFollowsGrowth(t *testing.T) {
+	w, h := 25, 4
+	vp := newViewport(w, h, WithFollowMode[object](true))
+	source := newCountingSource(3)
+	vp.SetItemSource(source)
+
+	source.n = 5
+	expectedView := internal.Pad(w, h, []string{
+		"line 0",
+		"line 1",
+		"line 2",
+		"FOLLOWING  100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.RefreshItemSource()
+	expectedView = internal.Pad(w, h, []string{
+		"line 2",
+		"line 3",
+		"line 4",
+		"FOLLOWING  100% (5/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestItemSource_SearchAndReplaceWithObjects(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	vp.SetItemSource(newCountingSource(1000))
+	vp.SetSearch("line 99")
+
+	if got := vp.GetSearchMatchCount(); got != 11 {
+		t.Errorf("expected 11 matches, got %d", got)
+	}
+	if vp.GetSelectedItemIdx() != 99 {
+		t.Errorf("expected the first match selected, got %d", vp.GetSelectedItemIdx())
+	}
+	if selected := vp.GetSelectedItem(); selected == nil || selected.GetItem().Content() != "line 99" {
+		t.Errorf("expected line 99 selected, got %v", selected)
+	}
+
+	vp.AppendObjects(toObjects([]string{"last"}))
+	if got := len(vp.GetGroupObjects(1000)); got != 1 {
+		t.Errorf("expected appended object read, got %d objects", got)
+	}
+
+	setContent(vp, []string{"a"})
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,63 @@
+package viewport
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyGroups_NavigationDisabled(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true), WithKeyGroupEnabled[object](KeyGroupNavigation, false))
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected navigation keys ignored, got selection %d", vp.GetSelectedItemIdx())
+	}
+	if vp.GetKeyGroupEnabled(KeyGroupNavigation) {
+		t.Error("expected navigation group disabled")
+	}
+
+	vp.SetKeyGroupEnabled(KeyGroupNavigation, true)
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected navigation keys handled once re-enabled, got selection %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestKeyGroups_SelectionDisabledKeepsNavigation(t *testing.T) {
+	keyMap := DefaultKeyMap()
+	keyMap.ToggleMarked.SetEnabled(true)
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true), WithKeyMap[object](keyMap))
+	vp.SetKeyGroupEnabled(KeyGroupSelection, false)
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, _ = vp.Update(toggleMarkedKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	if len(vp.GetMarkedItemIdxs()) != 0 {
+		t.Errorf("expected selection keys ignored, got marks %v", vp.GetMarkedItemIdxs())
+	}
+	if vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected navigation keys still handled, got selection %d", vp.GetSelectedItemIdx())
+	}
+	if !vp.GetKeyGroupEnabled(KeyGroupFeatures) {
+		t.Error("expected feature group still enabled")
+	}
+}
+
+func TestKeyGroups_CoverEveryBinding(t *testing.T) {
+	km := DefaultKeyMap()
+	for _, group := range []KeyGroup{KeyGroupNavigation, KeyGroupSelection, KeyGroupFeatures} {
+		km.SetGroupEnabled(group, false)
+	}
+	for _, group := range []KeyGroup{KeyGroupNavigation, KeyGroupSelection, KeyGroupFeatures} {
+		for _, b := range km.Group(group) {
+			if b.Enabled() {
+				t.Errorf("expected %v disabled with its group", b.Keys())
+			}
+		}
+	}
+	numBindings := reflect.TypeOf(km).NumField()
+	if n := len(km.Group(KeyGroupNavigation)) + len(km.Group(KeyGroupSelection)) + len(km.Group(KeyGroupFeatures)); n != numBindings {
+		t.Errorf("expected the groups to cover all %d bindings, got %d", numBindings, n)
+	}
+}
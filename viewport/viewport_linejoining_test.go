@@ -0,0 +1,154 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func isIndented(o object) bool {
+	return strings.HasPrefix(o.GetItem().ContentNoAnsi(), " ")
+}
+
+var toggleExpandKeyMsg = tea.KeyPressMsg{Code: tea.KeyTab}
+
+func TestLineJoiningCollapsedByDefault(t *testing.T) {
+	w, h := 30, 6
+	vp := newViewport(w, h, WithLineJoining[object](isIndented))
+	setContent(vp, []string{
+		"Exception in main",
+		"  at foo",
+		"  at bar",
+		"next line",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"Exception in main (+2)",
+		"next line",
+		"",
+		"",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineJoiningToggleExpand(t *testing.T) {
+	w, h := 30, 6
+	vp := newViewport(w, h, WithLineJoining[object](isIndented), WithSelectionEnabled[object](true))
+	vp.SetWrapText(true)
+	setContent(vp, []string{
+		"Exception in main",
+		"  at foo",
+		"  at bar",
+		"next line",
+	})
+
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if !vp.IsGroupExpanded(0) {
+		t.Fatal("expected group 0 to be expanded")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("Exception in main"),
+		selectionStyle.Render("  at foo"),
+		selectionStyle.Render("  at bar"),
+		"next line",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// moving down skips over the joined continuation lines
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected selected item 1, got %d", vp.GetSelectedItemIdx())
+	}
+
+	vp, _ = vp.Update(upKeyMsg)
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("Exception in main (+2)"),
+		"next line",
+		"",
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineJoiningExpandedStateSurvivesSetObjects(t *testing.T) {
+	w, h := 30, 6
+	vp := newViewport(w, h, WithLineJoining[object](isIndented))
+	vp.SetWrapText(true)
+	setContent(vp, []string{
+		"first",
+		"  detail",
+		"second",
+	})
+	vp.SetGroupExpanded(0, true)
+	setContent(vp, []string{
+		"first",
+		"  detail",
+		"second",
+		"third",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"first",
+		"  detail",
+		"second",
+		"third",
+		"",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineJoiningGetGroupObjectsAndDisable(t *testing.T) {
+	w, h := 30, 5
+	vp := newViewport(w, h)
+	setContent(vp, []string{
+		"parent",
+		"  child",
+		"other",
+	})
+	if n := len(vp.GetGroupObjects(0)); n != 1 {
+		t.Errorf("expected 1 object without joining, got %d", n)
+	}
+
+	vp.SetLineJoining(isIndented)
+	if n := len(vp.GetGroupObjects(0)); n != 2 {
+		t.Errorf("expected 2 objects in group, got %d", n)
+	}
+
+	vp.SetLineJoining(nil)
+	expectedView := internal.Pad(w, h, []string{
+		"parent",
+		"  child",
+		"other",
+		"",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineJoiningLeadingContinuationIsOwnGroup(t *testing.T) {
+	w, h := 30, 4
+	vp := newViewport(w, h, WithLineJoining[object](isIndented))
+	setContent(vp, []string{
+		"  orphan",
+		"  orphan child",
+		"parent",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"  orphan (+1)",
+		"parent",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
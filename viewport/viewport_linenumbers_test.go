@@ -0,0 +1,98 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+// newNumberedViewport returns a viewport with unstyled line numbers
+func newNumberedViewport(w, h int, opts ...Option[object]) *Model[object] {
+	vp := newViewport(w, h, opts...)
+	styles := vp.display.styles
+	styles.LineNumberStyle = lipgloss.NewStyle()
+	vp.SetStyles(styles)
+	return vp
+}
+
+func TestLineNumbers_AbsoluteGrowsWithContent(t *testing.T) {
+	w, h := 12, 4
+	vp := newNumberedViewport(w, h, WithLineNumbers[object](LineNumbersAbsolute, 1), WithStickyBottom[object](true))
+	setContent(vp, numberedContent(9))
+	vp.ScrollDown(6)
+
+	expectedView := internal.Pad(w, h, []string{
+		"7 6",
+		"8 7",
+		"9 8",
+		"100% (9/9)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// the tenth item widens the column
+	vp.AppendObjects(toObjects([]string{"9"}))
+	expectedView = internal.Pad(w, h, []string{
+		" 8 7",
+		" 9 8",
+		"10 9",
+		"100% (10/10)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineNumbers_WrappedItemNumberedOnce(t *testing.T) {
+	w, h := 10, 3
+	vp := newNumberedViewport(w, h, WithLineNumbers[object](LineNumbersAbsolute, 0), WithWrapText[object](true))
+	setContent(vp, []string{"abcdefghijkl", "m"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"0 abcdefgh",
+		"  ijkl",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// the item scrolled partly out of view has no number
+	vp.ScrollDown(1)
+	expectedView = internal.Pad(w, h, []string{
+		"  ijkl",
+		"1 m",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineNumbers_RelativeToSelection(t *testing.T) {
+	w, h := 10, 5
+	vp := newNumberedViewport(w, h, WithLineNumbers[object](LineNumbersRelative, 1),
+		WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c", "d"})
+	vp.SetSelectedItemIdx(1)
+
+	expectedView := internal.Pad(w, h, []string{
+		"1 a",
+		"2 " + selectionStyle.Render("b"),
+		"1 c",
+		"2 d",
+		"50% (2/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLineNumbers_ScreenPosition(t *testing.T) {
+	vp := newNumberedViewport(10, 4, WithLineNumbers[object](LineNumbersAbsolute, 1))
+	setContent(vp, []string{"hello", "world"})
+
+	if idx, offset, ok := vp.GetItemAtScreenPosition(1, 4); !ok || idx != 1 || offset != 2 {
+		t.Errorf("expected item 1 at byte 2 past the line numbers, got %d at %d (%v)", idx, offset, ok)
+	}
+	if row, col, ok := vp.ScreenPositionOf(1, 2); !ok || row != 1 || col != 4 {
+		t.Errorf("expected row 1, column 4, got %d, %d (%v)", row, col, ok)
+	}
+
+	vp.SetLineNumbers(LineNumbersOff, 1)
+	if mode, _ := vp.GetLineNumbers(); mode != LineNumbersOff || vp.numLineNumberCols() != 0 {
+		t.Error("expected line numbers off")
+	}
+}
@@ -0,0 +1,91 @@
+package viewport
+
+import (
+	"reflect"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestDetectLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []Link
+	}{
+		{name: "none", content: "no links here: none"},
+		{
+			name:    "url trimmed of trailing punctuation",
+			content: "see https://example.com/a?b=1.",
+			expected: []Link{{ID: "https://example.com/a?b=1", ByteRange: item.ByteRange{Start: 4, End: 29},
+				URL: "https://example.com/a?b=1", Kind: LinkKindURL}},
+		},
+		{
+			name:    "file paths",
+			content: "main.go:7 and ./pkg/a_b.go:12:3 but not a:1",
+			expected: []Link{
+				{ID: "main.go:7", ByteRange: item.ByteRange{Start: 0, End: 9}, Kind: LinkKindFilePath},
+				{ID: "./pkg/a_b.go:12:3", ByteRange: item.ByteRange{Start: 14, End: 31}, Kind: LinkKindFilePath},
+			},
+		},
+		{
+			name:    "path in url",
+			content: "http://host/x.go:8 y.go:9",
+			expected: []Link{
+				{ID: "http://host/x.go:8", ByteRange: item.ByteRange{Start: 0, End: 18}, URL: "http://host/x.go:8",
+					Kind: LinkKindURL},
+				{ID: "y.go:9", ByteRange: item.ByteRange{Start: 19, End: 25}, Kind: LinkKindFilePath},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectLinks(tt.content); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLinkDetection_StyledCycledAndListed(t *testing.T) {
+	w, h := 30, 4
+	vp := newViewport(w, h, WithLinkDetection[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		LinkStyle:         internal.RedFg,
+		DetectedLinkStyle: internal.BlueFg,
+		FocusedLinkStyle:  internal.GreenFg,
+	})
+	setContent(vp, []string{
+		"at main.go:12",
+		"see https://a.example",
+		"plain",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"at " + internal.BlueFg.Render("main.go:12"),
+		"see " + internal.BlueFg.Render("https://a.example"),
+		"plain",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	detected := vp.GetDetectedLinks()
+	if len(detected) != 2 || detected[0].ItemIndex != 0 || detected[0].Link.ID != "main.go:12" ||
+		detected[1].ItemIndex != 1 || detected[1].Link.URL != "https://a.example" {
+		t.Errorf("expected the path and the url detected, got %+v", detected)
+	}
+
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	_, cmd := vp.Update(activateLinkKeyMsg)
+	if msg, ok := cmd().(LinkActivatedMsg); !ok || msg.ID != "main.go:12" {
+		t.Errorf("expected main.go:12 activated, got %+v", cmd())
+	}
+
+	vp.SetLinkDetection(false)
+	if vp.GetLinkDetection() || len(vp.GetDetectedLinks()) != 0 {
+		t.Error("expected no detected links with detection off")
+	}
+}
@@ -0,0 +1,225 @@
+package viewport
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+var (
+	nextLinkKeyMsg     = internal.MakeKeyMsg(']')
+	prevLinkKeyMsg     = internal.MakeKeyMsg('[')
+	activateLinkKeyMsg = tea.KeyPressMsg{Code: tea.KeyEnter}
+)
+
+func newLinksViewport(width, height int, options ...Option[object]) *Model[object] {
+	vp := newViewport(width, height, options...)
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		LinkStyle:         internal.RedFg,
+		FocusedLinkStyle:  internal.GreenFg,
+	})
+	return vp
+}
+
+func TestLinks_StyledAndCycled(t *testing.T) {
+	w, h := 20, 3
+	vp := newLinksViewport(w, h)
+	setContent(vp, []string{
+		"[ok] [cancel]",
+		"see docs",
+	})
+	vp.SetLinks(0, []Link{
+		{ID: "cancel", ByteRange: item.ByteRange{Start: 5, End: 13}},
+		{ID: "ok", ByteRange: item.ByteRange{Start: 0, End: 4}},
+	})
+	vp.SetLinks(1, []Link{{ID: "docs", ByteRange: item.ByteRange{Start: 4, End: 8}}})
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("[ok]") + " " + internal.RedFg.Render("[cancel]"),
+		"see " + internal.RedFg.Render("docs"),
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	var ids []string
+	for range 4 {
+		vp, _ = vp.Update(nextLinkKeyMsg)
+		_, link, ok := vp.GetFocusedLink()
+		if !ok {
+			t.Fatal("expected a focused link")
+		}
+		ids = append(ids, link.ID)
+	}
+	if want := []string{"ok", "cancel", "docs", "ok"}; !slices.Equal(ids, want) {
+		t.Errorf("expected links focused in order %v, got %v", want, ids)
+	}
+
+	vp, _ = vp.Update(prevLinkKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		internal.RedFg.Render("[ok]") + " " + internal.RedFg.Render("[cancel]"),
+		"see " + internal.GreenFg.Render("docs"),
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestLinks_ActivateSendsMsg(t *testing.T) {
+	w, h := 20, 3
+	vp := newLinksViewport(w, h)
+	setContent(vp, []string{"a", "[go]"})
+
+	vp, cmd := vp.Update(activateLinkKeyMsg)
+	if cmd != nil {
+		t.Fatal("expected no command without links")
+	}
+
+	vp.SetLinks(1, []Link{{ID: "go", ByteRange: item.ByteRange{Start: 0, End: 4}}})
+	vp, cmd = vp.Update(activateLinkKeyMsg)
+	if cmd != nil {
+		t.Fatal("expected no command without a focused link")
+	}
+
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	_, cmd = vp.Update(activateLinkKeyMsg)
+	if cmd == nil {
+		t.Fatal("expected a command activating the focused link")
+	}
+	msg, ok := cmd().(LinkActivatedMsg)
+	if !ok {
+		t.Fatalf("expected LinkActivatedMsg, got %T", cmd())
+	}
+	if msg.ItemIndex != 1 || msg.ID != "go" {
+		t.Errorf("expected link go in item 1, got %+v", msg)
+	}
+}
+
+func TestLinks_OnlyVisibleLinksCycled(t *testing.T) {
+	w, h := 20, 3
+	vp := newLinksViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"[a]", "[b]", "[c]", "[d]"})
+	for i, id := range []string{"a", "b", "c", "d"} {
+		vp.SetLinks(i, []Link{{ID: id, ByteRange: item.ByteRange{Start: 0, End: 3}}})
+	}
+
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	if itemIdx, link, _ := vp.GetFocusedLink(); itemIdx != 0 || link.ID != "a" {
+		t.Errorf("expected focus to wrap to link a in view, got %q in item %d", link.ID, itemIdx)
+	}
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected focused link's item selected, got %d", vp.GetSelectedItemIdx())
+	}
+
+	// scrolled away from the focused link, focus moves to the first link in view
+	vp.SetSelectedItemIdx(3)
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	if itemIdx, link, _ := vp.GetFocusedLink(); itemIdx != 2 || link.ID != "c" {
+		t.Errorf("expected link c focused, got %q in item %d", link.ID, itemIdx)
+	}
+}
+
+func TestLinks_ShiftedOnPrepend(t *testing.T) {
+	w, h := 20, 4
+	vp := newLinksViewport(w, h)
+	setContent(vp, []string{"[a]"})
+	vp.SetLinks(0, []Link{{ID: "a", ByteRange: item.ByteRange{Start: 0, End: 3}}})
+	vp.NextLink()
+
+	vp.PrependObjects(toObjects([]string{"new"}))
+	if got := vp.GetLinks(1); len(got) != 1 || got[0].ID != "a" {
+		t.Errorf("expected link a moved to item 1, got %v", got)
+	}
+	if itemIdx, link, ok := vp.GetFocusedLink(); !ok || itemIdx != 1 || link.ID != "a" {
+		t.Errorf("expected link a still focused in item 1, got %q in item %d", link.ID, itemIdx)
+	}
+
+	vp.ClearLinks()
+	if _, _, ok := vp.GetFocusedLink(); ok {
+		t.Error("expected no focused link after ClearLinks")
+	}
+}
+
+// hyperlink returns text wrapped in an OSC 8 hyperlink to url
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+var openLinkKeyMsg = internal.MakeKeyMsg('O')
+
+func TestLinks_HyperlinksStyledAndHandled(t *testing.T) {
+	w, h := 20, 3
+	var opened []string
+	vp := newLinksViewport(w, h, WithSelectionEnabled[object](true), WithLinkHandler[object](func(url string) tea.Cmd {
+		opened = append(opened, url)
+		return func() tea.Msg { return nil }
+	}))
+	setContent(vp, []string{
+		"see " + hyperlink("https://a.example", "docs"),
+		"plain",
+	})
+
+	if links := vp.GetHyperlinks(0); len(links) != 1 || links[0].URL != "https://a.example" {
+		t.Fatalf("expected a hyperlink to https://a.example, got %+v", links)
+	}
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("see ") + internal.RedFg.Render("docs"),
+		"plain",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// open link opens the first link in the selected item
+	vp, cmd := vp.Update(openLinkKeyMsg)
+	if cmd == nil || !slices.Equal(opened, []string{"https://a.example"}) {
+		t.Errorf("expected https://a.example opened, got %v", opened)
+	}
+	if _, link, ok := vp.GetFocusedLink(); !ok || link.URL != "https://a.example" {
+		t.Errorf("expected the hyperlink focused, got %+v", link)
+	}
+
+	// nothing to open in the second item
+	vp, _ = vp.Update(downKeyMsg)
+	vp.ClearLinks()
+	if _, cmd = vp.Update(openLinkKeyMsg); cmd != nil {
+		t.Error("expected no command without a link")
+	}
+}
+
+func TestLinks_HyperlinkWithoutHandlerSendsMsg(t *testing.T) {
+	vp := newLinksViewport(20, 3)
+	setContent(vp, []string{"see " + hyperlink("https://a.example", "docs")})
+
+	vp, _ = vp.Update(nextLinkKeyMsg)
+	_, cmd := vp.Update(activateLinkKeyMsg)
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	msg, ok := cmd().(LinkActivatedMsg)
+	if !ok || msg.URL != "https://a.example" || msg.ID != "https://a.example" || msg.ItemIndex != 0 {
+		t.Errorf("expected a LinkActivatedMsg for https://a.example, got %+v", cmd())
+	}
+}
+
+func TestLinks_ClickHyperlink(t *testing.T) {
+	var opened []string
+	vp := newLinksViewport(20, 3, WithMouseSupport[object](true), WithLinkHandler[object](func(url string) tea.Cmd {
+		opened = append(opened, url)
+		return func() tea.Msg { return nil }
+	}))
+	setContent(vp, []string{"see " + hyperlink("https://a.example", "docs") + " " + hyperlink("https://b.example", "api")})
+
+	// clicking outside links doesn't open any
+	vp, _ = vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 1, Y: 0})
+	vp, _ = vp.Update(tea.MouseReleaseMsg{Button: tea.MouseLeft, X: 1, Y: 0})
+	_, cmd := vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 10, Y: 0})
+	if cmd == nil || !slices.Equal(opened, []string{"https://b.example"}) {
+		t.Errorf("expected https://b.example opened, got %v", opened)
+	}
+}
@@ -0,0 +1,60 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/spinner"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+// loadingSpinner is a spinner with recognizable frames
+var loadingSpinner = spinner.Spinner{Frames: []string{"-", "+"}}
+
+func TestLoading_CentersSpinnerWithoutContent(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h, WithLoadingSpinner[object](loadingSpinner), WithLoadingMessage[object]("Fetching"))
+	cmd := vp.SetLoading(true)
+	if cmd == nil {
+		t.Fatal("expected a command animating the spinner")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"",
+		" - Fetching",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// each tick advances the spinner and schedules the next
+	vp, cmd = vp.Update(cmd())
+	if cmd == nil {
+		t.Error("expected the spinner to keep ticking while loading")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"",
+		" + Fetching",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// once content arrives, the spinner leads the footer
+	setContent(vp, []string{"a"})
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"",
+		"",
+		"+ Fetchin...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	if vp.SetLoading(false) != nil {
+		t.Error("expected no command when loading stops")
+	}
+	if _, cmd = vp.Update(spinner.TickMsg{}); cmd != nil {
+		t.Error("expected the spinner to stop ticking once loading stops")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
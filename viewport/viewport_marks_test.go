@@ -0,0 +1,118 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var toggleMarkedKeyMsg = tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+
+func newMarksViewport(width, height int, options ...Option[object]) *Model[object] {
+	keyMap := DefaultKeyMap()
+	keyMap.ToggleMarked.SetEnabled(true)
+	options = append([]Option[object]{WithSelectionEnabled[object](true), WithKeyMap[object](keyMap)}, options...)
+	vp := newViewport(width, height, options...)
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		MarkedItemStyle:   internal.RedFg,
+	})
+	return vp
+}
+
+func TestMarks_ToggleMarkedKey(t *testing.T) {
+	w, h := 10, 4
+	vp := newMarksViewport(w, h)
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, _ = vp.Update(toggleMarkedKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(toggleMarkedKeyMsg)
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("a"),
+		"b",
+		selectionStyle.Render("c"),
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	marked := vp.GetMarkedItems()
+	if len(marked) != 2 || marked[0].GetItem().Content() != "a" || marked[1].GetItem().Content() != "c" {
+		t.Errorf("expected a and c marked, got %v", marked)
+	}
+
+	vp, _ = vp.Update(toggleMarkedKeyMsg)
+	if got := vp.GetMarkedItemIdxs(); len(got) != 1 || got[0] != 0 {
+		t.Errorf("expected only item 0 marked, got %v", got)
+	}
+}
+
+func TestMarks_ToggleMarkedKeyDisabledByDefault(t *testing.T) {
+	vp := newViewport(10, 3, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	vp, _ = vp.Update(toggleMarkedKeyMsg)
+	if got := vp.GetMarkedItemIdxs(); len(got) != 0 {
+		t.Errorf("expected no marks, got %v", got)
+	}
+}
+
+func TestMarks_IgnoredWithoutSelection(t *testing.T) {
+	w, h := 10, 3
+	vp := newMarksViewport(w, h)
+	vp.SetSelectionEnabled(false)
+	setContent(vp, []string{"a", "b"})
+
+	vp, _ = vp.Update(toggleMarkedKeyMsg)
+	if got := vp.GetMarkedItemIdxs(); len(got) != 0 {
+		t.Errorf("expected no marks, got %v", got)
+	}
+}
+
+func TestMarks_ClearMarks(t *testing.T) {
+	w, h := 10, 3
+	vp := newMarksViewport(w, h)
+	setContent(vp, []string{"a", "b"})
+	vp.SetMarked(0, true)
+	vp.SetMarked(1, true)
+	vp.SetMarked(5, true)
+	vp.ClearMarks()
+
+	if got := vp.GetMarkedItemIdxs(); len(got) != 0 {
+		t.Errorf("expected no marks, got %v", got)
+	}
+}
+
+func TestMarks_KeptAcrossContentChanges(t *testing.T) {
+	w, h := 10, 3
+	vp := newMarksViewport(w, h)
+	setContent(vp, []string{"a", "b"})
+	vp.SetMarked(1, true)
+
+	vp.PrependObjects(toObjects([]string{"z"}))
+	if !vp.IsMarked(2) || vp.IsMarked(1) {
+		t.Errorf("expected mark to shift to item 2, got %v", vp.GetMarkedItemIdxs())
+	}
+
+	vp.AppendObjects(toObjects([]string{"c"}))
+	if got := vp.GetMarkedItemIdxs(); len(got) != 1 || got[0] != 2 {
+		t.Errorf("expected item 2 marked after append, got %v", got)
+	}
+
+	vp.SetSelectionComparator(objectsEqual)
+	setContent(vp, []string{"c", "b", "a"})
+	if got := vp.GetMarkedItemIdxs(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected b at item 1 marked, got %v", got)
+	}
+
+	vp.SetSelectionComparator(nil)
+	setContent(vp, []string{"b"})
+	if got := vp.GetMarkedItemIdxs(); len(got) != 0 {
+		t.Errorf("expected marks cleared without comparator, got %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+)
+
+func isError(o object) bool {
+	return strings.HasPrefix(o.item.Content(), "ERROR")
+}
+
+func TestMatching_MovesSelection(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"ok", "ERROR a", "ok", "ok", "ERROR b", "ok"})
+
+	if !vp.NextItemMatching(isError) || vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected item 1 selected, got %d", vp.GetSelectedItemIdx())
+	}
+	if !vp.NextItemMatching(isError) || vp.GetSelectedItemIdx() != 4 {
+		t.Errorf("expected item 4 selected, got %d", vp.GetSelectedItemIdx())
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 2 {
+		t.Errorf("expected item 2 at the top, got %d", top)
+	}
+
+	// no later match leaves the selection
+	if vp.NextItemMatching(isError) || vp.GetSelectedItemIdx() != 4 {
+		t.Errorf("expected no match and item 4 selected, got %d", vp.GetSelectedItemIdx())
+	}
+
+	if !vp.PrevItemMatching(isError) || vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected item 1 selected, got %d", vp.GetSelectedItemIdx())
+	}
+	if vp.PrevItemMatching(isError) {
+		t.Error("expected no earlier match")
+	}
+}
+
+func TestMatching_Padding(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true), WithMatchingItemPadding[object](1))
+	setContent(vp, numberedContent(20))
+	if vp.GetMatchingItemPadding() != 1 {
+		t.Fatalf("expected padding 1, got %d", vp.GetMatchingItemPadding())
+	}
+
+	is := func(s string) func(object) bool {
+		return func(o object) bool { return o.item.Content() == s }
+	}
+	vp.NextItemMatching(is("10"))
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 9 {
+		t.Errorf("expected a line of context above item 10, got item %d at the top", top)
+	}
+}
+
+func TestMatching_WithoutSelection(t *testing.T) {
+	vp := newViewport(10, 4)
+	setContent(vp, []string{"ERROR a", "ok", "ERROR b", "ok", "ok", "ok", "ERROR c", "ok"})
+
+	// the top item itself can match, and later calls continue from the last match in view
+	for _, expected := range []int{0, 2, 6} {
+		if !vp.NextItemMatching(isError) {
+			t.Fatalf("expected a match at item %d", expected)
+		}
+		if vp.navigation.matchingItemIdx != expected {
+			t.Errorf("expected a match at item %d, got %d", expected, vp.navigation.matchingItemIdx)
+		}
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 4 {
+		t.Errorf("expected item 4 at the top, got %d", top)
+	}
+
+	// scrolling the last match out of view continues from the top of the view
+	vp.GoToTop()
+	if !vp.NextItemMatching(isError) || vp.navigation.matchingItemIdx != 0 {
+		t.Errorf("expected a match at item 0, got %d", vp.navigation.matchingItemIdx)
+	}
+}
@@ -0,0 +1,66 @@
+package viewport
+
+import (
+	"testing"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestMessage_ShowsOverFooterUntilCleared(t *testing.T) {
+	w, h := 20, 3
+	vp := newViewport(w, h)
+	setContent(vp, []string{"a", "b"})
+
+	cmd := vp.ShowMessage("Exported 2 rows", time.Millisecond)
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"Exported 2 rows",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if vp.GetMessage() != "Exported 2 rows" {
+		t.Errorf("expected the message shown, got %q", vp.GetMessage())
+	}
+
+	// a newer message isn't cleared by the older one's command
+	staleClear := cmd()
+	cmd = vp.ShowMessage("Exported 3 rows", time.Millisecond)
+	vp, _ = vp.Update(staleClear)
+	if vp.GetMessage() != "Exported 3 rows" {
+		t.Errorf("expected the newer message kept, got %q", vp.GetMessage())
+	}
+
+	vp, _ = vp.Update(cmd())
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMessage_ErrorStyleAndNoDuration(t *testing.T) {
+	w, h := 20, 3
+	vp := newViewport(w, h)
+	styles := vp.display.styles
+	styles.ErrorMessageStyle = lipgloss.NewStyle().Foreground(lipgloss.Red)
+	vp.SetStyles(styles)
+	setContent(vp, []string{"a", "b"})
+
+	if cmd := vp.ShowErrorMessage("Export failed", 0); cmd != nil {
+		t.Error("expected no command clearing a message without a duration")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		lipgloss.NewStyle().Foreground(lipgloss.Red).Render("Export failed"),
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ClearMessage()
+	if vp.GetMessage() != "" {
+		t.Errorf("expected the message cleared, got %q", vp.GetMessage())
+	}
+}
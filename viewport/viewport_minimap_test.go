@@ -0,0 +1,125 @@
+package viewport
+
+import (
+	"fmt"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// numberedContent returns n lines "0" to "n-1"
+func numberedContent(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprint(i)
+	}
+	return lines
+}
+
+func highlightItems(itemIdxs ...int) []Highlight {
+	var highlights []Highlight
+	for _, idx := range itemIdxs {
+		highlights = append(highlights, Highlight{
+			ItemIndex: idx,
+			ItemHighlight: item.Highlight{
+				Style:                    lipgloss.NewStyle(),
+				ByteRangeUnstyledContent: item.ByteRange{Start: 0, End: 1},
+			},
+		})
+	}
+	return highlights
+}
+
+func TestMinimap_MarksHighlightsAndSearchMatches(t *testing.T) {
+	w, h := 6, 6
+	vp := newViewport(w, h, WithMinimap[object](true))
+	setContent(vp, numberedContent(20))
+	vp.SetHighlights(highlightItems(3, 17, 18))
+	vp.SetSearch("10")
+
+	// each of the 5 rows stands for 4 items, and searching scrolled to the match
+	expectedView := internal.Pad(w, h, []string{
+		"6    •",
+		"7",
+		"8    •",
+		"9",
+		"10   •",
+		"55%...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if !vp.GetMinimap() {
+		t.Fatal("expected the minimap to be enabled")
+	}
+}
+
+func TestMinimap_WithScrollbar(t *testing.T) {
+	w, h := 6, 4
+	vp := newViewport(w, h, WithMinimap[object](true), WithScrollbar[object](true))
+	setContent(vp, numberedContent(3))
+	vp.SetHighlights(highlightItems(1))
+
+	// the minimap is left of the scrollbar, narrowing the content
+	expectedView := internal.Pad(w, h, []string{
+		"0    ┃",
+		"1   •┃",
+		"2    ┃",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetMinimap(false)
+	expectedView = internal.Pad(w, h, []string{
+		"0    ┃",
+		"1    ┃",
+		"2    ┃",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMinimap_ClickJumpsToMark(t *testing.T) {
+	w, h := 6, 6
+	vp := newViewport(w, h, WithMinimap[object](true), WithMouseSupport[object](true),
+		WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(20))
+	vp.SetHighlights(highlightItems(14))
+
+	// row 3 stands for items 12 to 15
+	vp, _ = vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: w - 1, Y: 3})
+	if vp.GetSelectedItemIdx() != 14 {
+		t.Errorf("expected selected item 14, got %d", vp.GetSelectedItemIdx())
+	}
+	// centered in view
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 12 {
+		t.Errorf("expected item 12 at the top, got %d", top)
+	}
+
+	// row 0 has no marks, jumping to its first item
+	vp, _ = vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: w - 1, Y: 0})
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected selected item 0, got %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestMinimapRowStart(t *testing.T) {
+	tests := []struct {
+		row, height, numItems, expected int
+	}{
+		{row: 0, height: 5, numItems: 20, expected: 0},
+		{row: 1, height: 5, numItems: 20, expected: 4},
+		{row: 5, height: 5, numItems: 20, expected: 20},
+		// fewer items than rows: rows without items start at the next item
+		{row: 1, height: 5, numItems: 2, expected: 1},
+		{row: 2, height: 5, numItems: 2, expected: 1},
+		{row: 3, height: 5, numItems: 2, expected: 2},
+	}
+	for _, tt := range tests {
+		if got := minimapRowStart(tt.row, tt.height, tt.numItems); got != tt.expected {
+			t.Errorf("row %d of %d for %d items: expected %d, got %d", tt.row, tt.height, tt.numItems, tt.expected,
+				got)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestMouse_DisabledByDefault(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h)
+	setContent(vp, []string{"a", "b", "c", "d"})
+
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"50% (2/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMouse_WheelScrolls(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h, WithMouseSupport[object](true))
+	setContent(vp, []string{"a", "b", "c", "d", "e", "f"})
+
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	expectedView := internal.Pad(w, h, []string{
+		"d",
+		"e",
+		"83% (5/6)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"33% (2/6)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMouse_WheelMovesSelection(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h, WithMouseSupport[object](true), WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c", "d", "e", "f"})
+
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if vp.GetSelectedItemIdx() != 3 {
+		t.Errorf("expected selected item 3, got %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestMouse_ShiftWheelPans(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h, WithMouseSupport[object](true))
+	setContent(vp, []string{"0123456789abcdefghij", "b"})
+
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown, Mod: tea.ModShift})
+	if vp.GetXOffsetWidth() != mouseWheelCols {
+		t.Errorf("expected x offset %d, got %d", mouseWheelCols, vp.GetXOffsetWidth())
+	}
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelLeft})
+	if vp.GetXOffsetWidth() != 0 {
+		t.Errorf("expected x offset 0, got %d", vp.GetXOffsetWidth())
+	}
+}
+
+func TestMouse_ClickSelects(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h, WithMouseSupport[object](true), WithSelectionEnabled[object](true))
+	vp.SetHeader([]string{"header"})
+	vp.SetMouseOrigin(2, 1)
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, _ = vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 3, Y: 4})
+	vp, _ = vp.Update(tea.MouseReleaseMsg{Button: tea.MouseLeft, X: 3, Y: 4})
+	expectedView := internal.Pad(w, h, []string{
+		"header",
+		"a",
+		"b",
+		selectionStyle.Render("c"),
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// clicking the header does nothing
+	vp, _ = vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 3, Y: 1})
+	if vp.GetSelectedItemIdx() != 2 {
+		t.Errorf("expected selected item 2, got %d", vp.GetSelectedItemIdx())
+	}
+}
+
+func TestMouse_DragScrolls(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h, WithMouseSupport[object](true), WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c", "d", "e", "f"})
+
+	vp, _ = vp.Update(tea.MouseClickMsg{Button: tea.MouseLeft, X: 0, Y: 2})
+	vp, _ = vp.Update(tea.MouseMotionMsg{Button: tea.MouseLeft, X: 0, Y: 0})
+	vp, _ = vp.Update(tea.MouseReleaseMsg{Button: tea.MouseLeft, X: 0, Y: 0})
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("c"),
+		"d",
+		"e",
+		"50% (3/6)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// motion after release doesn't scroll
+	vp, _ = vp.Update(tea.MouseMotionMsg{Button: tea.MouseLeft, X: 0, Y: 2})
+	internal.CmpStr(t, expectedView, vp.View())
+}
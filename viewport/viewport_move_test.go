@@ -0,0 +1,145 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var (
+	moveItemUpKeyMsg   = tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModAlt}
+	moveItemDownKeyMsg = tea.KeyPressMsg{Code: tea.KeyDown, Mod: tea.ModAlt}
+)
+
+func newMoveViewport(width, height int, options ...Option[object]) *Model[object] {
+	vp := newViewport(width, height, append([]Option[object]{
+		WithSelectionEnabled[object](true),
+		WithMoveMode[object](true),
+	}, options...)...)
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		MovingItemStyle:   internal.GreenFg,
+		MarkedItemStyle:   internal.RedFg,
+	})
+	return vp
+}
+
+func TestMove_KeysReorderSelectedItem(t *testing.T) {
+	w, h := 10, 4
+	vp := newMoveViewport(w, h)
+	setContent(vp, []string{"a", "b", "c"})
+	vp.SetMarked(1, true)
+
+	vp, cmd := vp.Update(moveItemDownKeyMsg)
+	if cmd == nil {
+		t.Fatal("expected a command on move")
+	}
+	if msg, ok := cmd().(ItemMovedMsg); !ok || msg.From != 0 || msg.To != 1 {
+		t.Errorf("expected a move from 0 to 1, got %+v", cmd())
+	}
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("b"),
+		internal.GreenFg.Render("a"),
+		"c",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(moveItemDownKeyMsg)
+	vp, cmd = vp.Update(moveItemDownKeyMsg)
+	if cmd != nil {
+		t.Error("expected no move past the last item")
+	}
+	vp, _ = vp.Update(moveItemUpKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		internal.RedFg.Render("b"),
+		internal.GreenFg.Render("a"),
+		"c",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMove_KeysIgnoredOutsideMoveMode(t *testing.T) {
+	w, h := 10, 4
+	vp := newMoveViewport(w, h)
+	vp.SetMoveMode(false)
+	setContent(vp, []string{"a", "b"})
+
+	vp, cmd := vp.Update(moveItemDownKeyMsg)
+	if cmd != nil {
+		t.Error("expected no move outside move mode")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMove_MoveItemKeepsSearchAndLinks(t *testing.T) {
+	w, h := 10, 5
+	vp := newMoveViewport(w, h)
+	setContent(vp, []string{"a", "b", "c", "d"})
+	vp.SetSearch("b")
+	vp.SetLinks(3, []Link{{ID: "d"}})
+
+	if !vp.MoveItem(3, 0) {
+		t.Fatal("expected the item to move")
+	}
+	if got := vp.GetLinks(0); len(got) != 1 || got[0].ID != "d" {
+		t.Errorf("expected link d moved to item 0, got %v", got)
+	}
+	if vp.content.search.matches[0].itemIdx != 2 {
+		t.Errorf("expected the search match in item 2, got %d", vp.content.search.matches[0].itemIdx)
+	}
+	if vp.GetSelectedItemIdx() != 2 {
+		t.Errorf("expected the selection to stay on item b at 2, got %d", vp.GetSelectedItemIdx())
+	}
+	if vp.MoveItem(0, 4) {
+		t.Error("expected a move out of range to fail")
+	}
+}
+
+func TestMove_MoveItemWithoutSelection(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h)
+	vp.SetStyles(Styles{FooterStyle: lipgloss.NewStyle()})
+	setContent(vp, []string{"a", "b", "c", "d"})
+
+	if !vp.MoveItem(3, 0) {
+		t.Fatal("expected the item to move")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"d",
+		"a",
+		"b",
+		"c",
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestMovedIdx(t *testing.T) {
+	tests := []struct {
+		idx, from, to, expected int
+	}{
+		{idx: 2, from: 2, to: 5, expected: 5},
+		{idx: 3, from: 2, to: 5, expected: 2},
+		{idx: 5, from: 2, to: 5, expected: 4},
+		{idx: 6, from: 2, to: 5, expected: 6},
+		{idx: 1, from: 4, to: 1, expected: 2},
+		{idx: 3, from: 4, to: 1, expected: 4},
+		{idx: 0, from: 4, to: 1, expected: 0},
+	}
+	for _, tt := range tests {
+		if got := movedIdx(tt.idx, tt.from, tt.to); got != tt.expected {
+			t.Errorf("movedIdx(%d, %d, %d) = %d, expected %d", tt.idx, tt.from, tt.to, got, tt.expected)
+		}
+	}
+}
@@ -286,3 +286,101 @@ func TestViewport_MultiLine_WrapOn_MultipleMultiLineItems(t *testing.T) {
 	})
 	internal.CmpStr(t, expectedView, vp.View())
 }
+
+func TestViewport_MultiLine_WrapOff_Basic(t *testing.T) {
+	w, h := 10, 6
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+
+	setMixedContent(vp, []item.Item{
+		item.NewMultiLineItemFromString("first\nsecond line long"),
+		item.NewItem("after"),
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.BlueFg.Render("first"),
+		internal.BlueFg.Render("second ..."),
+		"after",
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// panning applies to every line of the item
+	vp.SetXOffset(7)
+	expectedView = internal.Pad(w, h, []string{
+		internal.BlueFg.Render("..."),
+		internal.BlueFg.Render("...ne long"),
+		"...",
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_MultiLine_WrapOff_ScrollAndSelection(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h)
+	vp.SetSelectionEnabled(true)
+
+	setMixedContent(vp, []item.Item{
+		item.NewItem("zero"),
+		item.NewMultiLineItemFromString("a\nb\nc"),
+		item.NewItem("two"),
+	})
+
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		internal.BlueFg.Render("a"),
+		internal.BlueFg.Render("b"),
+		internal.BlueFg.Render("c"),
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"b",
+		"c",
+		internal.BlueFg.Render("two"),
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(goToTopKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		internal.BlueFg.Render("zero"),
+		"a",
+		"b",
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestViewport_MultiLine_WrapOff_NoSelectionScroll(t *testing.T) {
+	w, h := 10, 3
+	vp := newViewport(w, h)
+
+	setMixedContent(vp, []item.Item{
+		item.NewMultiLineItemFromString("a\nb\nc"),
+		item.NewItem("after"),
+	})
+
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		"c",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"c",
+		"after",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -348,3 +348,22 @@ func TestViewport_Navigation_ScrollLeftRight_WrapOnIsNoOp(t *testing.T) {
 	vp.ScrollLeft(5)
 	internal.CmpStr(t, wrappedView, vp.View())
 }
+
+func TestSetTopItemIdxAndLineOffset(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(10))
+
+	vp.SetTopItemIdxAndLineOffset(5, 0)
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 5 {
+		t.Errorf("expected item 5 at the top, got %d", top)
+	}
+	if vp.GetSelectedItemIdx() != 0 {
+		t.Errorf("expected the selection to stay on item 0, got %d", vp.GetSelectedItemIdx())
+	}
+
+	// clamped to the content
+	vp.SetTopItemIdxAndLineOffset(20, 0)
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 7 {
+		t.Errorf("expected item 7 at the top, got %d", top)
+	}
+}
@@ -0,0 +1,60 @@
+package viewport
+
+import (
+	"testing"
+)
+
+func contentIdentity(obj object) string {
+	return obj.item.ContentNoAnsi()
+}
+
+func TestSetObjectsDiffed_InsertionsAboveKeepView(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(20))
+	vp.SetSelectedItemIdx(12)
+	top, _ := vp.GetTopItemIdxAndLineOffset()
+
+	content := append([]string{"new1", "new2"}, numberedContent(20)...)
+	content = append(content, "new3")
+	vp.SetObjectsDiffed(toObjects(content), contentIdentity)
+
+	if newTop, _ := vp.GetTopItemIdxAndLineOffset(); newTop != top+2 {
+		t.Errorf("expected item %d at the top, got %d", top+2, newTop)
+	}
+	if selected := vp.GetSelectedItem(); selected == nil || selected.item.ContentNoAnsi() != "12" {
+		t.Errorf("expected 12 to stay selected, got %v", selected)
+	}
+}
+
+func TestSetObjectsDiffed_RemovedSelectionMovesToNearestSurvivor(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, numberedContent(20))
+	vp.SetSelectedItemIdx(12)
+
+	var content []string
+	for _, line := range numberedContent(20) {
+		if line != "0" && line != "12" && line != "13" {
+			content = append(content, line)
+		}
+	}
+	vp.SetObjectsDiffed(toObjects(content), contentIdentity)
+
+	if selected := vp.GetSelectedItem(); selected == nil || selected.item.ContentNoAnsi() != "14" {
+		t.Errorf("expected 14 to be selected, got %v", selected)
+	}
+
+	vp.SetObjectsDiffed(toObjects([]string{"1", "2"}), contentIdentity)
+	if selected := vp.GetSelectedItem(); selected == nil || selected.item.ContentNoAnsi() != "2" {
+		t.Errorf("expected the nearest preceding item 2 to be selected, got %v", selected)
+	}
+}
+
+func TestSetObjectsDiffed_StickyBottom(t *testing.T) {
+	vp := newViewport(10, 4, WithStickyBottom[object](true))
+	setContent(vp, numberedContent(20))
+	vp.SetObjectsDiffed(toObjects(numberedContent(25)), contentIdentity)
+
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 22 {
+		t.Errorf("expected to stay at the bottom with item 22 at the top, got %d", top)
+	}
+}
@@ -0,0 +1,77 @@
+package viewport
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestOverscroll_IndicatorAtBoundaries(t *testing.T) {
+	w, h := 15, 3
+	vp := newViewport(w, h, WithSelectionEnabled[object](true),
+		WithOverscrollIndicator[object]("TOP", "END", time.Millisecond))
+	styles := vp.display.styles
+	styles.OverscrollStyle = lipgloss.NewStyle()
+	vp.SetStyles(styles)
+	setContent(vp, []string{"a", "b", "c"})
+
+	// scrolling down that moves isn't overscrolling
+	vp, _ = vp.Update(downKeyMsg)
+	if info := vp.overscrollIndicator(); info != "" {
+		t.Errorf("expected no indicator while moving, got %q", info)
+	}
+
+	vp, _ = vp.Update(upKeyMsg)
+	vp, cmd := vp.Update(upKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"TOP  33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// the indicator hides after its duration
+	vp, _ = vp.Update(cmd())
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"b",
+		selectionStyle.Render("c"),
+		"END  100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestOverscroll_MouseWheelAndDisabled(t *testing.T) {
+	w, h := 15, 3
+	vp := newViewport(w, h, WithMouseSupport[object](true),
+		WithOverscrollIndicator[object]("TOP", "END", time.Second))
+	styles := vp.display.styles
+	styles.OverscrollStyle = lipgloss.NewStyle()
+	vp.SetStyles(styles)
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if info := vp.overscrollIndicator(); info != "TOP" {
+		t.Errorf("expected the TOP indicator after wheeling up at the top, got %q", info)
+	}
+
+	vp.SetOverscrollIndicator("", "", 0)
+	if info := vp.overscrollIndicator(); info != "" {
+		t.Errorf("expected the indicator hidden once disabled, got %q", info)
+	}
+	vp, _ = vp.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if info := vp.overscrollIndicator(); info != "" {
+		t.Errorf("expected no indicator while disabled, got %q", info)
+	}
+}
@@ -0,0 +1,66 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestPageScroll_KeepsSelectionInView(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true),
+		WithPageScrollSelection[object](PageScrollKeepsSelectionInView))
+	setContent(vp, numberedContent(10))
+	vp.SetSelectedItemIdx(1)
+
+	// the selection scrolls out of view, so moves to the top of it
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("3"),
+		"4",
+		"5",
+		"40% (4/10)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// the selection stays put while in view
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(halfPgUpKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"2",
+		"3",
+		selectionStyle.Render("4"),
+		"50% (5/10)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestPageScroll_LeavesSelection(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true),
+		WithPageScrollSelection[object](PageScrollLeavesSelection))
+	setContent(vp, numberedContent(10))
+
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		"6",
+		"7",
+		"8",
+		"10% (1/10)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// moving the selection scrolls back to it
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("1"),
+		"2",
+		"3",
+		"20% (2/10)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if vp.GetPageScrollSelection() != PageScrollLeavesSelection {
+		t.Errorf("expected PageScrollLeavesSelection, got %v", vp.GetPageScrollSelection())
+	}
+}
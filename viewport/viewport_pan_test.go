@@ -0,0 +1,136 @@
+package viewport
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var (
+	leftKeyMsg       = tea.KeyPressMsg{Code: tea.KeyLeft}
+	rightKeyMsg      = tea.KeyPressMsg{Code: tea.KeyRight}
+	panToStartKeyMsg = tea.KeyPressMsg{Code: tea.KeyHome}
+	panToEndKeyMsg   = tea.KeyPressMsg{Code: tea.KeyEnd}
+)
+
+// fakeClock returns a clock for the pan state that advances by step on each call
+func fakeClock(step time.Duration) func() time.Time {
+	now := time.Unix(0, 0)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestPan_DefaultStepIsQuarterWidth(t *testing.T) {
+	vp := newViewport(20, 3)
+	setContent(vp, []string{"0123456789012345678901234567890123456789"})
+
+	vp, _ = vp.Update(rightKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 5 {
+		t.Errorf("expected to pan a quarter of the width, 5, got %d", got)
+	}
+}
+
+func TestPan_Step(t *testing.T) {
+	vp := newViewport(20, 3, WithPanStep[object](2))
+	setContent(vp, []string{"0123456789012345678901234567890123456789"})
+
+	vp, _ = vp.Update(rightKeyMsg)
+	vp, _ = vp.Update(rightKeyMsg)
+	vp, _ = vp.Update(leftKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 2 {
+		t.Errorf("expected offset 2, got %d", got)
+	}
+}
+
+func TestPan_ToEndAndStart(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h)
+	setContent(vp, []string{"short", "a longer line", "mid line"})
+
+	vp, _ = vp.Update(panToEndKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		"..",
+		"...er line",
+		"...ne",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(panToStartKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 0 {
+		t.Errorf("expected offset 0, got %d", got)
+	}
+
+	vp.SetWrapText(true)
+	vp, _ = vp.Update(panToEndKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 0 {
+		t.Errorf("expected no panning when wrapped, got %d", got)
+	}
+}
+
+func TestPan_Acceleration(t *testing.T) {
+	vp := newViewport(20, 3, WithPanStep[object](1), WithPanAcceleration[object](3))
+	setContent(vp, []string{"0123456789012345678901234567890123456789"})
+
+	// held: repeats in quick succession speed up to the max multiplier
+	vp.navigation.pan.now = fakeClock(30 * time.Millisecond)
+	var offsets []int
+	for range 4 {
+		vp, _ = vp.Update(rightKeyMsg)
+		offsets = append(offsets, vp.GetXOffsetWidth())
+	}
+	if want := []int{1, 3, 6, 9}; !slices.Equal(offsets, want) {
+		t.Errorf("expected offsets %v, got %v", want, offsets)
+	}
+
+	// changing direction resets the step
+	vp, _ = vp.Update(leftKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 8 {
+		t.Errorf("expected offset 8 after changing direction, got %d", got)
+	}
+
+	// separate presses don't accelerate
+	vp.navigation.pan.now = fakeClock(time.Second)
+	vp, _ = vp.Update(leftKeyMsg)
+	vp, _ = vp.Update(leftKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 6 {
+		t.Errorf("expected offset 6 after separate presses, got %d", got)
+	}
+}
+
+func TestPan_SelectedOnly(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithPanStep[object](4),
+		WithPanSelectedOnly[object](true))
+	setContent(vp, []string{"first long line", "second long line", "short"})
+
+	vp, _ = vp.Update(rightKeyMsg)
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("...ong ..."),
+		"second ...",
+		"short",
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// panning is limited by the selected item
+	vp, _ = vp.Update(panToEndKeyMsg)
+	if got := vp.GetXOffsetWidth(); got != 5 {
+		t.Errorf("expected to pan to the end of the selected item, 5, got %d", got)
+	}
+
+	// toggled off, every item pans
+	vp.SetPanSelectedOnly(false)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("...ng line"),
+		"...ong ...",
+		"...",
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
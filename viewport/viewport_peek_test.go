@@ -0,0 +1,106 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var peekKeyMsg = internal.MakeKeyMsg('p')
+
+func TestPeek_KeyDisabledByDefault(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"the quick brown fox"})
+
+	vp, _ = vp.Update(peekKeyMsg)
+	if vp.GetPeek() {
+		t.Error("expected the peek key disabled by default")
+	}
+}
+
+func TestPeek_ExpandsSelectedItemUntilSelectionMoves(t *testing.T) {
+	w, h := 10, 6
+	keyMap := DefaultKeyMap()
+	keyMap.Peek.SetEnabled(true)
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithKeyMap[object](keyMap))
+	setContent(vp, []string{"short", "the quick brown fox", "last"})
+	vp.SetSelectedItemIdx(1)
+
+	expectedView := internal.Pad(w, h, []string{
+		"short",
+		selectionStyle.Render("the qui..."),
+		"last",
+		"",
+		"",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(peekKeyMsg)
+	if !vp.GetPeek() {
+		t.Fatal("expected peeking")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"short",
+		selectionStyle.Render("the quick "),
+		selectionStyle.Render("brown fox"),
+		"last",
+		"",
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetPeek() {
+		t.Error("expected peeking to stop once the selection moved")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"short",
+		"the qui...",
+		selectionStyle.Render("last"),
+		"",
+		"",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// moving back doesn't peek again
+	vp, _ = vp.Update(upKeyMsg)
+	if vp.GetPeek() {
+		t.Error("expected no peeking on returning to the item")
+	}
+}
+
+func TestPeek_ScrollsExpandedItemIntoView(t *testing.T) {
+	w, h := 5, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "abcdefghij"})
+	vp.SetSelectedItemIdx(2)
+
+	vp.SetPeek(true)
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		selectionStyle.Render("abcde"),
+		selectionStyle.Render("fghij"),
+		"10...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetPeek(false)
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		selectionStyle.Render("ab..."),
+		"10...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestPeek_NoEffectWhileWrapping(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true), WithWrapText[object](true))
+	setContent(vp, []string{"the quick brown fox"})
+	vp, _ = vp.Update(peekKeyMsg)
+	if vp.GetPeek() {
+		t.Error("expected no peeking while wrapping")
+	}
+}
@@ -0,0 +1,117 @@
+package viewport
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// splitWords is a preprocessor that puts each word on its own line, labeled with the width and wrapping it ran with
+func splitWords(objects []object, width int, wrapText bool) []object {
+	var res []object
+	for _, o := range objects {
+		for _, word := range strings.Fields(o.GetItem().ContentNoAnsi()) {
+			res = append(res, object{item: item.NewItem(fmt.Sprintf("%s %d %t", word, width, wrapText))})
+		}
+	}
+	return res
+}
+
+func TestPreprocessor_TransformsObjects(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h, WithPreprocessor[object](splitWords))
+	setContent(vp, []string{"a b", "c"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"a 20 false",
+		"b 20 false",
+		"c 20 false",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestPreprocessor_RerunsOnWidthAndWrapChange(t *testing.T) {
+	w, h := 20, 3
+	vp := newViewport(w, h, WithPreprocessor[object](splitWords))
+	setContent(vp, []string{"a b"})
+
+	vp.SetWidth(15)
+	expectedView := internal.Pad(15, h, []string{
+		"a 15 false",
+		"b 15 false",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetWrapText(true)
+	expectedView = internal.Pad(15, h, []string{
+		"a 15 true",
+		"b 15 true",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestPreprocessor_AppendAndDisable(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h, WithPreprocessor[object](splitWords))
+	setContent(vp, []string{"a"})
+	vp.AppendObjects(toObjects([]string{"b c"}))
+
+	expectedView := internal.Pad(w, h, []string{
+		"a 20 false",
+		"b 20 false",
+		"c 20 false",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetPreprocessor(nil)
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b c",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestItemStyleFunc_LayersUnderItemStyling(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h,
+		WithSelectionEnabled[object](true),
+		WithItemStyleFunc[object](func(o object) lipgloss.Style {
+			if strings.HasPrefix(o.GetItem().ContentNoAnsi(), "#") {
+				return internal.GreenFg
+			}
+			return lipgloss.NewStyle()
+		}),
+	)
+	setContent(vp, []string{
+		"first",
+		"# heading",
+		"# " + internal.RedFg.Render("red") + " heading",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("first"),
+		internal.GreenFg.Render("# heading"),
+		internal.GreenFg.Render("# ") + internal.RedFg.Render("red") + internal.GreenFg.Render(" heading"),
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetSelectedItemIdx(1)
+	expectedView = internal.Pad(w, h, []string{
+		"first",
+		selectionStyle.Render("# heading"),
+		internal.GreenFg.Render("# ") + internal.RedFg.Render("red") + internal.GreenFg.Render(" heading"),
+		"66% (2/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
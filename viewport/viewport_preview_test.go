@@ -0,0 +1,95 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func newPreviewViewport(w, h int) *Model[object] {
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	styles := vp.display.styles
+	styles.PreviewStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder())
+	vp.SetStyles(styles)
+	return vp
+}
+
+func TestPreview_SelectedItemWrappedOverContent(t *testing.T) {
+	w, h := 12, 7
+	vp := newPreviewViewport(w, h)
+	setContent(vp, []string{"first", "the quick brown fox", "c", "d", "e", "f"})
+	vp.SetSelectedItemIdx(1)
+
+	if !vp.PreviewSelected() {
+		t.Fatal("expected a preview of the selected item")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"f┌────────┐",
+		selectionStyle.Render("t") + "│the quic│" + selectionStyle.Render("."),
+		"c│k brown │",
+		"d│fox     │",
+		"e└────────┘",
+		"f",
+		"33% (2/6)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if !vp.IsCapturingInput() {
+		t.Error("expected the preview to capture input")
+	}
+
+	// keys other than those scrolling the popup are ignored
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 1 {
+		t.Errorf("expected the selection kept while previewing, got %d", vp.GetSelectedItemIdx())
+	}
+
+	vp, _ = vp.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if vp.IsPreviewing() {
+		t.Error("expected esc to dismiss the preview")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"first",
+		selectionStyle.Render("the quick..."),
+		"c",
+		"d",
+		"e",
+		"f",
+		"33% (2/6)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestPreview_TextClippedAndScrolled(t *testing.T) {
+	w, h := 10, 5
+	vp := newPreviewViewport(w, h)
+	setContent(vp, []string{"a", "b", "c", "d"})
+
+	vp.Preview("one\ntwo\nthree\nfour")
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a") + "┌─────┐",
+		"b│one  │",
+		"c│two  │",
+		"d└─────┘",
+		"25% (1/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("a") + "┌─────┐",
+		"b│three│",
+		"c│four │",
+		"d└─────┘",
+		"25% (1/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ClosePreview()
+	if vp.IsPreviewing() {
+		t.Error("expected the preview closed")
+	}
+}
@@ -0,0 +1,84 @@
+package viewport
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// settledMsgFromCmd returns the ResizeSettledMsg delivered by cmd, without waiting for its tick
+func settledMsgFromCmd(t *testing.T, cmd tea.Cmd) ResizeSettledMsg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("expected a command settling the resize")
+	}
+	msg, ok := cmd().(ResizeSettledMsg)
+	if !ok {
+		t.Fatalf("expected ResizeSettledMsg, got %T", msg)
+	}
+	return msg
+}
+
+func TestResize_NoThrottleAppliesImmediately(t *testing.T) {
+	vp := newViewport(10, 5)
+	if cmd := vp.Resize(20, 6); cmd != nil {
+		t.Error("expected no command without a throttle")
+	}
+	if vp.GetWidth() != 20 || vp.GetHeight() != 6 {
+		t.Errorf("expected 20x6, got %dx%d", vp.GetWidth(), vp.GetHeight())
+	}
+}
+
+func TestResize_ThrottlesAndSettlesOnLatestSize(t *testing.T) {
+	vp := newViewport(10, 5, WithResizeThrottle[object](50*time.Millisecond))
+	vp.display.resize.now = fakeClock(10 * time.Millisecond)
+
+	if cmd := vp.Resize(11, 5); cmd != nil || vp.GetWidth() != 11 {
+		t.Fatalf("expected the first resize applied immediately, got width %d", vp.GetWidth())
+	}
+
+	first := settledMsgFromCmd(t, vp.Resize(12, 5))
+	last := settledMsgFromCmd(t, vp.Resize(13, 6))
+	if vp.GetWidth() != 11 {
+		t.Errorf("expected resizes within the interval held, got width %d", vp.GetWidth())
+	}
+
+	vp, _ = vp.Update(first)
+	if vp.GetWidth() != 11 {
+		t.Errorf("expected a superseded settle ignored, got width %d", vp.GetWidth())
+	}
+	vp, _ = vp.Update(last)
+	if vp.GetWidth() != 13 || vp.GetHeight() != 6 {
+		t.Errorf("expected the latest size applied on settle, got %dx%d", vp.GetWidth(), vp.GetHeight())
+	}
+}
+
+func TestResize_AppliesAgainOnceIntervalPasses(t *testing.T) {
+	vp := newViewport(10, 5, WithResizeThrottle[object](50*time.Millisecond))
+	vp.display.resize.now = fakeClock(30 * time.Millisecond)
+
+	vp.Resize(11, 5)
+	held := settledMsgFromCmd(t, vp.Resize(12, 5))
+	if cmd := vp.Resize(13, 5); cmd != nil || vp.GetWidth() != 13 {
+		t.Fatalf("expected a resize after the interval applied immediately, got width %d", vp.GetWidth())
+	}
+
+	vp, _ = vp.Update(held)
+	if vp.GetWidth() != 13 {
+		t.Errorf("expected a settle with nothing held ignored, got width %d", vp.GetWidth())
+	}
+}
+
+func TestResize_SettleIgnoredByOtherViewport(t *testing.T) {
+	vp := newViewport(10, 5, WithResizeThrottle[object](50*time.Millisecond))
+	vp.display.resize.now = fakeClock(10 * time.Millisecond)
+	other := newViewport(10, 5, WithResizeThrottle[object](50*time.Millisecond))
+
+	vp.Resize(11, 5)
+	msg := settledMsgFromCmd(t, vp.Resize(12, 5))
+	other, _ = other.Update(msg)
+	if other.GetWidth() != 10 {
+		t.Errorf("expected another viewport's settle ignored, got width %d", other.GetWidth())
+	}
+}
@@ -0,0 +1,116 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestRetention_MaxItemsKeepsSelection(t *testing.T) {
+	vp := newViewport(10, 5, WithSelectionEnabled[object](true), WithMaxItems[object](10))
+	setContent(vp, numberedContent(10))
+	vp.SetSelectedItemIdx(5)
+	vp.SetMarked(6, true)
+
+	vp.AppendObjects(toObjects([]string{"10", "11", "12"}))
+	if sel := vp.GetSelectedItem(); sel == nil || sel.GetItem().Content() != "5" || vp.GetSelectedItemIdx() != 2 {
+		t.Errorf("expected item 5 still selected at index 2, got index %d", vp.GetSelectedItemIdx())
+	}
+	if !vp.IsMarked(3) || vp.IsMarked(6) {
+		t.Error("expected the mark to move with its item")
+	}
+	if marked := vp.GetMarkedItems(); len(marked) != 1 || marked[0].GetItem().Content() != "6" {
+		t.Errorf("expected item 6 still marked, got %v", marked)
+	}
+
+	// dropping the selected item selects the oldest kept
+	vp.AppendObjects(toObjects([]string{"13", "14", "15"}))
+	if sel := vp.GetSelectedItem(); sel == nil || sel.GetItem().Content() != "6" {
+		t.Errorf("expected the oldest item kept selected, got %v", sel)
+	}
+
+	setContent(vp, numberedContent(20))
+	if n, first := vp.content.numItems(), vp.content.objectAt(0).GetItem().Content(); n != 10 || first != "10" {
+		t.Errorf("expected only the newest 10 objects kept when set, got %d from %s", n, first)
+	}
+}
+
+func TestRetention_StickyBottom(t *testing.T) {
+	w, h := 10, 4
+	vp := newViewport(w, h, WithSelectionEnabled[object](true), WithStickyBottom[object](true),
+		WithMaxItems[object](5))
+	setContent(vp, numberedContent(5))
+	vp.SetSelectedItemIdx(4)
+
+	vp.AppendObjects(toObjects([]string{"5", "6"}))
+	expectedView := internal.Pad(w, h, []string{
+		"4",
+		"5",
+		selectionStyle.Render("6"),
+		"100% (5/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestRetention_MaxBytes(t *testing.T) {
+	vp := newViewport(10, 5, WithMaxBytes[object](10))
+	setContent(vp, []string{"aaaa", "bbbb", "cccc"})
+	if n := vp.content.numItems(); n != 2 {
+		t.Errorf("expected 2 objects within 10 bytes, got %d", n)
+	}
+
+	vp.AppendObjects(toObjects([]string{"ddd"}))
+	if n := vp.content.numItems(); n != 2 {
+		t.Errorf("expected cccc and ddd within 10 bytes, got %d objects", n)
+	}
+
+	// the newest object is kept even if it's larger than the limit
+	vp.AppendObjects(toObjects([]string{"eeeeeeeeeeee"}))
+	if n := vp.content.numItems(); n != 1 || vp.content.objectAt(0).GetItem().Content() != "eeeeeeeeeeee" {
+		t.Errorf("expected only the newest object, got %d objects", n)
+	}
+}
+
+func TestRetention_DroppedObjectsLeaveSoftLimits(t *testing.T) {
+	vp := newViewport(10, 5, WithMaxItems[object](3),
+		WithSoftLimits[object](SoftLimits{MaxTotalBytes: 8, MaxLineWidth: 5}))
+	setContent(vp, []string{"wide line", "a", "b"})
+	if !vp.SoftLimitsExceeded() {
+		t.Error("expected soft limits exceeded by the wide line")
+	}
+
+	vp.AppendObjects(toObjects([]string{"c"}))
+	if vp.SoftLimitsExceeded() {
+		t.Error("expected soft limits no longer exceeded once the wide line is dropped")
+	}
+	if expected := (softLimitUsage{numItems: 3, totalBytes: 3, maxLineWidth: 1}); vp.config.softLimitUsage != expected {
+		t.Errorf("expected usage %+v, got %+v", expected, vp.config.softLimitUsage)
+	}
+}
+
+func TestRetention_SetMaxItemsDropsNow(t *testing.T) {
+	vp := newViewport(10, 5)
+	setContent(vp, numberedContent(10))
+	vp.SetMaxItems(3)
+	if n := vp.content.numItems(); n != 3 || vp.GetMaxItems() != 3 {
+		t.Errorf("expected 3 objects kept, got %d", n)
+	}
+	vp.SetMaxItems(0)
+	vp.AppendObjects(toObjects([]string{"10"}))
+	if n := vp.content.numItems(); n != 4 {
+		t.Errorf("expected every object kept without a limit, got %d", n)
+	}
+}
+
+func TestRetention_RetainNewest(t *testing.T) {
+	objects := toObjects([]string{"aa", "bb", "cc", "dd"})
+	if got := RetainNewest(objects, 3, 0); len(got) != 3 || got[0].GetItem().Content() != "bb" {
+		t.Errorf("expected the last 3 objects, got %v", got)
+	}
+	if got := RetainNewest(objects, 3, 5); len(got) != 2 || got[0].GetItem().Content() != "cc" {
+		t.Errorf("expected the last 2 objects within 5 bytes, got %v", got)
+	}
+	if got := RetainNewest(objects, 0, 0); len(got) != 4 {
+		t.Errorf("expected every object without limits, got %v", got)
+	}
+}
@@ -120,21 +120,21 @@ func TestFileSaving_EnterWithEmptyInputUsesTimestampDefault(t *testing.T) {
 	}
 
 	msg := cmd()
-	savedMsg, ok := msg.(fileSavedMsg)
+	savedMsg, ok := msg.(FileSavedMsg)
 	if !ok {
-		t.Fatalf("expected fileSavedMsg, got %T", msg)
+		t.Fatalf("expected FileSavedMsg, got %T", msg)
 	}
-	if savedMsg.err != nil {
-		t.Fatalf("unexpected save error: %v", savedMsg.err)
+	if savedMsg.Err != nil {
+		t.Fatalf("unexpected save error: %v", savedMsg.Err)
 	}
 
-	filename := filepath.Base(savedMsg.filename)
+	filename := filepath.Base(savedMsg.Path)
 	if !strings.HasSuffix(filename, ".txt") {
 		t.Errorf("expected .txt extension, got %s", filename)
 	}
 
 	// verify file exists and has correct content
-	content, err := os.ReadFile(savedMsg.filename)
+	content, err := os.ReadFile(savedMsg.Path)
 	if err != nil {
 		t.Fatalf("failed to read saved file: %v", err)
 	}
@@ -144,8 +144,8 @@ func TestFileSaving_EnterWithEmptyInputUsesTimestampDefault(t *testing.T) {
 	}
 
 	// verify file is in the correct directory
-	if filepath.Dir(savedMsg.filename) != tmpDir {
-		t.Errorf("expected file in %s, got %s", tmpDir, filepath.Dir(savedMsg.filename))
+	if filepath.Dir(savedMsg.Path) != tmpDir {
+		t.Errorf("expected file in %s, got %s", tmpDir, filepath.Dir(savedMsg.Path))
 	}
 
 	// verify timestamp is reasonable (within test execution window)
@@ -178,17 +178,17 @@ func TestFileSaving_EnterWithCustomFilename(t *testing.T) {
 	}
 
 	msg := cmd()
-	savedMsg, ok := msg.(fileSavedMsg)
+	savedMsg, ok := msg.(FileSavedMsg)
 	if !ok {
-		t.Fatalf("expected fileSavedMsg, got %T", msg)
+		t.Fatalf("expected FileSavedMsg, got %T", msg)
 	}
-	if savedMsg.err != nil {
-		t.Fatalf("unexpected save error: %v", savedMsg.err)
+	if savedMsg.Err != nil {
+		t.Fatalf("unexpected save error: %v", savedMsg.Err)
 	}
 
 	expectedPath := filepath.Join(tmpDir, "myfile.txt")
-	if savedMsg.filename != expectedPath {
-		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.filename)
+	if savedMsg.Path != expectedPath {
+		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.Path)
 	}
 
 	// verify file exists
@@ -210,12 +210,12 @@ func TestFileSaving_CustomFilenameWithExtension(t *testing.T) {
 
 	_, cmd := vp.Update(enterKeyMsg)
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
+	savedMsg := msg.(FileSavedMsg)
 
 	// should not double the extension
 	expectedPath := filepath.Join(tmpDir, "already.txt")
-	if savedMsg.filename != expectedPath {
-		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.filename)
+	if savedMsg.Path != expectedPath {
+		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.Path)
 	}
 }
 
@@ -235,9 +235,9 @@ func TestFileSaving_ContentStripsAnsiCodes(t *testing.T) {
 	_, cmd := vp.Update(enterKeyMsg)
 
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
+	savedMsg := msg.(FileSavedMsg)
 
-	content, err := os.ReadFile(savedMsg.filename)
+	content, err := os.ReadFile(savedMsg.Path)
 	if err != nil {
 		t.Fatalf("failed to read file: %v", err)
 	}
@@ -266,7 +266,7 @@ func TestFileSaving_SuccessMessageShownAfterSave(t *testing.T) {
 
 	// execute save command
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
+	savedMsg := msg.(FileSavedMsg)
 
 	// send the result message back to viewport
 	vp, _ = vp.Update(savedMsg)
@@ -290,7 +290,7 @@ func TestFileSaving_ErrorMessageShownOnFailure(t *testing.T) {
 	vp, _ = vp.Update(enterKeyMsg)
 
 	// simulate error response
-	vp, _ = vp.Update(fileSavedMsg{err: os.ErrPermission})
+	vp, _ = vp.Update(FileSavedMsg{Err: os.ErrPermission})
 
 	// view should show error message
 	view := vp.View()
@@ -329,9 +329,9 @@ func TestFileSaving_IgnoresSaveKeyWhenAlreadyCapturingInput(t *testing.T) {
 		t.Fatal("expected save command")
 	}
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
-	if !strings.Contains(savedMsg.filename, "a.txt") {
-		t.Errorf("expected filename to contain 'a.txt', got %s", savedMsg.filename)
+	savedMsg := msg.(FileSavedMsg)
+	if !strings.Contains(savedMsg.Path, "a.txt") {
+		t.Errorf("expected filename to contain 'a.txt', got %s", savedMsg.Path)
 	}
 }
 
@@ -349,11 +349,11 @@ func TestFileSaving_TextInputReceivesKeyMessages(t *testing.T) {
 	// verify by completing the save and checking filename
 	_, cmd := vp.Update(enterKeyMsg)
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
+	savedMsg := msg.(FileSavedMsg)
 
 	expectedPath := filepath.Join(tmpDir, "abc.txt")
-	if savedMsg.filename != expectedPath {
-		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.filename)
+	if savedMsg.Path != expectedPath {
+		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.Path)
 	}
 }
 
@@ -412,11 +412,11 @@ func TestFileSaving_NavigationKeysIgnoredDuringFilenameEntry(t *testing.T) {
 	// filename should be jkgG.txt
 	_, cmd := vp.Update(enterKeyMsg)
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
+	savedMsg := msg.(FileSavedMsg)
 
 	expectedPath := filepath.Join(tmpDir, "jkgG.txt")
-	if savedMsg.filename != expectedPath {
-		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.filename)
+	if savedMsg.Path != expectedPath {
+		t.Errorf("expected filename %s, got %s", expectedPath, savedMsg.Path)
 	}
 }
 
@@ -433,10 +433,10 @@ func TestFileSaving_CreatesDirIfNotExists(t *testing.T) {
 	_, cmd := vp.Update(enterKeyMsg)
 
 	msg := cmd()
-	savedMsg := msg.(fileSavedMsg)
+	savedMsg := msg.(FileSavedMsg)
 
-	if savedMsg.err != nil {
-		t.Fatalf("save failed: %v", savedMsg.err)
+	if savedMsg.Err != nil {
+		t.Fatalf("save failed: %v", savedMsg.Err)
 	}
 
 	// verify directory was created
@@ -445,7 +445,101 @@ func TestFileSaving_CreatesDirIfNotExists(t *testing.T) {
 	}
 
 	// verify file exists
-	if _, err := os.Stat(savedMsg.filename); os.IsNotExist(err) {
-		t.Errorf("expected file %s to exist", savedMsg.filename)
+	if _, err := os.Stat(savedMsg.Path); os.IsNotExist(err) {
+		t.Errorf("expected file %s to exist", savedMsg.Path)
+	}
+}
+
+func TestFileSaving_Formats(t *testing.T) {
+	styled := lipgloss.NewStyle().Foreground(lipgloss.Red).Bold(true).Render("red") + " <plain>"
+	tests := []struct {
+		format   SaveFormat
+		ext      string
+		expected string
+	}{
+		{SaveText, ".txt", "red <plain>\n"},
+		{SaveRaw, ".ansi", styled + "\n"},
+		{SaveHTML, ".html", `<span style="color:#cd0000;font-weight:bold">red</span> &lt;plain&gt;</pre>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			vp, tmpDir := newSaveTestViewport(t)
+			vp.SetSaveFormat(tt.format)
+			setSaveTestContent(vp, []string{styled})
+
+			vp, _ = vp.Update(saveKeyMsg)
+			if view := vp.View(); !strings.Contains(view, "["+tt.format.String()+"]") {
+				t.Errorf("expected the prompt to show the %s format, got %q", tt.format, view)
+			}
+			vp, _ = vp.Update(internal.MakeKeyMsg('f'))
+			_, cmd := vp.Update(enterKeyMsg)
+			savedMsg := cmd().(FileSavedMsg)
+			if savedMsg.Err != nil {
+				t.Fatalf("save failed: %v", savedMsg.Err)
+			}
+			if expectedPath := filepath.Join(tmpDir, "f"+tt.ext); savedMsg.Path != expectedPath {
+				t.Errorf("expected path %s, got %s", expectedPath, savedMsg.Path)
+			}
+			if savedMsg.Format != tt.format {
+				t.Errorf("expected format %s, got %s", tt.format, savedMsg.Format)
+			}
+
+			content, err := os.ReadFile(savedMsg.Path)
+			if err != nil {
+				t.Fatalf("failed to read saved file: %v", err)
+			}
+			if savedMsg.Bytes != len(content) {
+				t.Errorf("expected %d bytes saved, got %d", len(content), savedMsg.Bytes)
+			}
+			if !strings.Contains(string(content), tt.expected) {
+				t.Errorf("expected content to contain %q, got %q", tt.expected, content)
+			}
+		})
+	}
+}
+
+func TestFileSaving_TabCyclesFormats(t *testing.T) {
+	vp := New[saveTestObject](80, 24,
+		WithFileSaving[saveTestObject](t.TempDir(), saveKey),
+		WithSaveFormat[saveTestObject](SaveRaw),
+	)
+	setSaveTestContent(vp, []string{"line1"})
+
+	vp, _ = vp.Update(saveKeyMsg)
+	tabKeyMsg := tea.KeyPressMsg{Code: tea.KeyTab}
+	for _, expected := range []SaveFormat{SaveHTML, SaveText, SaveRaw} {
+		vp, _ = vp.Update(tabKeyMsg)
+		if got := vp.GetSaveFormat(); got != expected {
+			t.Errorf("expected tab to switch to %s, got %s", expected, got)
+		}
+	}
+	if !vp.IsCapturingInput() {
+		t.Error("expected to still be entering the filename")
+	}
+
+	vp, _ = vp.Update(tabKeyMsg)
+	_, cmd := vp.Update(enterKeyMsg)
+	if savedMsg := cmd().(FileSavedMsg); !strings.HasSuffix(savedMsg.Path, ".html") {
+		t.Errorf("expected the default filename with the .html extension, got %s", savedMsg.Path)
+	}
+}
+
+func TestHTMLDocument_Styling(t *testing.T) {
+	lines := []string{
+		"\x1b[38;5;196mred256\x1b[m \x1b[48;2;1;2;3mbg\x1b[0m",
+		"\x1b[4;9mboth\x1b[24m strike\x1b[m \x1b[7mrev\x1b[m",
+		"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\ & done",
+	}
+	doc := htmlDocument(lines)
+	for _, expected := range []string{
+		`<span style="color:#ff0000">red256</span> <span style="background-color:#010203">bg</span>`,
+		`<span style="text-decoration:underline line-through">both</span>` +
+			`<span style="text-decoration:line-through"> strike</span> ` +
+			`<span style="color:#000000;background-color:#e5e5e5">rev</span>`,
+		"link &amp; done",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected the document to contain %q, got %q", expected, doc)
+		}
 	}
 }
@@ -0,0 +1,263 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestGetItemAtScreenPosition(t *testing.T) {
+	type position struct {
+		itemIdx, byteOffset int
+		ok                  bool
+	}
+	tests := []struct {
+		name     string
+		setup    func(vp *Model[object])
+		row, col int
+		expected position
+	}{
+		{
+			name:     "header row",
+			row:      0,
+			col:      0,
+			expected: position{ok: false},
+		},
+		{
+			name:     "first content row",
+			row:      1,
+			col:      2,
+			expected: position{itemIdx: 0, byteOffset: 2, ok: true},
+		},
+		{
+			name:     "past end of line",
+			row:      1,
+			col:      9,
+			expected: position{itemIdx: 0, byteOffset: 5, ok: true},
+		},
+		{
+			name:     "below content",
+			row:      5,
+			col:      0,
+			expected: position{ok: false},
+		},
+		{
+			name:     "footer row",
+			row:      6,
+			col:      0,
+			expected: position{ok: false},
+		},
+		{
+			name: "panned",
+			setup: func(vp *Model[object]) {
+				vp.SetXOffset(2)
+			},
+			row:      3,
+			col:      1,
+			expected: position{itemIdx: 2, byteOffset: 3, ok: true},
+		},
+		{
+			name: "selection prefix",
+			setup: func(vp *Model[object]) {
+				vp.SetSelectionEnabled(true)
+				vp.SetStyles(Styles{SelectionPrefix: "> "})
+			},
+			row:      2,
+			col:      3,
+			expected: position{itemIdx: 1, byteOffset: 1, ok: true},
+		},
+		{
+			name: "wrapped continuation row",
+			setup: func(vp *Model[object]) {
+				vp.SetWrapText(true)
+			},
+			row:      4,
+			col:      1,
+			expected: position{itemIdx: 2, byteOffset: 9, ok: true},
+		},
+		{
+			name: "wrapped past end of non-final row",
+			setup: func(vp *Model[object]) {
+				vp.SetWrapText(true)
+				vp.SetWidth(6)
+			},
+			row:      3,
+			col:      9,
+			expected: position{itemIdx: 2, byteOffset: 5, ok: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(8, 7)
+			vp.SetHeader([]string{"header"})
+			setContent(vp, []string{
+				"hello",
+				"world",
+				"a long line here",
+			})
+			if tt.setup != nil {
+				tt.setup(vp)
+			}
+			itemIdx, byteOffset, ok := vp.GetItemAtScreenPosition(tt.row, tt.col)
+			actual := position{itemIdx: itemIdx, byteOffset: byteOffset, ok: ok}
+			if !ok {
+				actual = position{ok: false}
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetItemAtScreenPosition_MultiLine(t *testing.T) {
+	vp := newViewport(10, 4)
+	vp.SetObjects([]object{
+		{item: item.NewMultiLineItemFromString("ab\ncd\nef")},
+		{item: item.NewItem("next")},
+	})
+	vp.ScrollDown(1)
+
+	// top row is the item's second line
+	itemIdx, byteOffset, ok := vp.GetItemAtScreenPosition(0, 1)
+	if !ok || itemIdx != 0 || byteOffset != 4 {
+		t.Errorf("expected (0, 4, true), got (%d, %d, %v)", itemIdx, byteOffset, ok)
+	}
+	itemIdx, byteOffset, ok = vp.GetItemAtScreenPosition(2, 0)
+	if !ok || itemIdx != 1 || byteOffset != 0 {
+		t.Errorf("expected (1, 0, true), got (%d, %d, %v)", itemIdx, byteOffset, ok)
+	}
+}
+
+func TestScreenPositionOf(t *testing.T) {
+	type position struct {
+		row, col int
+		visible  bool
+	}
+	tests := []struct {
+		name                string
+		setup               func(vp *Model[object])
+		itemIdx, byteOffset int
+		expected            position
+	}{
+		{
+			name:     "first item",
+			itemIdx:  0,
+			expected: position{row: 1, col: 0, visible: true},
+		},
+		{
+			name:       "end of line",
+			itemIdx:    1,
+			byteOffset: 5,
+			expected:   position{row: 2, col: 5, visible: true},
+		},
+		{
+			name:       "past the right edge",
+			itemIdx:    2,
+			byteOffset: 9,
+			expected:   position{visible: false},
+		},
+		{
+			name: "panned",
+			setup: func(vp *Model[object]) {
+				vp.SetXOffset(2)
+			},
+			itemIdx:    2,
+			byteOffset: 9,
+			expected:   position{row: 3, col: 7, visible: true},
+		},
+		{
+			name: "panned out of view",
+			setup: func(vp *Model[object]) {
+				vp.SetXOffset(2)
+			},
+			itemIdx:  0,
+			expected: position{visible: false},
+		},
+		{
+			name: "selection prefix",
+			setup: func(vp *Model[object]) {
+				vp.SetSelectionEnabled(true)
+				vp.SetStyles(Styles{SelectionPrefix: "> "})
+			},
+			itemIdx:    1,
+			byteOffset: 1,
+			expected:   position{row: 2, col: 3, visible: true},
+		},
+		{
+			name: "wrapped continuation row",
+			setup: func(vp *Model[object]) {
+				vp.SetWrapText(true)
+			},
+			itemIdx:    2,
+			byteOffset: 9,
+			expected:   position{row: 4, col: 1, visible: true},
+		},
+		{
+			name: "scrolled out of view",
+			setup: func(vp *Model[object]) {
+				vp.SetWrapText(true)
+				vp.SetHeight(4)
+				vp.ScrollDown(1)
+			},
+			itemIdx:  0,
+			expected: position{visible: false},
+		},
+		{
+			name: "below the view",
+			setup: func(vp *Model[object]) {
+				vp.SetWrapText(true)
+			},
+			itemIdx:    2,
+			byteOffset: 16,
+			expected:   position{visible: false},
+		},
+		{
+			name:     "no such item",
+			itemIdx:  3,
+			expected: position{visible: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vp := newViewport(8, 7)
+			vp.SetHeader([]string{"header"})
+			setContent(vp, []string{
+				"hello",
+				"world",
+				"a long line here",
+			})
+			if tt.setup != nil {
+				tt.setup(vp)
+			}
+			row, col, visible := vp.ScreenPositionOf(tt.itemIdx, tt.byteOffset)
+			if actual := (position{row: row, col: col, visible: visible}); actual != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestScreenPositionOf_RoundTrip(t *testing.T) {
+	vp := newViewport(10, 6, WithWrapText[object](true))
+	vp.SetObjects([]object{
+		{item: item.NewMultiLineItemFromString("ab\n世界 and more")},
+		{item: item.NewItem("next")},
+	})
+	content := vp.content.itemAt(0).ContentNoAnsi()
+	for byteOffset, r := range content {
+		if r == '\n' {
+			continue // the newline between lines isn't rendered
+		}
+		row, col, visible := vp.ScreenPositionOf(0, byteOffset)
+		if !visible {
+			t.Fatalf("expected byte %d to be visible", byteOffset)
+		}
+		itemIdx, gotOffset, ok := vp.GetItemAtScreenPosition(row, col)
+		if !ok || itemIdx != 0 || gotOffset != byteOffset {
+			t.Errorf("byte %d at (%d, %d) maps back to (%d, %d, %v)", byteOffset, row, col, itemIdx, gotOffset, ok)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestScrollbar_ThumbFollowsPosition(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h, WithScrollbar[object](true))
+	vp.SetHeader([]string{"header"})
+	setContent(vp, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"header",
+		"1        ┃",
+		"2        │",
+		"3        │",
+		"33% (3/9)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollDown(3)
+	expectedView = internal.Pad(w, h, []string{
+		"header",
+		"4        │",
+		"5        ┃",
+		"6        │",
+		"66% (6/9)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.GoToBottom()
+	expectedView = internal.Pad(w, h, []string{
+		"header",
+		"7        │",
+		"8        │",
+		"9        ┃",
+		"100% (9/9)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestScrollbar_AllContentInView(t *testing.T) {
+	w, h := 10, 5
+	vp := newViewport(w, h, WithScrollbar[object](true))
+	setContent(vp, []string{"1", "2"})
+
+	// the thumb fills the track, which runs below the content
+	expectedView := internal.Pad(w, h, []string{
+		"1        ┃",
+		"2        ┃",
+		"         ┃",
+		"         ┃",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestScrollbar_NarrowsContent(t *testing.T) {
+	w, h := 6, 4
+	vp := newViewport(w, h, WithWrapText[object](true))
+	setContent(vp, []string{"abcdefgh"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"abcdef",
+		"gh",
+		"",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetScrollbar(true)
+	if !vp.GetScrollbar() {
+		t.Fatal("expected the scrollbar to be enabled")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"abcde┃",
+		"fgh  ┃",
+		"     ┃",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetScrollbar(false)
+	vp.SetWrapText(false)
+	vp.SetScrollbar(true)
+	expectedView = internal.Pad(w, h, []string{
+		"ab...┃",
+		"     ┃",
+		"     ┃",
+		"100...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestScrollbar_WrappedLines(t *testing.T) {
+	w, h := 5, 4
+	vp := newViewport(w, h, WithWrapText[object](true), WithScrollbar[object](true),
+		WithFooterMetric[object](FooterMetricLines))
+	setContent(vp, []string{"abcdefghijklmnop", "q"})
+
+	// 5 lines of 4 columns, 3 in view
+	expectedView := internal.Pad(w, h, []string{
+		"abcd┃",
+		"efgh┃",
+		"ijkl│",
+		"60...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollDown(2)
+	expectedView = internal.Pad(w, h, []string{
+		"ijkl│",
+		"mnop┃",
+		"q   ┃",
+		"10...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestScrollbar_WrappedItems(t *testing.T) {
+	w, h := 5, 4
+	vp := newViewport(w, h, WithWrapText[object](true), WithScrollbar[object](true))
+	setContent(vp, []string{"abcdefghijklmnop", "q", "r", "s"})
+
+	// measured by item, the first of 4 items is in view
+	expectedView := internal.Pad(w, h, []string{
+		"abcd┃",
+		"efgh│",
+		"ijkl│",
+		"25...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestScrollbarThumbBounds(t *testing.T) {
+	tests := []struct {
+		name                         string
+		height, above, inView, total int
+		expectedTop, expectedSize    int
+	}{
+		{name: "no height", height: 0, above: 0, inView: 0, total: 10},
+		{name: "everything in view", height: 5, above: 0, inView: 3, total: 3, expectedSize: 5},
+		{name: "top", height: 10, above: 0, inView: 10, total: 100, expectedTop: 0, expectedSize: 1},
+		{name: "bottom", height: 10, above: 90, inView: 10, total: 100, expectedTop: 9, expectedSize: 1},
+		{name: "scrolled one line", height: 10, above: 1, inView: 10, total: 100, expectedTop: 1, expectedSize: 1},
+		{name: "one line from bottom", height: 10, above: 89, inView: 10, total: 100, expectedTop: 8, expectedSize: 1},
+		{name: "half in view", height: 10, above: 5, inView: 10, total: 20, expectedTop: 2, expectedSize: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			top, size := scrollbarThumbBounds(tt.height, tt.above, tt.inView, tt.total)
+			if top != tt.expectedTop || size != tt.expectedSize {
+				t.Errorf("expected thumb at %d of size %d, got %d of size %d", tt.expectedTop, tt.expectedSize, top,
+					size)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+var (
+	nextSearchMatchKeyMsg = internal.MakeKeyMsg('n')
+	prevSearchMatchKeyMsg = internal.MakeKeyMsg('N')
+)
+
+func newSearchViewport(width, height int, options ...Option[object]) *Model[object] {
+	vp := newViewport(width, height, options...)
+	vp.SetStyles(Styles{
+		FooterStyle:             lipgloss.NewStyle(),
+		SelectedItemStyle:       selectionStyle,
+		SearchMatchStyle:        internal.RedFg,
+		FocusedSearchMatchStyle: internal.GreenFg,
+	})
+	return vp
+}
+
+func TestSearch_HighlightsAllMatches(t *testing.T) {
+	w, h := 20, 4
+	vp := newSearchViewport(w, h)
+	setContent(vp, []string{
+		"an apple",
+		"banana",
+		"cherry",
+	})
+	vp.SetSearch("an")
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.GreenFg.Render("an") + " apple",
+		"b" + internal.RedFg.Render("an") + internal.RedFg.Render("an") + "a",
+		"cherry",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if got := vp.GetSearchMatchCount(); got != 3 {
+		t.Errorf("expected 3 matches, got %d", got)
+	}
+	if got := vp.GetFocusedSearchMatchIdx(); got != 0 {
+		t.Errorf("expected focused match 0, got %d", got)
+	}
+}
+
+func TestSearch_NextPrevWrapAround(t *testing.T) {
+	w, h := 20, 4
+	vp := newSearchViewport(w, h)
+	setContent(vp, []string{
+		"x",
+		"y x",
+		"z",
+	})
+	vp.SetSearch("x")
+
+	vp, _ = vp.Update(nextSearchMatchKeyMsg)
+	if got := vp.GetFocusedSearchMatchIdx(); got != 1 {
+		t.Errorf("expected focused match 1, got %d", got)
+	}
+	vp, _ = vp.Update(nextSearchMatchKeyMsg)
+	if got := vp.GetFocusedSearchMatchIdx(); got != 0 {
+		t.Errorf("expected focused match to wrap to 0, got %d", got)
+	}
+	vp, _ = vp.Update(prevSearchMatchKeyMsg)
+	if got := vp.GetFocusedSearchMatchIdx(); got != 1 {
+		t.Errorf("expected focused match to wrap to 1, got %d", got)
+	}
+}
+
+func TestSearch_NextMatchScrollsAndSelects(t *testing.T) {
+	w, h := 20, 3
+	vp := newSearchViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{
+		"a",
+		"b",
+		"c",
+		"d",
+		"e",
+	})
+	vp.SetSearch("d")
+
+	expectedView := internal.Pad(w, h, []string{
+		"c",
+		internal.GreenFg.Render("d"),
+		"80% (4/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if got := vp.GetSelectedItemIdx(); got != 3 {
+		t.Errorf("expected selected item 3, got %d", got)
+	}
+}
+
+func TestSearch_StartsFromSelection(t *testing.T) {
+	w, h := 20, 6
+	vp := newSearchViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{
+		"match",
+		"other",
+		"match",
+	})
+	vp.SetSelectedItemIdx(1)
+	vp.SetSearch("match")
+
+	if got := vp.GetFocusedSearchMatchIdx(); got != 1 {
+		t.Errorf("expected focused match 1, got %d", got)
+	}
+	if got := vp.GetSelectedItemIdx(); got != 2 {
+		t.Errorf("expected selected item 2, got %d", got)
+	}
+}
+
+func TestSearch_KeysIgnoredWithoutMatches(t *testing.T) {
+	w, h := 20, 3
+	vp := newSearchViewport(w, h, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c"})
+	vp.SetSearch("missing")
+
+	vp, _ = vp.Update(nextSearchMatchKeyMsg)
+	if got := vp.GetSearchMatchCount(); got != 0 {
+		t.Errorf("expected 0 matches, got %d", got)
+	}
+	if got := vp.GetFocusedSearchMatchIdx(); got != -1 {
+		t.Errorf("expected no focused match, got %d", got)
+	}
+}
+
+func TestSearch_UpdatedWithContent(t *testing.T) {
+	w, h := 20, 5
+	vp := newSearchViewport(w, h)
+	setContent(vp, []string{"a"})
+	vp.SetSearch("b")
+	if got := vp.GetSearchMatchCount(); got != 0 {
+		t.Errorf("expected 0 matches, got %d", got)
+	}
+
+	vp.AppendObjects(toObjects([]string{"b", "bb"}))
+	if got := vp.GetSearchMatchCount(); got != 3 {
+		t.Errorf("expected 3 matches after append, got %d", got)
+	}
+
+	vp.PrependObjects(toObjects([]string{"b"}))
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("b"),
+		"a",
+		internal.GreenFg.Render("b"),
+		internal.RedFg.Render("b") + internal.RedFg.Render("b"),
+		"100% (4/4)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	setContent(vp, []string{"c"})
+	if got := vp.GetSearchMatchCount(); got != 0 {
+		t.Errorf("expected 0 matches after set, got %d", got)
+	}
+}
+
+func TestSearch_Clear(t *testing.T) {
+	w, h := 20, 3
+	vp := newSearchViewport(w, h)
+	setContent(vp, []string{"a", "b"})
+	vp.SetSearch("a")
+	vp.SetSearch("")
+
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if got := vp.GetSearch(); got != "" {
+		t.Errorf("expected empty search, got %q", got)
+	}
+}
+
+func TestSearch_IncludesHeader(t *testing.T) {
+	w, h := 20, 4
+	vp := newSearchViewport(w, h)
+	vp.SetHeader([]string{"id name"})
+	setContent(vp, []string{"1 name"})
+	vp.SetSearch("name")
+
+	expectedView := internal.Pad(w, h, []string{
+		"id name",
+		"1 " + internal.GreenFg.Render("name"),
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetSearchIncludesHeader(true)
+	expectedView = internal.Pad(w, h, []string{
+		"id " + internal.RedFg.Render("name"),
+		"1 " + internal.GreenFg.Render("name"),
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if vp.GetSearchMatchCount() != 1 {
+		t.Errorf("expected header matches not counted, got %d matches", vp.GetSearchMatchCount())
+	}
+}
+
+func TestHeaderHighlights(t *testing.T) {
+	w, h := 20, 3
+	vp := newSearchViewport(w, h, WithWrapText[object](true))
+	vp.SetHeader([]string{"a header"})
+	vp.SetHeaderHighlights([]Highlight{{
+		ItemIndex: 0,
+		ItemHighlight: item.Highlight{
+			Style:                    internal.BlueFg,
+			ByteRangeUnstyledContent: item.ByteRange{Start: 2, End: 8},
+		},
+	}})
+
+	expectedView := internal.Pad(w, h, []string{
+		"a " + internal.BlueFg.Render("header"),
+		"",
+		"",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,77 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// selectionChanges returns the SelectionChangedMsgs sent by cmd
+func selectionChanges(cmd tea.Cmd) []SelectionChangedMsg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case SelectionChangedMsg:
+		return []SelectionChangedMsg{msg}
+	case tea.BatchMsg:
+		var res []SelectionChangedMsg
+		for _, c := range msg {
+			res = append(res, selectionChanges(c)...)
+		}
+		return res
+	}
+	return nil
+}
+
+func TestSelectionChanged_SentOnNavigation(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, cmd := vp.Update(downKeyMsg)
+	if changes := selectionChanges(cmd); len(changes) != 1 || changes[0].ItemIdx != 1 {
+		t.Errorf("expected a change to item 1, got %+v", changes)
+	}
+
+	vp, cmd = vp.Update(upKeyMsg)
+	if changes := selectionChanges(cmd); len(changes) != 1 || changes[0].ItemIdx != 0 {
+		t.Errorf("expected a change to item 0, got %+v", changes)
+	}
+
+	// already at the top
+	_, cmd = vp.Update(upKeyMsg)
+	if changes := selectionChanges(cmd); len(changes) != 0 {
+		t.Errorf("expected no change, got %+v", changes)
+	}
+}
+
+func TestSelectionChanged_NotSentOutsideUpdate(t *testing.T) {
+	vp := newViewport(10, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp.SetSelectedItemIdx(2)
+	_, cmd := vp.Update(nil)
+	if changes := selectionChanges(cmd); len(changes) != 0 {
+		t.Errorf("expected no change, got %+v", changes)
+	}
+}
+
+func TestSelectionChanged_NotSentWithoutSelection(t *testing.T) {
+	vp := newViewport(10, 4)
+	setContent(vp, []string{"a", "b", "c", "d", "e"})
+
+	_, cmd := vp.Update(downKeyMsg)
+	if changes := selectionChanges(cmd); len(changes) != 0 {
+		t.Errorf("expected no change, got %+v", changes)
+	}
+}
+
+func TestSelectionChanged_NotSentWhenMovingSelectedItem(t *testing.T) {
+	vp := newMoveViewport(10, 4)
+	setContent(vp, []string{"a", "b", "c"})
+
+	_, cmd := vp.Update(moveItemDownKeyMsg)
+	if changes := selectionChanges(cmd); len(changes) != 0 {
+		t.Errorf("expected no change, got %+v", changes)
+	}
+}
@@ -0,0 +1,61 @@
+package viewport
+
+import (
+	"slices"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestSeparators_ShownBeneathItemsOutsideSelection(t *testing.T) {
+	w, h := 20, 6
+	vp := newViewport(w, h, WithSelectionEnabled[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: selectionStyle,
+		SeparatorStyle:    internal.RedFg,
+	})
+	setContent(vp, []string{"a", "b", "c"})
+	vp.SetSeparators([]int{0})
+
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		internal.RedFg.Render("--"),
+		"b",
+		"c",
+		"",
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetSeparators(nil)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("a"),
+		"b",
+		"c",
+		"",
+		"",
+		"33% (1/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestSeparators_KeptOnSetAndShiftedOnPrepend(t *testing.T) {
+	vp := newViewport(20, 6)
+	setContent(vp, []string{"a", "b", "c"})
+	vp.SetSeparators([]int{1, 0})
+	if got := vp.GetSeparators(); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("expected separators beneath items 0 and 1, got %v", got)
+	}
+
+	setContent(vp, []string{"d", "e", "f"})
+	if got := vp.GetSeparators(); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("expected separators kept when objects are set, got %v", got)
+	}
+
+	vp.PrependObjects(toObjects([]string{"x"}))
+	if got := vp.GetSeparators(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("expected separators shifted down by the prepended object, got %v", got)
+	}
+}
@@ -0,0 +1,111 @@
+package viewport
+
+import (
+	"testing"
+	"time"
+)
+
+// runSmoothScroll shows the frames of the animation until it's done, returning the top item of each
+func runSmoothScroll(vp *Model[object]) []int {
+	var tops []int
+	for i := 0; vp.IsSmoothScrolling(); i++ {
+		if i > 2*smoothScrollFrames {
+			panic("smooth scroll never finished")
+		}
+		tops = append(tops, vp.display.topItemIdx)
+		vp, _ = vp.Update(smoothScrollMsg{id: vp.id, seq: vp.navigation.smoothScroll.seq})
+	}
+	return append(tops, vp.display.topItemIdx)
+}
+
+func TestSmoothScroll_AnimatesJumps(t *testing.T) {
+	vp := newViewport(10, 6, WithSmoothScroll[object](time.Millisecond))
+	setContent(vp, numberedContent(100))
+
+	vp, cmd := vp.Update(goToBottomKeyMsg)
+	if cmd == nil || !vp.IsSmoothScrolling() {
+		t.Fatal("expected the jump to the bottom to animate")
+	}
+
+	// a long jump starts 3 pages of 5 lines from the bottom and eases toward it
+	tops := runSmoothScroll(vp)
+	if tops[0] != 80 || tops[len(tops)-1] != 95 {
+		t.Errorf("expected to scroll from item 80 to 95, got %v", tops)
+	}
+	for i := 1; i < len(tops); i++ {
+		if tops[i] < tops[i-1] {
+			t.Errorf("expected to only scroll down, got %v", tops)
+		}
+	}
+	if tops[1]-tops[0] <= tops[len(tops)-1]-tops[len(tops)-2] {
+		t.Errorf("expected to slow down toward the end, got %v", tops)
+	}
+
+	vp, _ = vp.Update(goToTopKeyMsg)
+	tops = runSmoothScroll(vp)
+	if tops[0] != 15 || tops[len(tops)-1] != 0 {
+		t.Errorf("expected to scroll from item 15 to 0, got %v", tops)
+	}
+}
+
+func TestSmoothScroll_ShortScrollsAreInstant(t *testing.T) {
+	vp := newViewport(10, 6, WithSmoothScroll[object](time.Millisecond))
+	setContent(vp, numberedContent(100))
+
+	vp, _ = vp.Update(fullPgDownKeyMsg)
+	if vp.IsSmoothScrolling() {
+		t.Error("expected a page down not to animate")
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 5 {
+		t.Errorf("expected item 5 at the top, got %d", top)
+	}
+}
+
+func TestSmoothScroll_InputFinishesAnimation(t *testing.T) {
+	vp := newViewport(10, 6, WithSmoothScroll[object](time.Millisecond))
+	setContent(vp, numberedContent(100))
+
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	vp, _ = vp.Update(upKeyMsg)
+	if vp.IsSmoothScrolling() {
+		t.Error("expected input to end the animation")
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 94 {
+		t.Errorf("expected to scroll up from the bottom to item 94, got %d", top)
+	}
+}
+
+func TestSmoothScroll_ScrollingElsewhereEndsAnimation(t *testing.T) {
+	vp := newViewport(10, 6, WithSmoothScroll[object](time.Millisecond))
+	setContent(vp, numberedContent(100))
+
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	vp.ScrollUp(10)
+	vp, _ = vp.Update(smoothScrollMsg{id: vp.id, seq: vp.navigation.smoothScroll.seq})
+	if vp.IsSmoothScrolling() {
+		t.Error("expected scrolling during the animation to end it")
+	}
+	if top, _ := vp.GetTopItemIdxAndLineOffset(); top != 70 {
+		t.Errorf("expected item 70 at the top, got %d", top)
+	}
+}
+
+func TestSmoothScroll_EnsureItemInViewCmd(t *testing.T) {
+	vp := newViewport(10, 6, WithSelectionEnabled[object](true), WithSmoothScroll[object](time.Millisecond))
+	setContent(vp, numberedContent(100))
+
+	if cmd := vp.EnsureItemInViewCmd(3, 0, 0, 0, 0); cmd != nil {
+		t.Error("expected no animation for an item in view")
+	}
+	if cmd := vp.EnsureItemInViewCmd(40, 0, 0, 0, 0); cmd == nil {
+		t.Fatal("expected an animation for a far away item")
+	}
+	if tops := runSmoothScroll(vp); tops[len(tops)-1] != 36 {
+		t.Errorf("expected to end with item 36 at the top, got %v", tops)
+	}
+
+	vp.SetSmoothScroll(0)
+	if cmd := vp.EnsureItemInViewCmd(0, 0, 0, 0, 0); cmd != nil || vp.GetSmoothScroll() != 0 {
+		t.Error("expected no animation with smooth scrolling disabled")
+	}
+}
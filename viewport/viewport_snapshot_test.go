@@ -0,0 +1,55 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+)
+
+func TestSnapshot_HTML(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: lipgloss.NewStyle().Foreground(lipgloss.Blue),
+	})
+	setContent(vp, []string{"first", "a < b"})
+
+	doc := vp.Snapshot(SnapshotHTML)
+	if !strings.HasPrefix(doc, "<!DOCTYPE html>") {
+		t.Errorf("expected a standalone HTML document, got %q", doc)
+	}
+	for _, expected := range []string{
+		`<span style="color:#0000ee">first</span>`,
+		"a &lt; b",
+		"50% (1/2)",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected the snapshot to contain %q, got %q", expected, doc)
+		}
+	}
+}
+
+func TestSnapshot_SVG(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true))
+	vp.SetStyles(Styles{
+		FooterStyle:       lipgloss.NewStyle(),
+		SelectedItemStyle: lipgloss.NewStyle().Reverse(true),
+	})
+	setContent(vp, []string{"first", "a & b"})
+
+	doc := vp.Snapshot(SnapshotSVG)
+	if !strings.HasPrefix(doc, `<svg xmlns="http://www.w3.org/2000/svg" width="188" height="92"`) {
+		t.Errorf("expected an SVG sized to 20 cells by 4 lines, got %q", doc)
+	}
+	for _, expected := range []string{
+		// the reversed selection is drawn as a light background behind dark text
+		`<rect x="10" y="10" width="42" height="18" fill="#e5e5e5"/>`,
+		`<text x="10" y="23" textLength="42" lengthAdjust="spacingAndGlyphs" fill="#000000">first</text>`,
+		`<text x="10" y="41" textLength="42" lengthAdjust="spacingAndGlyphs" fill="#e5e5e5">a &amp; b</text>`,
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("expected the snapshot to contain %q, got %q", expected, doc)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestSoftLimits_MaxItemsDisablesAndRestoresWrap(t *testing.T) {
+	w, h := 60, 4
+	vp := newViewport(w, h, WithWrapText[object](true), WithSoftLimits[object](SoftLimits{MaxItems: 2}))
+	setContent(vp, []string{"a", "b", "c"})
+
+	if vp.GetWrapText() {
+		t.Error("expected wrapping disabled over soft limits")
+	}
+	if !vp.SoftLimitsExceeded() {
+		t.Error("expected soft limits exceeded")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"c",
+		"large content (over 2 items), wrap off  100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// requesting wrap while over limits is deferred
+	vp.SetWrapText(false)
+	vp.SetWrapText(true)
+	if vp.GetWrapText() {
+		t.Error("expected wrapping to stay disabled over soft limits")
+	}
+
+	setContent(vp, []string{"a", "b"})
+	if !vp.GetWrapText() {
+		t.Error("expected wrapping restored within soft limits")
+	}
+	if vp.SoftLimitsExceeded() {
+		t.Error("expected soft limits not exceeded")
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestSoftLimits_NoticeWithoutWrap(t *testing.T) {
+	w, h := 60, 3
+	vp := newViewport(w, h, WithSoftLimits[object](SoftLimits{MaxLineWidth: 5}))
+	setContent(vp, []string{"short", "too long"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"short",
+		"too long",
+		"large content (line over 5 wide)  100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestCheckSoftLimits(t *testing.T) {
+	objects := []object{
+		{item: item.NewItem("hello")},
+		{item: item.NewItem("world!")},
+	}
+	tests := []struct {
+		name     string
+		limits   SoftLimits
+		expected string
+	}{
+		{name: "no limits", limits: SoftLimits{}, expected: ""},
+		{name: "within limits", limits: SoftLimits{MaxItems: 2, MaxTotalBytes: 11, MaxLineWidth: 6}, expected: ""},
+		{name: "items", limits: SoftLimits{MaxItems: 1}, expected: "over 1 items"},
+		{name: "bytes", limits: SoftLimits{MaxTotalBytes: 10}, expected: "over 10 bytes"},
+		{name: "line width", limits: SoftLimits{MaxLineWidth: 5}, expected: "line over 5 wide"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := CheckSoftLimits(tt.limits, objects); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSoftLimitUsage_AddAndRemove(t *testing.T) {
+	usage := NewSoftLimitUsage[object](SoftLimits{MaxItems: 2, MaxLineWidth: 5})
+	wide := toObjects([]string{"wide line"})
+	narrow := toObjects([]string{"a", "b"})
+
+	usage.Add(narrow)
+	if actual := usage.Exceeded(); actual != "" {
+		t.Errorf("expected within limits, got %q", actual)
+	}
+	usage.Add(wide)
+	if actual := usage.Exceeded(); actual != "over 2 items" {
+		t.Errorf("expected over the item limit, got %q", actual)
+	}
+	usage.Remove(narrow[:1], append(narrow[1:], wide...))
+	if actual := usage.Exceeded(); actual != "line over 5 wide" {
+		t.Errorf("expected over the width limit, got %q", actual)
+	}
+	usage.Remove(wide, narrow[1:])
+	if actual := usage.Exceeded(); actual != "" {
+		t.Errorf("expected within limits once the wide line is removed, got %q", actual)
+	}
+}
+
+func TestSoftLimits_DisablesHighlightAll(t *testing.T) {
+	vp := newViewport(60, 4, WithSoftLimits[object](SoftLimits{MaxLineWidth: 5}))
+	setContent(vp, []string{"a wide line"})
+	vp.HighlightAll(0, "i", internal.RedFg)
+	if highlights := vp.GetHighlights(); len(highlights) != 0 {
+		t.Errorf("expected no highlights over soft limits, got %d", len(highlights))
+	}
+
+	setContent(vp, []string{"wide"})
+	vp.HighlightAll(0, "i", internal.RedFg)
+	if highlights := vp.GetHighlights(); len(highlights) != 1 {
+		t.Errorf("expected a highlight within soft limits, got %d", len(highlights))
+	}
+}
@@ -0,0 +1,88 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var toggleSortKeyMsg = internal.MakeKeyMsg('S')
+
+func byContent(a, b object) bool {
+	return a.GetItem().ContentNoAnsi() < b.GetItem().ContentNoAnsi()
+}
+
+func TestSort_CyclesDirections(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h, WithSortFunc[object](byContent))
+	setContent(vp, []string{"b", "c", "a"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"c",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(toggleSortKeyMsg)
+	if vp.GetSortDirection() != SortDescending {
+		t.Errorf("expected descending, got %d", vp.GetSortDirection())
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"c",
+		"b",
+		"a",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(toggleSortKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"b",
+		"c",
+		"a",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetSortDirection(SortAscending)
+	vp.SetSortFunc(nil)
+	if vp.GetSortDirection() != SortNone {
+		t.Error("expected no sorting without a sort function")
+	}
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestSort_AppendedObjectsSorted(t *testing.T) {
+	w, h := 15, 4
+	vp := newViewport(w, h, WithSortFunc[object](byContent))
+	setContent(vp, []string{"c", "a"})
+	vp.AppendObjects(toObjects([]string{"b"}))
+
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"c",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestSort_SelectionStable(t *testing.T) {
+	vp := newViewport(15, 4, WithSelectionEnabled[object](true), WithSortFunc[object](byContent))
+	vp.SetSelectionComparator(objectsEqual)
+	setContent(vp, []string{"b", "c", "a"})
+	vp.SetSelectedItemIdx(0)
+
+	vp, _ = vp.Update(toggleSortKeyMsg)
+	if got := vp.GetSelectedItem().GetItem().ContentNoAnsi(); got != "a" {
+		t.Errorf("expected a to stay selected, got %q", got)
+	}
+	if vp.GetSelectedItemIdx() != 2 {
+		t.Errorf("expected a selected at index 2, got %d", vp.GetSelectedItemIdx())
+	}
+	if vp.MoveItem(0, 1) {
+		t.Error("expected items not movable while sorted")
+	}
+}
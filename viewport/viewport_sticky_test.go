@@ -0,0 +1,121 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func isSection(o object) bool {
+	return strings.HasPrefix(o.GetItem().ContentNoAnsi(), "#")
+}
+
+func TestStickyItems_PinnedOnceScrolledPast(t *testing.T) {
+	w, h := 20, 5
+	vp := newViewport(w, h, WithStickyItemFunc[object](isSection))
+	vp.SetHeader([]string{"header"})
+	setContent(vp, []string{"# one", "a", "b", "# two", "c", "d", "e"})
+
+	// the sticky item is in view at the top
+	expectedView := internal.Pad(w, h, []string{
+		"header",
+		"# one",
+		"a",
+		"b",
+		"42% (3/7)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollDown(2)
+	expectedView = internal.Pad(w, h, []string{
+		"header",
+		"# one",
+		"# two",
+		"c",
+		"71% (5/7)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollDown(2)
+	expectedView = internal.Pad(w, h, []string{
+		"header",
+		"# two",
+		"d",
+		"e",
+		"100% (7/7)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestStickyItems_NotOverSelection(t *testing.T) {
+	w, h := 20, 4
+	vp := newViewport(w, h, WithStickyItemFunc[object](isSection), WithSelectionEnabled[object](true))
+	setContent(vp, []string{"# one", "a", "b", "c", "d"})
+
+	vp.SetSelectedItemIdx(4)
+	expectedView := internal.Pad(w, h, []string{
+		"# one",
+		"c",
+		selectionStyle.Render("d"),
+		"100% (5/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(upKeyMsg)
+	vp, _ = vp.Update(upKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("b"),
+		"c",
+		"d",
+		"60% (3/5)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestStickyItems_Disabled(t *testing.T) {
+	w, h := 20, 3
+	vp := newViewport(w, h, WithStickyItemFunc[object](isSection))
+	setContent(vp, []string{"# one", "a", "b"})
+	vp.SetStickyItemFunc(nil)
+	vp.ScrollDown(1)
+	expectedView := internal.Pad(w, h, []string{
+		"a",
+		"b",
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestStickyItems_OnlyNewItemsChecked(t *testing.T) {
+	w, h := 20, 3
+	numChecked := 0
+	vp := newViewport(w, h, WithStickyItemFunc[object](func(o object) bool {
+		numChecked++
+		return isSection(o)
+	}))
+	lines := []string{"# one"}
+	for range 1000 {
+		lines = append(lines, "x")
+	}
+	setContent(vp, lines)
+	vp.GoToBottom()
+	vp.View()
+
+	numChecked = 0
+	vp.View()
+	vp.ScrollUp(1)
+	vp.View()
+	if numChecked != 0 {
+		t.Errorf("expected no items checked again, got %d", numChecked)
+	}
+
+	// indexes shift, so the sticky item is found again
+	vp.PrependObjects(toObjects([]string{"# zero"}))
+	expectedView := internal.Pad(w, h, []string{
+		"# one",
+		"x",
+		"99% (1001/1002)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
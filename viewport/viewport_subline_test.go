@@ -0,0 +1,101 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func newSubLineViewport(width, height int) *Model[object] {
+	return newViewport(width, height, WithSelectionEnabled[object](true), WithWrapText[object](true),
+		WithSubLineSelection[object](true))
+}
+
+func TestSubLine_MovesThroughWrappedLines(t *testing.T) {
+	w, h := 4, 4
+	vp := newSubLineViewport(w, h)
+	setContent(vp, []string{"abcdefghij", "k"})
+
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("abcd"),
+		"efgh",
+		"ij",
+		"5...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 0 || vp.GetSelectedLineOffset() != 1 {
+		t.Errorf("expected line 1 of item 0, got line %d of item %d", vp.GetSelectedLineOffset(),
+			vp.GetSelectedItemIdx())
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"abcd",
+		selectionStyle.Render("efgh"),
+		"ij",
+		"5...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// past the last line to the next item, scrolling it into view
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	if vp.GetSelectedItemIdx() != 1 || vp.GetSelectedLineOffset() != 0 {
+		t.Errorf("expected line 0 of item 1, got line %d of item %d", vp.GetSelectedLineOffset(),
+			vp.GetSelectedItemIdx())
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"efgh",
+		"ij",
+		selectionStyle.Render("k"),
+		"1...",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// back up onto the previous item's last line
+	vp, _ = vp.Update(upKeyMsg)
+	if vp.GetSelectedItemIdx() != 0 || vp.GetSelectedLineOffset() != 2 {
+		t.Errorf("expected line 2 of item 0, got line %d of item %d", vp.GetSelectedLineOffset(),
+			vp.GetSelectedItemIdx())
+	}
+}
+
+func TestSubLine_ScrollsLongItemLineByLine(t *testing.T) {
+	w, h := 2, 3
+	vp := newSubLineViewport(w, h)
+	setContent(vp, []string{"aabbccdd"})
+
+	for range 2 {
+		vp, _ = vp.Update(downKeyMsg)
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"bb",
+		selectionStyle.Render("cc"),
+		"..",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if top, offset := vp.GetTopItemIdxAndLineOffset(); top != 0 || offset != 1 {
+		t.Errorf("expected line 1 of item 0 at the top, got line %d of item %d", offset, top)
+	}
+}
+
+func TestSubLine_OtherSelectionChangesStartAtFirstLine(t *testing.T) {
+	vp := newSubLineViewport(4, 5)
+	setContent(vp, []string{"abcdefgh", "ijklmnop"})
+
+	vp, _ = vp.Update(downKeyMsg)
+	vp.SetSelectedItemIdx(1)
+	if vp.GetSelectedLineOffset() != 0 {
+		t.Errorf("expected line 0, got %d", vp.GetSelectedLineOffset())
+	}
+
+	vp.SetSubLineSelection(false)
+	if vp.GetSubLineSelection() {
+		t.Fatal("expected sub-line selection off")
+	}
+	vp, _ = vp.Update(upKeyMsg)
+	if vp.GetSelectedItemIdx() != 0 || vp.GetSelectedLineOffset() != 0 {
+		t.Errorf("expected item 0 selected as a whole, got line %d of item %d", vp.GetSelectedLineOffset(),
+			vp.GetSelectedItemIdx())
+	}
+}
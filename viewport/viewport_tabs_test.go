@@ -0,0 +1,46 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+func TestTabs_ExpandedToTabStops(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h, WithTabWidth[object](4), WithWrapText[object](true))
+	setContent(vp, []string{"a\tb\tc", "name\tvalue\tx"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"a   b   c",
+		"name    valu",
+		"e   x",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetTabWidth(2)
+	if vp.GetTabWidth() != 2 {
+		t.Errorf("expected a tab width of 2, got %d", vp.GetTabWidth())
+	}
+	expectedView = internal.Pad(w, h, []string{
+		"a b c",
+		"name  value ",
+		"x",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestTabs_HighlightsAfterTabs(t *testing.T) {
+	w, h := 12, 2
+	vp := newViewport(w, h, WithTabWidth[object](4))
+	setContent(vp, []string{"a\tbc\td"})
+
+	vp.HighlightAll(0, "bc\td", internal.RedFg)
+	expectedView := internal.Pad(w, h, []string{
+		"a   " + internal.RedFg.Render("bc  d"),
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,126 @@
+package viewport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+// clockTime returns the time of day hhmm, e.g. "10:30", on a fixed day
+func clockTime(hhmm string) time.Time {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		panic(err)
+	}
+	return time.Date(2024, time.March, 1, t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+// objectTime returns the time at the start of an object's content, e.g. "10:30 started"
+func objectTime(o object) time.Time {
+	return clockTime(o.GetItem().Content()[:5])
+}
+
+// newTimestampedViewport returns a viewport of objects timed by their content, as of the time of day now
+func newTimestampedViewport(w, h int, now string, opts ...Option[object]) *Model[object] {
+	vp := newViewport(w, h, append([]Option[object]{WithTimestampFunc(objectTime)}, opts...)...)
+	vp.config.timestamps.now = func() time.Time { return clockTime(now) }
+	return vp
+}
+
+func TestTimestamps_MaxAge(t *testing.T) {
+	vp := newTimestampedViewport(20, 5, "10:30", WithMaxAge[object](15*time.Minute))
+	setContent(vp, []string{"10:00 a", "10:10 b", "10:20 c", "10:25 d"})
+	if n, first := vp.content.numItems(), vp.content.objectAt(0).GetItem().Content(); n != 2 || first != "10:20 c" {
+		t.Errorf("expected the objects of the last 15 minutes kept, got %d from %s", n, first)
+	}
+
+	vp.config.timestamps.now = func() time.Time { return clockTime("10:50") }
+	vp.AppendObjects(toObjects([]string{"10:40 e"}))
+	if n, first := vp.content.numItems(), vp.content.objectAt(0).GetItem().Content(); n != 1 || first != "10:40 e" {
+		t.Errorf("expected expired objects dropped on append, got %d from %s", n, first)
+	}
+
+	// the newest object is kept even once expired
+	vp.config.timestamps.now = func() time.Time { return clockTime("12:00") }
+	vp.DropExpired()
+	if n := vp.content.numItems(); n != 1 {
+		t.Errorf("expected the newest object kept, got %d objects", n)
+	}
+}
+
+func TestTimestamps_MaxAgeWithoutTimestampFunc(t *testing.T) {
+	vp := newViewport(20, 5, WithMaxAge[object](time.Minute))
+	setContent(vp, []string{"10:00 a", "10:10 b"})
+	if n := vp.content.numItems(); n != 2 || vp.GetMaxAge() != time.Minute {
+		t.Errorf("expected every object kept without a timestamp function, got %d", n)
+	}
+}
+
+func TestTimestamps_GoToTime(t *testing.T) {
+	vp := newTimestampedViewport(20, 5, "10:30", WithSelectionEnabled[object](true),
+		WithKeyMap[object](goToKeyMap()))
+	setContent(vp, []string{"10:00 a", "10:10 b", "10:20 c", "10:25 d"})
+
+	vp.GoToTime(clockTime("10:12"))
+	if idx := vp.GetSelectedItemIdx(); idx != 2 {
+		t.Errorf("expected the first item at or after 10:12 selected, got %d", idx)
+	}
+	vp.GoToTime(clockTime("11:00"))
+	if idx := vp.GetSelectedItemIdx(); idx != 3 {
+		t.Errorf("expected the last item selected when every item is earlier, got %d", idx)
+	}
+
+	vp, _ = typeGoTo(vp, "10:10")
+	if idx := vp.GetSelectedItemIdx(); idx != 1 {
+		t.Errorf("expected the go-to input to go to 10:10, got %d", idx)
+	}
+	vp, _ = typeGoTo(vp, "10:99")
+	if idx := vp.GetSelectedItemIdx(); idx != 1 {
+		t.Errorf("expected an invalid time to keep the selection, got %d", idx)
+	}
+}
+
+func TestTimestamps_TimeGutter(t *testing.T) {
+	w, h := 16, 4
+	vp := newTimestampedViewport(w, h, "10:30", WithTimeGutter[object](true), WithScrollbar[object](true),
+		WithWrapText[object](true))
+	vp.SetStyles(Styles{FooterStyle: vp.display.styles.FooterStyle})
+	setContent(vp, []string{"10:00 a", "10:29 long enough"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"10:00 a     30m┃",
+		"10:29 long   1m┃",
+		" enough        ┃",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetTimeGutter(false)
+	expectedView = internal.Pad(w, h, []string{
+		"10:00 a        ┃",
+		"10:29 long enou┃",
+		"gh             ┃",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestTimestamps_FormatAge(t *testing.T) {
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{age: -time.Second, expected: "0s"},
+		{age: 42 * time.Second, expected: "42s"},
+		{age: 15*time.Minute + 30*time.Second, expected: "15m"},
+		{age: 3 * time.Hour, expected: "3h"},
+		{age: 12 * 24 * time.Hour, expected: "12d"},
+		{age: 5000 * 24 * time.Hour, expected: "999d"},
+	}
+	for _, tt := range tests {
+		if got := formatAge(tt.age); got != tt.expected {
+			t.Errorf("formatAge(%v): expected %q, got %q", tt.age, tt.expected, got)
+		}
+	}
+}
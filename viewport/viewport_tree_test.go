@@ -0,0 +1,126 @@
+package viewport
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+type treeObject struct {
+	item  item.Item
+	depth int
+}
+
+func (o treeObject) GetItem() item.Item {
+	return o.item
+}
+
+func (o treeObject) GetTreeDepth() int {
+	return o.depth
+}
+
+func newTreeViewport(w, h int) *Model[treeObject] {
+	vp := New[treeObject](w, h,
+		WithSelectionEnabled[treeObject](true),
+		WithTreeMode[treeObject](true),
+		WithStyles[treeObject](Styles{
+			FooterStyle:       lipgloss.NewStyle(),
+			SelectedItemStyle: selectionStyle,
+		}),
+	)
+	vp.SetObjects([]treeObject{
+		{item: item.NewItem("src"), depth: 0},
+		{item: item.NewItem("main.go"), depth: 1},
+		{item: item.NewItem("util"), depth: 1},
+		{item: item.NewItem("str.go"), depth: 2},
+		{item: item.NewItem("README.md"), depth: 0},
+	})
+	return vp
+}
+
+func TestTree_Expanded(t *testing.T) {
+	w, h := 30, 7
+	vp := newTreeViewport(w, h)
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("▾ src"),
+		"    main.go",
+		"  ▾ util",
+		"      str.go",
+		"  README.md",
+		"",
+		"20% (1/5)  5/5 visible",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestTree_ToggleCollapse(t *testing.T) {
+	w, h := 30, 7
+	vp := newTreeViewport(w, h)
+	vp.SetSelectedItemIdx(2)
+
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if !vp.IsNodeCollapsed(2) {
+		t.Fatal("expected util to be collapsed")
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"▾ src",
+		"    main.go",
+		selectionStyle.Render("  ▸ util"),
+		"  README.md",
+		"",
+		"",
+		"75% (3/4)  4/5 visible",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if vp.IsNodeCollapsed(2) {
+		t.Fatal("expected util to be expanded")
+	}
+	if n := vp.content.numItems(); n != 5 {
+		t.Errorf("expected 5 visible nodes, got %d", n)
+	}
+}
+
+func TestTree_CollapsingHidesSelectionSelectsAncestor(t *testing.T) {
+	vp := newTreeViewport(30, 7)
+	vp.SetSelectedItemIdx(3)
+	vp.SetMarked(4, true)
+
+	vp.SetNodeCollapsed(0, true)
+	if idx := vp.GetSelectedItemIdx(); idx != 0 {
+		t.Errorf("expected the collapsed ancestor selected, got %d", idx)
+	}
+	if !vp.IsMarked(1) || len(vp.GetMarkedItemIdxs()) != 1 {
+		t.Errorf("expected the mark to follow README.md to index 1, got %v", vp.GetMarkedItemIdxs())
+	}
+}
+
+func TestTree_LeafIgnoresToggle(t *testing.T) {
+	vp := newTreeViewport(30, 7)
+	vp.SetSelectedItemIdx(1)
+	vp, _ = vp.Update(toggleExpandKeyMsg)
+	if vp.IsNodeCollapsed(1) || vp.content.numItems() != 5 {
+		t.Error("expected a leaf not to collapse")
+	}
+}
+
+func TestTree_CollapsedKeptAcrossSetObjects(t *testing.T) {
+	vp := newTreeViewport(30, 7)
+	vp.SetAllNodesCollapsed(true)
+	if n := vp.content.numItems(); n != 2 {
+		t.Fatalf("expected 2 visible nodes with all collapsed, got %d", n)
+	}
+
+	vp.AppendObjects([]treeObject{{item: item.NewItem("LICENSE"), depth: 0}})
+	if n := vp.content.numItems(); n != 3 || !vp.IsNodeCollapsed(0) {
+		t.Errorf("expected src to stay collapsed with 3 visible nodes, got %d", n)
+	}
+
+	vp.SetTreeMode(false)
+	if n := vp.content.numItems(); n != 6 {
+		t.Errorf("expected every object shown without tree mode, got %d", n)
+	}
+}
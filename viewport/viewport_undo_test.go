@@ -0,0 +1,192 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var (
+	undoKeyMsg = tea.KeyPressMsg{Code: 'z', Mod: tea.ModCtrl}
+	redoKeyMsg = tea.KeyPressMsg{Code: 'y', Mod: tea.ModCtrl}
+)
+
+func TestUndo_RevertsAndReappliesMoves(t *testing.T) {
+	w, h := 20, 4
+	vp := newMoveViewport(w, h, WithUndo[object](10))
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp, _ = vp.Update(moveItemDownKeyMsg)
+	vp, _ = vp.Update(moveItemDownKeyMsg)
+	vp, cmd := vp.Update(undoKeyMsg)
+	if msg, ok := firstMsgOfType[ItemMovedMsg](cmd); !ok || msg.From != 2 || msg.To != 1 {
+		t.Errorf("expected an undo move from 2 to 1, got %+v", msg)
+	}
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		internal.GreenFg.Render("a"),
+		"c",
+		"Undid move item",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(undoKeyMsg)
+	vp, _ = vp.Update(undoKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		internal.GreenFg.Render("a"),
+		"b",
+		"c",
+		"Nothing to undo",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, _ = vp.Update(redoKeyMsg)
+	vp, _ = vp.Update(redoKeyMsg)
+	expectedView = internal.Pad(w, h, []string{
+		"b",
+		"c",
+		internal.GreenFg.Render("a"),
+		"Redid move item",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+	if vp.CanRedo() || !vp.CanUndo() {
+		t.Error("expected only undo to be possible")
+	}
+}
+
+func TestUndo_RecordChangeFromHost(t *testing.T) {
+	vp := newViewport(20, 4, WithUndo[object](2))
+	items := []string{"a", "b", "c"}
+	setContent(vp, items)
+
+	deleteLast := func() {
+		removed := items[len(items)-1]
+		items = items[:len(items)-1]
+		setContent(vp, items)
+		vp.RecordChange(Change{
+			Description: "delete " + removed,
+			Revert: func() tea.Cmd {
+				items = append(items, removed)
+				setContent(vp, items)
+				return nil
+			},
+			Apply: func() tea.Cmd {
+				items = items[:len(items)-1]
+				setContent(vp, items)
+				return nil
+			},
+		})
+	}
+	deleteLast()
+	deleteLast()
+	deleteLast()
+
+	vp.Undo()
+	vp.Undo()
+	vp.Undo()
+	if len(items) != 2 || vp.CanUndo() {
+		t.Errorf("expected the oldest change beyond the limit dropped, got items %v", items)
+	}
+
+	vp.Redo()
+	if len(items) != 1 {
+		t.Errorf("expected redo to delete again, got items %v", items)
+	}
+
+	vp.Undo()
+	deleteLast()
+	if vp.CanRedo() {
+		t.Error("expected a new change to clear redo")
+	}
+}
+
+func TestUndo_MovesShiftedByPrepend(t *testing.T) {
+	w, h := 20, 6
+	vp := newViewport(w, h, WithUndo[object](10))
+	vp.SetStyles(Styles{FooterStyle: lipgloss.NewStyle()})
+	setContent(vp, []string{"a", "b", "c", "d"})
+
+	vp.MoveItem(0, 1)
+	vp.PrependObjects(toObjects([]string{"x"}))
+	vp.Undo()
+	expectedView := internal.Pad(w, h, []string{
+		"x",
+		"a",
+		"b",
+		"c",
+		"d",
+		"Undid move item",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestUndo_MovesShiftedByDroppingOldest(t *testing.T) {
+	w, h := 20, 5
+	vp := newViewport(w, h, WithUndo[object](10), WithMaxItems[object](4))
+	vp.SetStyles(Styles{FooterStyle: lipgloss.NewStyle()})
+	setContent(vp, []string{"a", "b", "c", "d"})
+
+	vp.MoveItem(1, 2)
+	vp.AppendObjects(toObjects([]string{"e"}))
+	vp.Undo()
+	expectedView := internal.Pad(w, h, []string{
+		"b",
+		"c",
+		"d",
+		"e",
+		"Undid move item",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.MoveItem(0, 1)
+	vp.AppendObjects(toObjects([]string{"f"}))
+	if vp.CanUndo() {
+		t.Error("expected the move of a dropped item to be forgotten")
+	}
+}
+
+func TestUndo_MovesDroppedBySetObjects(t *testing.T) {
+	vp := newViewport(20, 5, WithUndo[object](10))
+	setContent(vp, []string{"a", "b", "c"})
+
+	vp.MoveItem(0, 1)
+	setContent(vp, []string{"x", "y", "z"})
+	if vp.CanUndo() {
+		t.Error("expected moves to be forgotten once the objects are replaced")
+	}
+}
+
+func TestUndo_DisabledByDefault(t *testing.T) {
+	vp := newMoveViewport(20, 4)
+	setContent(vp, []string{"a", "b"})
+	vp, _ = vp.Update(moveItemDownKeyMsg)
+
+	if vp.CanUndo() {
+		t.Error("expected no undo history when undo is disabled")
+	}
+	_, cmd := vp.Update(undoKeyMsg)
+	if cmd != nil {
+		t.Error("expected the undo key to be ignored")
+	}
+}
+
+// firstMsgOfType runs cmd, descending into batches, and returns the first message of type M
+func firstMsgOfType[M tea.Msg](cmd tea.Cmd) (M, bool) {
+	var zero M
+	if cmd == nil {
+		return zero, false
+	}
+	switch msg := cmd().(type) {
+	case M:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if m, ok := firstMsgOfType[M](c); ok {
+				return m, true
+			}
+		}
+	}
+	return zero, false
+}
@@ -0,0 +1,129 @@
+package viewport
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/robinovitch61/viewport/internal"
+)
+
+var visualSelectKeyMsg = tea.KeyPressMsg{Code: 'v', Text: "v"}
+
+func newVisualViewport(width, height int) *Model[object] {
+	keyMap := DefaultKeyMap()
+	keyMap.VisualSelect.SetEnabled(true)
+	vp := newViewport(width, height, WithSelectionEnabled[object](true), WithKeyMap[object](keyMap))
+	vp.SetStyles(Styles{
+		FooterStyle:          lipgloss.NewStyle(),
+		SelectedItemStyle:    selectionStyle,
+		SelectionRegionStyle: internal.RedFg,
+	})
+	return vp
+}
+
+func TestVisualSelect_KeyDisabledByDefault(t *testing.T) {
+	vp := newViewport(20, 4, WithSelectionEnabled[object](true))
+	setContent(vp, []string{"a", "b"})
+
+	vp, _ = vp.Update(visualSelectKeyMsg)
+	if vp.IsCapturingInput() {
+		t.Error("expected the visual select key disabled by default")
+	}
+}
+
+func TestVisualSelect_KeysSelectAcrossItems(t *testing.T) {
+	w, h := 20, 4
+	vp := newVisualViewport(w, h)
+	setContent(vp, []string{
+		"first line",
+		"second",
+		"third line",
+	})
+
+	vp, _ = vp.Update(visualSelectKeyMsg)
+	if !vp.IsVisualSelecting() || !vp.IsCapturingInput() {
+		t.Fatal("expected visual selection to capture input")
+	}
+	vp, _ = vp.Update(rightKeyMsg)
+	if got := vp.GetVisualSelectionText(); got != "fi" {
+		t.Errorf("expected %q, got %q", "fi", got)
+	}
+
+	// moving down keeps the cursor column, running the selection to the end of the first item
+	vp, _ = vp.Update(downKeyMsg)
+	vp, _ = vp.Update(downKeyMsg)
+	if got, want := vp.GetVisualSelectionText(), "first line\nsecond\nth"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	expectedView := internal.Pad(w, h, []string{
+		internal.RedFg.Render("first line"),
+		internal.RedFg.Render("second"),
+		internal.RedFg.Render("th") + selectionStyle.Render("ird line"),
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp, cmd := vp.Update(enterKeyMsg)
+	if vp.IsVisualSelecting() {
+		t.Error("expected visual selection to end on enter")
+	}
+	msg, ok := cmd().(VisualSelectedMsg)
+	if !ok {
+		t.Fatalf("expected VisualSelectedMsg, got %T", cmd())
+	}
+	if want := "first line\nsecond\nth"; msg.Text != want {
+		t.Errorf("expected text %q, got %q", want, msg.Text)
+	}
+}
+
+func TestVisualSelect_SelectsBackwards(t *testing.T) {
+	vp := newVisualViewport(20, 4)
+	setContent(vp, []string{"abc", "defgh"})
+	vp.SetSelectedItemIdx(0)
+
+	// anchored after the cursor, the selection runs from the cursor to the anchor
+	vp.SetVisualSelection(1, 2, 1)
+	if got, want := vp.GetVisualSelectionText(), "bc\ndef"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	vp.SetSelectedItemIdx(1)
+	vp.SetVisualSelection(1, 3, 1)
+	if got, want := vp.GetVisualSelectionText(), "efg"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	vp, cmd := vp.Update(escapeKeyMsg)
+	if vp.IsVisualSelecting() || cmd != nil || vp.GetVisualSelectionText() != "" {
+		t.Error("expected esc to cancel visual selection without a command")
+	}
+}
+
+func TestVisualSelect_DisplayColumns(t *testing.T) {
+	vp := newVisualViewport(20, 4)
+	setContent(vp, []string{"世界 ok"})
+
+	// columns 2 and 3 hold 界
+	vp.SetVisualSelection(0, 2, 3)
+	if got, want := vp.GetVisualSelectionText(), "界"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	// the cursor stops at the last column
+	for range 10 {
+		vp, _ = vp.Update(rightKeyMsg)
+	}
+	if got, want := vp.GetVisualSelectionText(), "界 ok"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestVisualSelect_RequiresSelection(t *testing.T) {
+	vp := newViewport(20, 4)
+	setContent(vp, []string{"a"})
+	if vp.StartVisualSelection() {
+		t.Error("expected visual selection to require selection")
+	}
+}
@@ -0,0 +1,85 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+)
+
+// numWrapCacheEntries returns how many items have wrap cache entries for width
+func numWrapCacheEntries(vp *Model[object], width int) int {
+	n := 0
+	for _, elem := range vp.content.wrapCache.entries {
+		if elem.Value.(*itemCacheEntry[wrapCacheEntry]).value.key.width == width {
+			n++
+		}
+	}
+	return n
+}
+
+func TestWrapCache_ResizeRewrapsLazily(t *testing.T) {
+	vp := newViewport(10, 6, WithWrapText[object](true))
+	lines := make([]string, 10000)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i%25)
+	}
+	setContent(vp, lines)
+	vp.View()
+
+	vp.SetWidth(8)
+	vp.View()
+	if n := numWrapCacheEntries(vp, 8); n == 0 || n > 20 {
+		t.Errorf("expected only items near the view and at the bottom to be rewrapped, got %d", n)
+	}
+
+	vp.ScrollDown(10)
+	vp.View()
+	if n := numWrapCacheEntries(vp, 8); n == 0 || n > 30 {
+		t.Errorf("expected items scrolled into view to be rewrapped, got %d", n)
+	}
+}
+
+func TestWrapCache_ChangedContent(t *testing.T) {
+	vp := newViewport(12, 5, WithWrapText[object](true))
+	setContent(vp, []string{"ab", "cd"})
+	vp.View()
+
+	setContent(vp, []string{"abcdefghijklmnop", "gh"})
+	expectedView := internal.Pad(vp.GetWidth(), vp.GetHeight(), []string{
+		"abcdefghijkl",
+		"mnop",
+		"gh",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapCache_BoundedForManyItems(t *testing.T) {
+	numItems := maxItemCacheEntries + 100
+	lines := make([]string, numItems)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i%25)
+	}
+	vp := newViewport(10, 6, WithWrapText[object](true))
+	setContent(vp, lines)
+
+	for idx := range numItems {
+		vp.numLinesForItem(idx)
+	}
+	if n := len(vp.content.wrapCache.entries); n != maxItemCacheEntries {
+		t.Errorf("expected %d entries, got %d", maxItemCacheEntries, n)
+	}
+
+	// the least recently used entries are evicted
+	if _, ok := vp.content.wrapCache.entries[0]; ok {
+		t.Error("expected the first item's entry evicted")
+	}
+	if _, ok := vp.content.wrapCache.entries[numItems-1]; !ok {
+		t.Error("expected the last item's entry kept")
+	}
+	if n := vp.numLinesForItem(24); n != 3 {
+		t.Errorf("expected an evicted item rewrapped to 3 rows, got %d", n)
+	}
+}
@@ -0,0 +1,248 @@
+package viewport
+
+import (
+	"testing"
+
+	"github.com/robinovitch61/viewport/internal"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+func TestWrapModeDefaultIsChars(t *testing.T) {
+	vp := newViewport(10, 5)
+	if vp.GetWrapMode() != item.WrapChars {
+		t.Errorf("expected default wrap mode to be WrapChars, got %v", vp.GetWrapMode())
+	}
+}
+
+func TestWrapModeTokensBreaksBeforeURL(t *testing.T) {
+	w, h := 12, 5
+	vp := newViewport(w, h, WithWrapText[object](true), WithWrapMode[object](item.WrapTokens))
+	setContent(vp, []string{
+		"go to https://a.io",
+		"next",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"go to ",
+		"https://a.io",
+		"next",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	// switching back to char wrapping splits the url
+	vp.SetWrapMode(item.WrapChars)
+	expectedView = internal.Pad(w, h, []string{
+		"go to https:",
+		"//a.io",
+		"next",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapModeTokensScrollingAndHighlights(t *testing.T) {
+	w, h := 12, 3
+	vp := newViewport(w, h, WithWrapText[object](true), WithWrapMode[object](item.WrapTokens))
+	setContent(vp, []string{
+		"go to https://a.io",
+		"next",
+	})
+	vp.SetHighlights([]Highlight{
+		{
+			ItemIndex: 0,
+			ItemHighlight: item.Highlight{
+				Style:                    internal.RedFg,
+				ByteRangeUnstyledContent: item.ByteRange{Start: 14, End: 18},
+			},
+		},
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"go to ",
+		"https://" + internal.RedFg.Render("a.io"),
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.ScrollDown(1)
+	expectedView = internal.Pad(w, h, []string{
+		"https://" + internal.RedFg.Render("a.io"),
+		"next",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapModeTokensSelectionEnsuresInView(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h,
+		WithWrapText[object](true),
+		WithWrapMode[object](item.WrapTokens),
+		WithSelectionEnabled[object](true),
+	)
+	setContent(vp, []string{
+		"first",
+		"go to https://a.io",
+		"last",
+	})
+
+	vp.SetSelectedItemIdx(2)
+	expectedView := internal.Pad(w, h, []string{
+		"go to ",
+		"https://a.io",
+		selectionStyle.Render("last"),
+		"100% (3/3)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapModeWordsBreaksAtWhitespace(t *testing.T) {
+	w, h := 12, 5
+	vp := newViewport(w, h, WithWrapText[object](true), WithWrapMode[object](item.WrapWords))
+	setContent(vp, []string{
+		"the first line then the second",
+		"next",
+	})
+	vp.SetHighlights([]Highlight{
+		{
+			ItemIndex: 0,
+			ItemHighlight: item.Highlight{
+				Style:                    internal.RedFg,
+				ByteRangeUnstyledContent: item.ByteRange{Start: 15, End: 23},
+			},
+		},
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"the first ",
+		"line " + internal.RedFg.Render("then "),
+		internal.RedFg.Render("the") + " second",
+		"next",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapModeWordsUsesWordChars(t *testing.T) {
+	w, h := 12, 4
+	vp := newViewport(w, h, WithWrapText[object](true), WithWrapMode[object](item.WrapWords))
+	setContent(vp, []string{"see /var/log/app.log"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"see /var/",
+		"log/app.log",
+		"",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetWordChars(item.WordChars{Extra: "/."})
+	expectedView = internal.Pad(w, h, []string{
+		"see ",
+		"/var/log/app",
+		".log",
+		"100% (1/1)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapIndent(t *testing.T) {
+	w, h := 12, 5
+	vp := newViewport(w, h,
+		WithWrapText[object](true),
+		WithWrapMode[object](item.WrapWords),
+		WithWrapIndent[object](2),
+		WithSelectionEnabled[object](true),
+	)
+	setContent(vp, []string{
+		"the first line then the second",
+		"next",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		selectionStyle.Render("the first "),
+		"  " + selectionStyle.Render("line then "),
+		"  " + selectionStyle.Render("the second"),
+		"next",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	if itemIdx, byteOffset, ok := vp.GetItemAtScreenPosition(1, 2); !ok || itemIdx != 0 || byteOffset != 10 {
+		t.Errorf("expected the indented row to map to byte 10 of item 0, got %d, %d, %t", itemIdx, byteOffset, ok)
+	}
+
+	// indent applies to char wrapping too, and is dropped when unwrapped
+	vp.SetWrapMode(item.WrapChars)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("the first li"),
+		"  " + selectionStyle.Render("ne then th"),
+		"  " + selectionStyle.Render("e second"),
+		"next",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	vp.SetWrapText(false)
+	expectedView = internal.Pad(w, h, []string{
+		selectionStyle.Render("the first..."),
+		"next",
+		"",
+		"",
+		"50% (1/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapIndicator(t *testing.T) {
+	w, h := 12, 5
+	vp := newViewport(w, h,
+		WithWrapText[object](true),
+		WithWrapIndent[object](1),
+		WithWrapIndicator[object]("↪ ", internal.RedFg),
+	)
+	setContent(vp, []string{
+		"the first line then",
+		"next",
+	})
+
+	expectedView := internal.Pad(w, h, []string{
+		"the first li",
+		" " + internal.RedFg.Render("↪ ") + "ne then",
+		"next",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+
+	if itemIdx, byteOffset, ok := vp.GetItemAtScreenPosition(1, 3); !ok || itemIdx != 0 || byteOffset != 12 {
+		t.Errorf("expected the marked row to map to byte 12 of item 0, got %d, %d, %t", itemIdx, byteOffset, ok)
+	}
+
+	vp.SetWrapIndicator("", internal.RedFg)
+	expectedView = internal.Pad(w, h, []string{
+		"the first li",
+		" ne then",
+		"next",
+		"",
+		"100% (2/2)",
+	})
+	internal.CmpStr(t, expectedView, vp.View())
+}
+
+func TestWrapIndicatorCappedToWidth(t *testing.T) {
+	w, h := 4, 3
+	vp := newViewport(w, h, WithWrapText[object](true), WithWrapIndicator[object](">>>>>", internal.RedFg))
+	setContent(vp, []string{"abcdef"})
+
+	expectedView := internal.Pad(w, h, []string{
+		"abcd",
+		internal.RedFg.Render(">>>") + "e",
+		internal.RedFg.Render(">>>") + "f",
+	})
+	vp.SetFooterEnabled(false)
+	internal.CmpStr(t, expectedView, vp.View())
+}
@@ -0,0 +1,100 @@
+package viewport
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// wrapPrecomputeCmds returns the wrap precompute jobs started by cmd
+func wrapPrecomputeCmds(cmd tea.Cmd) []tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			cmds = append(cmds, wrapPrecomputeCmds(c)...)
+		}
+		return cmds
+	}
+	if _, ok := cmd().(wrapPrecomputedMsg); ok {
+		return []tea.Cmd{cmd}
+	}
+	return nil
+}
+
+func newWrapPrecomputeViewport() *Model[object] {
+	vp := newViewport(10, 6, WithWrapText[object](true), WithWrapPrecompute[object](20))
+	lines := make([]string, 1000)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", i%25)
+	}
+	setContent(vp, lines)
+	return vp
+}
+
+func TestWrapPrecompute_WrapsAheadOfScrolling(t *testing.T) {
+	vp := newWrapPrecomputeViewport()
+	vp, cmd := vp.Update(downKeyMsg)
+	cmds := wrapPrecomputeCmds(cmd)
+	if len(cmds) != 1 {
+		t.Fatalf("expected a wrap precompute job, got %d", len(cmds))
+	}
+	msg := cmds[0]().(wrapPrecomputedMsg)
+	itemIndexes := vp.getVisibleContentItemIndexes()
+	lastVisible := itemIndexes[len(itemIndexes)-1]
+	if len(msg.itemIdxs) == 0 || msg.itemIdxs[0] <= lastVisible || msg.itemIdxs[len(msg.itemIdxs)-1] != lastVisible+20 {
+		t.Errorf("expected the uncached items of the 20 below the view, got %v", msg.itemIdxs)
+	}
+
+	vp, cmd = vp.Update(msg)
+	if wrapPrecomputeCmds(cmd) != nil {
+		t.Error("expected no job once the items ahead are wrapped")
+	}
+	cw, layout := vp.contentWidth(), vp.wrapLayout()
+	for _, idx := range msg.itemIdxs {
+		if !vp.content.wrapCache.has(idx, vp.content.itemAt(idx), cw, layout) {
+			t.Errorf("expected item %d to be cached", idx)
+		}
+	}
+
+	// scrolling up wraps the items above the view
+	vp, _ = vp.Update(goToBottomKeyMsg)
+	vp, cmd = vp.Update(upKeyMsg)
+	cmds = wrapPrecomputeCmds(cmd)
+	if len(cmds) != 1 {
+		t.Fatalf("expected a wrap precompute job, got %d", len(cmds))
+	}
+	msg = cmds[0]().(wrapPrecomputedMsg)
+	if top := vp.getVisibleContentItemIndexes()[0]; msg.itemIdxs[len(msg.itemIdxs)-1] != top-1 {
+		t.Errorf("expected the items above item %d, got %v", top, msg.itemIdxs)
+	}
+}
+
+func TestWrapPrecompute_CanceledBySetObjects(t *testing.T) {
+	vp := newWrapPrecomputeViewport()
+	vp, cmd := vp.Update(downKeyMsg)
+	cmds := wrapPrecomputeCmds(cmd)
+	if len(cmds) != 1 {
+		t.Fatalf("expected a wrap precompute job, got %d", len(cmds))
+	}
+	job := cmds[0]
+
+	setContent(vp, []string{"a", "b"})
+	if msg := job(); msg != nil {
+		t.Errorf("expected the canceled job to return nothing, got %T", msg)
+	}
+}
+
+func TestWrapPrecompute_Disabled(t *testing.T) {
+	vp := newWrapPrecomputeViewport()
+	vp.SetWrapPrecompute(0)
+	if _, cmd := vp.Update(downKeyMsg); wrapPrecomputeCmds(cmd) != nil {
+		t.Error("expected no job with precomputing disabled")
+	}
+	if vp.GetWrapPrecompute() != 0 {
+		t.Error("expected precomputing to be disabled")
+	}
+}
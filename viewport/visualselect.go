@@ -0,0 +1,103 @@
+package viewport
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// VisualSelectedMsg is sent when a visual selection is confirmed, with the selected text
+type VisualSelectedMsg struct {
+	// Text is the unstyled text from the start to the end of the selection, with a newline between items
+	Text string
+}
+
+// visualSelection tracks a character-level selection running from the anchor to the cursor at the selected item,
+// like vim's visual mode. Unlike a block selection, it covers whole items between its first and last item.
+type visualSelection struct {
+	// active is true while selecting
+	active bool
+
+	// anchorItemIdx and anchorCol are where the selection was started
+	anchorItemIdx int
+	anchorCol     int
+
+	// cursorCol is the display column of the cursor in the selected item, moved with the Left and Right keys
+	cursorCol int
+}
+
+// visualBounds returns the first and last item of the visual selection, the display column it starts at in the
+// first and the inclusive display column it ends at in the last
+func (m *Model[T]) visualBounds() (fromIdx, toIdx, startCol, endCol int) {
+	v := m.config.visualSelection
+	anchorIdx := clampValZeroToMax(v.anchorItemIdx, m.content.numItems()-1)
+	selectedIdx := m.content.getSelectedIdx()
+	switch {
+	case anchorIdx < selectedIdx:
+		return anchorIdx, selectedIdx, v.anchorCol, v.cursorCol
+	case anchorIdx > selectedIdx:
+		return selectedIdx, anchorIdx, v.cursorCol, v.anchorCol
+	default:
+		return anchorIdx, anchorIdx, min(v.anchorCol, v.cursorCol), max(v.anchorCol, v.cursorCol)
+	}
+}
+
+// visualByteRange returns the byte range of the unstyled content of the item at itemIdx within the visual selection
+func (m *Model[T]) visualByteRange(itemIdx int) item.ByteRange {
+	fromIdx, toIdx, startCol, endCol := m.visualBounds()
+	content := m.content.itemAt(itemIdx).ContentNoAnsi()
+	r := item.ByteRange{Start: 0, End: len(content)}
+	if itemIdx == fromIdx {
+		r.Start = item.ByteOffsetAtCell(content, startCol)
+	}
+	if itemIdx == toIdx {
+		r.End = item.ByteOffsetAtCell(content, endCol+1)
+	}
+	r.End = max(r.Start, r.End)
+	return r
+}
+
+// visualHighlightsForItem returns a highlight for the part of the visual selection in the item at itemIdx
+func (m *Model[T]) visualHighlightsForItem(itemIdx int) []item.Highlight {
+	if !m.config.visualSelection.active {
+		return nil
+	}
+	fromIdx, toIdx, _, _ := m.visualBounds()
+	if itemIdx < fromIdx || itemIdx > toIdx {
+		return nil
+	}
+	r := m.visualByteRange(itemIdx)
+	if r.End == r.Start {
+		return nil
+	}
+	return []item.Highlight{{Style: m.display.styles.SelectionRegionStyle, ByteRangeUnstyledContent: r}}
+}
+
+// extractVisualSelection returns the unstyled text of the visual selection, with a newline between items
+func (m *Model[T]) extractVisualSelection() string {
+	fromIdx, toIdx, _, _ := m.visualBounds()
+	parts := make([]string, 0, toIdx-fromIdx+1)
+	for itemIdx := fromIdx; itemIdx <= toIdx; itemIdx++ {
+		r := m.visualByteRange(itemIdx)
+		parts = append(parts, m.content.itemAt(itemIdx).ContentNoAnsi()[r.Start:r.End])
+	}
+	return strings.Join(parts, "\n")
+}
+
+// moveVisualCursor moves the cursor by delta display columns, keeping it within the selected item and in view
+func (m *Model[T]) moveVisualCursor(delta int) {
+	selectedIdx := m.content.getSelectedIdx()
+	v := &m.config.visualSelection
+	v.cursorCol = max(0, min(maxSegmentWidth(m.content.itemAt(selectedIdx))-1, v.cursorCol+delta))
+	m.ensureItemInView(selectedIdx, v.cursorCol, v.cursorCol+1, 0, 0)
+}
+
+// confirmVisualSelection ends visual selection, returning a command sending a VisualSelectedMsg with its text
+func (m *Model[T]) confirmVisualSelection() tea.Cmd {
+	msg := VisualSelectedMsg{Text: m.GetVisualSelectionText()}
+	m.config.visualSelection = visualSelection{}
+	return func() tea.Msg {
+		return msg
+	}
+}
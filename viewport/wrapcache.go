@@ -0,0 +1,69 @@
+package viewport
+
+import (
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// wrapCacheKey is the wrap layout an item's number of rows was computed for
+type wrapCacheKey struct {
+	width     int
+	indent    int
+	mode      item.WrapMode
+	wordChars item.WordChars
+}
+
+// newWrapCacheKey returns the key for wrapping at width in layout
+func newWrapCacheKey(width int, layout wrapLayout) wrapCacheKey {
+	return wrapCacheKey{width: width, indent: layout.indent, mode: layout.mode, wordChars: layout.wordChars}
+}
+
+// wrapCacheEntry is the number of rows an item wraps to in a layout
+type wrapCacheEntry struct {
+	key wrapCacheKey
+
+	// content and numSegments are the item's unstyled content and number of line-broken items, checked when the
+	// entry is read so that it's discarded if the item at its index changed
+	content     string
+	numSegments int
+
+	numRows int
+}
+
+// newWrapCacheEntry wraps itm at width in layout. It only reads itm, so it's safe to call off the Update goroutine.
+func newWrapCacheEntry(itm item.Item, width int, layout wrapLayout) wrapCacheEntry {
+	return wrapCacheEntry{
+		key:         newWrapCacheKey(width, layout),
+		content:     itm.ContentNoAnsi(),
+		numSegments: item.NumLineBrokenItems(itm),
+		numRows:     layout.numRows(itm, width),
+	}
+}
+
+// matches returns whether the entry holds the number of rows itm wraps to at width in layout
+func (e wrapCacheEntry) matches(itm item.Item, width int, layout wrapLayout) bool {
+	return e.key == newWrapCacheKey(width, layout) &&
+		e.numSegments == item.NumLineBrokenItems(itm) && e.content == itm.ContentNoAnsi()
+}
+
+// wrapCache remembers how many rows items wrap to, keyed by item index. Entries are only used for the layout they were
+// computed for, so after a resize items are rewrapped lazily as they're needed, e.g. as they scroll into view, instead
+// of all at once.
+type wrapCache struct {
+	itemCache[wrapCacheEntry]
+}
+
+// numRows returns the number of rows itm, the item at itemIdx, wraps to at width in layout
+func (c *wrapCache) numRows(itemIdx int, itm item.Item, width int, layout wrapLayout) int {
+	entry, ok := c.get(itemIdx)
+	if !ok || !entry.matches(itm, width, layout) {
+		entry = newWrapCacheEntry(itm, width, layout)
+		c.store(itemIdx, entry)
+	}
+	return entry.numRows
+}
+
+// has returns whether the cache holds the number of rows itm, the item at itemIdx, wraps to at width in layout
+func (c *wrapCache) has(itemIdx int, itm item.Item, width int, layout wrapLayout) bool {
+	entry, ok := c.get(itemIdx)
+	return ok && entry.matches(itm, width, layout)
+}
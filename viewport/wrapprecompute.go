@@ -0,0 +1,130 @@
+package viewport
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/robinovitch61/viewport/viewport/item"
+)
+
+// wrapPrecomputedMsg carries the wrap cache entries of items wrapped in the background by the job with the same seq
+type wrapPrecomputedMsg struct {
+	id       int
+	seq      int
+	itemIdxs []int
+	entries  []wrapCacheEntry
+}
+
+// wrapPrecomputeState wraps items just outside the view in the background, see SetWrapPrecompute
+type wrapPrecomputeState struct {
+	// numItems is how many items past the view to wrap, 0 to disable
+	numItems int
+
+	// scrollingUp is true when the view last scrolled up, so that the items above it are wrapped
+	scrollingUp bool
+
+	// seq identifies the latest job, so that the results of an earlier one are ignored
+	seq int
+
+	// cancel stops the running job when closed, nil if none is running
+	cancel chan struct{}
+}
+
+// SetWrapPrecompute sets how many items past the view, in the direction last scrolled, are wrapped in the background
+// while wrapping is on, so that scrolling quickly through long wrapped content doesn't stall to wrap items as they come
+// into view. Jobs are started from Update and their results delivered to it, and are canceled when the objects change.
+// 0, the default, wraps items only as they're needed.
+func (m *Model[T]) SetWrapPrecompute(numItems int) {
+	m.display.wrapPrecompute.numItems = max(0, numItems)
+	if numItems <= 0 {
+		m.cancelWrapPrecompute()
+	}
+}
+
+// GetWrapPrecompute returns how many items past the view are wrapped in the background, 0 if disabled
+func (m *Model[T]) GetWrapPrecompute() int {
+	return m.display.wrapPrecompute.numItems
+}
+
+// startWrapPrecompute starts wrapping the items past the view that aren't cached, given the top of the view before
+// the last update, returning the command running the job. Returns nil if there's nothing to wrap or a job is already
+// running.
+func (m *Model[T]) startWrapPrecompute(prevTopItemIdx, prevTopItemLineOffset int) tea.Cmd {
+	p := &m.display.wrapPrecompute
+	if p.numItems <= 0 || !m.config.wrapText || m.content.isEmpty() {
+		return nil
+	}
+	scrollingUp := p.scrollingUp
+	if topItemIdx, topLineOffset := m.display.topItemIdx, m.display.topItemLineOffset; topItemIdx != prevTopItemIdx {
+		scrollingUp = topItemIdx < prevTopItemIdx
+	} else if topLineOffset != prevTopItemLineOffset {
+		scrollingUp = topLineOffset < prevTopItemLineOffset
+	}
+	if p.cancel != nil && scrollingUp == p.scrollingUp {
+		return nil
+	}
+	p.scrollingUp = scrollingUp
+
+	cw := m.contentWidth()
+	itemIndexes := m.getVisibleContentItemIndexes()
+	if cw == 0 || len(itemIndexes) == 0 {
+		return nil
+	}
+	layout := m.wrapLayout()
+	numItems := m.content.numItems()
+	from, to := itemIndexes[len(itemIndexes)-1]+1, itemIndexes[len(itemIndexes)-1]+p.numItems
+	if scrollingUp {
+		from, to = itemIndexes[0]-p.numItems, itemIndexes[0]-1
+	}
+	var itemIdxs []int
+	var items []item.Item
+	for idx := max(0, from); idx <= min(to, numItems-1); idx++ {
+		if itm := m.content.itemAt(idx); !m.content.wrapCache.has(idx, itm, cw, layout) {
+			itemIdxs = append(itemIdxs, idx)
+			items = append(items, itm)
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	m.cancelWrapPrecompute()
+	p.seq++
+	id, seq, cancel := m.id, p.seq, make(chan struct{})
+	p.cancel = cancel
+	return func() tea.Msg {
+		entries := make([]wrapCacheEntry, 0, len(items))
+		for _, itm := range items {
+			select {
+			case <-cancel:
+				return nil
+			default:
+			}
+			entries = append(entries, newWrapCacheEntry(itm, cw, layout))
+		}
+		return wrapPrecomputedMsg{id: id, seq: seq, itemIdxs: itemIdxs, entries: entries}
+	}
+}
+
+// finishWrapPrecompute caches the results of the latest job
+func (m *Model[T]) finishWrapPrecompute(msg wrapPrecomputedMsg) {
+	p := &m.display.wrapPrecompute
+	if msg.seq != p.seq || p.cancel == nil {
+		return
+	}
+	p.cancel = nil
+	numItems := m.content.numItems()
+	for i, idx := range msg.itemIdxs {
+		// the item may have changed since the job started
+		if idx < numItems && m.content.itemAt(idx).ContentNoAnsi() == msg.entries[i].content {
+			m.content.wrapCache.store(idx, msg.entries[i])
+		}
+	}
+}
+
+// cancelWrapPrecompute stops the running job, if any
+func (m *Model[T]) cancelWrapPrecompute() {
+	p := &m.display.wrapPrecompute
+	if p.cancel != nil {
+		close(p.cancel)
+		p.cancel = nil
+	}
+}